@@ -7,12 +7,14 @@ import (
 	"time"
 
 	"github.com/spetersoncode/wark/internal/db"
+	"github.com/spetersoncode/wark/internal/models"
 	"github.com/spf13/cobra"
 )
 
 // Analytics command flags
 var (
 	analyticsProject   string
+	analyticsLabels    []string
 	analyticsSince     string
 	analyticsUntil     string
 	analyticsTrendDays int
@@ -20,6 +22,7 @@ var (
 
 func init() {
 	analyticsCmd.Flags().StringVarP(&analyticsProject, "project", "p", "", "Filter by project")
+	analyticsCmd.Flags().StringSliceVar(&analyticsLabels, "label", nil, "Filter by label (repeatable; tickets must have all)")
 	analyticsCmd.Flags().StringVar(&analyticsSince, "since", "", "Filter from date (YYYY-MM-DD)")
 	analyticsCmd.Flags().StringVar(&analyticsUntil, "until", "", "Filter until date (YYYY-MM-DD)")
 	analyticsCmd.Flags().IntVar(&analyticsTrendDays, "trend-days", 30, "Number of days for completion trend (1-365)")
@@ -43,6 +46,7 @@ Metrics shown:
 Examples:
   wark analytics                      # All analytics
   wark analytics --project WEBAPP     # Analytics for specific project
+  wark analytics --label area:api     # Analytics for labelled tickets
   wark analytics --since 2024-01-01   # Analytics since a date
   wark analytics --json               # Output as JSON`,
 	Args: cobra.NoArgs,
@@ -62,10 +66,11 @@ type AnalyticsResult struct {
 
 // AnalyticsFilter shows what filters were applied
 type AnalyticsFilter struct {
-	Project   string `json:"project,omitempty"`
-	Since     string `json:"since,omitempty"`
-	Until     string `json:"until,omitempty"`
-	TrendDays int    `json:"trend_days"`
+	Project   string   `json:"project,omitempty"`
+	Labels    []string `json:"labels,omitempty"`
+	Since     string   `json:"since,omitempty"`
+	Until     string   `json:"until,omitempty"`
+	TrendDays int      `json:"trend_days"`
 }
 
 func runAnalytics(cmd *cobra.Command, args []string) error {
@@ -86,6 +91,15 @@ func runAnalytics(cmd *cobra.Command, args []string) error {
 		resultFilter.Project = filter.ProjectKey
 	}
 
+	if len(analyticsLabels) > 0 {
+		labels, err := models.ParseLabels(analyticsLabels)
+		if err != nil {
+			return ErrInvalidArgs("%s", err)
+		}
+		filter.Labels = labels
+		resultFilter.Labels = labels
+	}
+
 	if analyticsSince != "" {
 		since, err := time.Parse("2006-01-02", analyticsSince)
 		if err != nil {
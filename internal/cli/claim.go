@@ -21,6 +21,7 @@ var (
 	claimAll      bool
 	claimExpired  bool
 	claimTicket   string
+	claimWorker   string
 	claimDryRun   bool
 	claimDaemon   bool
 	claimInterval int
@@ -30,6 +31,7 @@ func init() {
 	// claim list
 	claimListCmd.Flags().BoolVar(&claimAll, "all", false, "Include completed/expired claims")
 	claimListCmd.Flags().BoolVar(&claimExpired, "expired", false, "Show only expired claims")
+	claimListCmd.Flags().StringVar(&claimWorker, "worker", "", "Show only claims held by this worker")
 
 	// claim expire
 	claimExpireCmd.Flags().BoolVar(&claimAll, "all", false, "Expire all expired claims")
@@ -61,7 +63,8 @@ var claimListCmd = &cobra.Command{
 Examples:
   wark claim list              # List active claims
   wark claim list --all        # Include completed/expired
-  wark claim list --expired    # Show only expired claims`,
+  wark claim list --expired    # Show only expired claims
+  wark claim list --worker agent-1  # Show only agent-1's claims`,
 	Args: cobra.NoArgs,
 	RunE: runClaimList,
 }
@@ -79,6 +82,11 @@ func runClaimList(cmd *cobra.Command, args []string) error {
 
 	if claimExpired {
 		claims, err = claimRepo.ListExpired()
+		if err == nil && claimWorker != "" {
+			claims = filterClaimsByWorker(claims, claimWorker)
+		}
+	} else if claimWorker != "" {
+		claims, err = claimRepo.GetActiveByWorkerID(claimWorker)
 	} else if claimAll {
 		// For --all, we need to implement a different query
 		// For now, list active claims
@@ -111,16 +119,17 @@ func runClaimList(cmd *cobra.Command, args []string) error {
 	}
 
 	// Table format
-	fmt.Printf("%-12s %-20s %-20s %s\n", "TICKET", "CLAIM ID", "EXPIRES", "REMAINING")
-	fmt.Println(strings.Repeat("-", 70))
+	fmt.Printf("%-12s %-20s %-20s %-20s %s\n", "TICKET", "CLAIM ID", "WORKER", "EXPIRES", "REMAINING")
+	fmt.Println(strings.Repeat("-", 91))
 	for _, c := range claims {
 		remaining := formatDuration(c.MinutesRemaining)
 		if c.MinutesRemaining <= 0 || c.IsExpired() {
 			remaining = "EXPIRED"
 		}
-		fmt.Printf("%-12s %-20s %-20s %s\n",
+		fmt.Printf("%-12s %-20s %-20s %-20s %s\n",
 			c.TicketKey,
 			truncate(c.ClaimID, 20),
+			truncate(c.WorkerID, 20),
 			c.ExpiresAt.Local().Format("2006-01-02 15:04:05"),
 			remaining,
 		)
@@ -129,6 +138,17 @@ func runClaimList(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// filterClaimsByWorker returns the claims held by workerID.
+func filterClaimsByWorker(claims []*models.Claim, workerID string) []*models.Claim {
+	var filtered []*models.Claim
+	for _, c := range claims {
+		if c.WorkerID == workerID {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered
+}
+
 // claim show
 var claimShowCmd = &cobra.Command{
 	Use:   "show <TICKET>",
@@ -191,6 +211,9 @@ func runClaimShow(cmd *cobra.Command, args []string) error {
 	fmt.Println()
 	fmt.Printf("Ticket:     %s - %s\n", ticket.TicketKey, ticket.Title)
 	fmt.Printf("Claim ID:   %s\n", claim.ClaimID)
+	if claim.WorkerID != "" {
+		fmt.Printf("Worker:     %s\n", claim.WorkerID)
+	}
 	fmt.Printf("Status:     %s\n", claim.Status)
 	fmt.Printf("Claimed:    %s\n", claim.ClaimedAt.Local().Format("2006-01-02 15:04:05"))
 	fmt.Printf("Expires:    %s\n", claim.ExpiresAt.Local().Format("2006-01-02 15:04:05"))
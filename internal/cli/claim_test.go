@@ -382,7 +382,7 @@ func TestClaimReleaseActivityLog(t *testing.T) {
 	assert.True(t, claimFound, "claim activity should be logged")
 
 	// Release the ticket via CLI
-	_, err = runCmd(t, dbPath, "ticket", "release", "ACTLOG-1", "--reason", "Testing release logging")
+	_, err = runCmd(t, dbPath, "ticket", "release", "ACTLOG-1", "--worker-id", "test-worker", "--reason", "Testing release logging")
 	require.NoError(t, err)
 
 	// Verify release activity was logged
@@ -400,3 +400,51 @@ func TestClaimReleaseActivityLog(t *testing.T) {
 	}
 	assert.True(t, releaseFound, "release activity should be logged")
 }
+
+// TestClaimWorkerOwnership verifies that claims are bound to the claiming worker
+func TestClaimWorkerOwnership(t *testing.T) {
+	database, dbPath, cleanup := testDBWithPath(t)
+	defer cleanup()
+
+	projectRepo := db.NewProjectRepo(database.DB)
+	project := &models.Project{Key: "OWN", Name: "Ownership Test"}
+	require.NoError(t, projectRepo.Create(project))
+
+	ticketRepo := db.NewTicketRepo(database.DB)
+	ticket := &models.Ticket{
+		ProjectID: project.ID,
+		Title:     "Owned Ticket",
+		Status:    models.StatusReady,
+	}
+	require.NoError(t, ticketRepo.Create(ticket))
+
+	_, err := runCmd(t, dbPath, "ticket", "claim", "OWN-1", "--worker-id", "agent-a")
+	require.NoError(t, err)
+
+	claim, err := db.NewClaimRepo(database.DB).GetActiveByTicketID(ticket.ID)
+	require.NoError(t, err)
+	require.NotNil(t, claim)
+	assert.Equal(t, "agent-a", claim.WorkerID)
+
+	// Claim list filters by worker
+	output, err := runCmd(t, dbPath, "claim", "list", "--worker", "agent-a")
+	require.NoError(t, err)
+	assert.Contains(t, output, "OWN-1")
+
+	output, err = runCmd(t, dbPath, "claim", "list", "--worker", "agent-b")
+	require.NoError(t, err)
+	assert.Contains(t, output, "No active claims found")
+
+	// Another worker cannot release the claim
+	_, err = runCmd(t, dbPath, "ticket", "release", "OWN-1", "--worker-id", "agent-b")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "claimed by agent-a")
+
+	// --force overrides ownership
+	_, err = runCmd(t, dbPath, "ticket", "release", "OWN-1", "--worker-id", "agent-b", "--force")
+	require.NoError(t, err)
+
+	updated, err := ticketRepo.GetByID(ticket.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusReady, updated.Status)
+}
@@ -9,9 +9,13 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
+	"github.com/spetersoncode/wark/internal/config"
 	"github.com/spetersoncode/wark/internal/db"
 	"github.com/spetersoncode/wark/internal/models"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -81,6 +85,11 @@ func resetGlobalFlags() {
 	projectDescription = ""
 	projectWithStats = false
 	projectForce = false
+	projectEditName = ""
+	projectEditDescription = ""
+	projectEditWIPLimit = 0
+	projectEditWorkingLimit = 0
+	projectEditReviewingLimit = 0
 
 	// Ticket command flags - note defaults match init() in ticket.go
 	ticketTitle = ""
@@ -91,6 +100,10 @@ func resetGlobalFlags() {
 	ticketParent = ""
 	ticketProject = ""
 	ticketStatus = nil
+	ticketLabels = nil
+	ticketMilestone = ""
+	ticketNotBefore = ""
+	ticketDue = ""
 	ticketWorkable = false
 	ticketReviewable = false
 	ticketLimit = 50
@@ -99,19 +112,79 @@ func resetGlobalFlags() {
 
 	// Workflow command flags
 	claimWorkerID = ""
+	claimForce = false
 	claimDuration = 60
+	heartbeatExtend = 0
 	releaseReason = ""
 	completeSummary = ""
 	autoAccept = false
 	flagReason = ""
 
+	// Claim command flags
+	claimWorker = ""
+
+	// Milestone command flags
+	milestoneName = ""
+	milestoneGoal = ""
+	milestoneTarget = ""
+	milestoneProject = ""
+	milestoneStatus = ""
+	milestoneAbandon = false
+
+	// Search command flags
+	searchProject = ""
+	searchLimit = 20
+
+	// Webhook command flags
+	webhookName = ""
+	webhookStatus = ""
+	webhookLimit = 20
+	webhookDaemon = false
+	webhookInterval = 10
+
+	// Schedule command flags
+	scheduleDaemon = false
+	scheduleInterval = 60
+
+	// Export/import command flags
+	exportProjects = nil
+	exportFormat = ""
+	exportOutput = ""
+	exportNoActivity = false
+	importDryRun = false
+	importProject = ""
+
+	// Plan command flags
+	planDryRun = false
+
+	// Graph command flags
+	graphProject = ""
+	graphEpic = ""
+	graphFormat = ""
+	graphLimit = 10
+
 	// Inbox command flags
 	inboxProject = ""
 	inboxType = ""
+	inboxResolve = false
+	inboxOptions = nil
+	inboxChoose = ""
+	inboxThen = ""
+	inboxTimeout = 30 * time.Minute
+	inboxPoll = time.Second
+	inboxOverdue = false
+	inboxDryRun = false
+	inboxDaemon = false
+	inboxEvery = 60
 
 	// Utility command flags
 	nextDryRun = false
 	nextComplexity = "large"
+	nextRole = ""
+	nextCapability = ""
+	nextLabels = nil
+	nextByImpact = false
+	nextPolicy = ""
 	branchSet = ""
 	logLimit = 20
 	logAction = ""
@@ -123,6 +196,30 @@ func resetGlobalFlags() {
 	rejectReason = ""
 	cancelReason = ""
 	closeResolution = "wont_do"
+	laterUntil = ""
+
+	// Template command flags
+	ticketTemplate = ""
+	ticketVars = nil
+	templateDescription = ""
+	templateDescriptionFile = ""
+	templatePriority = ""
+	templateComplexity = ""
+	templateRole = ""
+	templateTasks = nil
+
+	// Cobra also remembers which flags were set, which commands check with
+	// Changed
+	resetChangedFlags(rootCmd)
+}
+
+func resetChangedFlags(cmd *cobra.Command) {
+	reset := func(f *pflag.Flag) { f.Changed = false }
+	cmd.Flags().VisitAll(reset)
+	cmd.PersistentFlags().VisitAll(reset)
+	for _, sub := range cmd.Commands() {
+		resetChangedFlags(sub)
+	}
 }
 
 // runCmd executes a command with the given args and returns output and error.
@@ -792,6 +889,51 @@ func TestCmdTicketFlag(t *testing.T) {
 	assert.Contains(t, output, "Status: human")
 }
 
+func TestCmdTicketScheduleDates(t *testing.T) {
+	_, dbPath, cleanup := testDBWithPath(t)
+	defer cleanup()
+
+	_, _ = runCmd(t, dbPath, "project", "create", "SCH", "--name", "Schedule")
+	_, err := runCmd(t, dbPath, "ticket", "create", "SCH", "--title", "Bad", "--due", "someday")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--due: invalid date: someday")
+
+	_, err = runCmd(t, dbPath, "ticket", "create", "SCH", "--title", "Late report", "--due", "2020-01-31")
+	require.NoError(t, err)
+	_, err = runCmd(t, dbPath, "ticket", "create", "SCH", "--title", "Needs input")
+	require.NoError(t, err)
+
+	output, err := runCmd(t, dbPath, "--text", "ticket", "show", "SCH-1")
+	require.NoError(t, err)
+	assert.Contains(t, output, "2020-01-31 ⚠ overdue")
+
+	output, err = runCmd(t, dbPath, "--text", "status")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Overdue:")
+	assert.Contains(t, output, "SCH-1")
+
+	// later --until moves to backlog and hides the ticket once started again
+	_, _ = runCmd(t, dbPath, "ticket", "start", "SCH-2")
+	_, _ = runCmd(t, dbPath, "ticket", "claim", "SCH-2", "--worker-id", "agent")
+	_, err = runCmd(t, dbPath, "ticket", "human", "SCH-2", "--reason", "unclear_requirements", "--worker-id", "agent", "Which format?")
+	require.NoError(t, err)
+	output, err = runCmd(t, dbPath, "--text", "ticket", "later", "SCH-2", "--until", "2099-11-01")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Not before: 2099-11-01")
+	_, _ = runCmd(t, dbPath, "ticket", "start", "SCH-2")
+
+	output, err = runCmd(t, dbPath, "--text", "ticket", "list", "--workable")
+	require.NoError(t, err)
+	assert.NotContains(t, output, "SCH-2")
+
+	output, err = runCmd(t, dbPath, "ticket", "edit", "SCH-2", "--not-before", "")
+	require.NoError(t, err)
+	assert.NotContains(t, output, "not_before")
+	output, err = runCmd(t, dbPath, "--text", "ticket", "list", "--workable")
+	require.NoError(t, err)
+	assert.Contains(t, output, "SCH-2")
+}
+
 func TestCmdTicketFlagInvalidReason(t *testing.T) {
 	_, dbPath, cleanup := testDBWithPath(t)
 	defer cleanup()
@@ -1052,6 +1194,225 @@ func TestCmdInboxRespondAlreadyAnswered(t *testing.T) {
 	assert.Contains(t, err.Error(), "already been responded")
 }
 
+func TestCmdInboxReplyThread(t *testing.T) {
+	database, dbPath, cleanup := testDBWithPath(t)
+	defer cleanup()
+
+	// Setup
+	projectRepo := db.NewProjectRepo(database.DB)
+	project := &models.Project{Key: "THR", Name: "Thread"}
+	projectRepo.Create(project)
+
+	ticketRepo := db.NewTicketRepo(database.DB)
+	ticket := &models.Ticket{ProjectID: project.ID, Title: "Thread Ticket", Status: models.StatusHuman}
+	ticketRepo.Create(ticket)
+
+	inboxRepo := db.NewInboxRepo(database.DB)
+	msg := models.NewInboxMessage(ticket.ID, models.MessageTypeQuestion, "Which database?", "agent-1")
+	inboxRepo.Create(msg)
+
+	// A human reply doesn't unblock the ticket
+	output, err := runCmd(t, dbPath, "inbox", "reply", "1", "What load do you expect?")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Replied to message #1")
+	assert.NotContains(t, output, "ready")
+
+	_, err = runCmd(t, dbPath, "inbox", "reply", "1", "--worker-id", "agent-1", "About 1000 writes a second")
+	require.NoError(t, err)
+
+	_, err = runCmd(t, dbPath, "inbox", "reply", "1", "--worker-id", "agent-1", "--resolve")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "only a human")
+
+	output, err = runCmd(t, dbPath, "inbox", "reply", "1", "--resolve", "Use PostgreSQL")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Thread resolved")
+	assert.Contains(t, output, "human → ready")
+
+	output, err = runCmd(t, dbPath, "inbox", "thread", "1")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Which database?")
+	assert.Contains(t, output, "agent-1 · ")
+	assert.Contains(t, output, "Use PostgreSQL")
+	assert.Contains(t, output, "Resolved on")
+
+	var thread models.InboxThread
+	require.NoError(t, runCmdJSON(t, dbPath, &thread, "inbox", "thread", "1"))
+	assert.True(t, thread.Resolved)
+	require.Len(t, thread.Replies, 3)
+	assert.Equal(t, models.ActorTypeAgent, thread.Replies[1].AuthorType)
+
+	// An agent follow-up reopens the thread and escalates again
+	output, err = runCmd(t, dbPath, "inbox", "reply", "1", "--worker-id", "agent-1", "Which version?")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Thread reopened")
+	assert.Contains(t, output, "ready → human")
+}
+
+func TestCmdInboxDecisionOptions(t *testing.T) {
+	_, dbPath, cleanup := testDBWithPath(t)
+	defer cleanup()
+
+	_, _ = runCmd(t, dbPath, "project", "create", "DEC", "--name", "Decide")
+	_, _ = runCmd(t, dbPath, "ticket", "create", "DEC", "--title", "Pick a database")
+
+	_, err := runCmd(t, dbPath, "inbox", "send", "DEC-1", "--type", "decision", "--option", "use Postgres", "Which database?")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "KEY: label")
+
+	output, err := runCmd(t, dbPath, "inbox", "send", "DEC-1", "--type", "decision",
+		"--option", "A: use Postgres", "--option", "B: stay on SQLite", "Which database?")
+	require.NoError(t, err)
+	assert.Contains(t, output, "B: stay on SQLite")
+
+	_, err = runCmd(t, dbPath, "inbox", "respond", "1", "Postgres I think")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "choose one of A, B")
+
+	_, err = runCmd(t, dbPath, "inbox", "respond", "1", "--choose", "C")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid choice C")
+
+	output, err = runCmd(t, dbPath, "inbox", "respond", "1", "--choose", "B")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Chose: B: stay on SQLite")
+
+	var result ticketShowResult
+	require.NoError(t, runCmdJSON(t, dbPath, &result, "ticket", "show", "DEC-1"))
+	require.Len(t, result.Decisions, 1)
+	assert.Equal(t, "B", result.Decisions[0].Choice)
+	assert.Equal(t, "stay on SQLite", result.Decisions[0].Label)
+
+	output, err = runCmd(t, dbPath, "ticket", "show", "DEC-1")
+	require.NoError(t, err)
+	assert.Contains(t, output, "→ B: stay on SQLite")
+
+	var ctx ticketExecutionContextResult
+	require.NoError(t, runCmdJSON(t, dbPath, &ctx, "ticket", "execution-context", "DEC-1"))
+	require.Len(t, ctx.Decisions, 1)
+	assert.Equal(t, int64(1), ctx.Decisions[0].MessageID)
+}
+
+func TestCmdInboxRespondThen(t *testing.T) {
+	_, dbPath, cleanup := testDBWithPath(t)
+	defer cleanup()
+
+	_, _ = runCmd(t, dbPath, "project", "create", "THEN", "--name", "Then")
+	_, _ = runCmd(t, dbPath, "ticket", "create", "THEN", "--title", "Add caching")
+	_, _ = runCmd(t, dbPath, "inbox", "send", "THEN-1", "--type", "question", "Is this still needed?")
+
+	_, err := runCmd(t, dbPath, "inbox", "respond", "1", "--then", "close:done", "No")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid resolution")
+
+	// Accepting needs a ticket in review, so nothing is recorded
+	_, err = runCmd(t, dbPath, "inbox", "respond", "1", "--then", "accept", "No")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not in review")
+
+	var ticket ticketShowResult
+	require.NoError(t, runCmdJSON(t, dbPath, &ticket, "ticket", "show", "THEN-1"))
+	assert.Equal(t, models.StatusHuman, ticket.Status)
+
+	output, err := runCmd(t, dbPath, "inbox", "respond", "1", "--then", "close:wont-do", "No, the CDN covers it")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Action: close:wont_do")
+	assert.Contains(t, output, "Ticket status: human → closed")
+
+	require.NoError(t, runCmdJSON(t, dbPath, &ticket, "ticket", "show", "THEN-1"))
+	assert.Equal(t, models.StatusClosed, ticket.Status)
+	require.NotNil(t, ticket.Resolution)
+	assert.Equal(t, models.ResolutionWontDo, *ticket.Resolution)
+}
+
+func TestCmdInboxWait(t *testing.T) {
+	_, dbPath, cleanup := testDBWithPath(t)
+	defer cleanup()
+
+	_, _ = runCmd(t, dbPath, "project", "create", "WAIT", "--name", "Wait")
+	_, _ = runCmd(t, dbPath, "ticket", "create", "WAIT", "--title", "Wait Ticket")
+
+	_, err := runCmd(t, dbPath, "inbox", "wait", "WAIT-1", "--timeout", "50ms")
+	require.Error(t, err)
+	assert.Equal(t, ExitNotFound, ExitCode(err))
+
+	_, err = runCmd(t, dbPath, "inbox", "send", "WAIT-1", "--type", "question", "Which port?")
+	require.NoError(t, err)
+
+	_, err = runCmd(t, dbPath, "inbox", "wait", "1", "--timeout", "50ms", "--interval", "10ms")
+	require.Error(t, err)
+	assert.Equal(t, ExitTimeout, ExitCode(err))
+	assert.Contains(t, err.Error(), "timed out")
+
+	_, err = runCmd(t, dbPath, "inbox", "respond", "1", "8080")
+	require.NoError(t, err)
+
+	output, err := runCmd(t, dbPath, "inbox", "wait", "WAIT-1")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Response to message #1")
+	assert.Contains(t, output, "8080")
+
+	var msg models.InboxMessage
+	require.NoError(t, runCmdJSON(t, dbPath, &msg, "inbox", "wait", "1"))
+	assert.Equal(t, "8080", msg.Response)
+}
+
+func TestCmdInboxSLA(t *testing.T) {
+	database, dbPath, cleanup := testDBWithPath(t)
+	defer cleanup()
+
+	origConfig := globalConfig
+	defer func() { globalConfig = origConfig }()
+	globalConfig = config.DefaultConfig()
+	globalConfig.InboxSLA = []config.InboxSLAConfig{{Type: "question", Minutes: 60}}
+
+	_, _ = runCmd(t, dbPath, "project", "create", "SLA", "--name", "SLA")
+	_, _ = runCmd(t, dbPath, "ticket", "create", "SLA", "--title", "Waiting Ticket")
+	_, err := runCmd(t, dbPath, "inbox", "send", "SLA-1", "--type", "question", "Asked long ago")
+	require.NoError(t, err)
+	_, err = runCmd(t, dbPath, "inbox", "send", "SLA-1", "--type", "question", "Asked just now")
+	require.NoError(t, err)
+	_, err = database.Exec(`UPDATE inbox_messages SET created_at = ? WHERE id = 1`, db.FormatTime(time.Now().Add(-2*time.Hour)))
+	require.NoError(t, err)
+
+	output, err := runCmd(t, dbPath, "inbox", "list")
+	require.NoError(t, err)
+	assert.Contains(t, output, "⚠ overdue")
+
+	var overdue []models.InboxMessage
+	require.NoError(t, runCmdJSON(t, dbPath, &overdue, "inbox", "list", "--overdue"))
+	require.Len(t, overdue, 1)
+	assert.Equal(t, int64(1), overdue[0].ID)
+	assert.True(t, overdue[0].Overdue)
+	assert.NotNil(t, overdue[0].DueAt)
+
+	var status StatusResult
+	require.NoError(t, runCmdJSON(t, dbPath, &status, "status"))
+	require.Len(t, status.OverdueInbox, 1)
+	assert.Equal(t, "SLA-1", status.OverdueInbox[0].TicketKey)
+
+	output, err = runCmd(t, dbPath, "inbox", "escalate", "--dry-run")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Would escalate #1")
+
+	output, err = runCmd(t, dbPath, "inbox", "escalate")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Escalated 1 of 1 overdue message(s)")
+
+	output, err = runCmd(t, dbPath, "inbox", "escalate")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Escalated 0 of 1 overdue message(s)")
+
+	output, err = runCmd(t, dbPath, "inbox", "show", "1")
+	require.NoError(t, err)
+	assert.Contains(t, output, "(overdue)")
+
+	globalConfig.InboxSLA = []config.InboxSLAConfig{{Type: "memo", Minutes: 60}}
+	_, err = runCmd(t, dbPath, "inbox", "list")
+	require.Error(t, err)
+	assert.Equal(t, ExitInvalidArgs, ExitCode(err))
+}
+
 func TestCmdInboxSend(t *testing.T) {
 	_, dbPath, cleanup := testDBWithPath(t)
 	defer cleanup()
@@ -1343,6 +1704,72 @@ func TestCmdTicketNextDryRun(t *testing.T) {
 	assert.NotContains(t, output, "Claimed") // Should not claim in dry run
 }
 
+func TestCmdTicketNextPolicy(t *testing.T) {
+	_, dbPath, cleanup := testDBWithPath(t)
+	defer cleanup()
+
+	_, _ = runCmd(t, dbPath, "project", "create", "POL", "--name", "Policy")
+	_, _ = runCmd(t, dbPath, "ticket", "create", "POL", "--title", "Big Urgent", "--priority", "highest", "--complexity", "large")
+	_, _ = runCmd(t, dbPath, "ticket", "create", "POL", "--title", "Quick Fix", "--priority", "low", "--complexity", "trivial")
+	_, _ = runCmd(t, dbPath, "ticket", "start", "POL-1")
+	_, _ = runCmd(t, dbPath, "ticket", "start", "POL-2")
+
+	output, err := runCmd(t, dbPath, "ticket", "next", "--dry-run")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Big Urgent")
+	assert.Contains(t, output, "Policy:     priority")
+
+	output, err = runCmd(t, dbPath, "ticket", "next", "--dry-run", "--policy", "smallest")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Quick Fix")
+	assert.Contains(t, output, "Score:      5.00 (trivial complexity)")
+
+	var result map[string]interface{}
+	require.NoError(t, runCmdJSON(t, dbPath, &result, "ticket", "next", "--dry-run", "--policy", "smallest"))
+	assert.Equal(t, "smallest", result["policy"])
+	assert.Equal(t, 5.0, result["score"])
+
+	_, err = runCmd(t, dbPath, "ticket", "next", "--policy", "fifo")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid scheduling policy")
+}
+
+func TestCmdProjectWIPLimits(t *testing.T) {
+	_, dbPath, cleanup := testDBWithPath(t)
+	defer cleanup()
+
+	_, _ = runCmd(t, dbPath, "project", "create", "WIP", "--name", "Limited")
+	for _, title := range []string{"First", "Second"} {
+		_, _ = runCmd(t, dbPath, "ticket", "create", "WIP", "--title", title)
+	}
+	_, _ = runCmd(t, dbPath, "ticket", "start", "WIP-1")
+	_, _ = runCmd(t, dbPath, "ticket", "start", "WIP-2")
+
+	_, err := runCmd(t, dbPath, "project", "edit", "WIP", "--working-limit", "-1")
+	require.Error(t, err)
+
+	output, err := runCmd(t, dbPath, "project", "edit", "WIP", "--working-limit", "1")
+	require.NoError(t, err)
+	assert.Contains(t, output, "WIP limits: total -, working 1, reviewing -")
+
+	_, err = runCmd(t, dbPath, "ticket", "claim", "WIP-1")
+	require.NoError(t, err)
+
+	_, err = runCmd(t, dbPath, "ticket", "claim", "WIP-2")
+	require.Error(t, err)
+	assert.Equal(t, ExitWIPLimit, ExitCode(err))
+	assert.Contains(t, err.Error(), "1 of 1 working")
+
+	_, err = runCmd(t, dbPath, "ticket", "next")
+	require.Error(t, err)
+	assert.Equal(t, ExitWIPLimit, ExitCode(err))
+
+	output, err = runCmd(t, dbPath, "status")
+	require.NoError(t, err)
+	assert.Contains(t, output, "WIP limits:")
+	assert.Contains(t, output, "working 1/1")
+}
+
 func TestCmdTicketNextNoWorkable(t *testing.T) {
 	_, dbPath, cleanup := testDBWithPath(t)
 	defer cleanup()
@@ -174,6 +174,15 @@ func ErrConcurrentConflictWithSuggestion(suggestion, format string, args ...inte
 	}
 }
 
+// ErrWIPLimitWithSuggestion creates an error for work refused by a WIP limit (exit code 7)
+func ErrWIPLimitWithSuggestion(suggestion, format string, args ...interface{}) error {
+	return &WarkError{
+		Code:       ExitWIPLimit,
+		Message:    fmt.Sprintf(format, args...),
+		Suggestion: suggestion,
+	}
+}
+
 // ErrGeneral creates a general error (exit code 1)
 func ErrGeneral(format string, args ...interface{}) error {
 	return &WarkError{
@@ -208,5 +217,6 @@ const (
 	SuggestListTickets    = "Run 'wark ticket list' to see available tickets."
 	SuggestCheckStatus    = "Run 'wark ticket show %s' to check the ticket's current status."
 	SuggestReleaseClaim   = "The ticket may be claimed by another worker. Run 'wark claim list --active' to see."
+	SuggestForceClaim     = "Use --worker-id to act as the claim holder, or --force to override the claim."
 	SuggestWaitOrRetry    = "Wait for the current operation to complete, or try again."
 )
@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spetersoncode/wark/internal/db"
+	werrors "github.com/spetersoncode/wark/internal/errors"
+	"github.com/spetersoncode/wark/internal/service"
+	"github.com/spf13/cobra"
+)
+
+// Graph command flags
+var (
+	graphProject string
+	graphEpic    string
+	graphFormat  string
+	graphLimit   int
+)
+
+func init() {
+	graphCmd.Flags().StringVarP(&graphProject, "project", "p", "", "Project to graph (default all)")
+	graphCmd.Flags().StringVar(&graphEpic, "epic", "", "Graph an epic and its descendants")
+	graphCmd.Flags().StringVar(&graphFormat, "format", "", "Output format: dot, mermaid or json (default json, or dot with --text)")
+
+	graphCriticalPathCmd.Flags().StringVarP(&graphProject, "project", "p", "", "Project to analyze (default all)")
+	graphCriticalPathCmd.Flags().StringVar(&graphEpic, "epic", "", "Analyze an epic and its descendants")
+	graphCriticalPathCmd.Flags().IntVarP(&graphLimit, "limit", "n", service.DefaultImpactLimit, "Number of tickets to rank by impact")
+
+	graphCmd.AddCommand(graphCriticalPathCmd)
+	rootCmd.AddCommand(graphCmd)
+}
+
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Show the ticket dependency graph",
+	Long: `Show the dependency graph for a project or epic as Graphviz DOT, a Mermaid
+flowchart or JSON.
+
+Nodes are tickets, colored by status. Solid edges point from a ticket to a
+ticket it depends on; dashed edges point from a child to its parent. Tickets
+outside the project or epic that share a dependency are drawn dashed.
+
+Examples:
+  wark graph --project WEBAPP --format dot | dot -Tsvg > webapp.svg
+  wark graph --epic WEBAPP-12 --format mermaid
+  wark graph --project WEBAPP --format json`,
+	Args: cobra.NoArgs,
+	RunE: runGraph,
+}
+
+func runGraph(cmd *cobra.Command, args []string) error {
+	format := graphFormat
+	if format == "" {
+		format = "dot"
+		if IsJSON() {
+			format = "json"
+		}
+	}
+	if format != "dot" && format != "mermaid" && format != "json" {
+		return ErrInvalidArgs("invalid format: %s (must be dot, mermaid or json)", graphFormat)
+	}
+
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return ErrDatabaseWithSuggestion(err, SuggestRunInit, "failed to open database")
+	}
+	defer database.Close()
+
+	graph, err := service.NewTicketService(database.DB).Graph(service.GraphOptions{
+		ProjectKey: graphProject,
+		EpicKey:    graphEpic,
+	})
+	if err != nil {
+		return translateGraphError(err)
+	}
+
+	switch format {
+	case "dot":
+		fmt.Print(graph.DOT())
+	case "mermaid":
+		fmt.Print(graph.Mermaid())
+	default:
+		data, _ := json.MarshalIndent(graph, "", "  ")
+		fmt.Println(string(data))
+	}
+	return nil
+}
+
+var graphCriticalPathCmd = &cobra.Command{
+	Use:   "critical-path",
+	Short: "Show the longest chain of open work and what blocks the most",
+	Long: `Show the longest chain of open tickets in a project or epic, following
+dependencies and weighted by complexity (trivial 1, small 2, medium 3,
+large 5, xlarge 8), and rank open tickets by how many open tickets depend on
+them, directly or transitively.
+
+The chain may pass through tickets in other projects that the epic's tickets
+depend on. Epics themselves are not counted.
+
+Examples:
+  wark graph critical-path --epic WEBAPP-12
+  wark graph critical-path --project WEBAPP --limit 5`,
+	Args: cobra.NoArgs,
+	RunE: runGraphCriticalPath,
+}
+
+func runGraphCriticalPath(cmd *cobra.Command, args []string) error {
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return ErrDatabaseWithSuggestion(err, SuggestRunInit, "failed to open database")
+	}
+	defer database.Close()
+
+	result, err := service.NewTicketService(database.DB).CriticalPath(service.GraphOptions{
+		ProjectKey: graphProject,
+		EpicKey:    graphEpic,
+	}, graphLimit)
+	if err != nil {
+		return translateGraphError(err)
+	}
+
+	if IsJSON() {
+		data, _ := json.MarshalIndent(result, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(result.Path) == 0 {
+		OutputLine("No open tickets.")
+		return nil
+	}
+
+	OutputLine("Critical path: %d ticket(s), estimate %d (%d open ticket(s) in total)",
+		len(result.Path), result.Estimate, result.OpenTickets)
+	OutputLine("")
+	for i, t := range result.Path {
+		OutputLine("  %d. %-12s %-10s %-8s %s", i+1, t.Key, t.Status, t.Complexity, truncate(t.Title, 50))
+	}
+
+	if len(result.Impact) > 0 {
+		OutputLine("")
+		OutputLine("Blocking the most work:")
+		OutputLine("")
+		fmt.Printf("  %-12s %-10s %-9s %-10s %s\n", "TICKET", "STATUS", "UNBLOCKS", "DEPENDENTS", "TITLE")
+		for _, t := range result.Impact {
+			fmt.Printf("  %-12s %-10s %-9d %-10d %s\n", t.Key, t.Status, t.Unblocks, t.Dependents, truncate(t.Title, 40))
+		}
+	}
+	return nil
+}
+
+// translateGraphError converts a graph error to a CLI error, keeping the
+// service message, which names the missing project or ticket.
+func translateGraphError(err error) error {
+	svcErr, ok := err.(*service.TicketError)
+	if !ok {
+		return ErrDatabase(err, "failed to build graph")
+	}
+	return &werrors.Error{Kind: svcErr.Kind(), Message: svcErr.Message}
+}
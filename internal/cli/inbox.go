@@ -1,9 +1,14 @@
 package cli
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"os/signal"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/spetersoncode/wark/internal/common"
 	"github.com/spetersoncode/wark/internal/db"
@@ -17,18 +22,38 @@ import (
 var (
 	inboxProject string
 	inboxType    string
+	inboxResolve bool
+	inboxOptions []string
+	inboxChoose  string
+	inboxThen    string
+	inboxTimeout time.Duration
+	inboxPoll    time.Duration
+	inboxOverdue bool
+	inboxDryRun  bool
+	inboxDaemon  bool
+	inboxEvery   int
 )
 
 func init() {
 	// inbox list (always shows only pending - responded messages are gone)
 	inboxListCmd.Flags().StringVarP(&inboxProject, "project", "p", "", "Filter by project")
 	inboxListCmd.Flags().StringVar(&inboxType, "type", "", "Filter by message type (question, decision, review, escalation, info)")
+	inboxListCmd.Flags().BoolVar(&inboxOverdue, "overdue", false, "Only show messages past their response SLA")
+
+	// inbox escalate
+	inboxEscalateCmd.Flags().BoolVar(&inboxDryRun, "dry-run", false, "Show what would be escalated without making changes")
+	inboxEscalateCmd.Flags().BoolVar(&inboxDaemon, "daemon", false, "Run continuously, checking every N seconds")
+	inboxEscalateCmd.Flags().IntVar(&inboxEvery, "interval", 60, "Check interval in seconds (for --daemon mode)")
 
 	// Add subcommands
 	inboxCmd.AddCommand(inboxListCmd)
 	inboxCmd.AddCommand(inboxShowCmd)
 	inboxCmd.AddCommand(inboxSendCmd)
 	inboxCmd.AddCommand(inboxRespondCmd)
+	inboxCmd.AddCommand(inboxReplyCmd)
+	inboxCmd.AddCommand(inboxThreadCmd)
+	inboxCmd.AddCommand(inboxWaitCmd)
+	inboxCmd.AddCommand(inboxEscalateCmd)
 
 	rootCmd.AddCommand(inboxCmd)
 }
@@ -45,15 +70,24 @@ var inboxListCmd = &cobra.Command{
 	Short: "List inbox messages",
 	Long: `List pending inbox messages. Once responded, messages are removed from the inbox.
 
+Messages past the response SLA configured for their type and ticket
+priority ([[inbox_sla]] in the config file) are marked overdue.
+
 Examples:
   wark inbox list                           # List pending messages
   wark inbox list --project WEBAPP          # Filter by project
-  wark inbox list --type question           # Filter by type`,
+  wark inbox list --type question           # Filter by type
+  wark inbox list --overdue                 # Only messages past their SLA`,
 	Args: cobra.NoArgs,
 	RunE: runInboxList,
 }
 
 func runInboxList(cmd *cobra.Command, args []string) error {
+	policy, err := inboxSLAPolicy()
+	if err != nil {
+		return err
+	}
+
 	database, err := db.Open(GetDBPath())
 	if err != nil {
 		return fmt.Errorf("failed to open database: %w", err)
@@ -81,6 +115,16 @@ func runInboxList(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to list messages: %w", err)
 	}
+	policy.Apply(messages, time.Now())
+	if inboxOverdue {
+		overdue := messages[:0]
+		for _, m := range messages {
+			if m.Overdue {
+				overdue = append(overdue, m)
+			}
+		}
+		messages = overdue
+	}
 
 	if len(messages) == 0 {
 		if IsJSON() {
@@ -105,6 +149,8 @@ func runInboxList(cmd *cobra.Command, args []string) error {
 		status := ""
 		if m.RespondedAt != nil {
 			status = " ✓"
+		} else if m.Overdue {
+			status = " ⚠ overdue"
 		}
 		fmt.Printf("%-5d %-12s %-11s %-9s %s%s\n",
 			m.ID,
@@ -119,6 +165,15 @@ func runInboxList(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// inboxSLAPolicy returns the configured inbox response SLAs.
+func inboxSLAPolicy() (*service.SLAPolicy, error) {
+	policy, err := service.ParseSLAPolicy(GetConfig().InboxSLA)
+	if err != nil {
+		return nil, ErrInvalidArgsWithSuggestion("Check the [[inbox_sla]] section of your config file.", "invalid inbox SLA config: %s", err)
+	}
+	return policy, nil
+}
+
 // inbox show
 var inboxShowCmd = &cobra.Command{
 	Use:   "show <MESSAGE_ID>",
@@ -151,6 +206,9 @@ func runInboxShow(cmd *cobra.Command, args []string) error {
 	if message == nil {
 		return fmt.Errorf("message #%d not found", msgID)
 	}
+	if policy, err := inboxSLAPolicy(); err == nil {
+		policy.Apply([]*models.InboxMessage{message}, time.Now())
+	}
 
 	if IsJSON() {
 		data, _ := json.MarshalIndent(message, "", "  ")
@@ -175,6 +233,19 @@ func runInboxShow(cmd *cobra.Command, args []string) error {
 		status = fmt.Sprintf("Responded on %s", message.RespondedAt.Local().Format("2006-01-02 15:04:05"))
 	}
 	fmt.Printf("Status:     %s\n", status)
+	if message.DueAt != nil {
+		due := message.DueAt.Local().Format("2006-01-02 15:04:05")
+		if message.Overdue {
+			due += " (overdue)"
+		}
+		fmt.Printf("Due:        %s\n", due)
+	}
+	if message.EscalatedAt != nil {
+		fmt.Printf("Escalated:  %s (overdue)\n", message.EscalatedAt.Local().Format("2006-01-02 15:04:05"))
+	}
+	if message.ReplyCount > 0 {
+		fmt.Printf("Replies:    %d (see 'wark inbox thread %d')\n", message.ReplyCount, message.ID)
+	}
 
 	fmt.Println()
 	fmt.Println(strings.Repeat("-", 65))
@@ -182,6 +253,18 @@ func runInboxShow(cmd *cobra.Command, args []string) error {
 	fmt.Println(strings.Repeat("-", 65))
 	fmt.Println(message.Content)
 
+	if len(message.Options) > 0 {
+		fmt.Println()
+		fmt.Println("Options:")
+		for _, o := range message.Options {
+			marker := " "
+			if strings.EqualFold(o.Key, message.Choice) {
+				marker = "✓"
+			}
+			fmt.Printf("  %s %s\n", marker, o)
+		}
+	}
+
 	if message.Response != "" {
 		fmt.Println()
 		fmt.Println(strings.Repeat("-", 65))
@@ -208,9 +291,14 @@ Message types:
   escalation - Escalate an issue
   info       - Informational message
 
+A decision can offer options as "KEY: label" with --option (at least two).
+The human must then respond with one of them using --choose, and the
+chosen key is returned in 'ticket show' and the execution context.
+
 Examples:
   wark inbox send WEBAPP-42 --type question "Should I use REST or GraphQL?"
-  wark inbox send WEBAPP-42 --type decision "Choose between: 1) JWT 2) Sessions"`,
+  wark inbox send WEBAPP-42 --type decision "Which database?" \
+    --option "A: use Postgres" --option "B: stay on SQLite"`,
 	Args: cobra.MinimumNArgs(2),
 	RunE: runInboxSend,
 }
@@ -218,6 +306,7 @@ Examples:
 func init() {
 	inboxSendCmd.Flags().StringVar(&inboxType, "type", "question", "Message type")
 	inboxSendCmd.Flags().StringVar(&claimWorkerID, "worker-id", "", "Sending agent's ID")
+	inboxSendCmd.Flags().StringArrayVar(&inboxOptions, "option", nil, "Decision option as \"KEY: label\" (repeatable)")
 }
 
 func runInboxSend(cmd *cobra.Command, args []string) error {
@@ -244,14 +333,18 @@ func runInboxSend(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid message type: %s", inboxType)
 	}
 
-	// Use InboxService for the send operation
-	inboxRepo := db.NewInboxRepo(database.DB)
-	ticketRepo := db.NewTicketRepo(database.DB)
-	claimRepo := db.NewClaimRepo(database.DB)
-	activityRepo := db.NewActivityRepo(database.DB)
+	var options []models.DecisionOption
+	for _, o := range inboxOptions {
+		option, err := models.ParseDecisionOption(o)
+		if err != nil {
+			return ErrInvalidArgs("%s", err)
+		}
+		options = append(options, option)
+	}
 
-	inboxService := service.NewInboxService(inboxRepo, ticketRepo, claimRepo, activityRepo)
-	result, err := inboxService.Send(ticket.ID, msgType, message, claimWorkerID)
+	// Use InboxService for the send operation
+	inboxService := newInboxService(database)
+	result, err := inboxService.SendWithOptions(ticket.ID, msgType, message, claimWorkerID, options)
 	if err != nil {
 		// Convert shared errors to CLI-friendly messages
 		if sharedErr, ok := err.(*errors.Error); ok {
@@ -275,6 +368,9 @@ func runInboxSend(cmd *cobra.Command, args []string) error {
 		if result.ClaimReleased {
 			jsonResult["claim_released"] = true
 		}
+		if len(options) > 0 {
+			jsonResult["options"] = options
+		}
 		data, _ := json.MarshalIndent(jsonResult, "", "  ")
 		fmt.Println(string(data))
 		return nil
@@ -283,6 +379,9 @@ func runInboxSend(cmd *cobra.Command, args []string) error {
 	OutputLine("Message sent: #%d", result.Message.ID)
 	OutputLine("Ticket: %s", ticket.TicketKey)
 	OutputLine("Type: %s", msgType)
+	for _, o := range options {
+		OutputLine("  %s", o)
+	}
 	if result.StatusChanged {
 		OutputLine("Status: %s → %s", result.PreviousStatus, result.NewStatus)
 	}
@@ -295,13 +394,33 @@ func runInboxSend(cmd *cobra.Command, args []string) error {
 
 // inbox respond
 var inboxRespondCmd = &cobra.Command{
-	Use:   "respond <MESSAGE_ID> <RESPONSE>",
+	Use:   "respond <MESSAGE_ID> [RESPONSE]",
 	Short: "Respond to an inbox message",
-	Long: `Respond to an inbox message. This will unblock the associated ticket.
+	Long: `Respond to an inbox message. The response is added to the message's
+thread and resolves it, which unblocks the associated ticket. To reply
+without resolving, use 'wark inbox reply'.
+
+A decision with options must be answered with --choose and one of the
+option keys; the response text is then optional.
+
+With --then, the response also acts on the ticket instead of just returning
+it to ready. The action is checked like the matching ticket command, and if
+it fails nothing is recorded:
+  close:<resolution>  Close the ticket (completed, wont_do, duplicate,
+                      invalid or obsolete), with the response as the reason
+  deprioritize        Move the ticket to the backlog
+  priority:<level>    Set the priority and return the ticket to ready
+  accept              Accept a ticket in review
+  reject              Reject a ticket in review, with the response as the reason
 
 Examples:
-  wark inbox respond 12 "Use REST for simplicity."`,
-	Args: cobra.MinimumNArgs(2),
+  wark inbox respond 12 "Use REST for simplicity."
+  wark inbox respond 12 --choose B
+  wark inbox respond 12 --choose B "SQLite is enough until we shard."
+  wark inbox respond 12 --then close:wont_do "Not worth the complexity."
+  wark inbox respond 12 --then priority:highest "Customers are hitting this."
+  wark inbox respond 15 --then accept "Looks good."`,
+	Args: cobra.MinimumNArgs(1),
 	RunE: runInboxRespond,
 }
 
@@ -312,24 +431,26 @@ func runInboxRespond(cmd *cobra.Command, args []string) error {
 	}
 
 	response := strings.Join(args[1:], " ")
-	if response == "" {
+	if response == "" && inboxChoose == "" {
 		return fmt.Errorf("response is required")
 	}
 
+	var then *service.RespondAction
+	if inboxThen != "" {
+		if then, err = service.ParseRespondAction(inboxThen); err != nil {
+			return err
+		}
+	}
+
 	database, err := db.Open(GetDBPath())
 	if err != nil {
 		return fmt.Errorf("failed to open database: %w", err)
 	}
 	defer database.Close()
 
-	// Use InboxService for the respond operation
-	inboxRepo := db.NewInboxRepo(database.DB)
-	ticketRepo := db.NewTicketRepo(database.DB)
-	claimRepo := db.NewClaimRepo(database.DB)
-	activityRepo := db.NewActivityRepo(database.DB)
-
-	inboxService := service.NewInboxService(inboxRepo, ticketRepo, claimRepo, activityRepo)
-	result, err := inboxService.Respond(msgID, response)
+	// Respond and apply the action, if any, in one transaction
+	ticketService := service.NewTicketService(database.DB)
+	result, err := ticketService.RespondAndAct(msgID, service.RespondInput{Response: response, Choice: inboxChoose}, then)
 	if err != nil {
 		// Convert shared errors to CLI-friendly messages
 		if sharedErr, ok := err.(*errors.Error); ok {
@@ -348,6 +469,12 @@ func runInboxRespond(cmd *cobra.Command, args []string) error {
 		if result.TicketUpdated {
 			jsonResult["new_status"] = result.NewStatus
 		}
+		if result.Message.Choice != "" {
+			jsonResult["choice"] = result.Message.Choice
+		}
+		if then != nil {
+			jsonResult["action"] = then.String()
+		}
 		data, _ := json.MarshalIndent(jsonResult, "", "  ")
 		fmt.Println(string(data))
 		return nil
@@ -355,14 +482,299 @@ func runInboxRespond(cmd *cobra.Command, args []string) error {
 
 	OutputLine("Responded to message #%d", msgID)
 	OutputLine("Ticket: %s", result.Message.TicketKey)
+	if option := result.Message.FindOption(result.Message.Choice); option != nil {
+		OutputLine("Chose: %s", option)
+	}
+	if then != nil {
+		OutputLine("Action: %s", then)
+	}
 	if result.TicketUpdated {
-		OutputLine("Ticket status: %s → %s", models.StatusHuman, result.NewStatus)
-		OutputLine("Retry count reset to 0")
+		OutputLine("Ticket status: %s → %s", result.PreviousStatus, result.NewStatus)
+		if then == nil || then.Type == service.RespondActionPriority {
+			OutputLine("Retry count reset to 0")
+		}
+	}
+
+	return nil
+}
+
+// inbox reply
+var inboxReplyCmd = &cobra.Command{
+	Use:   "reply <MESSAGE_ID> [REPLY]",
+	Short: "Reply to an inbox message's thread",
+	Long: `Add a reply to an inbox message's thread.
+
+A reply without --worker-id is from a human. It leaves the ticket waiting
+on the thread until a human resolves it with --resolve, which returns the
+ticket to ready. Resolving without a reply uses the latest human reply as
+the response.
+
+A reply with --worker-id is an agent's follow-up. It reopens a resolved
+thread and, for questions, decisions, reviews and escalations, moves the
+ticket back to human.
+
+Examples:
+  wark inbox reply 12 "Which endpoints need auth?"                 # Human asks back
+  wark inbox reply 12 --worker-id agent-1 "Only the admin ones?"   # Agent follow-up
+  wark inbox reply 12 --resolve "Yes, admin only."                 # Answer and resolve
+  wark inbox reply 12 --resolve                                    # Resolve as discussed`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runInboxReply,
+}
+
+func init() {
+	inboxReplyCmd.Flags().BoolVar(&inboxResolve, "resolve", false, "Mark the thread resolved and return the ticket to ready")
+	inboxReplyCmd.Flags().StringVar(&claimWorkerID, "worker-id", "", "Replying agent's ID (omit for a human reply)")
+	inboxReplyCmd.Flags().StringVar(&inboxChoose, "choose", "", "Option key chosen when resolving a decision")
+}
+
+func init() {
+	inboxRespondCmd.Flags().StringVar(&inboxChoose, "choose", "", "Option key chosen for a decision")
+	inboxRespondCmd.Flags().StringVar(&inboxThen, "then", "", "Also act on the ticket: close:<resolution>, deprioritize, priority:<level>, accept or reject")
+}
+
+func runInboxReply(cmd *cobra.Command, args []string) error {
+	msgID, err := parseID(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid message ID: %w", err)
+	}
+
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer database.Close()
+
+	inboxService := newInboxService(database)
+	result, err := inboxService.Reply(msgID, service.ReplyInput{
+		Content:  strings.Join(args[1:], " "),
+		WorkerID: claimWorkerID,
+		Resolve:  inboxResolve,
+		Choice:   inboxChoose,
+	})
+	if err != nil {
+		// Convert shared errors to CLI-friendly messages
+		if sharedErr, ok := err.(*errors.Error); ok {
+			return fmt.Errorf("%s", sharedErr.Message)
+		}
+		return err
+	}
+
+	if IsJSON() {
+		jsonResult := map[string]interface{}{
+			"message_id":     msgID,
+			"ticket":         result.Message.TicketKey,
+			"resolved":       result.Resolved,
+			"status_changed": result.StatusChanged,
+		}
+		if result.Reply != nil {
+			jsonResult["reply"] = result.Reply
+		}
+		if result.Message.Choice != "" {
+			jsonResult["choice"] = result.Message.Choice
+		}
+		if result.Reopened {
+			jsonResult["reopened"] = true
+		}
+		if result.StatusChanged {
+			jsonResult["previous_status"] = string(result.PreviousStatus)
+			jsonResult["new_status"] = string(result.NewStatus)
+		}
+		if result.ClaimReleased {
+			jsonResult["claim_released"] = true
+		}
+		data, _ := json.MarshalIndent(jsonResult, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if result.Reply != nil {
+		OutputLine("Replied to message #%d", msgID)
+	}
+	OutputLine("Ticket: %s", result.Message.TicketKey)
+	if result.Reopened {
+		OutputLine("Thread reopened")
+	}
+	if result.Resolved {
+		OutputLine("Thread resolved")
+	}
+	if option := result.Message.FindOption(result.Message.Choice); option != nil {
+		OutputLine("Chose: %s", option)
+	}
+	if result.StatusChanged {
+		OutputLine("Ticket status: %s → %s", result.PreviousStatus, result.NewStatus)
+	}
+	if result.ClaimReleased {
+		OutputLine("Claim released")
+	}
+
+	return nil
+}
+
+// inbox thread
+var inboxThreadCmd = &cobra.Command{
+	Use:   "thread <MESSAGE_ID>",
+	Short: "Show an inbox message's thread",
+	Long: `Display an inbox message followed by its replies in order.
+
+Examples:
+  wark inbox thread 12`,
+	Args: cobra.ExactArgs(1),
+	RunE: runInboxThread,
+}
+
+func runInboxThread(cmd *cobra.Command, args []string) error {
+	msgID, err := parseID(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid message ID: %w", err)
+	}
+
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer database.Close()
+
+	inboxService := newInboxService(database)
+	thread, err := inboxService.Thread(msgID)
+	if err != nil {
+		if sharedErr, ok := err.(*errors.Error); ok {
+			return fmt.Errorf("%s", sharedErr.Message)
+		}
+		return err
+	}
+
+	if IsJSON() {
+		data, _ := json.MarshalIndent(thread, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	message := thread.Message
+	fmt.Println(strings.Repeat("=", 65))
+	fmt.Printf("Inbox Thread #%d: %s - %s\n", message.ID, message.TicketKey, message.TicketTitle)
+	fmt.Println(strings.Repeat("=", 65))
+
+	from := "agent"
+	if message.FromAgent != "" {
+		from = message.FromAgent
+	}
+	printThreadEntry(fmt.Sprintf("%s (%s)", from, message.MessageType), message.CreatedAt, message.Content)
+	for _, o := range message.Options {
+		fmt.Printf("  %s\n", o)
+	}
+	for _, reply := range thread.Replies {
+		author := string(reply.AuthorType)
+		if reply.Author != "" {
+			author = reply.Author
+		}
+		printThreadEntry(author, reply.CreatedAt, reply.Content)
+	}
+
+	fmt.Println()
+	if option := message.FindOption(message.Choice); option != nil {
+		fmt.Printf("Chose: %s\n", option)
+	}
+	if thread.Resolved {
+		fmt.Printf("Resolved on %s\n", message.RespondedAt.Local().Format("2006-01-02 15:04:05"))
+	} else {
+		fmt.Println("Open: waiting for a human to resolve")
 	}
 
 	return nil
 }
 
+func printThreadEntry(author string, at time.Time, content string) {
+	fmt.Println()
+	fmt.Printf("%s · %s\n", author, at.Local().Format("2006-01-02 15:04:05"))
+	fmt.Println(strings.Repeat("-", 65))
+	fmt.Println(content)
+}
+
+// inbox wait
+var inboxWaitCmd = &cobra.Command{
+	Use:   "wait <MESSAGE_ID|TICKET>",
+	Short: "Wait for a response to an inbox message",
+	Long: `Block until a human resolves an inbox message, then print the message
+with its response. Given a ticket, waits on its newest pending message (or
+returns its newest message if none is pending).
+
+The response is read from the database, so it arrives however it was given:
+another terminal, the web UI or the API. Exits with code 8 if no response
+arrives within --timeout; 0 waits indefinitely.
+
+Examples:
+  wark ticket human WEBAPP-42 "Which OAuth provider?" && wark inbox wait WEBAPP-42
+  wark inbox wait 12 --timeout 30m`,
+	Args: cobra.ExactArgs(1),
+	RunE: runInboxWait,
+}
+
+func init() {
+	inboxWaitCmd.Flags().DurationVar(&inboxTimeout, "timeout", 30*time.Minute, "How long to wait (0 waits indefinitely)")
+	inboxWaitCmd.Flags().DurationVar(&inboxPoll, "interval", time.Second, "How often to check for a response")
+}
+
+func runInboxWait(cmd *cobra.Command, args []string) error {
+	if inboxTimeout < 0 {
+		return ErrInvalidArgs("--timeout cannot be negative")
+	}
+	if inboxPoll <= 0 {
+		return ErrInvalidArgs("--interval must be positive")
+	}
+
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer database.Close()
+
+	inboxService := newInboxService(database)
+
+	msgID, err := parseID(args[0])
+	if err != nil {
+		ticket, err := resolveTicket(database, args[0], "")
+		if err != nil {
+			return err
+		}
+		message, err := inboxService.LatestMessage(ticket.ID)
+		if err != nil {
+			return err
+		}
+		msgID = message.ID
+	}
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if inboxTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, inboxTimeout)
+		defer cancel()
+	}
+
+	VerboseOutput("Waiting for a response to message #%d...\n", msgID)
+	message, err := inboxService.Wait(ctx, msgID, inboxPoll)
+	if err != nil {
+		// Keep the shared error so a timeout exits with its own code
+		return err
+	}
+
+	if IsJSON() {
+		data, _ := json.MarshalIndent(message, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	OutputLine("Response to message #%d (%s):", message.ID, message.TicketKey)
+	if option := message.FindOption(message.Choice); option != nil {
+		OutputLine("Chose: %s", option)
+	}
+	fmt.Println(message.Response)
+	return nil
+}
+
 // parseID parses a string as an int64 ID
 func parseID(s string) (int64, error) {
 	var id int64
@@ -372,3 +784,138 @@ func parseID(s string) (int64, error) {
 	}
 	return id, nil
 }
+
+// inbox escalate
+var inboxEscalateCmd = &cobra.Command{
+	Use:   "escalate",
+	Short: "Escalate inbox messages past their response SLA",
+	Long: `Escalate pending inbox messages that have passed the response SLA
+configured for their type and ticket priority ([[inbox_sla]] in the config
+file).
+
+Each overdue question, decision or escalation is escalated once, or once
+more after an agent reopens it: its ticket moves to human and its claim is
+released if it hadn't already, and an inbox.overdue event is emitted for
+webhooks and /api/events. Info and review messages are never escalated.
+
+Without --daemon, this runs once and exits, which suits cron. 'wark serve'
+escalates overdue messages itself while it runs.
+
+Examples:
+  wark inbox escalate                         # Run once
+  wark inbox escalate --dry-run               # Show what would be escalated
+  wark inbox escalate --daemon --interval 300 # Run continuously`,
+	Args: cobra.NoArgs,
+	RunE: runInboxEscalate,
+}
+
+func runInboxEscalate(cmd *cobra.Command, args []string) error {
+	if inboxEvery <= 0 {
+		return ErrInvalidArgs("--interval must be positive")
+	}
+	if inboxDaemon && inboxDryRun {
+		return ErrInvalidArgs("--daemon cannot be used with --dry-run")
+	}
+
+	policy, err := inboxSLAPolicy()
+	if err != nil {
+		return err
+	}
+
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return ErrDatabaseWithSuggestion(err, SuggestRunInit, "failed to open database")
+	}
+	defer database.Close()
+
+	inboxService := newInboxService(database)
+
+	if inboxDaemon {
+		return runInboxEscalateDaemon(inboxService, policy, time.Duration(inboxEvery)*time.Second)
+	}
+
+	result, err := inboxService.EscalateOverdue(policy, time.Now(), inboxDryRun)
+	if err != nil {
+		return ErrDatabase(err, "failed to escalate overdue messages")
+	}
+
+	if IsJSON() {
+		data, _ := json.MarshalIndent(result, "", "  ")
+		fmt.Println(string(data))
+	} else {
+		for _, e := range result.Escalations {
+			printOverdueEscalation("", e, result.DryRun)
+		}
+		if result.DryRun {
+			OutputLine("Would escalate %d of %d overdue message(s)", len(result.Escalations), result.Overdue)
+		} else {
+			OutputLine("Escalated %d of %d overdue message(s), %d failed", result.Escalated, result.Overdue, result.Errors)
+		}
+	}
+
+	if result.Errors > 0 {
+		return fmt.Errorf("%d overdue message(s) could not be escalated", result.Errors)
+	}
+	return nil
+}
+
+func newInboxService(database *db.DB) *service.InboxService {
+	return service.NewInboxServiceFromDB(database.DB)
+}
+
+// printOverdueEscalation prints one overdue message escalation, prefixed
+// with a timestamp in daemon mode.
+func printOverdueEscalation(prefix string, e *service.OverdueEscalation, dryRun bool) {
+	switch {
+	case e.Error != "":
+		OutputLine("%sFailed #%d (%s): %s", prefix, e.MessageID, e.TicketKey, e.Error)
+	case dryRun:
+		OutputLine("%sWould escalate #%d (%s, %s), due %s", prefix, e.MessageID, e.TicketKey, e.MessageType, common.FormatAge(e.DueAt))
+	case e.StatusChanged:
+		OutputLine("%sEscalated #%d (%s, %s): %s → %s", prefix, e.MessageID, e.TicketKey, e.MessageType, e.PreviousStatus, e.NewStatus)
+	default:
+		OutputLine("%sEscalated #%d (%s, %s)", prefix, e.MessageID, e.TicketKey, e.MessageType)
+	}
+}
+
+func runInboxEscalateDaemon(inboxService *service.InboxService, policy *service.SLAPolicy, interval time.Duration) error {
+	OutputLine("Starting inbox SLA escalation (checking every %s)", interval)
+	OutputLine("Press Ctrl+C to stop...")
+	OutputLine("")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Handle shutdown signals
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+
+	go func() {
+		<-sigChan
+		OutputLine("")
+		OutputLine("Shutting down daemon...")
+		cancel()
+	}()
+
+	err := inboxService.RunEscalateOverdueDaemon(ctx, policy, interval, logEscalateResult)
+	if err == context.Canceled {
+		OutputLine("Daemon stopped.")
+		return nil
+	}
+	return err
+}
+
+// logEscalateResult logs one run of the overdue escalation daemon.
+func logEscalateResult(result *service.EscalateOverdueResult, err error) {
+	now := time.Now().Format("15:04:05")
+	if err != nil {
+		OutputLine("[%s] Inbox escalation failed: %s", now, err)
+		return
+	}
+	for _, e := range result.Escalations {
+		printOverdueEscalation("["+now+"] ", e, false)
+	}
+	if len(result.Escalations) == 0 {
+		VerboseOutput("[%s] No overdue inbox messages to escalate\n", now)
+	}
+}
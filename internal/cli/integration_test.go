@@ -1113,9 +1113,7 @@ func TestWorkableExcludesBlocked(t *testing.T) {
 	assert.Equal(t, "Workable", tickets[0].Title)
 }
 
-// TestWorkableExcludesMaxRetries tests that tickets at max retries need application-level filtering
-// Note: The ListWorkable query returns ready tickets with resolved deps, but retry filtering
-// is done at application level (in ticket_util.go's runTicketNext function)
+// TestWorkableExcludesMaxRetries tests that tickets at max retries are not workable
 func TestWorkableExcludesMaxRetries(t *testing.T) {
 	database, cleanup := testDB(t)
 	defer cleanup()
@@ -1138,19 +1136,10 @@ func TestWorkableExcludesMaxRetries(t *testing.T) {
 	err = ticketRepo.Create(ticket)
 	require.NoError(t, err)
 
-	// ListWorkable returns the ticket (it's ready with no unresolved deps)
+	// ListWorkable filters out tickets with no retries left
 	tickets, err := ticketRepo.ListWorkable(db.TicketFilter{})
 	require.NoError(t, err)
-	assert.Len(t, tickets, 1) // Returned from DB
-
-	// Application-level filtering: exclude tickets at max retries
-	workable := make([]*models.Ticket, 0)
-	for _, t := range tickets {
-		if t.RetryCount < t.MaxRetries {
-			workable = append(workable, t)
-		}
-	}
-	assert.Len(t, workable, 0) // Filtered out at application level
+	assert.Len(t, tickets, 0)
 }
 
 // TestWorkableOnlyReady tests that workable only includes ready status
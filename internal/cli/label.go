@@ -0,0 +1,157 @@
+package cli
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spetersoncode/wark/internal/db"
+	"github.com/spetersoncode/wark/internal/models"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	labelCmd.AddCommand(labelAddCmd)
+	labelCmd.AddCommand(labelRemoveCmd)
+
+	ticketCmd.AddCommand(labelCmd)
+}
+
+var labelCmd = &cobra.Command{
+	Use:   "label",
+	Short: "Label management commands",
+	Long: `Manage free-form labels on tickets.
+
+Labels are lowercase and may contain letters, numbers, ':', '.', '_', '/'
+and '-' (e.g., "area:api", "needs-migration"). Use --label on ticket list,
+ticket next and analytics to filter by them.`,
+}
+
+// label add
+var labelAddCmd = &cobra.Command{
+	Use:   "add <TICKET> <LABEL>...",
+	Short: "Add labels to a ticket",
+	Long: `Add one or more labels to a ticket. Labels the ticket already has are ignored.
+
+Examples:
+  wark ticket label add WEBAPP-42 area:api
+  wark ticket label add WEBAPP-42 area:api needs-migration`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runLabelAdd,
+}
+
+// label remove
+var labelRemoveCmd = &cobra.Command{
+	Use:   "remove <TICKET> <LABEL>...",
+	Short: "Remove labels from a ticket",
+	Long: `Remove one or more labels from a ticket. Labels the ticket does not have are ignored.
+
+Examples:
+  wark ticket label remove WEBAPP-42 needs-migration`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runLabelRemove,
+}
+
+type labelResult struct {
+	Ticket  string   `json:"ticket"`
+	Labels  []string `json:"labels"`
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+}
+
+func runLabelAdd(cmd *cobra.Command, args []string) error {
+	return changeLabels(args[0], args[1:], true)
+}
+
+func runLabelRemove(cmd *cobra.Command, args []string) error {
+	return changeLabels(args[0], args[1:], false)
+}
+
+// changeLabels adds or removes labels on a ticket in one transaction,
+// logging a field change for each label actually added or removed.
+func changeLabels(ticketKey string, values []string, add bool) error {
+	labels, err := models.ParseLabels(values)
+	if err != nil {
+		return ErrInvalidArgs("%s", err)
+	}
+
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return ErrDatabaseWithSuggestion(err, SuggestRunInit, "failed to open database")
+	}
+	defer database.Close()
+
+	ticket, err := resolveTicket(database, ticketKey, "")
+	if err != nil {
+		return err
+	}
+
+	result := labelResult{Ticket: ticket.TicketKey}
+	err = db.WithTx(database.DB, func(tx *sql.Tx) error {
+		labelRepo := db.NewLabelRepo(tx)
+		activityRepo := db.NewActivityRepo(tx)
+
+		for _, label := range labels {
+			var changed bool
+			var err error
+			if add {
+				changed, err = labelRepo.Add(ticket.ID, label)
+			} else {
+				changed, err = labelRepo.Remove(ticket.ID, label)
+			}
+			if err != nil {
+				return err
+			}
+			if !changed {
+				continue
+			}
+
+			details := map[string]interface{}{"field": "labels"}
+			var summary string
+			if add {
+				result.Added = append(result.Added, label)
+				details["new"] = label
+				summary = fmt.Sprintf("Label added: %s", label)
+			} else {
+				result.Removed = append(result.Removed, label)
+				details["old"] = label
+				summary = fmt.Sprintf("Label removed: %s", label)
+			}
+			if err := activityRepo.LogActionWithDetails(ticket.ID, models.ActionFieldChanged, models.ActorTypeHuman, "", summary, details); err != nil {
+				return err
+			}
+		}
+
+		result.Labels, err = labelRepo.ListByTicket(ticket.ID)
+		return err
+	})
+	if err != nil {
+		return ErrDatabase(err, "failed to update labels")
+	}
+	if result.Labels == nil {
+		result.Labels = []string{}
+	}
+
+	if IsJSON() {
+		data, _ := json.MarshalIndent(result, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	switch {
+	case add && len(result.Added) > 0:
+		OutputLine("Added to %s: %s", ticket.TicketKey, strings.Join(result.Added, ", "))
+	case !add && len(result.Removed) > 0:
+		OutputLine("Removed from %s: %s", ticket.TicketKey, strings.Join(result.Removed, ", "))
+	default:
+		OutputLine("No label changes made to %s", ticket.TicketKey)
+	}
+	if len(result.Labels) > 0 {
+		OutputLine("Labels: %s", strings.Join(result.Labels, ", "))
+	} else {
+		OutputLine("Labels: (none)")
+	}
+
+	return nil
+}
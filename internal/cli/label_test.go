@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/spetersoncode/wark/internal/db"
+	"github.com/spetersoncode/wark/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTicketLabels(t *testing.T) {
+	database, dbPath, cleanup := testDBWithPath(t)
+	defer cleanup()
+
+	projectRepo := db.NewProjectRepo(database.DB)
+	project := &models.Project{Key: "LBL", Name: "Label Test"}
+	require.NoError(t, projectRepo.Create(project))
+
+	ticketRepo := db.NewTicketRepo(database.DB)
+	for _, title := range []string{"API ticket", "Other ticket"} {
+		require.NoError(t, ticketRepo.Create(&models.Ticket{
+			ProjectID: project.ID,
+			Title:     title,
+			Status:    models.StatusReady,
+		}))
+	}
+
+	var added labelResult
+	err := runCmdJSON(t, dbPath, &added, "ticket", "label", "add", "LBL-1", "Area:API", "needs-migration")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"area:api", "needs-migration"}, added.Labels)
+	assert.Equal(t, []string{"area:api", "needs-migration"}, added.Added)
+
+	_, err = runCmd(t, dbPath, "ticket", "label", "add", "LBL-1", "bad label")
+	require.Error(t, err)
+
+	output, err := runCmd(t, dbPath, "ticket", "show", "LBL-1")
+	require.NoError(t, err)
+	assert.Contains(t, output, "area:api, needs-migration")
+
+	var tickets []*models.Ticket
+	err = runCmdJSON(t, dbPath, &tickets, "ticket", "list", "--label", "area:api")
+	require.NoError(t, err)
+	require.Len(t, tickets, 1)
+	assert.Equal(t, "LBL-1", tickets[0].TicketKey)
+	assert.Equal(t, []string{"area:api", "needs-migration"}, tickets[0].Labels)
+
+	var removed labelResult
+	err = runCmdJSON(t, dbPath, &removed, "ticket", "label", "remove", "LBL-1", "needs-migration")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"area:api"}, removed.Labels)
+	assert.Equal(t, []string{"needs-migration"}, removed.Removed)
+
+	output, err = runCmd(t, dbPath, "ticket", "next", "--dry-run", "--label", "needs-migration")
+	require.NoError(t, err)
+	assert.NotContains(t, output, "LBL-1")
+
+	output, err = runCmd(t, dbPath, "ticket", "next", "--dry-run", "--label", "area:api")
+	require.NoError(t, err)
+	assert.Contains(t, output, "LBL-1")
+}
@@ -0,0 +1,316 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spetersoncode/wark/internal/db"
+	werrors "github.com/spetersoncode/wark/internal/errors"
+	"github.com/spetersoncode/wark/internal/models"
+	"github.com/spetersoncode/wark/internal/service"
+	"github.com/spf13/cobra"
+)
+
+// Milestone command flags
+var (
+	milestoneName    string
+	milestoneGoal    string
+	milestoneTarget  string
+	milestoneProject string
+	milestoneStatus  string
+	milestoneAbandon bool
+)
+
+func init() {
+	// milestone create
+	milestoneCreateCmd.Flags().StringVarP(&milestoneName, "name", "n", "", "Milestone name (required)")
+	milestoneCreateCmd.Flags().StringVarP(&milestoneGoal, "goal", "g", "", "What the milestone should achieve")
+	milestoneCreateCmd.Flags().StringVar(&milestoneTarget, "target", "", "Target date (YYYY-MM-DD)")
+	milestoneCreateCmd.MarkFlagRequired("name")
+
+	// milestone list
+	milestoneListCmd.Flags().StringVarP(&milestoneProject, "project", "p", "", "Filter by project")
+	milestoneListCmd.Flags().StringVarP(&milestoneStatus, "status", "s", "", "Filter by status (open, achieved, abandoned)")
+
+	// milestone close
+	milestoneCloseCmd.Flags().BoolVar(&milestoneAbandon, "abandon", false, "Close as abandoned instead of achieved")
+
+	// Add subcommands
+	milestoneCmd.AddCommand(milestoneCreateCmd)
+	milestoneCmd.AddCommand(milestoneListCmd)
+	milestoneCmd.AddCommand(milestoneShowCmd)
+	milestoneCmd.AddCommand(milestoneCloseCmd)
+
+	rootCmd.AddCommand(milestoneCmd)
+}
+
+var milestoneCmd = &cobra.Command{
+	Use:   "milestone",
+	Short: "Milestone management commands",
+	Long: `Manage milestones in wark. A milestone groups a project's tickets toward a
+goal and optional target date. Each ticket belongs to at most one milestone;
+assign it with 'wark ticket create --milestone' or 'wark ticket edit --milestone'.`,
+}
+
+// milestone create
+var milestoneCreateCmd = &cobra.Command{
+	Use:   "create <PROJECT> <KEY>",
+	Short: "Create a new milestone",
+	Long: `Create a new milestone in a project.
+
+Milestone keys must be 1-20 uppercase alphanumeric characters or underscores,
+starting with a letter, and unique within the project.
+
+Examples:
+  wark milestone create WEBAPP V1 --name "Version 1.0"
+  wark milestone create WEBAPP BETA --name "Public beta" --goal "Invite-only signups" --target 2024-06-30`,
+	Args: cobra.ExactArgs(2),
+	RunE: runMilestoneCreate,
+}
+
+func runMilestoneCreate(cmd *cobra.Command, args []string) error {
+	input := service.CreateInput{
+		ProjectKey: strings.ToUpper(args[0]),
+		Key:        strings.ToUpper(args[1]),
+		Name:       milestoneName,
+		Goal:       milestoneGoal,
+	}
+	if milestoneTarget != "" {
+		target, err := time.ParseInLocation("2006-01-02", milestoneTarget, time.Local)
+		if err != nil {
+			return ErrInvalidArgs("invalid --target date format (use YYYY-MM-DD): %s", milestoneTarget)
+		}
+		input.TargetDate = &target
+	}
+
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return ErrDatabaseWithSuggestion(err, SuggestRunInit, "failed to open database")
+	}
+	defer database.Close()
+
+	m, err := service.NewMilestoneService(database.DB).Create(input)
+	if err != nil {
+		return translateMilestoneError(err)
+	}
+
+	if IsJSON() {
+		data, _ := json.MarshalIndent(m, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	OutputLine("Created milestone: %s/%s", m.ProjectKey, m.Key)
+	OutputLine("Name: %s", m.Name)
+	if m.TargetDate != nil {
+		OutputLine("Target: %s", m.TargetDate.Local().Format("2006-01-02"))
+	}
+	return nil
+}
+
+// milestone list
+var milestoneListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List milestones",
+	Long: `List milestones with their progress (closed vs. total tickets).
+
+Examples:
+  wark milestone list
+  wark milestone list --project WEBAPP
+  wark milestone list --status open`,
+	Args: cobra.NoArgs,
+	RunE: runMilestoneList,
+}
+
+func runMilestoneList(cmd *cobra.Command, args []string) error {
+	if milestoneStatus != "" {
+		if err := models.ValidateMilestoneStatus(milestoneStatus); err != nil {
+			return ErrInvalidArgs("%s", err)
+		}
+	}
+
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return ErrDatabaseWithSuggestion(err, SuggestRunInit, "failed to open database")
+	}
+	defer database.Close()
+
+	all, err := service.NewMilestoneService(database.DB).List(strings.ToUpper(milestoneProject))
+	if err != nil {
+		return translateMilestoneError(err)
+	}
+
+	milestones := make([]*models.Milestone, 0, len(all))
+	for _, m := range all {
+		if milestoneStatus == "" || m.Status == milestoneStatus {
+			milestones = append(milestones, m)
+		}
+	}
+
+	if IsJSON() {
+		data, _ := json.MarshalIndent(milestones, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(milestones) == 0 {
+		OutputLine("No milestones found. Create one with: wark milestone create <PROJECT> <KEY> --name <NAME>")
+		return nil
+	}
+
+	fmt.Printf("%-20s %-30s %-10s %-10s %-10s\n", "MILESTONE", "NAME", "STATUS", "TARGET", "PROGRESS")
+	fmt.Println(strings.Repeat("-", 85))
+	for _, m := range milestones {
+		target := "-"
+		if m.TargetDate != nil {
+			target = m.TargetDate.Local().Format("2006-01-02")
+		}
+		fmt.Printf("%-20s %-30s %-10s %-10s %d/%d\n",
+			truncate(m.ProjectKey+"/"+m.Key, 20),
+			truncate(m.Name, 30),
+			m.Status,
+			target,
+			m.ClosedCount, m.TicketCount,
+		)
+	}
+
+	return nil
+}
+
+// milestone show
+var milestoneShowCmd = &cobra.Command{
+	Use:   "show <PROJECT> <KEY>",
+	Short: "Show milestone details",
+	Long: `Show a milestone's details, progress, and tickets.
+
+Examples:
+  wark milestone show WEBAPP V1`,
+	Args: cobra.ExactArgs(2),
+	RunE: runMilestoneShow,
+}
+
+type milestoneShowResult struct {
+	*models.Milestone
+	Progress float64          `json:"progress"`
+	Tickets  []*models.Ticket `json:"tickets"`
+}
+
+func runMilestoneShow(cmd *cobra.Command, args []string) error {
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return ErrDatabaseWithSuggestion(err, SuggestRunInit, "failed to open database")
+	}
+	defer database.Close()
+
+	svc := service.NewMilestoneService(database.DB)
+	m, err := svc.GetByKey(strings.ToUpper(args[0]), strings.ToUpper(args[1]))
+	if err != nil {
+		return translateMilestoneError(err)
+	}
+	tickets, err := svc.GetLinkedTickets(m.ID)
+	if err != nil {
+		return translateMilestoneError(err)
+	}
+
+	if IsJSON() {
+		result := milestoneShowResult{Milestone: m, Progress: m.Progress(), Tickets: tickets}
+		data, _ := json.MarshalIndent(result, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	printMainHeader(m.ProjectKey+"/"+m.Key, m.Name)
+	fmt.Printf("  %-12s %s\n", "Status:", m.Status)
+	if m.Goal != "" {
+		fmt.Printf("  %-12s %s\n", "Goal:", m.Goal)
+	}
+	if m.TargetDate != nil {
+		fmt.Printf("  %-12s %s\n", "Target:", m.TargetDate.Local().Format("2006-01-02"))
+	}
+	fmt.Printf("  %-12s %d/%d tickets closed (%.0f%%)\n", "Progress:", m.ClosedCount, m.TicketCount, m.Progress())
+
+	printSectionHeader(fmt.Sprintf("TICKETS (%d)", len(tickets)))
+	if len(tickets) == 0 {
+		fmt.Println("  (none)")
+		return nil
+	}
+	for _, t := range tickets {
+		fmt.Printf("  %-12s %-10s %s\n", t.TicketKey, t.Status, truncate(t.Title, 40))
+	}
+
+	return nil
+}
+
+// milestone close
+var milestoneCloseCmd = &cobra.Command{
+	Use:   "close <PROJECT> <KEY>",
+	Short: "Close a milestone",
+	Long: `Close a milestone as achieved, or as abandoned with --abandon.
+Closed milestones no longer accept new tickets; tickets already assigned keep it.
+
+Examples:
+  wark milestone close WEBAPP V1
+  wark milestone close WEBAPP BETA --abandon`,
+	Args: cobra.ExactArgs(2),
+	RunE: runMilestoneClose,
+}
+
+func runMilestoneClose(cmd *cobra.Command, args []string) error {
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return ErrDatabaseWithSuggestion(err, SuggestRunInit, "failed to open database")
+	}
+	defer database.Close()
+
+	svc := service.NewMilestoneService(database.DB)
+	m, err := svc.GetByKey(strings.ToUpper(args[0]), strings.ToUpper(args[1]))
+	if err != nil {
+		return translateMilestoneError(err)
+	}
+	if !m.IsOpen() {
+		return ErrStateError("milestone %s/%s is already %s", m.ProjectKey, m.Key, m.Status)
+	}
+
+	if milestoneAbandon {
+		m, err = svc.Abandon(m.ID)
+	} else {
+		m, err = svc.Achieve(m.ID)
+	}
+	if err != nil {
+		return translateMilestoneError(err)
+	}
+
+	if IsJSON() {
+		data, _ := json.MarshalIndent(m, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	OutputLine("Closed milestone %s/%s: %s", m.ProjectKey, m.Key, m.Status)
+	if open := m.TicketCount - m.ClosedCount; open > 0 {
+		OutputLine("Note: %d ticket(s) in this milestone are still open", open)
+	}
+	return nil
+}
+
+// translateMilestoneError converts a milestone service error to a shared
+// error carrying the matching exit code.
+func translateMilestoneError(err error) error {
+	me, ok := err.(*service.MilestoneError)
+	if !ok {
+		return ErrDatabase(err, "operation failed")
+	}
+
+	sharedErr := &werrors.Error{Kind: me.Kind(), Message: me.Message}
+	switch me.Code {
+	case service.ErrCodeMilestoneNotFound:
+		sharedErr.Suggestion = "Run 'wark milestone list' to see available milestones."
+	case service.ErrCodeProjectNotFound:
+		sharedErr.Suggestion = "Run 'wark project list' to see available projects."
+	case service.ErrCodeNotFound:
+		sharedErr.Suggestion = SuggestListTickets
+	}
+	return sharedErr
+}
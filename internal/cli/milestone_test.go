@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/spetersoncode/wark/internal/db"
+	"github.com/spetersoncode/wark/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMilestoneCommands(t *testing.T) {
+	database, dbPath, cleanup := testDBWithPath(t)
+	defer cleanup()
+
+	projectRepo := db.NewProjectRepo(database.DB)
+	project := &models.Project{Key: "MS", Name: "Milestone Test"}
+	require.NoError(t, projectRepo.Create(project))
+
+	var created models.Milestone
+	err := runCmdJSON(t, dbPath, &created, "milestone", "create", "MS", "v1", "--name", "Version 1", "--target", "2030-01-31")
+	require.NoError(t, err)
+	assert.Equal(t, "V1", created.Key)
+	assert.Equal(t, models.MilestoneStatusOpen, created.Status)
+
+	_, err = runCmd(t, dbPath, "milestone", "create", "MS", "V1", "--name", "Duplicate")
+	require.Error(t, err)
+
+	var ticket ticketCreateResult
+	err = runCmdJSON(t, dbPath, &ticket, "ticket", "create", "MS", "--title", "First", "--milestone", "V1")
+	require.NoError(t, err)
+	assert.Equal(t, "V1", ticket.MilestoneKey)
+
+	_, err = runCmd(t, dbPath, "ticket", "create", "MS", "--title", "Second")
+	require.NoError(t, err)
+	_, err = runCmd(t, dbPath, "ticket", "edit", "MS-2", "--milestone", "V1")
+	require.NoError(t, err)
+
+	output, err := runCmd(t, dbPath, "ticket", "show", "MS-2")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Milestone:")
+
+	var tickets []*models.Ticket
+	err = runCmdJSON(t, dbPath, &tickets, "ticket", "list", "--project", "MS", "--milestone", "V1")
+	require.NoError(t, err)
+	assert.Len(t, tickets, 2)
+
+	_, err = database.Exec("UPDATE tickets SET status = 'closed', resolution = 'completed' WHERE number = 1")
+	require.NoError(t, err)
+
+	var shown milestoneShowResult
+	err = runCmdJSON(t, dbPath, &shown, "milestone", "show", "MS", "V1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, shown.TicketCount)
+	assert.Equal(t, 1, shown.ClosedCount)
+	assert.Equal(t, 50.0, shown.Progress)
+	assert.Len(t, shown.Tickets, 2)
+
+	_, err = runCmd(t, dbPath, "ticket", "edit", "MS-2", "--milestone", "")
+	require.NoError(t, err)
+
+	var closed models.Milestone
+	err = runCmdJSON(t, dbPath, &closed, "milestone", "close", "MS", "V1")
+	require.NoError(t, err)
+	assert.Equal(t, models.MilestoneStatusAchieved, closed.Status)
+
+	_, err = runCmd(t, dbPath, "ticket", "edit", "MS-2", "--milestone", "V1")
+	require.Error(t, err)
+
+	var milestones []*models.Milestone
+	err = runCmdJSON(t, dbPath, &milestones, "milestone", "list", "--status", "open")
+	require.NoError(t, err)
+	assert.Empty(t, milestones)
+}
@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spetersoncode/wark/internal/db"
+	werrors "github.com/spetersoncode/wark/internal/errors"
+	"github.com/spetersoncode/wark/internal/service"
+	"github.com/spf13/cobra"
+)
+
+// Plan command flags
+var planDryRun bool
+
+func init() {
+	planApplyCmd.Flags().BoolVar(&planDryRun, "dry-run", false, "Show the tickets and keys that would be created without creating them")
+
+	planCmd.AddCommand(planApplyCmd)
+	rootCmd.AddCommand(planCmd)
+}
+
+var planCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Plan commands",
+	Long:  `Create many related tickets at once from a plan file.`,
+}
+
+var planApplyCmd = &cobra.Command{
+	Use:   "apply <FILE>",
+	Short: "Create the tickets in a plan file",
+	Long: `Create every ticket in a YAML or JSON plan file in one transaction. Use -
+to read from stdin.
+
+Tickets refer to each other by alias, so dependencies and parents can be
+declared before any keys exist. A reference that isn't an alias in the plan is
+taken to be an existing ticket key. If any ticket fails, nothing is created.
+
+Example plan:
+
+  project: WEBAPP
+  parent: auth              # default parent for non-epic tickets
+  tickets:
+    - alias: auth
+      title: Authentication
+      type: epic
+    - alias: schema
+      title: User schema
+      complexity: small
+      role: architect
+    - alias: login
+      title: Login form
+      depends_on: [schema, WEBAPP-12]
+      tasks:
+        - Build the form
+        - Wire up validation
+
+Examples:
+  wark plan apply auth.yaml --dry-run
+  wark plan apply auth.yaml`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPlanApply,
+}
+
+func runPlanApply(cmd *cobra.Command, args []string) error {
+	data, err := readInputFile(args[0])
+	if err != nil {
+		return ErrInvalidArgs("failed to read %s: %v", args[0], err)
+	}
+
+	var plan service.Plan
+	if err := decodeFile(data, args[0], &plan); err != nil {
+		return ErrInvalidArgs("failed to parse %s: %v", args[0], err)
+	}
+
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return ErrDatabaseWithSuggestion(err, SuggestRunInit, "failed to open database")
+	}
+	defer database.Close()
+
+	result, err := service.NewTicketService(database.DB).ApplyPlan(&plan, planDryRun)
+	if err != nil {
+		return translatePlanError(err)
+	}
+
+	if IsJSON() {
+		data, _ := json.MarshalIndent(result, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if result.DryRun {
+		OutputLine("[DRY RUN] Would create %d ticket(s) in %s:", len(result.Tickets), result.Project)
+	} else {
+		OutputLine("Created %d ticket(s) in %s:", len(result.Tickets), result.Project)
+	}
+	OutputLine("")
+	fmt.Printf("%-16s %-12s %-8s %-9s %-30s %s\n", "ALIAS", "KEY", "TYPE", "STATUS", "TITLE", "LINKS")
+	fmt.Println(strings.Repeat("-", 100))
+	for _, t := range result.Tickets {
+		var links []string
+		if t.Parent != "" {
+			links = append(links, "parent "+t.Parent)
+		}
+		if len(t.DependsOn) > 0 {
+			links = append(links, "depends on "+strings.Join(t.DependsOn, ", "))
+		}
+		if t.Tasks > 0 {
+			links = append(links, fmt.Sprintf("%d task(s)", t.Tasks))
+		}
+		fmt.Printf("%-16s %-12s %-8s %-9s %-30s %s\n",
+			truncate(t.Alias, 16),
+			t.Key,
+			t.Type,
+			t.Status,
+			truncate(t.Title, 30),
+			strings.Join(links, "; "),
+		)
+	}
+	return nil
+}
+
+// translatePlanError converts a plan error to a CLI error. Plan errors name the
+// ticket alias, so the service message is kept as is.
+func translatePlanError(err error) error {
+	svcErr, ok := err.(*service.TicketError)
+	if !ok {
+		return ErrDatabase(err, "failed to apply plan")
+	}
+	sharedErr := &werrors.Error{Kind: svcErr.Kind(), Message: svcErr.Message}
+	if svcErr.Code == service.ErrCodeNotFound {
+		sharedErr.Suggestion = "Plans can only reference existing projects, roles, milestones and tickets."
+	}
+	return sharedErr
+}
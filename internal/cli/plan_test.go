@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spetersoncode/wark/internal/db"
+	"github.com/spetersoncode/wark/internal/models"
+	"github.com/spetersoncode/wark/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanApply(t *testing.T) {
+	database, dbPath, cleanup := testDBWithPath(t)
+	defer cleanup()
+
+	require.NoError(t, db.NewProjectRepo(database.DB).Create(&models.Project{Key: "PLN", Name: "Plans"}))
+
+	file := filepath.Join(t.TempDir(), "plan.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`project: PLN
+parent: epic
+tickets:
+  - alias: epic
+    title: Search
+    type: epic
+  - alias: index
+    title: Build the index
+    complexity: large
+    tasks: [Schema, Triggers]
+  - alias: query
+    title: Query command
+    depends_on: [index]
+`), 0644))
+
+	output, err := runCmd(t, dbPath, "plan", "apply", file, "--dry-run")
+	require.NoError(t, err)
+	assert.Contains(t, output, "[DRY RUN] Would create 3 ticket(s) in PLN")
+	assert.Contains(t, output, "depends on PLN-2")
+
+	var result service.PlanResult
+	require.NoError(t, runCmdJSON(t, dbPath, &result, "plan", "apply", file))
+	assert.False(t, result.DryRun)
+	assert.Equal(t, map[string]string{"epic": "PLN-1", "index": "PLN-2", "query": "PLN-3"}, result.Aliases)
+
+	query, err := db.NewTicketRepo(database.DB).GetByKey("PLN", 3)
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusBlocked, query.Status)
+
+	bad := filepath.Join(t.TempDir(), "bad.yaml")
+	require.NoError(t, os.WriteFile(bad, []byte("project: PLN\ntickets:\n  - alias: a\n    title: A\n    depends_on: [b]\n"), 0644))
+	_, err = runCmd(t, dbPath, "plan", "apply", bad)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `a: dependency "b" is neither an alias in this plan nor a ticket key`)
+}
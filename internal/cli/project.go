@@ -22,8 +22,11 @@ var (
 
 // Edit command flags (separate from create to allow empty values)
 var (
-	projectEditName        string
-	projectEditDescription string
+	projectEditName           string
+	projectEditDescription    string
+	projectEditWIPLimit       int
+	projectEditWorkingLimit   int
+	projectEditReviewingLimit int
 )
 
 func init() {
@@ -38,6 +41,9 @@ func init() {
 	// project edit
 	projectEditCmd.Flags().StringVarP(&projectEditName, "name", "n", "", "Update project name")
 	projectEditCmd.Flags().StringVarP(&projectEditDescription, "description", "d", "", "Update project description")
+	projectEditCmd.Flags().IntVar(&projectEditWIPLimit, "wip-limit", 0, "Max working and reviewing tickets together (0 for no limit)")
+	projectEditCmd.Flags().IntVar(&projectEditWorkingLimit, "working-limit", 0, "Max working tickets (0 for no limit)")
+	projectEditCmd.Flags().IntVar(&projectEditReviewingLimit, "reviewing-limit", 0, "Max reviewing tickets (0 for no limit)")
 
 	// project delete
 	projectDeleteCmd.Flags().BoolVar(&projectForce, "force", false, "Skip confirmation prompt")
@@ -282,6 +288,14 @@ func runProjectShow(cmd *cobra.Command, args []string) error {
 	fmt.Println("  " + strings.Repeat("-", 17))
 	fmt.Printf("  Total:          %d\n", stats.TotalTickets)
 
+	if stats.WIP != nil && !stats.WIP.Limits.IsZero() {
+		fmt.Println()
+		fmt.Println("WIP Limits:")
+		fmt.Printf("  Working:        %d/%s\n", stats.WIP.Working, formatWIPLimit(stats.WIP.Limits.Working))
+		fmt.Printf("  Reviewing:      %d/%s\n", stats.WIP.Reviewing, formatWIPLimit(stats.WIP.Limits.Reviewing))
+		fmt.Printf("  In progress:    %d/%s\n", stats.WIP.InProgress(), formatWIPLimit(stats.WIP.Limits.Total))
+	}
+
 	return nil
 }
 
@@ -289,14 +303,19 @@ func runProjectShow(cmd *cobra.Command, args []string) error {
 var projectEditCmd = &cobra.Command{
 	Use:   "edit <KEY>",
 	Short: "Edit project properties",
-	Long: `Edit a project's name or description.
+	Long: `Edit a project's name, description or WIP limits.
 
-At least one of --name or --description must be provided.
+WIP limits cap how many of the project's tickets can be in progress at once.
+--wip-limit counts working and reviewing tickets together; --working-limit
+and --reviewing-limit cap each status. 'ticket claim', 'ticket next' and
+'ticket review' are refused with exit code 7 while a limit is reached. Set a
+limit to 0 to remove it.
 
 Examples:
   wark project edit WARK --description "New description"
   wark project edit POD --name "Podcast Episodes"
-  wark project edit MYAPP -n "My App" -d "Updated description"`,
+  wark project edit MYAPP -n "My App" -d "Updated description"
+  wark project edit WEBAPP --wip-limit 5 --reviewing-limit 2`,
 	Args: cobra.ExactArgs(1),
 	RunE: runProjectEdit,
 }
@@ -307,11 +326,14 @@ func runProjectEdit(cmd *cobra.Command, args []string) error {
 	// Check if at least one flag was provided
 	nameChanged := cmd.Flags().Changed("name")
 	descChanged := cmd.Flags().Changed("description")
+	wipChanged := cmd.Flags().Changed("wip-limit")
+	workingChanged := cmd.Flags().Changed("working-limit")
+	reviewingChanged := cmd.Flags().Changed("reviewing-limit")
 
-	if !nameChanged && !descChanged {
+	if !nameChanged && !descChanged && !wipChanged && !workingChanged && !reviewingChanged {
 		return ErrInvalidArgsWithSuggestion(
-			"Use --name/-n to update the name or --description/-d to update the description.",
-			"at least one of --name or --description must be provided",
+			"Use --name/-n to update the name, --description/-d to update the description, or --wip-limit, --working-limit and --reviewing-limit to set WIP limits.",
+			"at least one of --name, --description or a WIP limit must be provided",
 		)
 	}
 
@@ -343,6 +365,18 @@ func runProjectEdit(cmd *cobra.Command, args []string) error {
 	if descChanged {
 		project.Description = projectEditDescription
 	}
+	if wipChanged {
+		project.WIPLimits.Total = projectEditWIPLimit
+	}
+	if workingChanged {
+		project.WIPLimits.Working = projectEditWorkingLimit
+	}
+	if reviewingChanged {
+		project.WIPLimits.Reviewing = projectEditReviewingLimit
+	}
+	if err := project.WIPLimits.Validate(); err != nil {
+		return ErrInvalidArgs("%s", err)
+	}
 
 	if err := repo.Update(project); err != nil {
 		return ErrDatabase(err, "failed to update project")
@@ -359,6 +393,12 @@ func runProjectEdit(cmd *cobra.Command, args []string) error {
 	if project.Description != "" {
 		OutputLine("Description: %s", project.Description)
 	}
+	if !project.WIPLimits.IsZero() {
+		OutputLine("WIP limits: total %s, working %s, reviewing %s",
+			formatWIPLimit(project.WIPLimits.Total),
+			formatWIPLimit(project.WIPLimits.Working),
+			formatWIPLimit(project.WIPLimits.Reviewing))
+	}
 
 	return nil
 }
@@ -39,6 +39,8 @@ const (
 	ExitStateError        = 4
 	ExitDBError           = 5
 	ExitConcurrentConflict = 6
+	ExitWIPLimit          = 7
+	ExitTimeout           = 8
 )
 
 // skipBackupCommands lists commands that should not trigger automatic backup.
@@ -228,6 +230,18 @@ func GetDefaultWorkerID() string {
 	return ""
 }
 
+// ResolveWorkerID returns the worker identity for claim operations: the
+// --worker-id flag if set, then $WARK_WORKER_ID, then default_worker_id from config.
+func ResolveWorkerID(flag string) string {
+	if flag != "" {
+		return flag
+	}
+	if workerID := os.Getenv("WARK_WORKER_ID"); workerID != "" {
+		return workerID
+	}
+	return GetDefaultWorkerID()
+}
+
 // GetDefaultClaimDuration returns the default claim duration in minutes from config.
 func GetDefaultClaimDuration() int {
 	if globalConfig != nil && globalConfig.ClaimDuration > 0 {
@@ -236,6 +250,15 @@ func GetDefaultClaimDuration() int {
 	return 30
 }
 
+// GetClaimMaxLifetime returns the maximum claim lifetime in minutes from config.
+// Returns 0 when claims may be renewed indefinitely.
+func GetClaimMaxLifetime() int {
+	if globalConfig != nil {
+		return globalConfig.ClaimMaxLifetime
+	}
+	return 0
+}
+
 // GetConfig returns the global configuration.
 // This should only be used when direct access to all config values is needed.
 func GetConfig() *config.Config {
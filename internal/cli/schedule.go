@@ -0,0 +1,239 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spetersoncode/wark/internal/db"
+	"github.com/spetersoncode/wark/internal/service"
+	"github.com/spf13/cobra"
+)
+
+// Schedule command flags
+var (
+	scheduleDaemon   bool
+	scheduleInterval int
+)
+
+func init() {
+	scheduleRunCmd.Flags().BoolVar(&scheduleDaemon, "daemon", false, "Run continuously, checking every N seconds")
+	scheduleRunCmd.Flags().IntVar(&scheduleInterval, "interval", 60, "Check interval in seconds (for --daemon mode)")
+
+	scheduleCmd.AddCommand(scheduleListCmd)
+	scheduleCmd.AddCommand(scheduleRunCmd)
+
+	rootCmd.AddCommand(scheduleCmd)
+}
+
+var scheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Recurring ticket commands",
+	Long: `Create tickets on a schedule.
+
+Recurring tickets are configured in [[recurring]] sections of the config file,
+each with a cron schedule, a project, a title and optionally a description,
+role, priority and complexity. 'wark schedule run' creates a ticket for each
+one that has come due since it last ran.`,
+}
+
+// recurringTemplates parses the [[recurring]] config.
+func recurringTemplates() ([]*service.RecurringTemplate, error) {
+	templates, err := service.ParseRecurring(GetConfig().Recurring)
+	if err != nil {
+		return nil, ErrInvalidArgsWithSuggestion("Check the [[recurring]] section of your config file.", "invalid recurring ticket config: %s", err)
+	}
+	return templates, nil
+}
+
+// schedule list
+var scheduleListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List recurring tickets",
+	Long: `List recurring tickets with when they last ran and are next due.
+
+Examples:
+  wark schedule list`,
+	Args: cobra.NoArgs,
+	RunE: runScheduleList,
+}
+
+func runScheduleList(cmd *cobra.Command, args []string) error {
+	templates, err := recurringTemplates()
+	if err != nil {
+		return err
+	}
+
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return ErrDatabaseWithSuggestion(err, SuggestRunInit, "failed to open database")
+	}
+	defer database.Close()
+
+	statuses, err := service.NewTicketService(database.DB).RecurringStatuses(templates, time.Now())
+	if err != nil {
+		return ErrDatabase(err, "failed to get recurring tickets")
+	}
+
+	if IsJSON() {
+		data, _ := json.MarshalIndent(statuses, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(statuses) == 0 {
+		OutputLine("No recurring tickets configured. Add a [[recurring]] section to your config file.")
+		return nil
+	}
+
+	fmt.Printf("%-20s %-16s %-8s %-16s %-16s %-12s %s\n", "NAME", "SCHEDULE", "PROJECT", "LAST RUN", "NEXT RUN", "LAST TICKET", "TITLE")
+	fmt.Println(strings.Repeat("-", 120))
+	for _, s := range statuses {
+		lastTicket := s.LastTicket
+		if lastTicket == "" {
+			lastTicket = "-"
+		}
+		fmt.Printf("%-20s %-16s %-8s %-16s %-16s %-12s %s\n",
+			truncate(s.Name, 20),
+			truncate(s.Schedule, 16),
+			s.Project,
+			formatRunTime(s.LastRunAt),
+			formatRunTime(s.NextRunAt),
+			lastTicket,
+			truncate(s.Title, 40),
+		)
+	}
+
+	return nil
+}
+
+// formatRunTime formats a run time in local time, or "-" if unset.
+func formatRunTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+// schedule run
+var scheduleRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Create recurring tickets that are due",
+	Long: `Create a ticket for each recurring ticket that has come due since it last ran.
+
+Each recurring ticket creates at most one ticket per run, however many
+scheduled times were missed, and the last run is recorded in the database, so
+running again, or from several places at once, never creates duplicates. The
+first run of a new recurring ticket only records the time; its first ticket is
+created at the next scheduled time.
+
+Without --daemon, this runs once and exits, which suits cron.
+
+Examples:
+  wark schedule run                         # Run once
+  wark schedule run --daemon                # Run continuously
+  wark schedule run --daemon --interval 300`,
+	Args: cobra.NoArgs,
+	RunE: runScheduleRun,
+}
+
+func runScheduleRun(cmd *cobra.Command, args []string) error {
+	if scheduleInterval <= 0 {
+		return ErrInvalidArgs("--interval must be positive")
+	}
+
+	templates, err := recurringTemplates()
+	if err != nil {
+		return err
+	}
+
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return ErrDatabaseWithSuggestion(err, SuggestRunInit, "failed to open database")
+	}
+	defer database.Close()
+
+	svc := service.NewTicketService(database.DB)
+
+	if scheduleDaemon {
+		return runScheduleDaemon(svc, templates, time.Duration(scheduleInterval)*time.Second)
+	}
+
+	result, err := svc.RunRecurring(templates, time.Now())
+	if err != nil {
+		return ErrDatabase(err, "failed to run recurring tickets")
+	}
+
+	if IsJSON() {
+		data, _ := json.MarshalIndent(result, "", "  ")
+		fmt.Println(string(data))
+	} else {
+		for _, s := range result.Templates {
+			switch s.Action {
+			case service.RecurringCreated:
+				OutputLine("Created %s from %s", s.Ticket, s.Name)
+			case service.RecurringStarted:
+				OutputLine("Started %s; first ticket due %s", s.Name, formatRunTime(s.NextRunAt))
+			case service.RecurringFailed:
+				OutputLine("Failed %s: %s", s.Name, s.Error)
+			}
+		}
+		OutputLine("Created %d ticket(s), %d failed", result.Created, result.Failed)
+	}
+
+	if result.Failed > 0 {
+		return fmt.Errorf("%d recurring ticket(s) failed", result.Failed)
+	}
+	return nil
+}
+
+func runScheduleDaemon(svc *service.TicketService, templates []*service.RecurringTemplate, interval time.Duration) error {
+	OutputLine("Starting recurring ticket scheduler (checking every %s)", interval)
+	OutputLine("Press Ctrl+C to stop...")
+	OutputLine("")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Handle shutdown signals
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+
+	go func() {
+		<-sigChan
+		OutputLine("")
+		OutputLine("Shutting down daemon...")
+		cancel()
+	}()
+
+	callback := func(result *service.RecurringResult, err error) {
+		now := time.Now().Format("15:04:05")
+		if err != nil {
+			OutputLine("[%s] Recurring tickets failed: %s", now, err)
+			return
+		}
+		for _, s := range result.Templates {
+			switch s.Action {
+			case service.RecurringCreated:
+				OutputLine("[%s] Created %s from %s", now, s.Ticket, s.Name)
+			case service.RecurringFailed:
+				OutputLine("[%s] Failed %s: %s", now, s.Name, s.Error)
+			}
+		}
+		if result.Created+result.Failed == 0 {
+			VerboseOutput("[%s] No recurring tickets due\n", now)
+		}
+	}
+
+	err := svc.RunRecurringDaemon(ctx, templates, interval, callback)
+	if err == context.Canceled {
+		OutputLine("Daemon stopped.")
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spetersoncode/wark/internal/config"
+	"github.com/spetersoncode/wark/internal/db"
+	"github.com/spetersoncode/wark/internal/models"
+	"github.com/spetersoncode/wark/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleCommands(t *testing.T) {
+	database, dbPath, cleanup := testDBWithPath(t)
+	defer cleanup()
+
+	origConfig := globalConfig
+	defer func() { globalConfig = origConfig }()
+	globalConfig = config.DefaultConfig()
+	globalConfig.Recurring = []config.RecurringConfig{
+		{Name: "changelog", Schedule: "@daily", Project: "SCHED", Title: "Write changelog", Complexity: "small"},
+	}
+
+	projectRepo := db.NewProjectRepo(database.DB)
+	require.NoError(t, projectRepo.Create(&models.Project{Key: "SCHED", Name: "Scheduled"}))
+
+	// The first run starts the clock without creating a ticket
+	var result service.RecurringResult
+	require.NoError(t, runCmdJSON(t, dbPath, &result, "schedule", "run"))
+	assert.Equal(t, 0, result.Created)
+	require.Len(t, result.Templates, 1)
+	assert.Equal(t, service.RecurringStarted, result.Templates[0].Action)
+
+	// Three days missed create one ticket
+	_, err := database.Exec("UPDATE recurring_runs SET last_run_at = ?", db.FormatTime(time.Now().Add(-72*time.Hour)))
+	require.NoError(t, err)
+	require.NoError(t, runCmdJSON(t, dbPath, &result, "schedule", "run"))
+	assert.Equal(t, 1, result.Created)
+	assert.Equal(t, "SCHED-1", result.Templates[0].Ticket)
+
+	require.NoError(t, runCmdJSON(t, dbPath, &result, "schedule", "run"))
+	assert.Equal(t, 0, result.Created)
+	assert.Equal(t, service.RecurringWaiting, result.Templates[0].Action)
+
+	var statuses []service.RecurringStatus
+	require.NoError(t, runCmdJSON(t, dbPath, &statuses, "schedule", "list"))
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "SCHED-1", statuses[0].LastTicket)
+	require.NotNil(t, statuses[0].NextRunAt)
+	assert.True(t, statuses[0].NextRunAt.After(time.Now()))
+
+	output, err := runCmd(t, dbPath, "schedule", "list", "--text")
+	require.NoError(t, err)
+	assert.Contains(t, output, "changelog")
+	assert.Contains(t, output, "SCHED-1")
+
+	// A bad schedule is reported as a config error
+	globalConfig.Recurring[0].Schedule = "daily"
+	_, err = runCmd(t, dbPath, "schedule", "run")
+	assert.ErrorContains(t, err, "invalid recurring ticket config")
+}
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spetersoncode/wark/internal/db"
+	"github.com/spf13/cobra"
+)
+
+// Search command flags
+var (
+	searchProject string
+	searchLimit   int
+)
+
+func init() {
+	searchCmd.Flags().StringVarP(&searchProject, "project", "p", "", "Limit to a project")
+	searchCmd.Flags().IntVarP(&searchLimit, "limit", "l", 20, "Max results to show")
+
+	rootCmd.AddCommand(searchCmd)
+}
+
+var searchCmd = &cobra.Command{
+	Use:   "search <QUERY>...",
+	Short: "Full-text search across tickets",
+	Long: `Search ticket titles and descriptions, comments, completion summaries,
+and inbox messages. Results are ranked by relevance (title matches first),
+one per ticket, with a snippet of the best match; matched terms are marked
+with **asterisks**.
+
+Every word must match, and the last word also matches as a prefix. A query
+that is exactly a ticket key shows that ticket first.
+
+Examples:
+  wark search oauth callback
+  wark search "rate limit" --project WEBAPP
+  wark search WEBAPP-42`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runSearch,
+}
+
+func runSearch(cmd *cobra.Command, args []string) error {
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return ErrDatabaseWithSuggestion(err, SuggestRunInit, "failed to open database")
+	}
+	defer database.Close()
+
+	query := strings.Join(args, " ")
+	results, err := db.NewSearchRepo(database.DB).Search(query, db.SearchOptions{
+		ProjectKey:     strings.ToUpper(searchProject),
+		Limit:          searchLimit,
+		HighlightOpen:  "**",
+		HighlightClose: "**",
+	})
+	if err != nil {
+		return ErrDatabase(err, "search failed")
+	}
+
+	if IsJSON() {
+		data, _ := json.MarshalIndent(results, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(results) == 0 {
+		OutputLine("No results for %q", query)
+		return nil
+	}
+
+	for _, res := range results {
+		t := res.Ticket
+		fmt.Printf("%-12s %-10s %s\n", t.TicketKey, t.Status, truncate(t.Title, 50))
+		if res.Source != db.SearchSourceKey {
+			fmt.Printf("%-12s %s: %s\n", "", res.Source, oneLine(res.Snippet))
+		}
+	}
+
+	return nil
+}
+
+// oneLine collapses whitespace so a snippet fits on one line.
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/spetersoncode/wark/internal/db"
+	"github.com/spetersoncode/wark/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearch(t *testing.T) {
+	database, dbPath, cleanup := testDBWithPath(t)
+	defer cleanup()
+
+	projectRepo := db.NewProjectRepo(database.DB)
+	project := &models.Project{Key: "SRCH", Name: "Search Test"}
+	require.NoError(t, projectRepo.Create(project))
+
+	ticketRepo := db.NewTicketRepo(database.DB)
+	ticket := &models.Ticket{ProjectID: project.ID, Title: "Rate limit the API", Status: models.StatusReady}
+	require.NoError(t, ticketRepo.Create(ticket))
+	require.NoError(t, ticketRepo.Create(&models.Ticket{ProjectID: project.ID, Title: "Unrelated", Status: models.StatusReady}))
+
+	_, err := runCmd(t, dbPath, "ticket", "comment", "SRCH-1", "-m", "Use a token bucket per client")
+	require.NoError(t, err)
+
+	output, err := runCmd(t, dbPath, "search", "token", "bucket")
+	require.NoError(t, err)
+	assert.Contains(t, output, "SRCH-1")
+	assert.Contains(t, output, "comment: Use a **token** **bucket** per client")
+	assert.NotContains(t, output, "SRCH-2")
+
+	var results []*db.SearchResult
+	err = runCmdJSON(t, dbPath, &results, "search", "rate", "--project", "srch")
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "SRCH-1", results[0].Ticket.TicketKey)
+	assert.Equal(t, db.SearchSourceTicket, results[0].Source)
+
+	output, err = runCmd(t, dbPath, "search", "nothing-matches-this")
+	require.NoError(t, err)
+	assert.Contains(t, output, "No results")
+}
@@ -13,6 +13,13 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// webhookDispatchInterval is how often the server dispatches webhooks.
+const webhookDispatchInterval = 10 * time.Second
+
+// inboxEscalateInterval is how often the server escalates overdue inbox
+// messages.
+const inboxEscalateInterval = time.Minute
+
 // Serve command flags
 var (
 	servePort       int
@@ -40,6 +47,9 @@ The web UI provides:
   - Claim monitoring
   - Activity feed
 
+When webhooks are configured, the server also dispatches their deliveries,
+and when inbox SLAs are configured it escalates overdue messages.
+
 The server runs on localhost by default and auto-opens your browser.
 
 Examples:
@@ -65,6 +75,7 @@ func runServe(cmd *cobra.Command, args []string) error {
 		Host:            serveHost,
 		DB:              database.DB,
 		AutoOpenBrowser: !serveNoBrowser,
+		Settings:        GetConfig(),
 	}
 
 	// Create and start server
@@ -73,6 +84,26 @@ func runServe(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create server: %w", err)
 	}
 
+	// Dispatch webhooks in the background while the server runs
+	dispatchCtx, stopDispatch := context.WithCancel(context.Background())
+	defer stopDispatch()
+	if len(GetConfig().Webhooks) > 0 {
+		dispatcher, err := newDispatcher(database)
+		if err != nil {
+			return err
+		}
+		go dispatcher.RunDaemon(dispatchCtx, webhookDispatchInterval, logDispatchResult)
+	}
+
+	// Escalate overdue inbox messages in the background too
+	if len(GetConfig().InboxSLA) > 0 {
+		policy, err := inboxSLAPolicy()
+		if err != nil {
+			return err
+		}
+		go newInboxService(database).RunEscalateOverdueDaemon(dispatchCtx, policy, inboxEscalateInterval, logEscalateResult)
+	}
+
 	// Handle graceful shutdown
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
@@ -34,6 +34,9 @@ Shows:
   - Blocked (human) count
   - Pending inbox messages
   - Expiring claims soon
+  - Overdue tickets (open past their due date)
+  - Overdue inbox messages (pending past their response SLA)
+  - WIP usage for projects with WIP limits
   - Recent activity
 
 Examples:
@@ -45,15 +48,18 @@ Examples:
 
 // StatusResult represents the status overview data (CLI-specific response format).
 type StatusResult struct {
-	Workable       int                        `json:"workable"`
-	Working     int                        `json:"working"`
-	Review         int                        `json:"review"`
-	BlockedDeps    int                        `json:"blocked_deps"`
-	BlockedHuman   int                        `json:"blocked_human"`
-	PendingInbox   int                        `json:"pending_inbox"`
-	ExpiringSoon   []*ExpiringSoon            `json:"expiring_soon"`
-	RecentActivity []*ActivitySummary         `json:"recent_activity"`
-	Project        string                     `json:"project,omitempty"`
+	Workable       int                          `json:"workable"`
+	Working        int                          `json:"working"`
+	Review         int                          `json:"review"`
+	BlockedDeps    int                          `json:"blocked_deps"`
+	BlockedHuman   int                          `json:"blocked_human"`
+	PendingInbox   int                          `json:"pending_inbox"`
+	ExpiringSoon   []*ExpiringSoon              `json:"expiring_soon"`
+	Overdue        []service.OverdueItem        `json:"overdue"`
+	OverdueInbox   []service.OverdueMessageItem `json:"overdue_inbox"`
+	RecentActivity []*ActivitySummary           `json:"recent_activity"`
+	Project        string                       `json:"project,omitempty"`
+	WIP            []service.ProjectWIP         `json:"wip,omitempty"`
 }
 
 // ExpiringSoon represents a claim that will expire soon (CLI format).
@@ -72,6 +78,11 @@ type ActivitySummary struct {
 }
 
 func runStatus(cmd *cobra.Command, args []string) error {
+	policy, err := inboxSLAPolicy()
+	if err != nil {
+		return err
+	}
+
 	database, err := db.Open(GetDBPath())
 	if err != nil {
 		return fmt.Errorf("failed to open database: %w", err)
@@ -83,7 +94,9 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	claimRepo := db.NewClaimRepo(database.DB)
 	activityRepo := db.NewActivityRepo(database.DB)
 
-	statusService := service.NewStatusService(ticketRepo, inboxRepo, claimRepo, activityRepo)
+	statusService := service.NewStatusService(ticketRepo, inboxRepo, claimRepo, activityRepo).
+		WithProjectRepo(db.NewProjectRepo(database.DB)).
+		WithSLAPolicy(policy)
 	summary, err := statusService.GetSummary(statusProject)
 	if err != nil {
 		return fmt.Errorf("failed to get status: %w", err)
@@ -98,6 +111,9 @@ func runStatus(cmd *cobra.Command, args []string) error {
 		BlockedHuman: summary.BlockedHuman,
 		PendingInbox: summary.PendingInbox,
 		Project:      summary.ProjectKey,
+		Overdue:      summary.Overdue,
+		OverdueInbox: summary.OverdueInbox,
+		WIP:          summary.WIP,
 	}
 
 	for _, e := range summary.ExpiringSoon {
@@ -141,7 +157,11 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	fmt.Println()
 
 	// Inbox and claims
-	fmt.Printf("Pending inbox:        %d message(s)\n", result.PendingInbox)
+	if len(result.OverdueInbox) > 0 {
+		fmt.Printf("Pending inbox:        %d message(s), %d overdue\n", result.PendingInbox, len(result.OverdueInbox))
+	} else {
+		fmt.Printf("Pending inbox:        %d message(s)\n", result.PendingInbox)
+	}
 
 	if len(result.ExpiringSoon) > 0 {
 		for _, e := range result.ExpiringSoon {
@@ -152,6 +172,46 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	}
 	fmt.Println()
 
+	// Overdue tickets
+	if len(result.Overdue) > 0 {
+		fmt.Println("Overdue:")
+		for _, o := range result.Overdue {
+			fmt.Printf("  %-12s %-8s due %-8s %s\n", o.TicketKey, o.Priority, o.Age, truncate(o.Title, 35))
+		}
+		fmt.Println()
+	}
+
+	// Inbox messages past their SLA
+	if len(result.OverdueInbox) > 0 {
+		fmt.Println("Overdue inbox:")
+		for _, m := range result.OverdueInbox {
+			fmt.Printf("  #%-4d %-12s %-10s due %-8s %s\n", m.MessageID, m.TicketKey, m.MessageType, m.Age, truncate(m.Content, 30))
+		}
+		fmt.Println()
+	}
+
+	// WIP limits
+	if len(result.WIP) > 0 {
+		fmt.Println("WIP limits:")
+		for _, w := range result.WIP {
+			line := fmt.Sprintf("  %-10s working %d/%s  reviewing %d/%s  total %d/%s",
+				w.ProjectKey,
+				w.Working, formatWIPLimit(w.Limits.Working),
+				w.Reviewing, formatWIPLimit(w.Limits.Reviewing),
+				w.InProgress(), formatWIPLimit(w.Limits.Total))
+			switch {
+			case w.WorkingFull && w.ReviewingFull:
+				line += "  (full)"
+			case w.WorkingFull:
+				line += "  (no new claims)"
+			case w.ReviewingFull:
+				line += "  (no new reviews)"
+			}
+			fmt.Println(line)
+		}
+		fmt.Println()
+	}
+
 	// Recent activity
 	if len(result.RecentActivity) > 0 {
 		fmt.Println("Recent activity:")
@@ -162,3 +222,11 @@ func runStatus(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// formatWIPLimit formats a WIP limit, showing "-" when there is none.
+func formatWIPLimit(limit int) string {
+	if limit == 0 {
+		return "-"
+	}
+	return fmt.Sprint(limit)
+}
@@ -0,0 +1,262 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spetersoncode/wark/internal/db"
+	werrors "github.com/spetersoncode/wark/internal/errors"
+	"github.com/spetersoncode/wark/internal/service"
+	"github.com/spf13/cobra"
+)
+
+// Template command flags
+var (
+	templateDescription     string
+	templateDescriptionFile string
+	templatePriority        string
+	templateComplexity      string
+	templateRole            string
+	templateTasks           []string
+)
+
+func init() {
+	// template create
+	templateCreateCmd.Flags().StringVarP(&templateDescription, "description", "d", "", "Description template (Go text/template, variables as {{.name}})")
+	templateCreateCmd.Flags().StringVar(&templateDescriptionFile, "description-file", "", "Read the description template from a file ('-' for stdin)")
+	templateCreateCmd.Flags().StringVarP(&templatePriority, "priority", "p", "", "Priority for tickets created from the template")
+	templateCreateCmd.Flags().StringVarP(&templateComplexity, "complexity", "c", "", "Complexity for tickets created from the template")
+	templateCreateCmd.Flags().StringVar(&templateRole, "role", "", "Role for tickets created from the template")
+	templateCreateCmd.Flags().StringArrayVar(&templateTasks, "task", nil, "Task added to each ticket (repeatable, in order)")
+
+	// Add subcommands
+	templateCmd.AddCommand(templateCreateCmd)
+	templateCmd.AddCommand(templateListCmd)
+	templateCmd.AddCommand(templateShowCmd)
+
+	rootCmd.AddCommand(templateCmd)
+}
+
+var templateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Ticket template commands",
+	Long: `Manage ticket templates. A template pre-fills tickets created with
+'wark ticket create --template': its description is rendered with the
+--var values given, and its priority, complexity, role and tasks are applied.
+Flags given to 'ticket create' take precedence over the template.`,
+}
+
+// template create
+var templateCreateCmd = &cobra.Command{
+	Use:   "create <NAME>",
+	Short: "Create a ticket template",
+	Long: `Create a ticket template.
+
+Template names must be 2-50 lowercase alphanumeric characters with hyphens,
+starting with a letter. The description is a Go text/template; each
+'--var key=value' given to 'ticket create' is available as {{.key}}, and a
+variable the description uses must be given.
+
+Examples:
+  wark template create bug --priority high --complexity small \
+    --description "Component: {{.component}}" \
+    --task "Reproduce" --task "Write a failing test" --task "Fix"
+  wark template create spike --complexity small --role architect --description-file spike.md`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTemplateCreate,
+}
+
+func runTemplateCreate(cmd *cobra.Command, args []string) error {
+	description := templateDescription
+	if templateDescriptionFile != "" {
+		if description != "" {
+			return ErrInvalidArgs("cannot use both --description and --description-file")
+		}
+		data, err := readDescriptionFile(templateDescriptionFile)
+		if err != nil {
+			return ErrInvalidArgs("failed to read --description-file: %s", err)
+		}
+		description = data
+	}
+
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return ErrDatabaseWithSuggestion(err, SuggestRunInit, "failed to open database")
+	}
+	defer database.Close()
+
+	t, err := service.NewTicketService(database.DB).CreateTemplate(service.CreateTemplateInput{
+		Name:        args[0],
+		Description: description,
+		Priority:    templatePriority,
+		Complexity:  templateComplexity,
+		RoleName:    templateRole,
+		Tasks:       templateTasks,
+	})
+	if err != nil {
+		return translateTemplateError(err)
+	}
+
+	if IsJSON() {
+		data, _ := json.MarshalIndent(t, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	OutputLine("Created template: %s", t.Name)
+	if len(t.Tasks) > 0 {
+		OutputLine("Tasks: %d", len(t.Tasks))
+	}
+	return nil
+}
+
+func readDescriptionFile(path string) (string, error) {
+	if path == "-" {
+		data, err := io.ReadAll(os.Stdin)
+		return string(data), err
+	}
+	data, err := os.ReadFile(path)
+	return string(data), err
+}
+
+// template list
+var templateListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List ticket templates",
+	Long: `List ticket templates.
+
+Examples:
+  wark template list`,
+	Args: cobra.NoArgs,
+	RunE: runTemplateList,
+}
+
+func runTemplateList(cmd *cobra.Command, args []string) error {
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return ErrDatabaseWithSuggestion(err, SuggestRunInit, "failed to open database")
+	}
+	defer database.Close()
+
+	templates, err := service.NewTicketService(database.DB).ListTemplates()
+	if err != nil {
+		return translateTemplateError(err)
+	}
+
+	if IsJSON() {
+		data, _ := json.MarshalIndent(templates, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(templates) == 0 {
+		OutputLine("No templates found. Create one with: wark template create <NAME> --description <TEMPLATE>")
+		return nil
+	}
+
+	fmt.Printf("%-20s %-10s %-10s %-24s %s\n", "NAME", "PRIORITY", "COMPLEXITY", "ROLE", "TASKS")
+	fmt.Println(strings.Repeat("-", 75))
+	for _, t := range templates {
+		fmt.Printf("%-20s %-10s %-10s %-24s %d\n",
+			truncate(t.Name, 20),
+			orDash(string(t.Priority)),
+			orDash(string(t.Complexity)),
+			truncate(orDash(t.RoleName), 24),
+			len(t.Tasks),
+		)
+	}
+
+	return nil
+}
+
+// orDash returns s, or "-" if it is empty.
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// template show
+var templateShowCmd = &cobra.Command{
+	Use:   "show <NAME>",
+	Short: "Show a ticket template",
+	Long: `Show a ticket template's settings, description template and tasks.
+
+Examples:
+  wark template show bug`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTemplateShow,
+}
+
+func runTemplateShow(cmd *cobra.Command, args []string) error {
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return ErrDatabaseWithSuggestion(err, SuggestRunInit, "failed to open database")
+	}
+	defer database.Close()
+
+	t, err := service.NewTicketService(database.DB).GetTemplate(args[0])
+	if err != nil {
+		return translateTemplateError(err)
+	}
+
+	if IsJSON() {
+		data, _ := json.MarshalIndent(t, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	printMainHeader(t.Name, "ticket template")
+	fmt.Printf("  %-12s %s\n", "Priority:", orDash(string(t.Priority)))
+	fmt.Printf("  %-12s %s\n", "Complexity:", orDash(string(t.Complexity)))
+	fmt.Printf("  %-12s %s\n", "Role:", orDash(t.RoleName))
+
+	printSectionHeader("DESCRIPTION")
+	if t.Description == "" {
+		fmt.Println("  (none)")
+	} else {
+		fmt.Println(t.Description)
+	}
+
+	printSectionHeader(fmt.Sprintf("TASKS (%d)", len(t.Tasks)))
+	if len(t.Tasks) == 0 {
+		fmt.Println("  (none)")
+	}
+	for i, task := range t.Tasks {
+		fmt.Printf("  %d. %s\n", i+1, task)
+	}
+
+	return nil
+}
+
+// parseTemplateVars parses --var key=value flags.
+func parseTemplateVars(values []string) (map[string]string, error) {
+	vars := make(map[string]string, len(values))
+	for _, v := range values {
+		key, value, ok := strings.Cut(v, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, ErrInvalidArgs("invalid --var %q (use key=value)", v)
+		}
+		vars[key] = value
+	}
+	return vars, nil
+}
+
+// translateTemplateError converts a template error to a CLI error, keeping
+// the service message, which names the missing template or role.
+func translateTemplateError(err error) error {
+	svcErr, ok := err.(*service.TicketError)
+	if !ok {
+		return ErrDatabase(err, "template operation failed")
+	}
+	sharedErr := &werrors.Error{Kind: svcErr.Kind(), Message: svcErr.Message}
+	if svcErr.Code == service.ErrCodeNotFound && strings.HasPrefix(svcErr.Message, "template") {
+		sharedErr.Suggestion = "Run 'wark template list' to see available templates."
+	}
+	return sharedErr
+}
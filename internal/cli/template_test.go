@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spetersoncode/wark/internal/db"
+	"github.com/spetersoncode/wark/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateCommands(t *testing.T) {
+	database, dbPath, cleanup := testDBWithPath(t)
+	defer cleanup()
+
+	projectRepo := db.NewProjectRepo(database.DB)
+	require.NoError(t, projectRepo.Create(&models.Project{Key: "TPL", Name: "Templates"}))
+
+	var tmpl models.TicketTemplate
+	require.NoError(t, runCmdJSON(t, dbPath, &tmpl, "template", "create", "bug",
+		"--priority", "high", "--complexity", "small",
+		"--description", "Component: {{.component}}",
+		"--task", "Reproduce", "--task", "Write a failing test", "--task", "Fix"))
+	assert.Equal(t, "bug", tmpl.Name)
+	assert.Len(t, tmpl.Tasks, 3)
+
+	_, err := runCmd(t, dbPath, "template", "create", "bug")
+	assert.ErrorContains(t, err, "already exists")
+
+	var templates []models.TicketTemplate
+	require.NoError(t, runCmdJSON(t, dbPath, &templates, "template", "list"))
+	require.Len(t, templates, 1)
+
+	output, err := runCmd(t, dbPath, "template", "show", "bug")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Component: {{.component}}")
+	assert.Contains(t, output, "2. Write a failing test")
+
+	// Creating a ticket renders the description and applies the template
+	var created ticketCreateResult
+	require.NoError(t, runCmdJSON(t, dbPath, &created, "ticket", "create", "TPL", "-t", "Login fails",
+		"--template", "bug", "--var", "component=auth"))
+	assert.Equal(t, "Component: auth", created.Description)
+	assert.Equal(t, models.PriorityHigh, created.Priority)
+	assert.Equal(t, models.ComplexitySmall, created.Complexity)
+	assert.Equal(t, 3, created.Tasks)
+	tasks, err := db.NewTasksRepo(database.DB).ListTasks(context.Background(), created.ID)
+	require.NoError(t, err)
+	require.Len(t, tasks, 3)
+	assert.Equal(t, "Reproduce", tasks[0].Description)
+
+	// Explicit flags win over the template
+	require.NoError(t, runCmdJSON(t, dbPath, &created, "ticket", "create", "TPL", "-t", "Crash",
+		"--template", "bug", "--priority", "low", "-d", "See logs"))
+	assert.Equal(t, "See logs", created.Description)
+	assert.Equal(t, models.PriorityLow, created.Priority)
+	assert.Equal(t, models.ComplexitySmall, created.Complexity)
+
+	_, err = runCmd(t, dbPath, "ticket", "create", "TPL", "-t", "No vars", "--template", "bug")
+	assert.ErrorContains(t, err, "component")
+	_, err = runCmd(t, dbPath, "ticket", "create", "TPL", "-t", "Unknown", "--template", "feature")
+	assert.ErrorContains(t, err, "template feature not found")
+	_, err = runCmd(t, dbPath, "ticket", "create", "TPL", "-t", "Vars only", "--var", "component=auth")
+	assert.ErrorContains(t, err, "--var requires --template")
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/spetersoncode/wark/internal/common"
 	"github.com/spetersoncode/wark/internal/db"
+	werrors "github.com/spetersoncode/wark/internal/errors"
 	"github.com/spetersoncode/wark/internal/models"
 	"github.com/spetersoncode/wark/internal/service"
 	"github.com/spf13/cobra"
@@ -71,6 +72,7 @@ var (
 	ticketEpic           string
 	ticketProject        string
 	ticketStatus         []string
+	ticketLabels         []string
 	ticketWorkable       bool
 	ticketReviewable     bool
 	ticketLimit          int
@@ -79,6 +81,11 @@ var (
 	ticketCommentMessage string
 	ticketCommentWorker  string
 	ticketRole           string
+	ticketMilestone      string
+	ticketNotBefore      string
+	ticketDue            string
+	ticketTemplate       string
+	ticketVars           []string
 )
 
 func init() {
@@ -92,6 +99,11 @@ func init() {
 	ticketCreateCmd.Flags().StringVar(&ticketParent, "parent", "", "Parent ticket ID")
 	ticketCreateCmd.Flags().StringVar(&ticketEpic, "epic", "", "Epic ticket ID (alternative to --parent for clearer semantics)")
 	ticketCreateCmd.Flags().StringVar(&ticketRole, "role", "", "Role to use for this ticket (e.g., 'software-engineer', 'code-reviewer', 'worker')")
+	ticketCreateCmd.Flags().StringVar(&ticketMilestone, "milestone", "", "Milestone key in the ticket's project")
+	ticketCreateCmd.Flags().StringVar(&ticketNotBefore, "not-before", "", "Not workable before this date (YYYY-MM-DD or RFC 3339)")
+	ticketCreateCmd.Flags().StringVar(&ticketDue, "due", "", "Due date (YYYY-MM-DD for the end of that day, or RFC 3339)")
+	ticketCreateCmd.Flags().StringVar(&ticketTemplate, "template", "", "Ticket template to pre-fill the description, levels, role and tasks")
+	ticketCreateCmd.Flags().StringArrayVar(&ticketVars, "var", nil, "Template variable as key=value (repeatable)")
 	ticketCreateCmd.MarkFlagRequired("title")
 
 	// ticket list
@@ -99,6 +111,8 @@ func init() {
 	ticketListCmd.Flags().StringSliceVarP(&ticketStatus, "status", "s", nil, "Filter by status (comma-separated)")
 	ticketListCmd.Flags().StringVar(&ticketPriority, "priority", "", "Filter by priority")
 	ticketListCmd.Flags().StringVar(&ticketComplexity, "complexity", "", "Filter by complexity")
+	ticketListCmd.Flags().StringSliceVar(&ticketLabels, "label", nil, "Filter by label (repeatable; tickets must have all)")
+	ticketListCmd.Flags().StringVar(&ticketMilestone, "milestone", "", "Filter by milestone key (requires --project)")
 	ticketListCmd.Flags().BoolVarP(&ticketWorkable, "workable", "w", false, "Show only workable tickets")
 	ticketListCmd.Flags().BoolVarP(&ticketReviewable, "reviewable", "r", false, "Show only tickets in review status")
 	ticketListCmd.Flags().IntVarP(&ticketLimit, "limit", "l", 50, "Max tickets to show")
@@ -110,10 +124,13 @@ func init() {
 	ticketEditCmd.Flags().StringVarP(&ticketComplexity, "complexity", "c", "", "New complexity")
 	ticketEditCmd.Flags().StringSliceVar(&ticketAddDep, "add-dep", nil, "Add dependencies (comma-separated)")
 	ticketEditCmd.Flags().StringSliceVar(&ticketRemoveDep, "remove-dep", nil, "Remove dependencies (comma-separated)")
+	ticketEditCmd.Flags().StringVar(&ticketMilestone, "milestone", "", "Move to milestone (empty string to clear)")
+	ticketEditCmd.Flags().StringVar(&ticketNotBefore, "not-before", "", "Not workable before this date (empty string to clear)")
+	ticketEditCmd.Flags().StringVar(&ticketDue, "due", "", "Due date (empty string to clear)")
 
 	// ticket comment
 	ticketCommentCmd.Flags().StringVarP(&ticketCommentMessage, "message", "m", "", "Comment text (required)")
-	ticketCommentCmd.Flags().StringVar(&ticketCommentWorker, "worker-id", "", "Worker identifier (defaults to $WARK_WORKER_ID or hostname)")
+	ticketCommentCmd.Flags().StringVar(&ticketCommentWorker, "worker-id", "", "Worker identifier (defaults to $WARK_WORKER_ID or config)")
 	ticketCommentCmd.MarkFlagRequired("message")
 
 	// Add subcommands
@@ -216,7 +233,10 @@ Examples:
   wark ticket create WEBAPP -t "Set up OAuth routes" --parent WEBAPP-15
   wark ticket create WEBAPP -t "Add login form" --epic WEBAPP-15
   wark ticket create WEBAPP -t "Add login"
-  wark ticket create WEBAPP -t "Implement feature" --role software-engineer`,
+  wark ticket create WEBAPP -t "Implement feature" --role software-engineer
+  wark ticket create WEBAPP -t "Ship login" --milestone V1
+  wark ticket create WEBAPP -t "Renew certificate" --not-before 2026-11-01 --due 2026-11-15
+  wark ticket create WEBAPP -t "Login fails on Safari" --template bug --var component=auth`,
 	Args: cobra.ExactArgs(1),
 	RunE: runTicketCreate,
 }
@@ -224,6 +244,7 @@ Examples:
 type ticketCreateResult struct {
 	*models.Ticket
 	Worktree string `json:"worktree"`
+	Tasks    int    `json:"tasks,omitempty"`
 }
 
 func runTicketCreate(cmd *cobra.Command, args []string) error {
@@ -235,154 +256,70 @@ func runTicketCreate(cmd *cobra.Command, args []string) error {
 	}
 	defer database.Close()
 
-	// Get project
-	projectRepo := db.NewProjectRepo(database.DB)
-	project, err := projectRepo.GetByKey(projectKey)
+	vars, err := parseTemplateVars(ticketVars)
 	if err != nil {
-		return ErrDatabase(err, "failed to get project")
-	}
-	if project == nil {
-		return ErrNotFoundWithSuggestion(SuggestListProjects, "project %s not found", projectKey)
-	}
-
-	// Apply defaults before validation (shared flag variables with other commands may override defaults)
-	if ticketPriority == "" {
-		ticketPriority = "medium"
-	}
-	if ticketComplexity == "" {
-		ticketComplexity = "medium"
-	}
-
-	// Parse priority
-	priority, err := models.ParsePriority(ticketPriority)
-	if err != nil {
-		return ErrInvalidArgs("%s", err)
-	}
-
-	// Parse complexity
-	complexity, err := models.ParseComplexity(ticketComplexity)
-	if err != nil {
-		return ErrInvalidArgs("%s", err)
-	}
-
-	// Parse ticket type
-	var tType models.TicketType
-	if ticketType == "" {
-		ticketType = "task"
-	}
-	tType, err = models.ParseTicketType(ticketType)
-	if err != nil {
-		return ErrInvalidArgs("%s", err)
+		return err
 	}
-
-	// Initial status is backlog (may change to blocked if deps added)
-	ticket := &models.Ticket{
-		ProjectID:   project.ID,
-		Title:       ticketTitle,
-		Description: ticketDescription,
-		Priority:    priority,
-		Complexity:  complexity,
-		Type:        tType,
-		Status:      models.StatusBacklog, // May change to blocked if deps added
-	}
-
-	// Set role if provided
-	if ticketRole != "" {
-		roleRepo := db.NewRoleRepo(database.DB)
-		role, err := roleRepo.GetByName(ticketRole)
-		if err != nil {
-			return ErrDatabase(err, "failed to get role")
-		}
-		if role == nil {
-			return ErrNotFoundWithSuggestion(
-				"Run 'wark role list' to see available roles or create one with 'wark role create'.",
-				"role '%s' not found", ticketRole,
-			)
-		}
-		ticket.RoleID = &role.ID
+	if ticketTemplate == "" && len(vars) > 0 {
+		return ErrInvalidArgs("--var requires --template")
 	}
 
 	// Handle parent ticket (--parent or --epic)
 	if ticketParent != "" && ticketEpic != "" {
 		return ErrInvalidArgs("cannot use both --parent and --epic flags (they serve the same purpose)")
 	}
-	
 	parentKey := ticketParent
 	if ticketEpic != "" {
 		parentKey = ticketEpic
 	}
-	
-	if parentKey != "" {
-		parentTicket, err := resolveTicket(database, parentKey, projectKey)
-		if err != nil {
-			return fmt.Errorf("failed to resolve parent ticket: %w", err)
-		}
-		ticket.ParentTicketID = &parentTicket.ID
-	}
 
-	ticketRepo := db.NewTicketRepo(database.DB)
-	if err := ticketRepo.Create(ticket); err != nil {
-		return ErrDatabase(err, "failed to create ticket")
+	input := service.CreateTicketInput{
+		ProjectKey:   projectKey,
+		Title:        ticketTitle,
+		Description:  ticketDescription,
+		Type:         ticketType,
+		ParentKey:    parentKey,
+		DependsOn:    ticketDependsOn,
+		RoleName:     ticketRole,
+		MilestoneKey: ticketMilestone,
+		NotBefore:    ticketNotBefore,
+		DueAt:        ticketDue,
+		Template:     ticketTemplate,
+		Vars:         vars,
 	}
-
-	// Generate worktree name (auto-generate for epics, or on-demand for tasks)
-	worktreeName := generateWorktreeName(projectKey, ticket.Number, ticket.Title)
-
-	// Handle worktree assignment: epics get their own worktree, child tasks of epics inherit the epic's worktree
-	if ticket.IsEpic() {
-		// Epics always get a worktree name stored
-		ticket.Worktree = worktreeName
-	} else if ticket.ParentTicketID != nil {
-		// Check if parent is an epic - if so, inherit the worktree
-		parentTicket, err := ticketRepo.GetByID(*ticket.ParentTicketID)
-		if err == nil && parentTicket != nil && parentTicket.IsEpic() && parentTicket.Worktree != "" {
-			ticket.Worktree = parentTicket.Worktree
-			worktreeName = parentTicket.Worktree // Use epic's worktree name for display
-		}
+	// Leave levels that weren't given to the template
+	if ticketTemplate == "" || cmd.Flags().Changed("priority") {
+		input.Priority = ticketPriority
+	}
+	if ticketTemplate == "" || cmd.Flags().Changed("complexity") {
+		input.Complexity = ticketComplexity
 	}
-	ticket.ProjectKey = projectKey
-	ticket.TicketKey = fmt.Sprintf("%s-%d", projectKey, ticket.Number)
 
-	// Update with worktree name (for epics and children of epics)
-	if ticket.Worktree != "" {
-		if err := ticketRepo.Update(ticket); err != nil {
-			VerboseOutput("Warning: failed to save worktree name: %v\n", err)
-		}
+	ticket, err := service.NewTicketService(database.DB).Create(input)
+	if err != nil {
+		return translateCreateError(err, input)
 	}
 
-	// Add dependencies
-	depRepo := db.NewDependencyRepo(database.DB)
-	if len(ticketDependsOn) > 0 {
-		for _, depKey := range ticketDependsOn {
-			depTicket, err := resolveTicket(database, depKey, projectKey)
-			if err != nil {
-				return err // Already wrapped with proper error type
-			}
-			if err := depRepo.Add(ticket.ID, depTicket.ID); err != nil {
-				return ErrDatabase(err, "failed to add dependency on %s", depKey)
-			}
+	var taskCount int
+	if ticketTemplate != "" {
+		counts, err := db.NewTasksRepo(database.DB).GetTaskCounts(context.Background(), ticket.ID)
+		if err != nil {
+			return ErrDatabase(err, "failed to count tasks")
 		}
+		taskCount = counts.Total
 	}
 
-	// Auto-transition: check if ticket should be blocked based on dependencies
-	// State machine rule: ON create: if has_open_deps → blocked, else → backlog
-	hasUnresolved, err := depRepo.HasUnresolvedDependencies(ticket.ID)
-	if err != nil {
-		VerboseOutput("Warning: failed to check dependencies: %v\n", err)
-	} else if hasUnresolved {
-		ticket.Status = models.StatusBlocked
-		if err := ticketRepo.Update(ticket); err != nil {
-			VerboseOutput("Warning: failed to update status to blocked: %v\n", err)
-		} else {
-			activityRepo := db.NewActivityRepo(database.DB)
-			activityRepo.LogAction(ticket.ID, models.ActionBlocked, models.ActorTypeSystem, "",
-				"Blocked by unresolved dependencies")
-		}
+	// Epics and their children share a stored worktree; other tickets get
+	// one named on demand
+	worktreeName := ticket.Worktree
+	if worktreeName == "" {
+		worktreeName = generateWorktreeName(ticket.ProjectKey, ticket.Number, ticket.Title)
 	}
 
 	result := ticketCreateResult{
 		Ticket:   ticket,
 		Worktree: worktreeName,
+		Tasks:    taskCount,
 	}
 
 	if IsJSON() {
@@ -396,10 +333,87 @@ func runTicketCreate(cmd *cobra.Command, args []string) error {
 	OutputLine("Type: %s", ticket.Type)
 	OutputLine("Status: %s", ticket.Status)
 	OutputLine("Worktree: %s", worktreeName)
+	if taskCount > 0 {
+		OutputLine("Tasks: %d from template %s", taskCount, ticketTemplate)
+	}
 
 	return nil
 }
 
+// translateCreateError converts a ticket creation error to a CLI error,
+// suggesting how to find what was not found and naming date errors by
+// their flags.
+func translateCreateError(err error, input service.CreateTicketInput) error {
+	if sharedErr, ok := err.(*werrors.Error); ok {
+		return sharedErr
+	}
+	svcErr, ok := err.(*service.TicketError)
+	if !ok {
+		return ErrDatabase(err, "failed to create ticket")
+	}
+	sharedErr := &werrors.Error{Kind: svcErr.Kind(), Message: svcErr.Message}
+	if rest, ok := strings.CutPrefix(svcErr.Message, "not_before: "); ok {
+		sharedErr.Message = "--not-before: " + rest
+	} else if rest, ok := strings.CutPrefix(svcErr.Message, "due_at: "); ok {
+		sharedErr.Message = "--due: " + rest
+	}
+	if strings.HasPrefix(svcErr.Message, "failed to render template") {
+		sharedErr.Suggestion = fmt.Sprintf("Run 'wark template show %s' to see the variables it uses.", input.Template)
+	}
+	if svcErr.Code != service.ErrCodeNotFound {
+		return sharedErr
+	}
+	switch {
+	case strings.HasPrefix(svcErr.Message, "template"):
+		sharedErr.Suggestion = "Run 'wark template list' to see available templates."
+	case strings.HasPrefix(svcErr.Message, "project"):
+		sharedErr.Suggestion = SuggestListProjects
+	case strings.HasPrefix(svcErr.Message, "role"):
+		sharedErr.Suggestion = "Run 'wark role list' to see available roles or create one with 'wark role create'."
+	case strings.HasPrefix(svcErr.Message, "milestone"):
+		sharedErr.Suggestion = "Run 'wark milestone list --project " + input.ProjectKey + "' to see available milestones."
+	case strings.HasPrefix(svcErr.Message, "ticket"):
+		sharedErr.Suggestion = SuggestListTickets
+	}
+	return sharedErr
+}
+
+// parseDateFlag parses an optional date flag; an empty value is no date.
+// A --due date without a time is the end of that day.
+func parseDateFlag(flag, value string) (*time.Time, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	parse := common.ParseDate
+	if flag == "due" {
+		parse = common.ParseDueDate
+	}
+	t, err := parse(strings.TrimSpace(value))
+	if err != nil {
+		return nil, ErrInvalidArgs("--%s: %s", flag, err)
+	}
+	return &t, nil
+}
+
+// formatDate formats a schedule date, leaving off midnight and the end of
+// the day.
+func formatDate(t time.Time) string {
+	t = t.Local()
+	if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 ||
+		t.Hour() == 23 && t.Minute() == 59 && t.Second() == 59 {
+		return t.Format("2006-01-02")
+	}
+	return t.Format("2006-01-02 15:04")
+}
+
+// formatOptionalDate formats an optional schedule date, or "(none)".
+func formatOptionalDate(t *time.Time) string {
+	if t == nil {
+		return "(none)"
+	}
+	return formatDate(*t)
+}
+
 // ticket list
 var ticketListCmd = &cobra.Command{
 	Use:   "list",
@@ -411,7 +425,9 @@ Examples:
   wark ticket list --status ready,working
   wark ticket list --workable
   wark ticket list --reviewable
-  wark ticket list --priority high,highest`,
+  wark ticket list --priority high,highest
+  wark ticket list --label area:api --label needs-migration
+  wark ticket list --project WEBAPP --milestone V1`,
 	Args: cobra.NoArgs,
 	RunE: runTicketList,
 }
@@ -440,7 +456,20 @@ func runTicketList(cmd *cobra.Command, args []string) error {
 		Limit:      ticketLimit,
 	}
 
-	// Note: milestone filter removed - milestones were deprecated in WARK-13
+	if ticketMilestone != "" {
+		if filter.ProjectKey == "" {
+			return ErrInvalidArgs("--milestone requires --project (milestone keys are per project)")
+		}
+		filter.MilestoneKey = strings.ToUpper(ticketMilestone)
+	}
+
+	if len(ticketLabels) > 0 {
+		labels, err := models.ParseLabels(ticketLabels)
+		if err != nil {
+			return ErrInvalidArgs("%s", err)
+		}
+		filter.Labels = labels
+	}
 
 	if ticketWorkable {
 		tickets, err = ticketRepo.ListWorkable(filter)
@@ -604,6 +633,7 @@ type ticketShowResult struct {
 	TasksComplete  int                    `json:"tasks_complete,omitempty"`
 	TasksTotal     int                    `json:"tasks_total,omitempty"`
 	Claim          *models.Claim          `json:"claim,omitempty"`
+	Decisions      []*models.Decision     `json:"decisions,omitempty"`
 }
 
 func runTicketShow(cmd *cobra.Command, args []string) error {
@@ -664,6 +694,12 @@ func runTicketShow(cmd *cobra.Command, args []string) error {
 		VerboseOutput("Warning: failed to get claim: %v\n", err)
 	}
 
+	// Fetch decisions made on the ticket's inbox messages
+	decisions, err := db.NewInboxRepo(database.DB).ListDecisions(ticket.ID)
+	if err != nil {
+		return ErrDatabase(err, "failed to get decisions")
+	}
+
 	// Identify blocking dependencies for blocked tickets
 	var blockingDeps []*models.Ticket
 	if ticket.Status == models.StatusBlocked {
@@ -682,6 +718,7 @@ func runTicketShow(cmd *cobra.Command, args []string) error {
 		Comments:     comments,
 		History:      history,
 		Claim:        claim,
+		Decisions:    decisions,
 	}
 
 	// Only include task fields if there are tasks
@@ -715,6 +752,26 @@ func runTicketShow(cmd *cobra.Command, args []string) error {
 	if ticket.RoleName != "" {
 		fmt.Printf("  %-12s @%s\n", "Role:", ticket.RoleName)
 	}
+	if len(ticket.Labels) > 0 {
+		fmt.Printf("  %-12s %s\n", "Labels:", strings.Join(ticket.Labels, ", "))
+	}
+	if ticket.MilestoneKey != "" {
+		fmt.Printf("  %-12s %s\n", "Milestone:", ticket.MilestoneKey)
+	}
+	if ticket.NotBefore != nil {
+		if ticket.IsDeferred(time.Now()) {
+			fmt.Printf("  %-12s %s (not workable yet)\n", "Not before:", formatDate(*ticket.NotBefore))
+		} else {
+			fmt.Printf("  %-12s %s\n", "Not before:", formatDate(*ticket.NotBefore))
+		}
+	}
+	if ticket.DueAt != nil {
+		if ticket.IsOverdue(time.Now()) {
+			fmt.Printf("  %-12s %s ⚠ overdue\n", "Due:", formatDate(*ticket.DueAt))
+		} else {
+			fmt.Printf("  %-12s %s\n", "Due:", formatDate(*ticket.DueAt))
+		}
+	}
 	if ticket.Worktree != "" {
 		fmt.Printf("  %-12s %s\n", "Worktree:", ticket.Worktree)
 	}
@@ -772,6 +829,14 @@ func runTicketShow(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if len(decisions) > 0 {
+		printSectionHeader("Decisions")
+		for _, d := range decisions {
+			fmt.Printf("  #%d %s\n", d.MessageID, truncate(d.Question, 50))
+			fmt.Printf("     → %s: %s\n", d.Choice, d.Label)
+		}
+	}
+
 	if len(dependencies) > 0 {
 		printSectionHeader("Dependencies")
 		for _, dep := range dependencies {
@@ -857,7 +922,10 @@ var ticketEditCmd = &cobra.Command{
 Examples:
   wark ticket edit WEBAPP-42 --priority highest
   wark ticket edit WEBAPP-42 --title "New title" --description "Updated description"
-  wark ticket edit WEBAPP-42 --add-dep WEBAPP-41 --remove-dep WEBAPP-40`,
+  wark ticket edit WEBAPP-42 --add-dep WEBAPP-41 --remove-dep WEBAPP-40
+  wark ticket edit WEBAPP-42 --milestone V1
+  wark ticket edit WEBAPP-42 --milestone ""
+  wark ticket edit WEBAPP-42 --due 2026-11-15 --not-before ""`,
 	Args: cobra.ExactArgs(1),
 	RunE: runTicketEdit,
 }
@@ -922,6 +990,33 @@ func runTicketEdit(cmd *cobra.Command, args []string) error {
 			map[string]interface{}{"field": "complexity", "old": string(oldComplexity), "new": string(complexity)})
 	}
 
+	// Update schedule dates
+	for _, date := range []struct {
+		flag, field, label string
+		value              string
+		target             **time.Time
+	}{
+		{"not-before", "not_before", "Not before", ticketNotBefore, &ticket.NotBefore},
+		{"due", "due_at", "Due", ticketDue, &ticket.DueAt},
+	} {
+		if !cmd.Flags().Changed(date.flag) {
+			continue
+		}
+		parsed, err := parseDateFlag(date.flag, date.value)
+		if err != nil {
+			return err
+		}
+		from, to := formatOptionalDate(*date.target), formatOptionalDate(parsed)
+		if from == to {
+			continue
+		}
+		*date.target = parsed
+		changed = true
+		activityRepo.LogActionWithDetails(ticket.ID, models.ActionFieldChanged, models.ActorTypeHuman, "",
+			fmt.Sprintf("%s: %s → %s", date.label, from, to),
+			map[string]interface{}{"field": date.field, "old": from, "new": to})
+	}
+
 	// Save ticket changes
 	if changed {
 		ticketRepo := db.NewTicketRepo(database.DB)
@@ -930,6 +1025,19 @@ func runTicketEdit(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Move to (or out of) a milestone
+	if cmd.Flags().Changed("milestone") {
+		milestoneKey := strings.ToUpper(strings.TrimSpace(ticketMilestone))
+		if milestoneKey != ticket.MilestoneKey {
+			updated, err := service.NewMilestoneService(database.DB).Assign(ticket.ID, milestoneKey)
+			if err != nil {
+				return translateMilestoneError(err)
+			}
+			ticket = updated
+			changed = true
+		}
+	}
+
 	// Handle dependency changes
 	depRepo := db.NewDependencyRepo(database.DB)
 
@@ -1064,10 +1172,7 @@ func runTicketComment(cmd *cobra.Command, args []string) error {
 		actorType = models.ActorTypeClaim
 		actorID = activeClaim.ClaimID
 	} else {
-		actorID = ticketCommentWorker
-		if actorID == "" {
-			actorID = GetDefaultWorkerID()
-		}
+		actorID = ResolveWorkerID(ticketCommentWorker)
 	}
 
 	activityRepo := db.NewActivityRepo(database.DB)
@@ -1096,7 +1201,8 @@ var ticketExecutionContextCmd = &cobra.Command{
 	Use:   "execution-context <TICKET>",
 	Short: "Show execution context for a ticket",
 	Long: `Display the execution context for a ticket including role instructions,
-model selection based on complexity, and capability level.
+model selection based on complexity, capability level, and the options
+humans chose on the ticket's decision messages.
 
 Examples:
   wark ticket execution-context WEBAPP-42
@@ -1106,11 +1212,12 @@ Examples:
 }
 
 type ticketExecutionContextResult struct {
-	TicketKey    string `json:"ticket_key"`
-	Instructions string `json:"instructions"`
-	Role         string `json:"role,omitempty"`
-	Model        string `json:"model"`
-	Capability   string `json:"capability"`
+	TicketKey    string             `json:"ticket_key"`
+	Instructions string             `json:"instructions"`
+	Role         string             `json:"role,omitempty"`
+	Model        string             `json:"model"`
+	Capability   string             `json:"capability"`
+	Decisions    []*models.Decision `json:"decisions,omitempty"`
 }
 
 func runTicketExecutionContext(cmd *cobra.Command, args []string) error {
@@ -1139,6 +1246,7 @@ func runTicketExecutionContext(cmd *cobra.Command, args []string) error {
 			Role:         ctx.Role,
 			Model:        ctx.Model,
 			Capability:   ctx.Capability,
+			Decisions:    ctx.Decisions,
 		}
 		data, _ := json.MarshalIndent(result, "", "  ")
 		fmt.Println(string(data))
@@ -1167,5 +1275,16 @@ func runTicketExecutionContext(cmd *cobra.Command, args []string) error {
 		fmt.Println("No role instructions configured for this ticket.")
 	}
 
+	if len(ctx.Decisions) > 0 {
+		fmt.Println()
+		fmt.Println(strings.Repeat("-", 65))
+		fmt.Println("Decisions:")
+		fmt.Println(strings.Repeat("-", 65))
+		for _, d := range ctx.Decisions {
+			fmt.Printf("#%d %s\n", d.MessageID, d.Question)
+			fmt.Printf("  → %s: %s\n", d.Choice, d.Label)
+		}
+	}
+
 	return nil
 }
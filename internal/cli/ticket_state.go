@@ -15,6 +15,7 @@ var (
 	rejectReason    string
 	cancelReason    string
 	closeResolution string
+	laterUntil      string
 )
 
 func init() {
@@ -28,6 +29,9 @@ func init() {
 
 	// ticket resume (no flags needed - just moves human -> ready)
 
+	// ticket later
+	ticketLaterCmd.Flags().StringVar(&laterUntil, "until", "", "Keep out of ticket next until this date (YYYY-MM-DD or RFC 3339)")
+
 	// Add subcommands
 	ticketCmd.AddCommand(ticketStartCmd)
 	ticketCmd.AddCommand(ticketReviewCmd)
@@ -464,8 +468,12 @@ var ticketLaterCmd = &cobra.Command{
 This command is used when a human has responded but the ticket should
 be deprioritized and done later rather than now.
 
-Example:
-  wark ticket later WEBAPP-42`,
+With --until, the ticket is also given a not-before date: once started again
+it stays out of 'ticket next' and workable lists until then.
+
+Examples:
+  wark ticket later WEBAPP-42
+  wark ticket later WEBAPP-42 --until 2026-11-01`,
 	Args: cobra.ExactArgs(1),
 	RunE: runTicketLater,
 }
@@ -481,10 +489,14 @@ func runTicketLater(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	until, err := parseDateFlag("until", laterUntil)
+	if err != nil {
+		return err
+	}
 
 	// Use service layer for deprioritize operation
 	ticketSvc := service.NewTicketService(database.DB)
-	if err := ticketSvc.Deprioritize(ticket.ID); err != nil {
+	if err := ticketSvc.DeprioritizeUntil(ticket.ID, until); err != nil {
 		return translateServiceError(err, ticket.TicketKey)
 	}
 
@@ -496,17 +508,24 @@ func runTicketLater(cmd *cobra.Command, args []string) error {
 	}
 
 	if IsJSON() {
-		data, _ := json.MarshalIndent(map[string]interface{}{
+		result := map[string]interface{}{
 			"ticket":       updatedTicket.TicketKey,
 			"status":       updatedTicket.Status,
 			"deprioritized": true,
-		}, "", "  ")
+		}
+		if updatedTicket.NotBefore != nil {
+			result["not_before"] = updatedTicket.NotBefore
+		}
+		data, _ := json.MarshalIndent(result, "", "  ")
 		fmt.Println(string(data))
 		return nil
 	}
 
 	OutputLine("Deprioritized: %s", updatedTicket.TicketKey)
 	OutputLine("Status: %s (do this later)", updatedTicket.Status)
+	if until != nil {
+		OutputLine("Not before: %s", formatDate(*until))
+	}
 
 	return nil
 }
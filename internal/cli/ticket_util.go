@@ -8,6 +8,7 @@ import (
 
 	"github.com/spetersoncode/wark/internal/db"
 	"github.com/spetersoncode/wark/internal/models"
+	"github.com/spetersoncode/wark/internal/service"
 	"github.com/spf13/cobra"
 )
 
@@ -15,6 +16,11 @@ import (
 var (
 	nextDryRun       bool
 	nextComplexity   string
+	nextRole         string
+	nextCapability   string
+	nextLabels       []string
+	nextByImpact     bool
+	nextPolicy       string
 	branchSet        string
 	logLimit         int
 	logAction        string
@@ -28,6 +34,12 @@ func init() {
 	ticketNextCmd.Flags().StringVarP(&ticketProject, "project", "p", "", "Limit to project")
 	ticketNextCmd.Flags().BoolVar(&nextDryRun, "dry-run", false, "Show ticket without claiming")
 	ticketNextCmd.Flags().StringVar(&nextComplexity, "complexity", "large", "Max complexity to accept")
+	ticketNextCmd.Flags().StringVar(&nextRole, "role", "", "Only tickets assigned this role")
+	ticketNextCmd.Flags().StringVar(&nextCapability, "capability", "", "Only tickets needing this capability (fast, standard, powerful)")
+	ticketNextCmd.Flags().StringSliceVar(&nextLabels, "label", nil, "Only tickets with this label (repeatable; tickets must have all)")
+	ticketNextCmd.Flags().BoolVar(&nextByImpact, "by-impact", false, "Break priority ties by how much open work each ticket blocks")
+	ticketNextCmd.Flags().StringVar(&nextPolicy, "policy", "", "Scheduling policy: priority, aging, impact, smallest, round-robin (default from config)")
+	ticketNextCmd.Flags().StringVar(&claimWorkerID, "worker-id", "", "Worker identity (defaults to $WARK_WORKER_ID or config)")
 
 	// ticket branch
 	ticketBranchCmd.Flags().StringVar(&branchSet, "set", "", "Override auto-generated branch name")
@@ -49,20 +61,44 @@ func init() {
 var ticketNextCmd = &cobra.Command{
 	Use:   "next",
 	Short: "Get and claim the next workable ticket",
-	Long: `Get and claim the next workable ticket based on priority.
+	Long: `Get and claim the next workable ticket based on the scheduling policy.
 
 Selection criteria (in order):
 1. Status is ready
 2. All dependencies resolved
 3. No active claim
 4. retry_count < max_retries
-5. Ordered by: priority (highest first), then created_at (oldest first)
+5. Matches --role, --capability and --label if given
+6. Ordered by the scheduling policy, then priority (highest first), then
+   created_at (oldest first)
+
+Scheduling policies (--policy, or policy in the [scheduling] config section):
+  priority     highest priority first (default)
+  aging        priority, raised one level for every aging_hours a ticket has
+               waited (24 by default), up to four levels
+  impact       tickets blocking the most open work first
+  smallest     lowest complexity first
+  round-robin  tickets from the project claimed from least recently first
+
+With --by-impact, tickets the policy scores equally are ordered by how many
+open tickets depend on them, directly or transitively, before created_at. See
+'wark graph critical-path'.
+
+--dry-run shows the policy and the score it gave the ticket.
+
+--capability maps complexity to the model tier that should handle it:
+trivial/small are fast, medium/large are standard, xlarge is powerful.
+When --capability is given, --complexity only applies if set explicitly.
 
 Examples:
   wark ticket next
   wark ticket next --project WEBAPP
   wark ticket next --dry-run
-  wark ticket next --complexity medium`,
+  wark ticket next --complexity medium
+  wark ticket next --role software-engineer --capability fast
+  wark ticket next --label area:api
+  wark ticket next --by-impact
+  wark ticket next --policy round-robin --dry-run`,
 	Args: cobra.NoArgs,
 	RunE: runTicketNext,
 }
@@ -74,59 +110,73 @@ func runTicketNext(cmd *cobra.Command, args []string) error {
 	}
 	defer database.Close()
 
-	// Parse max complexity
-	maxComplexity := models.Complexity(strings.ToLower(nextComplexity))
-	if !maxComplexity.IsValid() {
-		return fmt.Errorf("invalid complexity: %s", nextComplexity)
-	}
-
-	// Get workable tickets
-	ticketRepo := db.NewTicketRepo(database.DB)
 	filter := db.TicketFilter{
 		ProjectKey: strings.ToUpper(ticketProject),
-		Limit:      100, // Get more to filter by complexity and claims
 	}
 
-	tickets, err := ticketRepo.ListWorkable(filter)
-	if err != nil {
-		return fmt.Errorf("failed to list tickets: %w", err)
+	// Parse max complexity; with --capability it only applies if set explicitly
+	if nextCapability == "" || cmd.Flags().Changed("complexity") {
+		maxComplexity := models.Complexity(strings.ToLower(nextComplexity))
+		if !maxComplexity.IsValid() {
+			return fmt.Errorf("invalid complexity: %s", nextComplexity)
+		}
+		filter.MaxComplexity = &maxComplexity
 	}
 
-	// Filter by complexity and claims
-	claimRepo := db.NewClaimRepo(database.DB)
-	var nextTicket *models.Ticket
-
-	complexityOrder := map[models.Complexity]int{
-		models.ComplexityTrivial: 1,
-		models.ComplexitySmall:   2,
-		models.ComplexityMedium:  3,
-		models.ComplexityLarge:   4,
-		models.ComplexityXLarge:  5,
+	if nextCapability != "" {
+		filter.Capability = strings.ToLower(nextCapability)
+		if !models.IsValidCapability(filter.Capability) {
+			return ErrInvalidArgs("invalid capability: %s (must be fast, standard, or powerful)", nextCapability)
+		}
 	}
-	maxOrder := complexityOrder[maxComplexity]
 
-	for _, t := range tickets {
-		// Check complexity
-		if complexityOrder[t.Complexity] > maxOrder {
-			continue
+	if nextRole != "" {
+		role, err := db.NewRoleRepo(database.DB).GetByName(nextRole)
+		if err != nil {
+			return ErrDatabase(err, "failed to get role")
 		}
-
-		// Check retry count
-		if t.RetryCount >= t.MaxRetries {
-			continue
+		if role == nil {
+			return ErrNotFoundWithSuggestion(
+				"Run 'wark role list' to see available roles.",
+				"role '%s' not found", nextRole,
+			)
 		}
+		filter.RoleName = role.Name
+	}
 
-		// Check for active claim
-		hasClaim, err := claimRepo.HasActiveClaim(t.ID)
+	if len(nextLabels) > 0 {
+		labels, err := models.ParseLabels(nextLabels)
 		if err != nil {
-			continue
-		}
-		if hasClaim {
-			continue
+			return ErrInvalidArgs("%s", err)
 		}
+		filter.Labels = labels
+	}
+	scheduling, err := schedulingOptions(nextPolicy)
+	if err != nil {
+		return err
+	}
+	ticketSvc := service.NewTicketService(database.DB).
+		BreakTiesByImpact(nextByImpact).
+		WithScheduling(scheduling)
 
-		nextTicket = t
-		break
+	// Without --dry-run, pick and claim in one transaction
+	durationMins := GetDefaultClaimDuration()
+	var nextTicket *models.Ticket
+	var scheduled *service.ScheduledTicket
+	var result *service.ClaimResult
+	if nextDryRun {
+		scheduled, err = ticketSvc.NextScheduled(filter)
+		if scheduled != nil {
+			nextTicket = scheduled.Ticket
+		}
+	} else {
+		result, err = ticketSvc.ClaimNext(filter, ResolveWorkerID(claimWorkerID), time.Duration(durationMins)*time.Minute)
+		if result != nil {
+			nextTicket = result.Ticket
+		}
+	}
+	if err != nil {
+		return translateServiceError(err, "")
 	}
 
 	if nextTicket == nil {
@@ -144,6 +194,9 @@ func runTicketNext(cmd *cobra.Command, args []string) error {
 			data, _ := json.MarshalIndent(map[string]interface{}{
 				"ticket":  nextTicket,
 				"dry_run": true,
+				"policy":  scheduled.Policy,
+				"score":   scheduled.Score,
+				"reason":  scheduled.Reason,
 			}, "", "  ")
 			fmt.Println(string(data))
 			return nil
@@ -154,42 +207,15 @@ func runTicketNext(cmd *cobra.Command, args []string) error {
 		OutputLine("  Title:      %s", nextTicket.Title)
 		OutputLine("  Priority:   %s", nextTicket.Priority)
 		OutputLine("  Complexity: %s", nextTicket.Complexity)
+		OutputLine("  Policy:     %s", scheduled.Policy)
+		OutputLine("  Score:      %.2f (%s)", scheduled.Score, scheduled.Reason)
 		OutputLine("")
 		OutputLine("Use 'wark ticket claim %s' to claim this ticket.", nextTicket.TicketKey)
 		return nil
 	}
 
-	// Create claim using config duration
-	durationMins := GetDefaultClaimDuration()
-	duration := time.Duration(durationMins) * time.Minute
-	claim := models.NewClaim(nextTicket.ID, duration)
-	if err := claimRepo.Create(claim); err != nil {
-		return fmt.Errorf("failed to create claim: %w", err)
-	}
-
-	// Update ticket status
-	nextTicket.Status = models.StatusWorking
-	if err := ticketRepo.Update(nextTicket); err != nil {
-		// Rollback claim
-		claimRepo.Release(claim.ID, models.ClaimStatusReleased)
-		return fmt.Errorf("failed to update ticket status: %w", err)
-	}
-
-	// Log activity
-	activityRepo := db.NewActivityRepo(database.DB)
-	activityRepo.LogActionWithDetails(nextTicket.ID, models.ActionClaimed, models.ActorTypeAgent, claim.ClaimID,
-		"Claimed via 'ticket next'",
-		map[string]interface{}{
-			"claim_id":      claim.ClaimID,
-			"duration_mins": durationMins,
-			"expires_at":    claim.ExpiresAt.Format(time.RFC3339),
-		})
-
-	// Generate worktree name
-	worktreeName := nextTicket.Worktree
-	if worktreeName == "" {
-		worktreeName = generateWorktreeName(nextTicket.ProjectKey, nextTicket.Number, nextTicket.Title)
-	}
+	claim := result.Claim
+	worktreeName := result.Branch
 
 	if IsJSON() {
 		data, _ := json.MarshalIndent(map[string]interface{}{
@@ -213,6 +239,23 @@ func runTicketNext(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// schedulingOptions returns the scheduling options for policy, falling back
+// to the configured policy when it is empty.
+func schedulingOptions(policy string) (service.SchedulingOptions, error) {
+	cfg := GetConfig().Scheduling
+	if policy == "" {
+		policy = cfg.Policy
+	}
+	parsed, err := service.ParseSchedulingPolicy(policy)
+	if err != nil {
+		return service.SchedulingOptions{}, ErrInvalidArgs("%s", err)
+	}
+	return service.SchedulingOptions{
+		Policy:        parsed,
+		AgingInterval: time.Duration(cfg.AgingHours) * time.Hour,
+	}, nil
+}
+
 // ticket worktree (alias: ticket branch for backwards compatibility)
 var ticketBranchCmd = &cobra.Command{
 	Use:     "branch <TICKET>",
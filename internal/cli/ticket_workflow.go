@@ -16,7 +16,9 @@ import (
 // Workflow command flags
 var (
 	claimWorkerID   string
+	claimForce      bool
 	claimDuration   int
+	heartbeatExtend time.Duration
 	releaseReason   string
 	completeSummary string
 	autoAccept      bool
@@ -37,21 +39,33 @@ type claimResult struct {
 
 func init() {
 	// ticket claim
+	ticketClaimCmd.Flags().StringVar(&claimWorkerID, "worker-id", "", "Worker identity (defaults to $WARK_WORKER_ID or config)")
 	ticketClaimCmd.Flags().IntVar(&claimDuration, "duration", 60, "Claim duration in minutes")
 
+	// ticket heartbeat
+	ticketHeartbeatCmd.Flags().DurationVar(&heartbeatExtend, "extend", 0, "Extend the claim to this long from now (default: claim duration from config)")
+	ticketHeartbeatCmd.Flags().StringVar(&claimWorkerID, "worker-id", "", "Worker identity (defaults to $WARK_WORKER_ID or config)")
+	ticketHeartbeatCmd.Flags().BoolVar(&claimForce, "force", false, "Renew even if the claim is held by another worker")
+
 	// ticket release
 	ticketReleaseCmd.Flags().StringVar(&releaseReason, "reason", "", "Reason for release (logged)")
+	ticketReleaseCmd.Flags().StringVar(&claimWorkerID, "worker-id", "", "Worker identity (defaults to $WARK_WORKER_ID or config)")
+	ticketReleaseCmd.Flags().BoolVar(&claimForce, "force", false, "Release even if the claim is held by another worker")
 
 	// ticket complete
 	ticketCompleteCmd.Flags().StringVar(&completeSummary, "summary", "", "Summary of work done")
 	ticketCompleteCmd.Flags().BoolVar(&autoAccept, "auto-accept", false, "Skip review, go directly to done")
+	ticketCompleteCmd.Flags().StringVar(&claimWorkerID, "worker-id", "", "Worker identity (defaults to $WARK_WORKER_ID or config)")
+	ticketCompleteCmd.Flags().BoolVar(&claimForce, "force", false, "Complete even if the claim is held by another worker")
 
 	// ticket human (escalate)
 	ticketHumanCmd.Flags().StringVar(&flagReason, "reason", "", "Reason code for escalation (required)")
+	ticketHumanCmd.Flags().StringVar(&claimWorkerID, "worker-id", "", "Worker identity (defaults to $WARK_WORKER_ID or config)")
 	ticketHumanCmd.MarkFlagRequired("reason")
 
 	// Add subcommands
 	ticketCmd.AddCommand(ticketClaimCmd)
+	ticketCmd.AddCommand(ticketHeartbeatCmd)
 	ticketCmd.AddCommand(ticketReleaseCmd)
 	ticketCmd.AddCommand(ticketCompleteCmd)
 	ticketCmd.AddCommand(ticketHumanCmd)
@@ -63,6 +77,10 @@ var ticketClaimCmd = &cobra.Command{
 	Short: "Claim a ticket for work",
 	Long: `Claim a ticket to begin working on it. This acquires a time-limited claim.
 
+The claim is bound to the worker identity from --worker-id, $WARK_WORKER_ID
+or default_worker_id in config. Only that worker can release or complete it
+without --force.
+
 Examples:
   wark ticket claim WEBAPP-42
   wark ticket claim WEBAPP-42 --worker-id session-abc123 --duration 120`,
@@ -90,7 +108,7 @@ func runTicketClaim(cmd *cobra.Command, args []string) error {
 
 	// Use service layer for claim operation
 	ticketSvc := service.NewTicketService(database.DB)
-	result, err := ticketSvc.Claim(ticket.ID, duration)
+	result, err := ticketSvc.Claim(ticket.ID, ResolveWorkerID(claimWorkerID), duration)
 	if err != nil {
 		return translateServiceError(err, ticket.TicketKey)
 	}
@@ -112,6 +130,9 @@ func runTicketClaim(cmd *cobra.Command, args []string) error {
 
 	OutputLine("Claimed: %s", result.Ticket.TicketKey)
 	OutputLine("Claim ID: %s", result.Claim.ClaimID)
+	if result.Claim.WorkerID != "" {
+		OutputLine("Worker: %s", result.Claim.WorkerID)
+	}
 	OutputLine("Expires: %s (%d minutes)", result.Claim.ExpiresAt.Local().Format("2006-01-02 15:04:05"), claimDuration)
 	OutputLine("Worktree: %s", result.Branch)
 
@@ -130,6 +151,67 @@ func runTicketClaim(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// ticket heartbeat
+var ticketHeartbeatCmd = &cobra.Command{
+	Use:   "heartbeat <TICKET>",
+	Short: "Renew the claim on a ticket",
+	Long: `Renew an active claim so long-running work doesn't lose the ticket to
+claim expiry. The claim is extended to --extend from now; it is never shortened.
+
+If claim_max_lifetime is set in config, a claim cannot be renewed past that
+many minutes after it was taken.
+
+Examples:
+  wark ticket heartbeat WEBAPP-42
+  wark ticket heartbeat WEBAPP-42 --extend 30m`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTicketHeartbeat,
+}
+
+func runTicketHeartbeat(cmd *cobra.Command, args []string) error {
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return ErrDatabaseWithSuggestion(err, SuggestRunInit, "failed to open database")
+	}
+	defer database.Close()
+
+	ticket, err := resolveTicket(database, args[0], "")
+	if err != nil {
+		return err // Already wrapped with proper error type
+	}
+
+	extend := heartbeatExtend
+	if extend <= 0 {
+		extend = time.Duration(GetDefaultClaimDuration()) * time.Minute
+	}
+	maxLifetime := time.Duration(GetClaimMaxLifetime()) * time.Minute
+
+	ticketSvc := service.NewTicketService(database.DB).ActingAs(ResolveWorkerID(claimWorkerID), claimForce)
+	result, err := ticketSvc.Heartbeat(ticket.ID, extend, maxLifetime)
+	if err != nil {
+		return translateServiceError(err, ticket.TicketKey)
+	}
+
+	if IsJSON() {
+		data, _ := json.MarshalIndent(map[string]interface{}{
+			"ticket":     ticket.TicketKey,
+			"claim_id":   result.Claim.ClaimID,
+			"expires_at": result.Claim.ExpiresAt,
+			"capped":     result.Capped,
+		}, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	OutputLine("Renewed: %s", ticket.TicketKey)
+	OutputLine("Expires: %s (%s remaining)", result.Claim.ExpiresAt.Local().Format("2006-01-02 15:04:05"), formatDurationTime(result.Claim.TimeRemaining()))
+	if result.Capped {
+		OutputLine("Claim has reached its maximum lifetime and cannot be renewed further.")
+	}
+
+	return nil
+}
+
 // ticket release
 var ticketReleaseCmd = &cobra.Command{
 	Use:   "release <TICKET>",
@@ -138,7 +220,8 @@ var ticketReleaseCmd = &cobra.Command{
 
 Examples:
   wark ticket release WEBAPP-42
-  wark ticket release WEBAPP-42 --reason "Need clarification on design"`,
+  wark ticket release WEBAPP-42 --reason "Need clarification on design"
+  wark ticket release WEBAPP-42 --force   # Release another worker's claim`,
 	Args: cobra.ExactArgs(1),
 	RunE: runTicketRelease,
 }
@@ -156,7 +239,7 @@ func runTicketRelease(cmd *cobra.Command, args []string) error {
 	}
 
 	// Use service layer for release operation
-	ticketSvc := service.NewTicketService(database.DB)
+	ticketSvc := service.NewTicketService(database.DB).ActingAs(ResolveWorkerID(claimWorkerID), claimForce)
 	if err := ticketSvc.Release(ticket.ID, releaseReason); err != nil {
 		return translateServiceError(err, ticket.TicketKey)
 	}
@@ -196,7 +279,8 @@ var ticketCompleteCmd = &cobra.Command{
 Examples:
   wark ticket complete WEBAPP-42
   wark ticket complete WEBAPP-42 --summary "Implemented login page with validation"
-  wark ticket complete WEBAPP-42 --auto-accept`,
+  wark ticket complete WEBAPP-42 --auto-accept
+  wark ticket complete WEBAPP-42 --force   # Complete another worker's claim`,
 	Args: cobra.ExactArgs(1),
 	RunE: runTicketComplete,
 }
@@ -214,7 +298,7 @@ func runTicketComplete(cmd *cobra.Command, args []string) error {
 	}
 
 	// Use service layer for complete operation
-	ticketSvc := service.NewTicketService(database.DB)
+	ticketSvc := service.NewTicketService(database.DB).ActingAs(ResolveWorkerID(claimWorkerID), claimForce)
 	result, err := ticketSvc.Complete(ticket.ID, completeSummary, autoAccept)
 	if err != nil {
 		// Check for incomplete tasks error and format specially
@@ -303,10 +387,7 @@ func runTicketHuman(cmd *cobra.Command, args []string) error {
 	}
 
 	// Get worker ID if claimed (for logging)
-	workerID := claimWorkerID
-	if workerID == "" {
-		workerID = GetDefaultWorkerID()
-	}
+	workerID := ResolveWorkerID(claimWorkerID)
 
 	// Use service layer for flag operation
 	ticketSvc := service.NewTicketService(database.DB)
@@ -355,6 +436,8 @@ func translateServiceError(err error, ticketKey string) error {
 			"%s", svcErr.Message)
 	case service.ErrCodeAlreadyClaimed:
 		return ErrConcurrentConflictWithSuggestion(SuggestReleaseClaim, "%s", svcErr.Message)
+	case service.ErrCodeClaimNotOwned:
+		return ErrConcurrentConflictWithSuggestion(SuggestForceClaim, "%s", svcErr.Message)
 	case service.ErrCodeUnresolvedDeps:
 		return ErrStateErrorWithSuggestion(
 			fmt.Sprintf("Run 'wark ticket show %s' to see blocking dependencies.", ticketKey),
@@ -367,6 +450,14 @@ func translateServiceError(err error, ticketKey string) error {
 		return ErrInvalidArgs("%s", svcErr.Message)
 	case service.ErrCodeInvalidResolution:
 		return ErrInvalidArgs("%s", svcErr.Message)
+	case service.ErrCodeInvalidInput:
+		return ErrInvalidArgs("%s", svcErr.Message)
+	case service.ErrCodeWIPLimit:
+		suggestion := "Finish or release in-progress work, or raise the limit with 'wark project edit'."
+		if project, ok := svcErr.Details["project"].(string); ok {
+			suggestion = fmt.Sprintf("Run 'wark project show %s' to see WIP usage; finish in-progress work or raise the limit with 'wark project edit %s'.", project, project)
+		}
+		return ErrWIPLimitWithSuggestion(suggestion, "%s", svcErr.Message)
 	case service.ErrCodeDatabase:
 		return ErrDatabase(err, "%s", svcErr.Message)
 	default:
@@ -0,0 +1,263 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spetersoncode/wark/internal/db"
+	werrors "github.com/spetersoncode/wark/internal/errors"
+	"github.com/spetersoncode/wark/internal/service"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Export/import command flags
+var (
+	exportProjects   []string
+	exportFormat     string
+	exportOutput     string
+	exportNoActivity bool
+	importDryRun     bool
+	importProject    string
+)
+
+func init() {
+	exportCmd.Flags().StringSliceVarP(&exportProjects, "project", "p", nil, "Project to export (repeatable; default all)")
+	exportCmd.Flags().StringVar(&exportFormat, "format", "", "Output format: json or yaml (default from --output extension, else json)")
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Write to a file instead of stdout")
+	exportCmd.Flags().BoolVar(&exportNoActivity, "no-activity", false, "Leave out ticket history")
+
+	importCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "Validate and show what would be imported without making changes")
+	importCmd.Flags().StringVarP(&importProject, "project", "p", "", "Import a single-project document under this project key")
+
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(importCmd)
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export projects and tickets",
+	Long: `Export projects with their milestones, tickets, dependencies, tasks,
+labels, roles and activity as a versioned JSON or YAML document.
+
+Tickets refer to parents and dependencies by key, so the document can be
+imported into another database with 'wark import'.
+
+Examples:
+  wark export --project WEBAPP > webapp.json
+  wark export --project WEBAPP -o webapp.yaml
+  wark export --format yaml --no-activity`,
+	Args: cobra.NoArgs,
+	RunE: runExport,
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	format, err := transferFormat(exportFormat, exportOutput)
+	if err != nil {
+		return err
+	}
+
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return ErrDatabaseWithSuggestion(err, SuggestRunInit, "failed to open database")
+	}
+	defer database.Close()
+
+	doc, err := service.NewTransferService(database.DB).Export(service.ExportOptions{
+		Projects:   exportProjects,
+		NoActivity: exportNoActivity,
+	})
+	if err != nil {
+		return translateTransferError(err)
+	}
+
+	data, err := encodeDocument(doc, format)
+	if err != nil {
+		return ErrGeneralWithCause(err, "failed to encode export")
+	}
+
+	if exportOutput == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(exportOutput, data, 0644); err != nil {
+		return ErrGeneralWithCause(err, "failed to write %s", exportOutput)
+	}
+
+	tickets := 0
+	for _, p := range doc.Projects {
+		tickets += len(p.Tickets)
+	}
+	ErrorOutput("Exported %d project(s), %d ticket(s) to %s\n", len(doc.Projects), tickets, exportOutput)
+	return nil
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import <FILE>",
+	Short: "Import projects and tickets",
+	Long: `Import a document written by 'wark export'. Use - to read from stdin.
+
+Projects, milestones and roles that already exist are reused. Tickets keep
+their numbers unless the target project already has tickets, in which case
+they are numbered after the existing ones; the output maps old keys to new.
+Dependencies are checked for cycles, and the whole import runs in one
+transaction, so nothing is imported if any ticket fails.
+
+Examples:
+  wark import webapp.json --dry-run
+  wark import webapp.yaml
+  wark import webapp.json --project NEWAPP`,
+	Args: cobra.ExactArgs(1),
+	RunE: runImport,
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	data, err := readInputFile(args[0])
+	if err != nil {
+		return ErrInvalidArgs("failed to read %s: %v", args[0], err)
+	}
+
+	var doc service.ExportDocument
+	if err := decodeFile(data, args[0], &doc); err != nil {
+		return ErrInvalidArgs("failed to parse %s: %v", args[0], err)
+	}
+
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return ErrDatabaseWithSuggestion(err, SuggestRunInit, "failed to open database")
+	}
+	defer database.Close()
+
+	result, err := service.NewTransferService(database.DB).Import(&doc, service.ImportOptions{
+		DryRun:     importDryRun,
+		ProjectKey: importProject,
+	})
+	if err != nil {
+		return translateTransferError(err)
+	}
+
+	if IsJSON() {
+		data, _ := json.MarshalIndent(result, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	prefix := ""
+	if result.DryRun {
+		prefix = "[DRY RUN] "
+	}
+	OutputLine("%sImported %d ticket(s), %d dependencies, %d task(s), %d activity entries",
+		prefix, result.Tickets, result.Dependencies, result.Tasks, result.Activity)
+	for _, p := range result.Projects {
+		note := "existing project"
+		if p.Created {
+			note = "new project"
+		}
+		if p.Renumbered {
+			note += ", renumbered"
+		}
+		OutputLine("  %s: %d ticket(s) (%s)", p.Key, p.Tickets, note)
+	}
+	if len(result.RolesCreated) > 0 {
+		OutputLine("  Roles created: %s", strings.Join(result.RolesCreated, ", "))
+	}
+
+	oldKeys := make([]string, 0, len(result.KeyMap))
+	for old, newKey := range result.KeyMap {
+		if old != newKey {
+			oldKeys = append(oldKeys, old)
+		}
+	}
+	if len(oldKeys) > 0 {
+		sort.Strings(oldKeys)
+		OutputLine("")
+		OutputLine("Renumbered tickets:")
+		for _, old := range oldKeys {
+			OutputLine("  %s -> %s", old, result.KeyMap[old])
+		}
+	}
+	return nil
+}
+
+// transferFormat picks the document format from --format or the file
+// extension.
+func transferFormat(format, path string) (string, error) {
+	switch strings.ToLower(format) {
+	case "json", "yaml":
+		return strings.ToLower(format), nil
+	case "yml":
+		return "yaml", nil
+	case "":
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".yaml", ".yml":
+			return "yaml", nil
+		}
+		return "json", nil
+	}
+	return "", ErrInvalidArgs("invalid format: %s (must be json or yaml)", format)
+}
+
+func encodeDocument(doc *service.ExportDocument, format string) ([]byte, error) {
+	if format == "yaml" {
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(doc); err != nil {
+			return nil, err
+		}
+		if err := enc.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// decodeFile parses a JSON or YAML file into v, going by the file extension
+// or, for stdin and other names, by whether it starts with '{'.
+func decodeFile(data []byte, path string, v interface{}) error {
+	ext := strings.ToLower(filepath.Ext(path))
+	isYAML := ext == ".yaml" || ext == ".yml" ||
+		(ext != ".json" && !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")))
+	if isYAML {
+		return yaml.Unmarshal(data, v)
+	}
+	return json.Unmarshal(data, v)
+}
+
+// readInputFile reads a file, or stdin when path is "-".
+func readInputFile(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(path)
+}
+
+// translateTransferError converts export/import errors to CLI errors.
+func translateTransferError(err error) error {
+	te, ok := err.(*service.TransferError)
+	if !ok {
+		return ErrDatabase(err, "operation failed")
+	}
+
+	sharedErr := &werrors.Error{Kind: te.Kind(), Message: te.Error()}
+	switch te.Code {
+	case service.ErrCodeProjectNotFound:
+		sharedErr.Suggestion = "Run 'wark project list' to see available projects."
+	case service.ErrCodeInvalidDocument:
+		if len(te.Problems) > 0 {
+			sharedErr.Message = te.Message + ":\n  - " + strings.Join(te.Problems, "\n  - ")
+		}
+	}
+	return sharedErr
+}
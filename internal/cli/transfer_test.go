@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spetersoncode/wark/internal/db"
+	"github.com/spetersoncode/wark/internal/models"
+	"github.com/spetersoncode/wark/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportImport(t *testing.T) {
+	source, sourcePath, cleanupSource := testDBWithPath(t)
+	defer cleanupSource()
+
+	project := &models.Project{Key: "MOVE", Name: "Moving"}
+	require.NoError(t, db.NewProjectRepo(source.DB).Create(project))
+	_, err := runCmd(t, sourcePath, "ticket", "create", "MOVE", "--title", "Pack boxes")
+	require.NoError(t, err)
+	_, err = runCmd(t, sourcePath, "ticket", "create", "MOVE", "--title", "Load truck", "--depends-on", "MOVE-1")
+	require.NoError(t, err)
+
+	file := filepath.Join(t.TempDir(), "move.yaml")
+	_, err = runCmd(t, sourcePath, "export", "--project", "MOVE", "-o", file)
+	require.NoError(t, err)
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "version: 1")
+	assert.Contains(t, string(data), "MOVE-1")
+
+	target, targetPath, cleanupTarget := testDBWithPath(t)
+	defer cleanupTarget()
+
+	var result service.ImportResult
+	require.NoError(t, runCmdJSON(t, targetPath, &result, "import", file, "--dry-run"))
+	assert.True(t, result.DryRun)
+	assert.Equal(t, 2, result.Tickets)
+	assert.Equal(t, 1, result.Dependencies)
+	p, err := db.NewProjectRepo(target.DB).GetByKey("MOVE")
+	require.NoError(t, err)
+	assert.Nil(t, p, "dry run should not create the project")
+
+	output, err := runCmd(t, targetPath, "import", file)
+	require.NoError(t, err)
+	assert.Contains(t, output, "Imported 2 ticket(s)")
+
+	// A second import lands after the existing tickets
+	output, err = runCmd(t, targetPath, "import", file)
+	require.NoError(t, err)
+	assert.Contains(t, output, "MOVE-2 -> MOVE-4")
+
+	bad := filepath.Join(t.TempDir(), "bad.json")
+	require.NoError(t, os.WriteFile(bad, []byte(`{"version": 1, "projects": [{"key": "BAD", "name": "Bad", "tickets": [{"key": "BAD-1", "status": "ready"}]}]}`), 0644))
+	_, err = runCmd(t, targetPath, "import", bad)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "title is required")
+}
@@ -0,0 +1,369 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spetersoncode/wark/internal/db"
+	"github.com/spetersoncode/wark/internal/models"
+	"github.com/spetersoncode/wark/internal/tasks"
+	"github.com/spf13/cobra"
+)
+
+// Webhook command flags
+var (
+	webhookName     string
+	webhookStatus   string
+	webhookLimit    int
+	webhookDaemon   bool
+	webhookInterval int
+)
+
+func init() {
+	// webhook deliveries
+	webhookDeliveriesCmd.Flags().StringVar(&webhookName, "webhook", "", "Show only deliveries for this webhook")
+	webhookDeliveriesCmd.Flags().StringVar(&webhookStatus, "status", "", "Filter by status (pending, delivered, failed)")
+	webhookDeliveriesCmd.Flags().IntVarP(&webhookLimit, "limit", "n", 20, "Maximum number of deliveries to show")
+
+	// webhook run
+	webhookRunCmd.Flags().BoolVar(&webhookDaemon, "daemon", false, "Run continuously, dispatching every N seconds")
+	webhookRunCmd.Flags().IntVar(&webhookInterval, "interval", 10, "Dispatch interval in seconds (for --daemon mode)")
+
+	webhookCmd.AddCommand(webhookListCmd)
+	webhookCmd.AddCommand(webhookTestCmd)
+	webhookCmd.AddCommand(webhookDeliveriesCmd)
+	webhookCmd.AddCommand(webhookRunCmd)
+
+	rootCmd.AddCommand(webhookCmd)
+}
+
+var webhookCmd = &cobra.Command{
+	Use:   "webhook",
+	Short: "Webhook commands",
+	Long: `Manage outbound webhooks.
+
+Webhooks are configured in the [[webhooks]] section of the config file. Each
+matching event is queued in the database and POSTed as JSON, signed with
+HMAC-SHA256 in the X-Wark-Signature header when a secret is set. Failed
+deliveries are retried with exponential backoff.
+
+Deliveries are sent while 'wark serve' or 'wark webhook run --daemon' is
+running.`,
+}
+
+// newDispatcher creates a webhook dispatcher from the current config.
+func newDispatcher(database *db.DB) (*tasks.WebhookDispatcher, error) {
+	dispatcher, err := tasks.NewWebhookDispatcher(database.DB, GetConfig().Webhooks)
+	if err != nil {
+		return nil, ErrInvalidArgsWithSuggestion("Check the [[webhooks]] section of your config file.", "invalid webhook config: %s", err)
+	}
+	return dispatcher, nil
+}
+
+// webhook list
+var webhookListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List configured webhooks",
+	Long: `List configured webhooks with their delivery counts.
+
+Examples:
+  wark webhook list`,
+	Args: cobra.NoArgs,
+	RunE: runWebhookList,
+}
+
+// WebhookSummary is a configured webhook with its delivery counts.
+type WebhookSummary struct {
+	Name      string   `json:"name"`
+	URL       string   `json:"url"`
+	Signed    bool     `json:"signed"`
+	Events    []string `json:"events,omitempty"`
+	Statuses  []string `json:"statuses,omitempty"`
+	Pending   int      `json:"pending"`
+	Delivered int      `json:"delivered"`
+	Failed    int      `json:"failed"`
+}
+
+func runWebhookList(cmd *cobra.Command, args []string) error {
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return ErrDatabaseWithSuggestion(err, SuggestRunInit, "failed to open database")
+	}
+	defer database.Close()
+
+	webhookRepo := db.NewWebhookRepo(database.DB)
+	hooks := GetConfig().Webhooks
+	summaries := make([]WebhookSummary, 0, len(hooks))
+	for _, h := range hooks {
+		counts, err := webhookRepo.CountByStatus(h.Name)
+		if err != nil {
+			return ErrDatabase(err, "failed to count deliveries")
+		}
+		summaries = append(summaries, WebhookSummary{
+			Name:      h.Name,
+			URL:       h.URL,
+			Signed:    h.Secret != "",
+			Events:    h.Events,
+			Statuses:  h.Statuses,
+			Pending:   counts[models.DeliveryStatusPending],
+			Delivered: counts[models.DeliveryStatusDelivered],
+			Failed:    counts[models.DeliveryStatusFailed],
+		})
+	}
+
+	if IsJSON() {
+		data, _ := json.MarshalIndent(summaries, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(summaries) == 0 {
+		OutputLine("No webhooks configured. Add a [[webhooks]] section to your config file.")
+		return nil
+	}
+
+	fmt.Printf("%-16s %-40s %-24s %-8s %-10s %-6s\n", "NAME", "URL", "EVENTS", "PENDING", "DELIVERED", "FAILED")
+	fmt.Println(strings.Repeat("-", 109))
+	for _, s := range summaries {
+		events := "all"
+		if len(s.Events) > 0 {
+			events = strings.Join(s.Events, ",")
+		}
+		fmt.Printf("%-16s %-40s %-24s %-8d %-10d %-6d\n",
+			truncate(s.Name, 16),
+			truncate(s.URL, 40),
+			truncate(events, 24),
+			s.Pending, s.Delivered, s.Failed,
+		)
+	}
+
+	return nil
+}
+
+// webhook test
+var webhookTestCmd = &cobra.Command{
+	Use:   "test <NAME>",
+	Short: "Send a test event to a webhook",
+	Long: `Send a signed webhook.test event to a webhook and report the response.
+The test event is sent immediately and is not queued.
+
+Examples:
+  wark webhook test slack`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWebhookTest,
+}
+
+func runWebhookTest(cmd *cobra.Command, args []string) error {
+	if GetConfig().Webhook(args[0]) == nil {
+		return ErrNotFoundWithSuggestion("Run 'wark webhook list' to see configured webhooks.", "webhook %s is not configured", args[0])
+	}
+
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return ErrDatabaseWithSuggestion(err, SuggestRunInit, "failed to open database")
+	}
+	defer database.Close()
+
+	dispatcher, err := newDispatcher(database)
+	if err != nil {
+		return err
+	}
+
+	result, err := dispatcher.Test(context.Background(), args[0])
+	if err != nil {
+		return err
+	}
+
+	if IsJSON() {
+		data, _ := json.MarshalIndent(result, "", "  ")
+		fmt.Println(string(data))
+	} else if result.Error == "" {
+		OutputLine("Delivered test event to %s (HTTP %d, %dms)", result.Webhook, result.StatusCode, result.DurationMs)
+	}
+
+	if result.Error != "" {
+		return fmt.Errorf("test delivery to %s failed: %s", result.Webhook, result.Error)
+	}
+	return nil
+}
+
+// webhook deliveries
+var webhookDeliveriesCmd = &cobra.Command{
+	Use:   "deliveries",
+	Short: "List webhook deliveries",
+	Long: `List queued and past webhook deliveries, newest first.
+
+Examples:
+  wark webhook deliveries
+  wark webhook deliveries --webhook slack
+  wark webhook deliveries --status failed`,
+	Args: cobra.NoArgs,
+	RunE: runWebhookDeliveries,
+}
+
+func runWebhookDeliveries(cmd *cobra.Command, args []string) error {
+	if webhookStatus != "" {
+		if err := models.ValidateDeliveryStatus(webhookStatus); err != nil {
+			return ErrInvalidArgs("%s", err)
+		}
+	}
+
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return ErrDatabaseWithSuggestion(err, SuggestRunInit, "failed to open database")
+	}
+	defer database.Close()
+
+	deliveries, err := db.NewWebhookRepo(database.DB).List(db.DeliveryFilter{
+		Webhook: webhookName,
+		Status:  webhookStatus,
+		Limit:   webhookLimit,
+	})
+	if err != nil {
+		return ErrDatabase(err, "failed to list deliveries")
+	}
+
+	if IsJSON() {
+		data, _ := json.MarshalIndent(deliveries, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(deliveries) == 0 {
+		OutputLine("No deliveries found.")
+		return nil
+	}
+
+	fmt.Printf("%-6s %-16s %-22s %-12s %-10s %-8s %s\n", "ID", "WEBHOOK", "EVENT", "TICKET", "STATUS", "ATTEMPTS", "LAST RESULT")
+	fmt.Println(strings.Repeat("-", 100))
+	for _, d := range deliveries {
+		ticket := d.TicketKey
+		if ticket == "" {
+			ticket = "-"
+		}
+		last := "-"
+		if d.LastError != "" {
+			last = d.LastError
+		} else if d.ResponseCode > 0 {
+			last = fmt.Sprintf("HTTP %d", d.ResponseCode)
+		}
+		if d.Status == models.DeliveryStatusPending && d.Attempts > 0 {
+			last += fmt.Sprintf(" (retry at %s)", d.NextAttemptAt.Local().Format("15:04:05"))
+		}
+		fmt.Printf("%-6d %-16s %-22s %-12s %-10s %-8d %s\n",
+			d.ID,
+			truncate(d.Webhook, 16),
+			truncate(string(d.EventType), 22),
+			ticket,
+			d.Status,
+			d.Attempts,
+			truncate(last, 60),
+		)
+	}
+
+	return nil
+}
+
+// webhook run
+var webhookRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Dispatch webhook deliveries",
+	Long: `Queue new events for configured webhooks and send deliveries that are due.
+
+Without --daemon, this runs once and exits, which suits cron. 'wark serve'
+dispatches webhooks itself, so a separate daemon is only needed when the
+server isn't running.
+
+Examples:
+  wark webhook run                         # Dispatch once
+  wark webhook run --daemon                # Run continuously
+  wark webhook run --daemon --interval 30`,
+	Args: cobra.NoArgs,
+	RunE: runWebhookRun,
+}
+
+func runWebhookRun(cmd *cobra.Command, args []string) error {
+	if webhookInterval <= 0 {
+		return ErrInvalidArgs("--interval must be positive")
+	}
+
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return ErrDatabaseWithSuggestion(err, SuggestRunInit, "failed to open database")
+	}
+	defer database.Close()
+
+	dispatcher, err := newDispatcher(database)
+	if err != nil {
+		return err
+	}
+
+	if webhookDaemon {
+		return runWebhookDaemon(dispatcher, time.Duration(webhookInterval)*time.Second)
+	}
+
+	result, err := dispatcher.RunOnce(context.Background())
+	if err != nil {
+		return ErrDatabase(err, "failed to dispatch webhooks")
+	}
+
+	if IsJSON() {
+		data, _ := json.MarshalIndent(result, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	OutputLine("Queued %d, delivered %d, retrying %d, failed %d",
+		result.Queued, result.Delivered, result.Retrying, result.Failed)
+	return nil
+}
+
+func runWebhookDaemon(dispatcher *tasks.WebhookDispatcher, interval time.Duration) error {
+	OutputLine("Starting webhook dispatcher (checking every %s)", interval)
+	OutputLine("Press Ctrl+C to stop...")
+	OutputLine("")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Handle shutdown signals
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+
+	go func() {
+		<-sigChan
+		OutputLine("")
+		OutputLine("Shutting down daemon...")
+		cancel()
+	}()
+
+	err := dispatcher.RunDaemon(ctx, interval, logDispatchResult)
+	if err == context.Canceled {
+		OutputLine("Daemon stopped.")
+		return nil
+	}
+	return err
+}
+
+// logDispatchResult prints a line for each dispatcher run that did something.
+func logDispatchResult(result *tasks.DispatchResult, err error) {
+	now := time.Now().Format("15:04:05")
+	if err != nil {
+		if err != context.Canceled {
+			OutputLine("[%s] Webhook dispatch failed: %s", now, err)
+		}
+		return
+	}
+	if result.Delivered+result.Retrying+result.Failed > 0 {
+		OutputLine("[%s] Webhooks: delivered %d, retrying %d, failed %d",
+			now, result.Delivered, result.Retrying, result.Failed)
+	} else {
+		VerboseOutput("[%s] No webhook deliveries due\n", now)
+	}
+}
@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/spetersoncode/wark/internal/config"
+	"github.com/spetersoncode/wark/internal/db"
+	"github.com/spetersoncode/wark/internal/models"
+	"github.com/spetersoncode/wark/internal/tasks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookCommands(t *testing.T) {
+	database, dbPath, cleanup := testDBWithPath(t)
+	defer cleanup()
+
+	var received atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	origConfig := globalConfig
+	defer func() { globalConfig = origConfig }()
+	globalConfig = config.DefaultConfig()
+	globalConfig.Webhooks = []config.WebhookConfig{
+		{Name: "ci", URL: srv.URL, Secret: "key", Events: []string{"comment.created"}},
+	}
+
+	projectRepo := db.NewProjectRepo(database.DB)
+	project := &models.Project{Key: "WH", Name: "Webhooks"}
+	require.NoError(t, projectRepo.Create(project))
+
+	var summaries []WebhookSummary
+	require.NoError(t, runCmdJSON(t, dbPath, &summaries, "webhook", "list"))
+	require.Len(t, summaries, 1)
+	assert.Equal(t, "ci", summaries[0].Name)
+	assert.True(t, summaries[0].Signed)
+
+	var testResult tasks.WebhookTestResult
+	require.NoError(t, runCmdJSON(t, dbPath, &testResult, "webhook", "test", "ci"))
+	assert.Equal(t, http.StatusOK, testResult.StatusCode)
+	assert.Equal(t, int32(1), received.Load())
+
+	_, err := runCmd(t, dbPath, "webhook", "test", "missing")
+	assert.Error(t, err)
+
+	// The first run starts from current activity
+	var result tasks.DispatchResult
+	require.NoError(t, runCmdJSON(t, dbPath, &result, "webhook", "run"))
+	assert.Equal(t, 0, result.Queued)
+
+	_, err = runCmd(t, dbPath, "ticket", "create", "WH", "--title", "Hooked")
+	require.NoError(t, err)
+	_, err = runCmd(t, dbPath, "ticket", "comment", "WH-1", "-m", "Looks good")
+	require.NoError(t, err)
+
+	require.NoError(t, runCmdJSON(t, dbPath, &result, "webhook", "run"))
+	assert.Equal(t, 1, result.Queued)
+	assert.Equal(t, 1, result.Delivered)
+	assert.Equal(t, int32(2), received.Load())
+
+	var deliveries []*models.WebhookDelivery
+	require.NoError(t, runCmdJSON(t, dbPath, &deliveries, "webhook", "deliveries", "--status", "delivered"))
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, "WH-1", deliveries[0].TicketKey)
+	assert.Equal(t, models.EventComment, deliveries[0].EventType)
+
+	_, err = runCmd(t, dbPath, "webhook", "deliveries", "--status", "bogus")
+	assert.Error(t, err)
+}
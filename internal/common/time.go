@@ -28,3 +28,25 @@ func FormatDuration(d time.Duration) string {
 	days := int(d.Hours() / 24)
 	return fmt.Sprintf("%dd ago", days)
 }
+
+// ParseDate parses a date as YYYY-MM-DD, meaning midnight local time, or as an
+// RFC 3339 timestamp.
+func ParseDate(s string) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date: %s (use YYYY-MM-DD or RFC 3339)", s)
+	}
+	return t, nil
+}
+
+// ParseDueDate parses a due date like ParseDate, except that YYYY-MM-DD means
+// the last second of that day, so a ticket isn't overdue on the day it's due.
+func ParseDueDate(s string) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t.AddDate(0, 0, 1).Add(-time.Second), nil
+	}
+	return ParseDate(s)
+}
@@ -59,3 +59,29 @@ func TestFormatDuration(t *testing.T) {
 		})
 	}
 }
+
+func TestParseDate(t *testing.T) {
+	got, err := ParseDate("2026-11-01")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 11, 1, 0, 0, 0, 0, time.Local), got)
+
+	got, err = ParseDate("2026-11-01T09:30:00Z")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 11, 1, 9, 30, 0, 0, time.UTC), got.UTC())
+
+	_, err = ParseDate("next week")
+	assert.ErrorContains(t, err, "invalid date: next week")
+}
+
+func TestParseDueDate(t *testing.T) {
+	got, err := ParseDueDate("2026-11-01")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 11, 1, 23, 59, 59, 0, time.Local), got)
+
+	got, err = ParseDueDate("2026-11-01T09:30:00Z")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 11, 1, 9, 30, 0, 0, time.UTC), got.UTC())
+
+	_, err = ParseDueDate("next week")
+	assert.ErrorContains(t, err, "invalid date: next week")
+}
@@ -51,6 +51,87 @@ type ModelsConfig struct {
 	Powerful string `toml:"powerful"`
 }
 
+// SchedulingConfig holds the scheduling policy used by `ticket next`.
+type SchedulingConfig struct {
+	// Policy orders workable tickets: "priority", "aging", "impact",
+	// "smallest" or "round-robin".
+	// Default: "priority"
+	Policy string `toml:"policy"`
+
+	// AgingHours is how long a ticket waits under the aging policy before
+	// it is treated as one priority level higher.
+	// Default: 24
+	AgingHours int `toml:"aging_hours"`
+}
+
+// WebhookConfig configures an outgoing webhook, declared as a [[webhooks]] table.
+type WebhookConfig struct {
+	// Name identifies the webhook in `wark webhook` commands and deliveries.
+	Name string `toml:"name"`
+
+	// URL receives a POST with a JSON payload for each matching event.
+	URL string `toml:"url"`
+
+	// Secret signs payloads with HMAC-SHA256 in the X-Wark-Signature header.
+	// Payloads are unsigned if empty.
+	Secret string `toml:"secret"`
+
+	// Events limits the webhook to these event types (e.g., "inbox.message",
+	// "claim.expired", "ticket.status_changed"). Empty means all events.
+	Events []string `toml:"events"`
+
+	// Statuses limits ticket.status_changed events to changes into these
+	// statuses (e.g., "review", "closed"). Empty means any status.
+	Statuses []string `toml:"statuses"`
+
+	// MaxAttempts is how many times a delivery is tried before it is marked failed.
+	// Default: 8
+	MaxAttempts int `toml:"max_attempts"`
+}
+
+// RecurringConfig configures a ticket created on a schedule, declared as a
+// [[recurring]] table.
+type RecurringConfig struct {
+	// Name identifies the template in `wark schedule` commands.
+	Name string `toml:"name"`
+
+	// Schedule is a five-field cron expression (e.g., "0 9 * * MON") or
+	// @hourly, @daily, @weekly, @monthly or @yearly, in local time.
+	Schedule string `toml:"schedule"`
+
+	// Project is the key of the project tickets are created in.
+	Project string `toml:"project"`
+
+	// Title, Description, Role, Priority and Complexity are given to each
+	// ticket created. Priority and complexity default to medium.
+	Title       string `toml:"title"`
+	Description string `toml:"description"`
+	Role        string `toml:"role"`
+	Priority    string `toml:"priority"`
+	Complexity  string `toml:"complexity"`
+
+	// Ready moves created tickets straight to ready instead of backlog.
+	// Default: false
+	Ready bool `toml:"ready"`
+}
+
+// InboxSLAConfig sets how soon pending inbox messages should be answered,
+// declared as an [[inbox_sla]] table. A message uses the most specific
+// table that matches it: type and priority, then type, then priority, then
+// neither.
+type InboxSLAConfig struct {
+	// Type limits the SLA to one message type (e.g., "question"). Empty
+	// means any type.
+	Type string `toml:"type"`
+
+	// Priority limits the SLA to messages on tickets of one priority
+	// (e.g., "highest"). Empty means any priority.
+	Priority string `toml:"priority"`
+
+	// Minutes is how long after it is sent a message becomes overdue.
+	Minutes int `toml:"minutes"`
+}
+
 // Config represents the wark configuration.
 type Config struct {
 	// DB is the path to the database file.
@@ -74,11 +155,28 @@ type Config struct {
 	// Default: 60
 	ClaimDuration int `toml:"claim_duration"`
 
+	// ClaimMaxLifetime caps how long a claim can be kept alive by heartbeats,
+	// in minutes from when it was claimed. 0 means no cap.
+	// Default: 0
+	ClaimMaxLifetime int `toml:"claim_max_lifetime"`
+
 	// Backup contains backup-related settings.
 	Backup BackupConfig `toml:"backup"`
 
 	// Models contains model configuration for different capability levels.
 	Models ModelsConfig `toml:"models"`
+
+	// Scheduling contains the policy used to pick the next ticket.
+	Scheduling SchedulingConfig `toml:"scheduling"`
+
+	// Webhooks lists outgoing webhooks.
+	Webhooks []WebhookConfig `toml:"webhooks"`
+
+	// Recurring lists tickets created on a schedule by `wark schedule run`.
+	Recurring []RecurringConfig `toml:"recurring"`
+
+	// InboxSLA lists response SLAs for inbox messages.
+	InboxSLA []InboxSLAConfig `toml:"inbox_sla"`
 }
 
 // DefaultConfig returns a Config with default values.
@@ -98,6 +196,10 @@ func DefaultConfig() *Config {
 			Standard: "sonnet",
 			Powerful: "opus",
 		},
+		Scheduling: SchedulingConfig{
+			Policy:     "priority",
+			AgingHours: 24,
+		},
 	}
 }
 
@@ -169,6 +271,12 @@ func (c *Config) applyEnv() {
 		}
 	}
 
+	if lifetime := os.Getenv("WARK_CLAIM_MAX_LIFETIME"); lifetime != "" {
+		if l, err := strconv.Atoi(lifetime); err == nil && l >= 0 {
+			c.ClaimMaxLifetime = l
+		}
+	}
+
 	// Backup settings
 	if _, ok := os.LookupEnv("WARK_BACKUP_DISABLED"); ok {
 		c.Backup.Enabled = false
@@ -189,6 +297,27 @@ func (c *Config) applyEnv() {
 	if backupPath := os.Getenv("WARK_BACKUP_PATH"); backupPath != "" {
 		c.Backup.Path = backupPath
 	}
+
+	// Scheduling settings
+	if policy := os.Getenv("WARK_SCHEDULING_POLICY"); policy != "" {
+		c.Scheduling.Policy = policy
+	}
+
+	if aging := os.Getenv("WARK_SCHEDULING_AGING_HOURS"); aging != "" {
+		if a, err := strconv.Atoi(aging); err == nil && a > 0 {
+			c.Scheduling.AgingHours = a
+		}
+	}
+}
+
+// Webhook returns the webhook with the given name, or nil if there is none.
+func (c *Config) Webhook(name string) *WebhookConfig {
+	for i := range c.Webhooks {
+		if c.Webhooks[i].Name == name {
+			return &c.Webhooks[i]
+		}
+	}
+	return nil
 }
 
 // GetDB returns the database path, using the default if not set.
@@ -236,6 +365,12 @@ func SampleConfig() string {
 # Environment: WARK_CLAIM_DURATION
 # claim_duration = 60
 
+# Maximum claim lifetime in minutes, counted from when the ticket was claimed
+# Heartbeats cannot extend a claim past this. 0 = no limit
+# Default: 0
+# Environment: WARK_CLAIM_MAX_LIFETIME
+# claim_max_lifetime = 480
+
 # =============================================================================
 # Backup Settings
 # =============================================================================
@@ -259,6 +394,79 @@ func SampleConfig() string {
 # Directory for backup files (default: same directory as database)
 # Environment: WARK_BACKUP_PATH
 # path = "/path/to/backups"
+
+# =============================================================================
+# Scheduling Settings
+# =============================================================================
+
+[scheduling]
+# Policy 'wark ticket next' uses to pick a ticket (--policy overrides it):
+#   priority     highest priority first, then oldest
+#   aging        priority, raised one level per aging_hours waited
+#   impact       tickets blocking the most open work first
+#   smallest     lowest complexity first
+#   round-robin  the project claimed from least recently first
+# Default: "priority"
+# Environment: WARK_SCHEDULING_POLICY
+# policy = "priority"
+
+# Hours a ticket waits under the aging policy to gain one priority level
+# Default: 24
+# Environment: WARK_SCHEDULING_AGING_HOURS
+# aging_hours = 24
+
+# =============================================================================
+# Webhooks
+# =============================================================================
+# Each [[webhooks]] table POSTs matching events as JSON. Deliveries are queued
+# in the database and retried with backoff; they are sent while 'wark serve'
+# or 'wark webhook run --daemon' is running.
+#
+# Event types: ticket.status_changed, claim.created, claim.expired,
+# inbox.message (escalations), inbox.response, inbox.overdue, comment.created
+
+# [[webhooks]]
+# name = "reviews"
+# url = "https://example.com/hooks/wark"
+# secret = "change-me"              # HMAC-SHA256 signature in X-Wark-Signature
+# events = ["inbox.message", "claim.expired", "ticket.status_changed"]
+# statuses = ["review", "closed"]   # Only status changes into these statuses
+# max_attempts = 8
+
+# =============================================================================
+# Recurring Tickets
+# =============================================================================
+# Each [[recurring]] table creates a ticket on a cron schedule (local time)
+# when 'wark schedule run' runs, from cron or as 'wark schedule run --daemon'.
+# A schedule missed for several intervals creates one ticket, not one each.
+
+# [[recurring]]
+# name = "dependency-audit"
+# schedule = "0 9 * * MON"          # minute hour day month weekday, or @weekly
+# project = "WEBAPP"
+# title = "Audit dependencies"
+# description = "Run the audit and update anything with a known vulnerability."
+# role = "software-engineer"
+# priority = "medium"
+# complexity = "small"
+# ready = true                      # Create in ready instead of backlog
+
+# =============================================================================
+# Inbox SLAs
+# =============================================================================
+# Each [[inbox_sla]] table sets how many minutes a pending inbox message may
+# wait for a response. A message uses the most specific matching table (type
+# and priority, then type, then priority). Overdue messages are marked in
+# 'wark inbox list' and 'wark status', and escalated once, with an
+# inbox.overdue event, by 'wark serve' or 'wark inbox escalate --daemon'.
+
+# [[inbox_sla]]
+# type = "question"
+# minutes = 240
+
+# [[inbox_sla]]
+# priority = "highest"              # Any message on a highest-priority ticket
+# minutes = 60
 `
 }
 
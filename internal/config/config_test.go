@@ -118,6 +118,7 @@ claim_duration = 30
 	t.Setenv("WARK_DEFAULT_PROJECT", "ENVPROJ")
 	t.Setenv("WARK_DEFAULT_WORKER_ID", "env-worker")
 	t.Setenv("WARK_CLAIM_DURATION", "90")
+	t.Setenv("WARK_CLAIM_MAX_LIFETIME", "240")
 
 	cfg, err := LoadFromPath(configPath)
 	require.NoError(t, err)
@@ -128,6 +129,7 @@ claim_duration = 30
 	assert.Equal(t, "ENVPROJ", cfg.DefaultProject)
 	assert.Equal(t, "env-worker", cfg.DefaultWorkerID)
 	assert.Equal(t, 90, cfg.ClaimDuration)
+	assert.Equal(t, 240, cfg.ClaimMaxLifetime)
 }
 
 func TestEnvOverrides_PartialEnv(t *testing.T) {
@@ -231,6 +233,7 @@ func TestSampleConfig(t *testing.T) {
 	assert.Contains(t, sample, "WARK_DEFAULT_PROJECT")
 	assert.Contains(t, sample, "WARK_DEFAULT_WORKER_ID")
 	assert.Contains(t, sample, "WARK_CLAIM_DURATION")
+	assert.Contains(t, sample, "WARK_CLAIM_MAX_LIFETIME")
 }
 
 func TestDefaultConfigPath(t *testing.T) {
@@ -412,3 +415,120 @@ func TestSampleConfig_IncludesBackup(t *testing.T) {
 	assert.Contains(t, sample, "WARK_BACKUP_MAX_COUNT")
 	assert.Contains(t, sample, "WARK_BACKUP_PATH")
 }
+
+func TestLoadFromPath_Webhooks(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.toml")
+
+	content := `
+[[webhooks]]
+name = "slack"
+url = "https://hooks.example.com/abc"
+secret = "s3cret"
+events = ["inbox.message", "ticket.status_changed"]
+statuses = ["review"]
+
+[[webhooks]]
+name = "ci"
+url = "https://ci.example.com/wark"
+max_attempts = 3
+`
+	err := os.WriteFile(configPath, []byte(content), 0644)
+	require.NoError(t, err)
+
+	cfg, err := LoadFromPath(configPath)
+	require.NoError(t, err)
+
+	require.Len(t, cfg.Webhooks, 2)
+	assert.Equal(t, "slack", cfg.Webhooks[0].Name)
+	assert.Equal(t, "s3cret", cfg.Webhooks[0].Secret)
+	assert.Equal(t, []string{"inbox.message", "ticket.status_changed"}, cfg.Webhooks[0].Events)
+	assert.Equal(t, []string{"review"}, cfg.Webhooks[0].Statuses)
+	assert.Equal(t, 3, cfg.Webhooks[1].MaxAttempts)
+
+	assert.Equal(t, "https://ci.example.com/wark", cfg.Webhook("ci").URL)
+	assert.Nil(t, cfg.Webhook("missing"))
+}
+
+func TestSchedulingConfig(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.toml")
+
+	cfg, err := LoadFromPath(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "priority", cfg.Scheduling.Policy)
+	assert.Equal(t, 24, cfg.Scheduling.AgingHours)
+
+	content := `
+[scheduling]
+policy = "aging"
+aging_hours = 12
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0644))
+
+	cfg, err = LoadFromPath(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "aging", cfg.Scheduling.Policy)
+	assert.Equal(t, 12, cfg.Scheduling.AgingHours)
+
+	t.Setenv("WARK_SCHEDULING_POLICY", "round-robin")
+	t.Setenv("WARK_SCHEDULING_AGING_HOURS", "0") // Zero should be ignored
+	cfg, err = LoadFromPath(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "round-robin", cfg.Scheduling.Policy)
+	assert.Equal(t, 12, cfg.Scheduling.AgingHours)
+}
+
+func TestLoadFromPath_Recurring(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.toml")
+
+	content := `
+[[recurring]]
+name = "changelog"
+schedule = "0 9 * * FRI"
+project = "WEBAPP"
+title = "Write weekly changelog"
+role = "writer"
+complexity = "small"
+ready = true
+`
+	err := os.WriteFile(configPath, []byte(content), 0644)
+	require.NoError(t, err)
+
+	cfg, err := LoadFromPath(configPath)
+	require.NoError(t, err)
+
+	require.Len(t, cfg.Recurring, 1)
+	r := cfg.Recurring[0]
+	assert.Equal(t, "changelog", r.Name)
+	assert.Equal(t, "0 9 * * FRI", r.Schedule)
+	assert.Equal(t, "WEBAPP", r.Project)
+	assert.Equal(t, "writer", r.Role)
+	assert.Equal(t, "small", r.Complexity)
+	assert.True(t, r.Ready)
+}
+
+func TestLoadFromPath_InboxSLA(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.toml")
+
+	content := `
+[[inbox_sla]]
+type = "question"
+minutes = 240
+
+[[inbox_sla]]
+priority = "highest"
+minutes = 60
+`
+	err := os.WriteFile(configPath, []byte(content), 0644)
+	require.NoError(t, err)
+
+	cfg, err := LoadFromPath(configPath)
+	require.NoError(t, err)
+
+	require.Len(t, cfg.InboxSLA, 2)
+	assert.Equal(t, InboxSLAConfig{Type: "question", Minutes: 240}, cfg.InboxSLA[0])
+	assert.Equal(t, InboxSLAConfig{Priority: "highest", Minutes: 60}, cfg.InboxSLA[1])
+}
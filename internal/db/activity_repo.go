@@ -10,11 +10,11 @@ import (
 
 // ActivityRepo provides database operations for activity log entries.
 type ActivityRepo struct {
-	db *sql.DB
+	db DBTX
 }
 
 // NewActivityRepo creates a new ActivityRepo.
-func NewActivityRepo(db *sql.DB) *ActivityRepo {
+func NewActivityRepo(db DBTX) *ActivityRepo {
 	return &ActivityRepo{db: db}
 }
 
@@ -58,6 +58,37 @@ func (r *ActivityRepo) Create(a *models.ActivityLog) error {
 	return nil
 }
 
+// ReplaceHistory replaces a ticket's activity log with the given entries,
+// keeping their original timestamps. It is used when importing tickets, whose
+// history would otherwise start with the trigger-written "created" entry.
+func (r *ActivityRepo) ReplaceHistory(ticketID int64, entries []*models.ActivityLog) error {
+	if _, err := r.db.Exec(`DELETE FROM activity_log WHERE ticket_id = ?`, ticketID); err != nil {
+		return fmt.Errorf("failed to clear activity log: %w", err)
+	}
+
+	for _, a := range entries {
+		a.TicketID = ticketID
+		if err := a.Validate(); err != nil {
+			return fmt.Errorf("invalid activity log: %w", err)
+		}
+		if a.CreatedAt.IsZero() {
+			a.CreatedAt = time.Now()
+		}
+		result, err := r.db.Exec(`
+			INSERT INTO activity_log (ticket_id, action, actor_type, actor_id, details, summary, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+		`, a.TicketID, a.Action, a.ActorType, nullString(a.ActorID),
+			nullString(a.Details), nullString(a.Summary), FormatTime(a.CreatedAt))
+		if err != nil {
+			return fmt.Errorf("failed to restore activity log: %w", err)
+		}
+		if a.ID, err = result.LastInsertId(); err != nil {
+			return fmt.Errorf("failed to get activity log id: %w", err)
+		}
+	}
+	return nil
+}
+
 // GetByID retrieves an activity log entry by ID.
 func (r *ActivityRepo) GetByID(id int64) (*models.ActivityLog, error) {
 	query := `
@@ -180,6 +211,46 @@ func (r *ActivityRepo) ListCommentsByTicket(ticketID int64, limit int) ([]*model
 	return r.scanMany(rows)
 }
 
+// ListAfter retrieves entries with an ID greater than afterID, oldest first.
+// Activity IDs only grow, so callers can page through new entries by passing
+// the last ID they saw.
+func (r *ActivityRepo) ListAfter(afterID int64, limit int) ([]*models.ActivityLog, error) {
+	query := `
+		SELECT a.id, a.ticket_id, a.action, a.actor_type, a.actor_id,
+			a.details, a.summary, a.created_at,
+			p.key || '-' || t.number AS ticket_key
+		FROM activity_log a
+		JOIN tickets t ON a.ticket_id = t.id
+		JOIN projects p ON t.project_id = p.id
+		WHERE a.id > ?
+		ORDER BY a.id
+	`
+	args := []interface{}{afterID}
+
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list activity log: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanMany(rows)
+}
+
+// LatestID returns the ID of the newest activity log entry, or 0 if there are none.
+func (r *ActivityRepo) LatestID() (int64, error) {
+	var id int64
+	err := r.db.QueryRow(`SELECT COALESCE(MAX(id), 0) FROM activity_log`).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get latest activity id: %w", err)
+	}
+	return id, nil
+}
+
 // CountByTicket counts activity log entries for a ticket.
 func (r *ActivityRepo) CountByTicket(ticketID int64) (int, error) {
 	query := `SELECT COUNT(*) FROM activity_log WHERE ticket_id = ?`
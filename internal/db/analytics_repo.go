@@ -8,17 +8,18 @@ import (
 
 // AnalyticsRepo provides database operations for analytics queries.
 type AnalyticsRepo struct {
-	db *sql.DB
+	db DBTX
 }
 
 // NewAnalyticsRepo creates a new AnalyticsRepo.
-func NewAnalyticsRepo(db *sql.DB) *AnalyticsRepo {
+func NewAnalyticsRepo(db DBTX) *AnalyticsRepo {
 	return &AnalyticsRepo{db: db}
 }
 
 // AnalyticsFilter defines filters for analytics queries.
 type AnalyticsFilter struct {
 	ProjectKey string
+	Labels     []string
 	Since      *time.Time
 	Until      *time.Time
 }
@@ -276,6 +277,9 @@ func (r *AnalyticsRepo) GetThroughputMetrics(filter AnalyticsFilter) (*Throughpu
 		projectWhere = " AND p.key = ?"
 		projectArgs = append(projectArgs, filter.ProjectKey)
 	}
+	labelWhere, labelArgs := labelsWhere(filter.Labels, "t")
+	projectWhere += labelWhere
+	projectArgs = append(projectArgs, labelArgs...)
 
 	now := time.Now()
 	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
@@ -407,6 +411,11 @@ func (r *AnalyticsRepo) buildFilterWhere(filter AnalyticsFilter, alias string) (
 		where += " AND p.key = ?"
 		args = append(args, filter.ProjectKey)
 	}
+	if len(filter.Labels) > 0 {
+		labelWhere, labelArgs := labelsWhere(filter.Labels, alias)
+		where += labelWhere
+		args = append(args, labelArgs...)
+	}
 	if filter.Since != nil {
 		where += fmt.Sprintf(" AND %s.created_at >= ?", alias)
 		args = append(args, FormatTime(*filter.Since))
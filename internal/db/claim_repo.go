@@ -3,22 +3,26 @@ package db
 import (
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/spetersoncode/wark/internal/models"
 )
 
 // ClaimRepo provides database operations for claims.
 type ClaimRepo struct {
-	db *sql.DB
+	db DBTX
 }
 
 // NewClaimRepo creates a new ClaimRepo.
-func NewClaimRepo(db *sql.DB) *ClaimRepo {
+func NewClaimRepo(db DBTX) *ClaimRepo {
 	return &ClaimRepo{db: db}
 }
 
-// Create creates a new claim.
+// Create creates a new claim. A claim ID is generated if none is set.
 func (r *ClaimRepo) Create(c *models.Claim) error {
+	if c.ClaimID == "" {
+		c.ClaimID = models.GenerateClaimID()
+	}
 	if err := c.Validate(); err != nil {
 		return fmt.Errorf("invalid claim: %w", err)
 	}
@@ -83,13 +87,13 @@ func (r *ClaimRepo) GetActiveByTicketID(ticketID int64) (*models.Claim, error) {
 	return r.scanOne(r.db.QueryRow(query, ticketID, NowRFC3339()))
 }
 
-// GetActiveByWorkerID retrieves all active claims for a worker.
-// Deprecated: Use claim-based tracking instead of worker-based tracking.
+// GetActiveByWorkerID retrieves all active claims held by a worker.
 func (r *ClaimRepo) GetActiveByWorkerID(workerID string) ([]*models.Claim, error) {
 	query := `
 		SELECT c.id, c.claim_id, c.ticket_id, c.worker_id, c.claimed_at, c.expires_at,
 			c.released_at, c.status, t.title AS ticket_title,
-			p.key || '-' || t.number AS ticket_key
+			p.key || '-' || t.number AS ticket_key,
+			CAST((julianday(c.expires_at) - julianday('now')) * 24 * 60 AS INTEGER) AS minutes_remaining
 		FROM claims c
 		JOIN tickets t ON c.ticket_id = t.id
 		JOIN projects p ON t.project_id = p.id
@@ -102,7 +106,7 @@ func (r *ClaimRepo) GetActiveByWorkerID(workerID string) ([]*models.Claim, error
 	}
 	defer rows.Close()
 
-	return r.scanMany(rows)
+	return r.scanManyWithMinutes(rows)
 }
 
 // ListActive retrieves all active claims.
@@ -189,6 +193,25 @@ func (r *ClaimRepo) Release(id int64, status models.ClaimStatus) error {
 	return nil
 }
 
+// Extend moves an active claim's expiry to expiresAt.
+func (r *ClaimRepo) Extend(id int64, expiresAt time.Time) error {
+	query := `UPDATE claims SET expires_at = ? WHERE id = ? AND status = 'active'`
+	result, err := r.db.Exec(query, FormatTime(expiresAt), id)
+	if err != nil {
+		return fmt.Errorf("failed to extend claim: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("claim not found")
+	}
+
+	return nil
+}
+
 // ExpireAll marks all expired active claims as expired.
 func (r *ClaimRepo) ExpireAll() (int64, error) {
 	now := NowRFC3339()
@@ -214,6 +237,38 @@ func (r *ClaimRepo) HasActiveClaim(ticketID int64) (bool, error) {
 	return true, nil
 }
 
+// LastClaimByProject returns, for each project with at least one claim, when
+// one of its tickets was last claimed.
+func (r *ClaimRepo) LastClaimByProject() (map[int64]time.Time, error) {
+	query := `
+		SELECT t.project_id, MAX(c.claimed_at)
+		FROM claims c
+		JOIN tickets t ON c.ticket_id = t.id
+		GROUP BY t.project_id
+	`
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list last claims: %w", err)
+	}
+	defer rows.Close()
+
+	last := make(map[int64]time.Time)
+	for rows.Next() {
+		var projectID int64
+		var claimedAt string
+		if err := rows.Scan(&projectID, &claimedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan last claim: %w", err)
+		}
+		// MAX() drops the column type, so the timestamp comes back as text
+		t, err := time.Parse(time.RFC3339, claimedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse claim time %q: %w", claimedAt, err)
+		}
+		last[projectID] = t
+	}
+	return last, rows.Err()
+}
+
 func (r *ClaimRepo) scanOne(row *sql.Row) (*models.Claim, error) {
 	var c models.Claim
 	var releasedAt sql.NullTime
@@ -40,7 +40,7 @@ func Open(path string) (*DB, error) {
 	}
 
 	// Open the database with SQLite pragmas for better performance
-	dsn := fmt.Sprintf("file:%s?_pragma=journal_mode(WAL)&_pragma=foreign_keys(ON)&_pragma=busy_timeout(5000)", path)
+	dsn := fmt.Sprintf("file:%s?_pragma=journal_mode(WAL)&_pragma=foreign_keys(ON)&_pragma=busy_timeout(5000)&_txlock=immediate", path)
 
 	db, err := sql.Open("sqlite", dsn)
 	if err != nil {
@@ -3,6 +3,8 @@ package db
 import (
 	"database/sql"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/spetersoncode/wark/internal/models"
@@ -10,11 +12,11 @@ import (
 
 // DependencyRepo provides database operations for ticket dependencies.
 type DependencyRepo struct {
-	db *sql.DB
+	db DBTX
 }
 
 // NewDependencyRepo creates a new DependencyRepo.
-func NewDependencyRepo(db *sql.DB) *DependencyRepo {
+func NewDependencyRepo(db DBTX) *DependencyRepo {
 	return &DependencyRepo{db: db}
 }
 
@@ -191,6 +193,76 @@ func (r *DependencyRepo) wouldCreateCycle(ticketID, dependsOnID int64) (bool, er
 	return true, nil
 }
 
+// Dependency is an edge in the dependency graph: TicketID depends on
+// DependsOnID.
+type Dependency struct {
+	TicketID    int64
+	DependsOnID int64
+}
+
+// maxIDsPerQuery caps the IDs bound in one IN list, keeping a query that
+// binds them twice under SQLite's limit on bound variables (999 in older
+// builds).
+const maxIDsPerQuery = 400
+
+// ListForTickets returns every dependency with at least one end in ticketIDs.
+// Large sets are queried in chunks.
+func (r *DependencyRepo) ListForTickets(ticketIDs []int64) ([]Dependency, error) {
+	var deps []Dependency
+	seen := make(map[Dependency]bool)
+	for start := 0; start < len(ticketIDs); start += maxIDsPerQuery {
+		chunk := ticketIDs[start:min(start+maxIDsPerQuery, len(ticketIDs))]
+		placeholders := strings.TrimSuffix(strings.Repeat("?,", len(chunk)), ",")
+		args := make([]interface{}, 0, 2*len(chunk))
+		for _, id := range chunk {
+			args = append(args, id)
+		}
+		args = append(args, args...)
+
+		rows, err := r.db.Query(`SELECT ticket_id, depends_on_id FROM ticket_dependencies
+			WHERE ticket_id IN (`+placeholders+`) OR depends_on_id IN (`+placeholders+`)`, args...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list dependencies: %w", err)
+		}
+		found, err := scanDependencies(rows)
+		rows.Close()
+		if err != nil {
+			return nil, err
+		}
+		// A dependency between two chunks is found by both
+		for _, d := range found {
+			if !seen[d] {
+				seen[d] = true
+				deps = append(deps, d)
+			}
+		}
+	}
+
+	sort.Slice(deps, func(i, j int) bool {
+		if deps[i].TicketID != deps[j].TicketID {
+			return deps[i].TicketID < deps[j].TicketID
+		}
+		return deps[i].DependsOnID < deps[j].DependsOnID
+	})
+	return deps, nil
+}
+
+// ListOpen returns every dependency between two tickets that aren't closed.
+func (r *DependencyRepo) ListOpen() ([]Dependency, error) {
+	query := `SELECT td.ticket_id, td.depends_on_id
+		FROM ticket_dependencies td
+		JOIN tickets t ON td.ticket_id = t.id
+		JOIN tickets dep ON td.depends_on_id = dep.id
+		WHERE t.status != 'closed' AND dep.status != 'closed'
+		ORDER BY td.ticket_id, td.depends_on_id`
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dependencies: %w", err)
+	}
+	defer rows.Close()
+	return scanDependencies(rows)
+}
+
 // CountDependencies counts the number of dependencies for a ticket.
 func (r *DependencyRepo) CountDependencies(ticketID int64) (int, error) {
 	query := `SELECT COUNT(*) FROM ticket_dependencies WHERE ticket_id = ?`
@@ -257,3 +329,15 @@ func (r *DependencyRepo) scanTickets(rows *sql.Rows) ([]*models.Ticket, error) {
 	}
 	return tickets, nil
 }
+
+func scanDependencies(rows *sql.Rows) ([]Dependency, error) {
+	var deps []Dependency
+	for rows.Next() {
+		var d Dependency
+		if err := rows.Scan(&d.TicketID, &d.DependsOnID); err != nil {
+			return nil, fmt.Errorf("failed to scan dependency: %w", err)
+		}
+		deps = append(deps, d)
+	}
+	return deps, rows.Err()
+}
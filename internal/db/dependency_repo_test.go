@@ -0,0 +1,42 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/spetersoncode/wark/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDependencyRepo_ListForTicketsManyIDs(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	projectID := createTestProject(t, db)
+	ticketRepo := NewTicketRepo(db)
+	depRepo := NewDependencyRepo(db)
+
+	var tickets []*models.Ticket
+	for _, title := range []string{"First", "Second", "Third"} {
+		ticket := &models.Ticket{ProjectID: projectID, Title: title, Status: models.StatusReady}
+		require.NoError(t, ticketRepo.Create(ticket))
+		tickets = append(tickets, ticket)
+	}
+	require.NoError(t, depRepo.Add(tickets[1].ID, tickets[0].ID))
+	require.NoError(t, depRepo.Add(tickets[2].ID, tickets[1].ID))
+
+	// More IDs than SQLite can bind in one query, with the tickets in
+	// different chunks
+	ids := []int64{tickets[0].ID}
+	for id := int64(100000); len(ids) < 20000; id++ {
+		ids = append(ids, id)
+	}
+	ids = append(ids, tickets[1].ID, tickets[2].ID)
+
+	deps, err := depRepo.ListForTickets(ids)
+	require.NoError(t, err)
+	assert.Equal(t, []Dependency{
+		{TicketID: tickets[1].ID, DependsOnID: tickets[0].ID},
+		{TicketID: tickets[2].ID, DependsOnID: tickets[1].ID},
+	}, deps)
+}
@@ -2,6 +2,7 @@ package db
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -10,11 +11,11 @@ import (
 
 // InboxRepo provides database operations for inbox messages.
 type InboxRepo struct {
-	db *sql.DB
+	db DBTX
 }
 
 // NewInboxRepo creates a new InboxRepo.
-func NewInboxRepo(db *sql.DB) *InboxRepo {
+func NewInboxRepo(db DBTX) *InboxRepo {
 	return &InboxRepo{db: db}
 }
 
@@ -35,12 +36,21 @@ func (r *InboxRepo) Create(m *models.InboxMessage) error {
 		return fmt.Errorf("invalid inbox message: %w", err)
 	}
 
+	var options sql.NullString
+	if len(m.Options) > 0 {
+		data, err := json.Marshal(m.Options)
+		if err != nil {
+			return fmt.Errorf("failed to encode options: %w", err)
+		}
+		options = sql.NullString{String: string(data), Valid: true}
+	}
+
 	query := `
-		INSERT INTO inbox_messages (ticket_id, message_type, content, from_agent, created_at)
-		VALUES (?, ?, ?, ?, ?)
+		INSERT INTO inbox_messages (ticket_id, message_type, content, from_agent, options, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
 	`
 	now := time.Now()
-	result, err := r.db.Exec(query, m.TicketID, m.MessageType, m.Content, nullString(m.FromAgent), FormatTime(now))
+	result, err := r.db.Exec(query, m.TicketID, m.MessageType, m.Content, nullString(m.FromAgent), options, FormatTime(now))
 	if err != nil {
 		return fmt.Errorf("failed to create inbox message: %w", err)
 	}
@@ -59,8 +69,9 @@ func (r *InboxRepo) Create(m *models.InboxMessage) error {
 func (r *InboxRepo) GetByID(id int64) (*models.InboxMessage, error) {
 	query := `
 		SELECT m.id, m.ticket_id, m.message_type, m.content, m.from_agent,
-			m.response, m.responded_at, m.created_at,
-			t.title AS ticket_title, p.key || '-' || t.number AS ticket_key
+			m.response, m.responded_at, m.created_at, m.options, m.choice, m.escalated_at, m.waiting_since,
+			t.title AS ticket_title, p.key || '-' || t.number AS ticket_key, t.priority,
+			(SELECT COUNT(*) FROM inbox_replies ir WHERE ir.message_id = m.id) AS reply_count
 		FROM inbox_messages m
 		JOIN tickets t ON m.ticket_id = t.id
 		JOIN projects p ON t.project_id = p.id
@@ -73,8 +84,9 @@ func (r *InboxRepo) GetByID(id int64) (*models.InboxMessage, error) {
 func (r *InboxRepo) List(filter InboxFilter) ([]*models.InboxMessage, error) {
 	query := `
 		SELECT m.id, m.ticket_id, m.message_type, m.content, m.from_agent,
-			m.response, m.responded_at, m.created_at,
-			t.title AS ticket_title, p.key || '-' || t.number AS ticket_key
+			m.response, m.responded_at, m.created_at, m.options, m.choice, m.escalated_at, m.waiting_since,
+			t.title AS ticket_title, p.key || '-' || t.number AS ticket_key, t.priority,
+			(SELECT COUNT(*) FROM inbox_replies ir WHERE ir.message_id = m.id) AS reply_count
 		FROM inbox_messages m
 		JOIN tickets t ON m.ticket_id = t.id
 		JOIN projects p ON t.project_id = p.id
@@ -102,7 +114,7 @@ func (r *InboxRepo) List(filter InboxFilter) ([]*models.InboxMessage, error) {
 		query += " AND m.responded_at IS NULL"
 	}
 
-	query += " ORDER BY m.created_at DESC"
+	query += " ORDER BY m.created_at DESC, m.id DESC"
 
 	if filter.Limit > 0 {
 		query += " LIMIT ?"
@@ -151,6 +163,149 @@ func (r *InboxRepo) Respond(id int64, response string) error {
 	return nil
 }
 
+// SetChoice records the key of the option chosen on a decision message.
+func (r *InboxRepo) SetChoice(id int64, choice string) error {
+	result, err := r.db.Exec(`UPDATE inbox_messages SET choice = ? WHERE id = ?`, nullString(choice), id)
+	if err != nil {
+		return fmt.Errorf("failed to record choice: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("message not found")
+	}
+
+	return nil
+}
+
+// MarkEscalated records that a message was escalated for passing its
+// response SLA.
+func (r *InboxRepo) MarkEscalated(id int64) error {
+	result, err := r.db.Exec(`UPDATE inbox_messages SET escalated_at = ? WHERE id = ?`, NowRFC3339(), id)
+	if err != nil {
+		return fmt.Errorf("failed to mark message escalated: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("message not found")
+	}
+
+	return nil
+}
+
+// ListDecisions retrieves a ticket's resolved messages with a chosen
+// option, oldest first.
+func (r *InboxRepo) ListDecisions(ticketID int64) ([]*models.Decision, error) {
+	messages, err := r.List(InboxFilter{TicketID: &ticketID})
+	if err != nil {
+		return nil, err
+	}
+
+	decisions := []*models.Decision{}
+	// List is newest first
+	for i := len(messages) - 1; i >= 0; i-- {
+		if d := messages[i].Decision(); d != nil {
+			decisions = append(decisions, d)
+		}
+	}
+	return decisions, nil
+}
+
+// Reopen clears the response and choice on an inbox message, making it
+// pending again. Its response SLA starts over, and it can be escalated as
+// overdue again.
+func (r *InboxRepo) Reopen(id int64) error {
+	query := `
+		UPDATE inbox_messages
+		SET response = NULL, responded_at = NULL, choice = NULL, escalated_at = NULL, waiting_since = ?
+		WHERE id = ?
+	`
+	result, err := r.db.Exec(query, NowRFC3339(), id)
+	if err != nil {
+		return fmt.Errorf("failed to reopen message: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("message not found")
+	}
+
+	return nil
+}
+
+// AddReply adds a reply to an inbox message's thread. An agent's reply
+// restarts the message's response SLA.
+func (r *InboxRepo) AddReply(reply *models.InboxReply) error {
+	if err := reply.Validate(); err != nil {
+		return fmt.Errorf("invalid inbox reply: %w", err)
+	}
+
+	query := `
+		INSERT INTO inbox_replies (message_id, author_type, author, content, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+	now := time.Now()
+	result, err := r.db.Exec(query, reply.MessageID, reply.AuthorType, nullString(reply.Author), reply.Content, FormatTime(now))
+	if err != nil {
+		return fmt.Errorf("failed to create inbox reply: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get reply id: %w", err)
+	}
+
+	if reply.AuthorType == models.ActorTypeAgent {
+		if _, err := r.db.Exec(`UPDATE inbox_messages SET waiting_since = ? WHERE id = ?`, FormatTime(now), reply.MessageID); err != nil {
+			return fmt.Errorf("failed to restart message SLA: %w", err)
+		}
+	}
+
+	reply.ID = id
+	reply.CreatedAt = now
+	return nil
+}
+
+// ListReplies retrieves the replies to an inbox message, oldest first.
+func (r *InboxRepo) ListReplies(messageID int64) ([]*models.InboxReply, error) {
+	query := `
+		SELECT id, message_id, author_type, author, content, created_at
+		FROM inbox_replies
+		WHERE message_id = ?
+		ORDER BY id
+	`
+	rows, err := r.db.Query(query, messageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list inbox replies: %w", err)
+	}
+	defer rows.Close()
+
+	replies := []*models.InboxReply{}
+	for rows.Next() {
+		var reply models.InboxReply
+		var author sql.NullString
+		if err := rows.Scan(&reply.ID, &reply.MessageID, &reply.AuthorType, &author, &reply.Content, &reply.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan inbox reply: %w", err)
+		}
+		reply.Author = author.String
+		replies = append(replies, &reply)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating inbox replies: %w", err)
+	}
+	return replies, nil
+}
+
 // Delete deletes an inbox message.
 func (r *InboxRepo) Delete(id int64) error {
 	query := `DELETE FROM inbox_messages WHERE id = ?`
@@ -194,14 +349,14 @@ func (r *InboxRepo) CountPendingByTicket(ticketID int64) (int, error) {
 
 func (r *InboxRepo) scanOne(row *sql.Row) (*models.InboxMessage, error) {
 	var m models.InboxMessage
-	var fromAgent, response sql.NullString
-	var respondedAt sql.NullTime
-	var ticketTitle, ticketKey sql.NullString
+	var fromAgent, response, options, choice sql.NullString
+	var respondedAt, escalatedAt, waitingSince sql.NullTime
+	var ticketTitle, ticketKey, ticketPriority sql.NullString
 
 	err := row.Scan(
 		&m.ID, &m.TicketID, &m.MessageType, &m.Content, &fromAgent,
-		&response, &respondedAt, &m.CreatedAt,
-		&ticketTitle, &ticketKey,
+		&response, &respondedAt, &m.CreatedAt, &options, &choice, &escalatedAt, &waitingSince,
+		&ticketTitle, &ticketKey, &ticketPriority, &m.ReplyCount,
 	)
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -215,23 +370,45 @@ func (r *InboxRepo) scanOne(row *sql.Row) (*models.InboxMessage, error) {
 	if respondedAt.Valid {
 		m.RespondedAt = &respondedAt.Time
 	}
+	if err := decodeOptions(&m, options); err != nil {
+		return nil, err
+	}
+	m.Choice = choice.String
+	if escalatedAt.Valid {
+		m.EscalatedAt = &escalatedAt.Time
+	}
+	if waitingSince.Valid {
+		m.WaitingSince = &waitingSince.Time
+	}
 	m.TicketTitle = ticketTitle.String
 	m.TicketKey = ticketKey.String
+	m.TicketPriority = models.Priority(ticketPriority.String)
 	return &m, nil
 }
 
+// decodeOptions decodes a message's JSON options column.
+func decodeOptions(m *models.InboxMessage, options sql.NullString) error {
+	if !options.Valid || options.String == "" {
+		return nil
+	}
+	if err := json.Unmarshal([]byte(options.String), &m.Options); err != nil {
+		return fmt.Errorf("failed to decode options of message #%d: %w", m.ID, err)
+	}
+	return nil
+}
+
 func (r *InboxRepo) scanMany(rows *sql.Rows) ([]*models.InboxMessage, error) {
 	var messages []*models.InboxMessage
 	for rows.Next() {
 		var m models.InboxMessage
-		var fromAgent, response sql.NullString
-		var respondedAt sql.NullTime
-		var ticketTitle, ticketKey sql.NullString
+		var fromAgent, response, options, choice sql.NullString
+		var respondedAt, escalatedAt, waitingSince sql.NullTime
+		var ticketTitle, ticketKey, ticketPriority sql.NullString
 
 		err := rows.Scan(
 			&m.ID, &m.TicketID, &m.MessageType, &m.Content, &fromAgent,
-			&response, &respondedAt, &m.CreatedAt,
-			&ticketTitle, &ticketKey,
+			&response, &respondedAt, &m.CreatedAt, &options, &choice, &escalatedAt, &waitingSince,
+			&ticketTitle, &ticketKey, &ticketPriority, &m.ReplyCount,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan inbox message: %w", err)
@@ -242,8 +419,19 @@ func (r *InboxRepo) scanMany(rows *sql.Rows) ([]*models.InboxMessage, error) {
 		if respondedAt.Valid {
 			m.RespondedAt = &respondedAt.Time
 		}
+		if err := decodeOptions(&m, options); err != nil {
+			return nil, err
+		}
+		m.Choice = choice.String
+		if escalatedAt.Valid {
+			m.EscalatedAt = &escalatedAt.Time
+		}
+		if waitingSince.Valid {
+			m.WaitingSince = &waitingSince.Time
+		}
 		m.TicketTitle = ticketTitle.String
 		m.TicketKey = ticketKey.String
+		m.TicketPriority = models.Priority(ticketPriority.String)
 		messages = append(messages, &m)
 	}
 	if err := rows.Err(); err != nil {
@@ -0,0 +1,89 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// LabelRepo provides database operations for ticket labels.
+type LabelRepo struct {
+	db DBTX
+}
+
+// NewLabelRepo creates a new LabelRepo.
+func NewLabelRepo(db DBTX) *LabelRepo {
+	return &LabelRepo{db: db}
+}
+
+// Add attaches a label to a ticket. Returns false if the ticket already had it.
+func (r *LabelRepo) Add(ticketID int64, label string) (bool, error) {
+	result, err := r.db.Exec(
+		`INSERT OR IGNORE INTO ticket_labels (ticket_id, label, created_at) VALUES (?, ?, ?)`,
+		ticketID, label, NowRFC3339(),
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to add label: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// Remove detaches a label from a ticket. Returns false if the ticket did not have it.
+func (r *LabelRepo) Remove(ticketID int64, label string) (bool, error) {
+	result, err := r.db.Exec(`DELETE FROM ticket_labels WHERE ticket_id = ? AND label = ?`, ticketID, label)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove label: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// ListByTicket returns a ticket's labels in alphabetical order.
+func (r *LabelRepo) ListByTicket(ticketID int64) ([]string, error) {
+	rows, err := r.db.Query(`SELECT label FROM ticket_labels WHERE ticket_id = ? ORDER BY label`, ticketID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list labels: %w", err)
+	}
+	defer rows.Close()
+
+	var labels []string
+	for rows.Next() {
+		var label string
+		if err := rows.Scan(&label); err != nil {
+			return nil, fmt.Errorf("failed to scan label: %w", err)
+		}
+		labels = append(labels, label)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating labels: %w", err)
+	}
+	return labels, nil
+}
+
+// labelsWhere builds an "AND EXISTS (...)" clause per label, so a ticket
+// matches only if it has every label. alias is the tickets table alias.
+func labelsWhere(labels []string, alias string) (string, []interface{}) {
+	var where string
+	var args []interface{}
+	for _, label := range labels {
+		where += fmt.Sprintf(" AND EXISTS (SELECT 1 FROM ticket_labels tl WHERE tl.ticket_id = %s.id AND tl.label = ?)", alias)
+		args = append(args, label)
+	}
+	return where, args
+}
+
+// splitLabels converts the comma-separated labels column selected by the
+// ticket queries back into a slice.
+func splitLabels(s sql.NullString) []string {
+	if !s.Valid || s.String == "" {
+		return nil
+	}
+	return strings.Split(s.String, ",")
+}
@@ -0,0 +1,107 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/spetersoncode/wark/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabelRepo(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	projectID := createTestProject(t, db)
+	ticketRepo := NewTicketRepo(db)
+	labelRepo := NewLabelRepo(db)
+
+	ticket := &models.Ticket{ProjectID: projectID, Title: "Labelled", Status: models.StatusReady}
+	require.NoError(t, ticketRepo.Create(ticket))
+
+	added, err := labelRepo.Add(ticket.ID, "needs-migration")
+	require.NoError(t, err)
+	assert.True(t, added)
+	added, err = labelRepo.Add(ticket.ID, "area:api")
+	require.NoError(t, err)
+	assert.True(t, added)
+
+	added, err = labelRepo.Add(ticket.ID, "area:api")
+	require.NoError(t, err)
+	assert.False(t, added, "duplicate label should be ignored")
+
+	labels, err := labelRepo.ListByTicket(ticket.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"area:api", "needs-migration"}, labels)
+
+	got, err := ticketRepo.GetByID(ticket.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"area:api", "needs-migration"}, got.Labels)
+
+	removed, err := labelRepo.Remove(ticket.ID, "needs-migration")
+	require.NoError(t, err)
+	assert.True(t, removed)
+	removed, err = labelRepo.Remove(ticket.ID, "needs-migration")
+	require.NoError(t, err)
+	assert.False(t, removed)
+
+	got, err = ticketRepo.GetByID(ticket.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"area:api"}, got.Labels)
+}
+
+func TestLabelFilters(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	projectID := createTestProject(t, db)
+	ticketRepo := NewTicketRepo(db)
+	labelRepo := NewLabelRepo(db)
+
+	create := func(title string, labels ...string) *models.Ticket {
+		ticket := &models.Ticket{ProjectID: projectID, Title: title, Status: models.StatusReady}
+		require.NoError(t, ticketRepo.Create(ticket))
+		for _, label := range labels {
+			_, err := labelRepo.Add(ticket.ID, label)
+			require.NoError(t, err)
+		}
+		return ticket
+	}
+	api := create("API", "area:api")
+	apiMigration := create("API migration", "area:api", "needs-migration")
+	create("Unlabelled")
+
+	ids := func(tickets []*models.Ticket) []int64 {
+		var out []int64
+		for _, t := range tickets {
+			out = append(out, t.ID)
+		}
+		return out
+	}
+
+	t.Run("list", func(t *testing.T) {
+		tickets, err := ticketRepo.List(TicketFilter{Labels: []string{"area:api"}})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []int64{api.ID, apiMigration.ID}, ids(tickets))
+	})
+
+	t.Run("list requires every label", func(t *testing.T) {
+		tickets, err := ticketRepo.List(TicketFilter{Labels: []string{"area:api", "needs-migration"}})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{apiMigration.ID}, ids(tickets))
+	})
+
+	t.Run("workable", func(t *testing.T) {
+		tickets, err := ticketRepo.ListWorkable(TicketFilter{Labels: []string{"needs-migration"}})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{apiMigration.ID}, ids(tickets))
+	})
+
+	t.Run("analytics", func(t *testing.T) {
+		wip, err := NewAnalyticsRepo(db).GetWIPByStatus(AnalyticsFilter{Labels: []string{"area:api"}})
+		require.NoError(t, err)
+		require.Len(t, wip, 1)
+		assert.Equal(t, "ready", wip[0].Status)
+		assert.Equal(t, 2, wip[0].Count)
+	})
+}
@@ -1,9 +1,31 @@
+-- +goose NO TRANSACTION
+
+-- =============================================================================
+-- Add backlog and reviewing statuses
+-- =============================================================================
+-- Rebuilds the tickets table so databases created before 'backlog' and
+-- 'reviewing' existed pick up the updated status CHECK constraint.
+--
+-- NOTE: For fresh databases, 001_initial_schema.sql already allows both
+-- statuses; the rebuild is then a plain copy.
+--
+-- SQLite cannot alter a CHECK constraint in place, so this follows the
+-- documented table rebuild procedure. It runs outside a transaction because
+-- PRAGMA foreign_keys is a no-op inside one, and dropping the old table with
+-- foreign keys on would cascade-delete dependencies, tasks and activity.
+-- Views, indexes and triggers that reference tickets are recreated.
+-- =============================================================================
+
 -- +goose Up
+PRAGMA foreign_keys = OFF;
+
 -- +goose StatementBegin
+BEGIN;
 
-PRAGMA foreign_keys = OFF;
+DROP VIEW IF EXISTS workable_tickets;
+DROP VIEW IF EXISTS pending_human_input;
+DROP VIEW IF EXISTS active_claims;
 
--- Recreate tickets table with updated status CHECK constraint
 CREATE TABLE tickets_new (
     id                  INTEGER PRIMARY KEY AUTOINCREMENT,
     project_id          INTEGER NOT NULL REFERENCES projects(id),
@@ -38,95 +60,174 @@ CREATE TABLE tickets_new (
                         CHECK (complexity IN (
                             'trivial', 'small', 'medium', 'large', 'xlarge'
                         )),
-    type                TEXT NOT NULL DEFAULT 'task'
-                        CHECK (type IN ('task', 'epic')),
+    worktree            TEXT,
     retry_count         INTEGER NOT NULL DEFAULT 0,
     max_retries         INTEGER NOT NULL DEFAULT 3,
-    epic_id             INTEGER REFERENCES tickets(id),
     parent_ticket_id    INTEGER REFERENCES tickets(id),
-    milestone_id        INTEGER REFERENCES milestones(id),
-    role_id             INTEGER REFERENCES roles(id),
-    worktree            TEXT,
-    claim_id            INTEGER REFERENCES claims(id),
     created_at          DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at          DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
     completed_at        DATETIME,
-    FOREIGN KEY (project_id, number) REFERENCES ticket_sequences(project_id, last_number)
+    ticket_type         TEXT NOT NULL DEFAULT 'task'
+                        CHECK (ticket_type IN ('task', 'epic')),
+    role_id             INTEGER REFERENCES roles(id),
+
+    UNIQUE(project_id, number)
 );
 
--- Copy all data from old table
-INSERT INTO tickets_new SELECT * FROM tickets;
+-- Copy all data from old table (explicit columns; positions differ after
+-- the ALTER TABLE history of 003-008)
+INSERT INTO tickets_new (
+    id, project_id, number, title, description, status, resolution,
+    human_flag_reason, priority, complexity, worktree, retry_count, max_retries,
+    parent_ticket_id, created_at, updated_at, completed_at, ticket_type, role_id
+)
+SELECT
+    id, project_id, number, title, description, status, resolution,
+    human_flag_reason, priority, complexity, worktree, retry_count, max_retries,
+    parent_ticket_id, created_at, updated_at, completed_at, ticket_type, role_id
+FROM tickets;
 
--- Drop old table
 DROP TABLE tickets;
-
--- Rename new table
 ALTER TABLE tickets_new RENAME TO tickets;
 
-PRAGMA foreign_keys = ON;
+-- Indexes (from 001, 003, 006)
+CREATE INDEX idx_tickets_project_id ON tickets(project_id);
+CREATE INDEX idx_tickets_status ON tickets(status);
+CREATE INDEX idx_tickets_priority ON tickets(priority);
+CREATE INDEX idx_tickets_parent ON tickets(parent_ticket_id);
+CREATE INDEX idx_tickets_project_status ON tickets(project_id, status);
+CREATE INDEX idx_tickets_type ON tickets(ticket_type);
+CREATE INDEX idx_tickets_parent_type ON tickets(parent_ticket_id, ticket_type);
+CREATE INDEX idx_tickets_role_id ON tickets(role_id);
 
--- +goose StatementEnd
+-- Views (from 001)
+CREATE VIEW workable_tickets AS
+SELECT t.*,
+       p.key AS project_key,
+       p.key || '-' || t.number AS ticket_key
+FROM tickets t
+JOIN projects p ON t.project_id = p.id
+WHERE t.status = 'ready'
+  AND NOT EXISTS (
+      SELECT 1 FROM ticket_dependencies td
+      JOIN tickets dep ON td.depends_on_id = dep.id
+      WHERE td.ticket_id = t.id
+        AND dep.status != 'closed'
+  )
+ORDER BY
+    CASE t.priority
+        WHEN 'highest' THEN 1
+        WHEN 'high' THEN 2
+        WHEN 'medium' THEN 3
+        WHEN 'low' THEN 4
+        WHEN 'lowest' THEN 5
+    END,
+    t.created_at;
 
--- +goose Down
--- +goose StatementBegin
+CREATE VIEW pending_human_input AS
+SELECT
+    im.*,
+    t.title AS ticket_title,
+    p.key || '-' || t.number AS ticket_key
+FROM inbox_messages im
+JOIN tickets t ON im.ticket_id = t.id
+JOIN projects p ON t.project_id = p.id
+WHERE im.responded_at IS NULL
+ORDER BY im.created_at;
 
-PRAGMA foreign_keys = OFF;
+CREATE VIEW active_claims AS
+SELECT
+    c.*,
+    t.title AS ticket_title,
+    p.key || '-' || t.number AS ticket_key,
+    CAST((julianday(c.expires_at) - julianday('now')) * 24 * 60 AS INTEGER) AS minutes_remaining
+FROM claims c
+JOIN tickets t ON c.ticket_id = t.id
+JOIN projects p ON t.project_id = p.id
+WHERE c.status = 'active'
+  AND c.expires_at > CURRENT_TIMESTAMP;
 
--- Revert to old status constraint (backlog and reviewing not allowed)
-CREATE TABLE tickets_new (
-    id                  INTEGER PRIMARY KEY AUTOINCREMENT,
-    project_id          INTEGER NOT NULL REFERENCES projects(id),
-    number              INTEGER NOT NULL,
-    title               TEXT NOT NULL,
-    description         TEXT,
-    status              TEXT NOT NULL DEFAULT 'ready'
-                        CHECK (status IN (
-                            'blocked',
-                            'ready',
-                            'working',
-                            'human',
-                            'review',
-                            'closed'
-                        )),
-    resolution          TEXT
-                        CHECK (resolution IS NULL OR resolution IN (
-                            'completed',
-                            'wont_do',
-                            'duplicate',
-                            'invalid',
-                            'obsolete'
-                        )),
-    human_flag_reason   TEXT,
-    priority            TEXT NOT NULL DEFAULT 'medium'
-                        CHECK (priority IN (
-                            'highest', 'high', 'medium', 'low', 'lowest'
-                        )),
-    complexity          TEXT NOT NULL DEFAULT 'medium'
-                        CHECK (complexity IN (
-                            'trivial', 'small', 'medium', 'large', 'xlarge'
-                        )),
-    type                TEXT NOT NULL DEFAULT 'task'
-                        CHECK (type IN ('task', 'epic')),
-    retry_count         INTEGER NOT NULL DEFAULT 0,
-    max_retries         INTEGER NOT NULL DEFAULT 3,
-    epic_id             INTEGER REFERENCES tickets(id),
-    parent_ticket_id    INTEGER REFERENCES tickets(id),
-    milestone_id        INTEGER REFERENCES milestones(id),
-    role_id             INTEGER REFERENCES roles(id),
-    worktree            TEXT,
-    claim_id            INTEGER REFERENCES claims(id),
-    created_at          DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
-    updated_at          DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
-    completed_at        DATETIME,
-    FOREIGN KEY (project_id, number) REFERENCES ticket_sequences(project_id, last_number)
-);
+-- Triggers (from 001)
+CREATE TRIGGER update_ticket_timestamp
+AFTER UPDATE ON tickets
+FOR EACH ROW
+BEGIN
+    UPDATE tickets SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+END;
 
--- Copy data (will fail if any tickets have 'backlog' or 'reviewing' status)
-INSERT INTO tickets_new SELECT * FROM tickets;
+CREATE TRIGGER generate_ticket_number
+AFTER INSERT ON tickets
+FOR EACH ROW
+WHEN NEW.number IS NULL OR NEW.number = 0
+BEGIN
+    UPDATE tickets
+    SET number = (
+        SELECT COALESCE(MAX(number), 0) + 1
+        FROM tickets
+        WHERE project_id = NEW.project_id
+    )
+    WHERE id = NEW.id;
+END;
 
-DROP TABLE tickets;
-ALTER TABLE tickets_new RENAME TO tickets;
+CREATE TRIGGER record_ticket_creation
+AFTER INSERT ON tickets
+FOR EACH ROW
+BEGIN
+    INSERT INTO activity_log (ticket_id, action, actor_type, summary)
+    VALUES (NEW.id, 'created', 'system', 'Ticket created');
+END;
 
-PRAGMA foreign_keys = ON;
+CREATE TRIGGER record_status_change
+AFTER UPDATE OF status ON tickets
+FOR EACH ROW
+WHEN OLD.status != NEW.status
+BEGIN
+    INSERT INTO activity_log (ticket_id, action, actor_type, details, summary)
+    VALUES (
+        NEW.id,
+        'field_changed',
+        'system',
+        json_object('field', 'status', 'old', OLD.status, 'new', NEW.status),
+        'Status: ' || OLD.status || ' -> ' || NEW.status
+    );
+END;
+
+CREATE TRIGGER record_priority_change
+AFTER UPDATE OF priority ON tickets
+FOR EACH ROW
+WHEN OLD.priority != NEW.priority
+BEGIN
+    INSERT INTO activity_log (ticket_id, action, actor_type, details, summary)
+    VALUES (
+        NEW.id,
+        'field_changed',
+        'system',
+        json_object('field', 'priority', 'old', OLD.priority, 'new', NEW.priority),
+        'Priority: ' || OLD.priority || ' -> ' || NEW.priority
+    );
+END;
+
+CREATE TRIGGER record_complexity_change
+AFTER UPDATE OF complexity ON tickets
+FOR EACH ROW
+WHEN OLD.complexity != NEW.complexity
+BEGIN
+    INSERT INTO activity_log (ticket_id, action, actor_type, details, summary)
+    VALUES (
+        NEW.id,
+        'field_changed',
+        'system',
+        json_object('field', 'complexity', 'old', OLD.complexity, 'new', NEW.complexity),
+        'Complexity: ' || OLD.complexity || ' -> ' || NEW.complexity
+    );
+END;
 
+COMMIT;
 -- +goose StatementEnd
+
+PRAGMA foreign_keys = ON;
+
+-- +goose Down
+-- No-op: 001_initial_schema.sql already allows 'backlog' and 'reviewing', so
+-- rebuilding with the older constraint would reject valid rows.
+SELECT 1;
@@ -0,0 +1,172 @@
+-- +goose NO TRANSACTION
+
+-- =============================================================================
+-- Add heartbeat activity action
+-- =============================================================================
+-- Claim renewals are logged as 'heartbeat' entries. SQLite cannot alter the
+-- action CHECK constraint in place, so the activity_log table is rebuilt
+-- following the same procedure as 009 (foreign keys off, outside goose's
+-- transaction). The field_change_history view and the ticket triggers that
+-- write to activity_log are recreated.
+-- =============================================================================
+
+-- +goose Up
+PRAGMA foreign_keys = OFF;
+
+-- +goose StatementBegin
+BEGIN;
+
+DROP VIEW IF EXISTS field_change_history;
+DROP TRIGGER IF EXISTS record_ticket_creation;
+DROP TRIGGER IF EXISTS record_status_change;
+DROP TRIGGER IF EXISTS record_priority_change;
+DROP TRIGGER IF EXISTS record_complexity_change;
+
+CREATE TABLE activity_log_new (
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    ticket_id       INTEGER NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+
+    -- What happened
+    action          TEXT NOT NULL
+                    CHECK (action IN (
+                        -- Lifecycle actions
+                        'created',
+                        'vetted',
+                        'claimed',
+                        'heartbeat',
+                        'released',
+                        'expired',
+                        'completed',
+                        'accepted',
+                        'rejected',
+                        'cancelled',
+                        'reopened',
+                        'closed',
+                        'promoted',
+
+                        -- Dependency actions
+                        'dependency_added',
+                        'dependency_removed',
+                        'blocked',
+                        'unblocked',
+
+                        -- Decomposition
+                        'decomposed',
+                        'child_created',
+
+                        -- Task actions
+                        'task_completed',
+
+                        -- Human interaction
+                        'escalated',
+                        'flagged_human',
+                        'human_responded',
+
+                        -- Field changes
+                        'field_changed',
+
+                        -- Comments/notes
+                        'comment'
+                    )),
+
+    -- Who did it
+    actor_type      TEXT NOT NULL
+                    CHECK (actor_type IN ('human', 'agent', 'system', 'claim')),
+    actor_id        TEXT,
+
+    -- Details (JSON for flexibility)
+    details         TEXT,
+
+    -- Human-readable summary
+    summary         TEXT,
+
+    -- Timestamps
+    created_at      DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO activity_log_new (id, ticket_id, action, actor_type, actor_id, details, summary, created_at)
+SELECT id, ticket_id, action, actor_type, actor_id, details, summary, created_at
+FROM activity_log;
+
+DROP TABLE activity_log;
+ALTER TABLE activity_log_new RENAME TO activity_log;
+
+CREATE INDEX idx_activity_log_ticket_id ON activity_log(ticket_id);
+CREATE INDEX idx_activity_log_action ON activity_log(action);
+CREATE INDEX idx_activity_log_created_at ON activity_log(created_at);
+
+CREATE VIEW field_change_history AS
+SELECT
+    id,
+    ticket_id,
+    json_extract(details, '$.field') AS field_name,
+    json_extract(details, '$.old') AS old_value,
+    json_extract(details, '$.new') AS new_value,
+    actor_type || COALESCE(':' || actor_id, '') AS changed_by,
+    created_at
+FROM activity_log
+WHERE action = 'field_changed';
+
+-- Triggers (from 001)
+CREATE TRIGGER record_ticket_creation
+AFTER INSERT ON tickets
+FOR EACH ROW
+BEGIN
+    INSERT INTO activity_log (ticket_id, action, actor_type, summary)
+    VALUES (NEW.id, 'created', 'system', 'Ticket created');
+END;
+
+CREATE TRIGGER record_status_change
+AFTER UPDATE OF status ON tickets
+FOR EACH ROW
+WHEN OLD.status != NEW.status
+BEGIN
+    INSERT INTO activity_log (ticket_id, action, actor_type, details, summary)
+    VALUES (
+        NEW.id,
+        'field_changed',
+        'system',
+        json_object('field', 'status', 'old', OLD.status, 'new', NEW.status),
+        'Status: ' || OLD.status || ' -> ' || NEW.status
+    );
+END;
+
+CREATE TRIGGER record_priority_change
+AFTER UPDATE OF priority ON tickets
+FOR EACH ROW
+WHEN OLD.priority != NEW.priority
+BEGIN
+    INSERT INTO activity_log (ticket_id, action, actor_type, details, summary)
+    VALUES (
+        NEW.id,
+        'field_changed',
+        'system',
+        json_object('field', 'priority', 'old', OLD.priority, 'new', NEW.priority),
+        'Priority: ' || OLD.priority || ' -> ' || NEW.priority
+    );
+END;
+
+CREATE TRIGGER record_complexity_change
+AFTER UPDATE OF complexity ON tickets
+FOR EACH ROW
+WHEN OLD.complexity != NEW.complexity
+BEGIN
+    INSERT INTO activity_log (ticket_id, action, actor_type, details, summary)
+    VALUES (
+        NEW.id,
+        'field_changed',
+        'system',
+        json_object('field', 'complexity', 'old', OLD.complexity, 'new', NEW.complexity),
+        'Complexity: ' || OLD.complexity || ' -> ' || NEW.complexity
+    );
+END;
+
+COMMIT;
+-- +goose StatementEnd
+
+PRAGMA foreign_keys = ON;
+
+-- +goose Down
+-- No-op: the wider constraint accepts every row the old one did, and
+-- narrowing it would reject existing heartbeat entries.
+SELECT 1;
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- =============================================================================
+-- Ticket Labels
+-- =============================================================================
+-- Free-form labels on tickets (e.g., "area:api", "needs-migration").
+-- A ticket has each label at most once; labels are removed with the ticket.
+-- =============================================================================
+
+CREATE TABLE ticket_labels (
+    ticket_id       INTEGER NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    label           TEXT NOT NULL,
+    created_at      DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (ticket_id, label)
+);
+
+-- Index on label for filtering tickets by label
+CREATE INDEX idx_ticket_labels_label ON ticket_labels(label);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_ticket_labels_label;
+DROP TABLE IF EXISTS ticket_labels;
+
+-- +goose StatementEnd
@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- =============================================================================
+-- Milestones
+-- =============================================================================
+-- Milestones group a project's tickets toward a goal and optional target date.
+-- Keys are unique within a project (e.g., "V1" in WEBAPP). Each ticket belongs
+-- to at most one milestone; deleting a milestone unassigns its tickets.
+-- =============================================================================
+
+CREATE TABLE milestones (
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    project_id      INTEGER NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    key             TEXT NOT NULL,             -- Unique within project (e.g., "V1")
+    name            TEXT NOT NULL,
+    goal            TEXT,
+    target_date     DATETIME,
+    status          TEXT NOT NULL DEFAULT 'open'
+                    CHECK (status IN ('open', 'achieved', 'abandoned')),
+    created_at      DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (project_id, key)
+);
+
+CREATE INDEX idx_milestones_project_id ON milestones(project_id);
+
+-- Add milestone_id column (nullable foreign key to milestones table)
+ALTER TABLE tickets ADD COLUMN milestone_id INTEGER REFERENCES milestones(id) ON DELETE SET NULL;
+
+-- Index for milestone progress and filtering
+CREATE INDEX idx_tickets_milestone_id ON tickets(milestone_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_tickets_milestone_id;
+ALTER TABLE tickets DROP COLUMN milestone_id;
+DROP INDEX IF EXISTS idx_milestones_project_id;
+DROP TABLE IF EXISTS milestones;
+
+-- +goose StatementEnd
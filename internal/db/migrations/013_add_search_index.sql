@@ -0,0 +1,134 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- =============================================================================
+-- Full-text Search Index
+-- =============================================================================
+-- search_documents holds one row per searchable text: a ticket's title and
+-- description, a comment, a completion summary, or an inbox message with its
+-- response. search_fts is an FTS5 index over it (external content), ranked
+-- with BM25. Triggers on the source tables keep both in sync, so any table
+-- rebuild of tickets, activity_log or inbox_messages must recreate them.
+-- =============================================================================
+
+CREATE TABLE search_documents (
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    ticket_id       INTEGER NOT NULL,
+    source          TEXT NOT NULL
+                    CHECK (source IN ('ticket', 'comment', 'summary', 'inbox')),
+    source_id       INTEGER NOT NULL,
+    title           TEXT NOT NULL DEFAULT '',
+    body            TEXT NOT NULL DEFAULT '',
+    UNIQUE (source, source_id)
+);
+
+CREATE INDEX idx_search_documents_ticket_id ON search_documents(ticket_id);
+
+CREATE VIRTUAL TABLE search_fts USING fts5(
+    title,
+    body,
+    content = 'search_documents',
+    content_rowid = 'id',
+    tokenize = 'porter unicode61'
+);
+
+-- Keep the FTS index in step with search_documents
+CREATE TRIGGER search_documents_insert AFTER INSERT ON search_documents
+BEGIN
+    INSERT INTO search_fts (rowid, title, body) VALUES (new.id, new.title, new.body);
+END;
+
+CREATE TRIGGER search_documents_delete AFTER DELETE ON search_documents
+BEGIN
+    INSERT INTO search_fts (search_fts, rowid, title, body) VALUES ('delete', old.id, old.title, old.body);
+END;
+
+CREATE TRIGGER search_documents_update AFTER UPDATE ON search_documents
+BEGIN
+    INSERT INTO search_fts (search_fts, rowid, title, body) VALUES ('delete', old.id, old.title, old.body);
+    INSERT INTO search_fts (rowid, title, body) VALUES (new.id, new.title, new.body);
+END;
+
+-- Tickets: title and description
+CREATE TRIGGER search_index_ticket_insert AFTER INSERT ON tickets
+BEGIN
+    INSERT INTO search_documents (ticket_id, source, source_id, title, body)
+    VALUES (new.id, 'ticket', new.id, new.title, COALESCE(new.description, ''));
+END;
+
+CREATE TRIGGER search_index_ticket_update AFTER UPDATE OF title, description ON tickets
+BEGIN
+    UPDATE search_documents SET title = new.title, body = COALESCE(new.description, '')
+    WHERE source = 'ticket' AND source_id = new.id;
+END;
+
+CREATE TRIGGER search_index_ticket_delete AFTER DELETE ON tickets
+BEGIN
+    DELETE FROM search_documents WHERE ticket_id = old.id;
+END;
+
+-- Activity log: comments and completion summaries
+CREATE TRIGGER search_index_activity_insert AFTER INSERT ON activity_log
+WHEN new.action IN ('comment', 'completed') AND COALESCE(new.summary, '') != ''
+BEGIN
+    INSERT INTO search_documents (ticket_id, source, source_id, body)
+    VALUES (new.ticket_id, CASE new.action WHEN 'comment' THEN 'comment' ELSE 'summary' END, new.id, new.summary);
+END;
+
+CREATE TRIGGER search_index_activity_delete AFTER DELETE ON activity_log
+WHEN old.action IN ('comment', 'completed')
+BEGIN
+    DELETE FROM search_documents WHERE source IN ('comment', 'summary') AND source_id = old.id;
+END;
+
+-- Inbox: message content and response
+CREATE TRIGGER search_index_inbox_insert AFTER INSERT ON inbox_messages
+BEGIN
+    INSERT INTO search_documents (ticket_id, source, source_id, body)
+    VALUES (new.ticket_id, 'inbox', new.id, new.content || COALESCE(char(10) || new.response, ''));
+END;
+
+CREATE TRIGGER search_index_inbox_update AFTER UPDATE OF content, response ON inbox_messages
+BEGIN
+    UPDATE search_documents SET body = new.content || COALESCE(char(10) || new.response, '')
+    WHERE source = 'inbox' AND source_id = new.id;
+END;
+
+CREATE TRIGGER search_index_inbox_delete AFTER DELETE ON inbox_messages
+BEGIN
+    DELETE FROM search_documents WHERE source = 'inbox' AND source_id = old.id;
+END;
+
+-- Backfill existing content
+INSERT INTO search_documents (ticket_id, source, source_id, title, body)
+SELECT id, 'ticket', id, title, COALESCE(description, '') FROM tickets;
+
+INSERT INTO search_documents (ticket_id, source, source_id, body)
+SELECT ticket_id, CASE action WHEN 'comment' THEN 'comment' ELSE 'summary' END, id, summary
+FROM activity_log
+WHERE action IN ('comment', 'completed') AND COALESCE(summary, '') != '';
+
+INSERT INTO search_documents (ticket_id, source, source_id, body)
+SELECT ticket_id, 'inbox', id, content || COALESCE(char(10) || response, '') FROM inbox_messages;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TRIGGER IF EXISTS search_index_inbox_delete;
+DROP TRIGGER IF EXISTS search_index_inbox_update;
+DROP TRIGGER IF EXISTS search_index_inbox_insert;
+DROP TRIGGER IF EXISTS search_index_activity_delete;
+DROP TRIGGER IF EXISTS search_index_activity_insert;
+DROP TRIGGER IF EXISTS search_index_ticket_delete;
+DROP TRIGGER IF EXISTS search_index_ticket_update;
+DROP TRIGGER IF EXISTS search_index_ticket_insert;
+DROP TRIGGER IF EXISTS search_documents_update;
+DROP TRIGGER IF EXISTS search_documents_delete;
+DROP TRIGGER IF EXISTS search_documents_insert;
+DROP TABLE IF EXISTS search_fts;
+DROP INDEX IF EXISTS idx_search_documents_ticket_id;
+DROP TABLE IF EXISTS search_documents;
+
+-- +goose StatementEnd
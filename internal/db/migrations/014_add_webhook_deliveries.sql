@@ -0,0 +1,50 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- =============================================================================
+-- Webhook Deliveries
+-- =============================================================================
+-- Webhooks are configured in config.toml. The dispatcher reads new activity
+-- after webhook_cursor, queues one delivery per matching webhook and event,
+-- and retries failed deliveries with backoff until max attempts.
+-- =============================================================================
+
+CREATE TABLE webhook_deliveries (
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    webhook         TEXT NOT NULL,             -- Webhook name from config
+    event_id        INTEGER NOT NULL,          -- Activity log ID the event came from
+    event_type      TEXT NOT NULL,
+    ticket_key      TEXT,
+    payload         TEXT NOT NULL,             -- JSON body, signed when sent
+    status          TEXT NOT NULL DEFAULT 'pending'
+                    CHECK (status IN ('pending', 'delivered', 'failed')),
+    attempts        INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at DATETIME NOT NULL,
+    last_attempt_at DATETIME,
+    response_code   INTEGER,
+    last_error      TEXT,
+    created_at      DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    delivered_at    DATETIME,
+    UNIQUE (webhook, event_id)
+);
+
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries(status, next_attempt_at);
+CREATE INDEX idx_webhook_deliveries_webhook ON webhook_deliveries(webhook, created_at);
+
+-- Single row holding the last activity log ID the dispatcher has queued
+CREATE TABLE webhook_cursor (
+    id              INTEGER PRIMARY KEY CHECK (id = 1),
+    last_event_id   INTEGER NOT NULL
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS webhook_cursor;
+DROP INDEX IF EXISTS idx_webhook_deliveries_webhook;
+DROP INDEX IF EXISTS idx_webhook_deliveries_due;
+DROP TABLE IF EXISTS webhook_deliveries;
+
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- =============================================================================
+-- Work-in-Progress Limits
+-- =============================================================================
+-- Caps on how many of a project's tickets may be in progress at once.
+-- wip_limit counts working and reviewing tickets together; the per-status
+-- limits cap each status on its own. 0 means no limit.
+-- =============================================================================
+
+ALTER TABLE projects ADD COLUMN wip_limit INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE projects ADD COLUMN wip_working_limit INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE projects ADD COLUMN wip_reviewing_limit INTEGER NOT NULL DEFAULT 0;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE projects DROP COLUMN wip_reviewing_limit;
+ALTER TABLE projects DROP COLUMN wip_working_limit;
+ALTER TABLE projects DROP COLUMN wip_limit;
+
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- =============================================================================
+-- Scheduled and Deferred Tickets
+-- =============================================================================
+-- not_before hides a ready ticket from workable lists until that time.
+-- due_at marks when a ticket should be done; open tickets past it are overdue
+-- and rank one priority level higher.
+-- =============================================================================
+
+ALTER TABLE tickets ADD COLUMN not_before DATETIME;
+ALTER TABLE tickets ADD COLUMN due_at DATETIME;
+
+CREATE INDEX idx_tickets_due_at ON tickets(due_at) WHERE due_at IS NOT NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_tickets_due_at;
+ALTER TABLE tickets DROP COLUMN due_at;
+ALTER TABLE tickets DROP COLUMN not_before;
+
+-- +goose StatementEnd
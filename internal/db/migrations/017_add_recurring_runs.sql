@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- =============================================================================
+-- Recurring Ticket Runs
+-- =============================================================================
+-- Recurring tickets are configured in config.toml. One row per template
+-- records when it last ran, so 'wark schedule run' creates at most one ticket
+-- per due interval no matter how often, or how late, it runs.
+-- =============================================================================
+
+CREATE TABLE recurring_runs (
+    name            TEXT PRIMARY KEY,          -- Template name from config
+    last_run_at     DATETIME NOT NULL,
+    last_ticket_id  INTEGER REFERENCES tickets(id) ON DELETE SET NULL
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS recurring_runs;
+
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- =============================================================================
+-- Ticket Templates
+-- =============================================================================
+-- Named templates that pre-fill new tickets. The description is a Go
+-- text/template rendered with variables given at creation; tasks is a JSON
+-- array of task descriptions added to each ticket. Empty priority, complexity
+-- and role leave the ticket's defaults alone.
+-- =============================================================================
+
+CREATE TABLE ticket_templates (
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    name            TEXT NOT NULL UNIQUE,      -- e.g., "bug", "spike"
+    description     TEXT,                      -- text/template source
+    priority        TEXT CHECK (priority IN ('highest', 'high', 'medium', 'low', 'lowest')),
+    complexity      TEXT CHECK (complexity IN ('trivial', 'small', 'medium', 'large', 'xlarge')),
+    role_id         INTEGER REFERENCES roles(id) ON DELETE SET NULL,
+    tasks           TEXT NOT NULL DEFAULT '[]',
+    created_at      DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS ticket_templates;
+
+-- +goose StatementEnd
@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- =============================================================================
+-- Inbox Replies
+-- =============================================================================
+-- The ordered thread of replies under an inbox message, from agents and
+-- humans. A message stays pending until a human resolves the thread, which
+-- sets its response and responded_at; an agent reply to a resolved thread
+-- reopens it.
+-- =============================================================================
+
+CREATE TABLE inbox_replies (
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    message_id      INTEGER NOT NULL REFERENCES inbox_messages(id) ON DELETE CASCADE,
+    author_type     TEXT NOT NULL CHECK (author_type IN ('human', 'agent')),
+    author          TEXT,                      -- Worker ID for agents
+    content         TEXT NOT NULL,
+    created_at      DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_inbox_replies_message ON inbox_replies(message_id, id);
+
+-- Existing responses become the first reply of their thread
+INSERT INTO inbox_replies (message_id, author_type, content, created_at)
+SELECT id, 'human', response, responded_at
+FROM inbox_messages
+WHERE response IS NOT NULL AND responded_at IS NOT NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_inbox_replies_message;
+DROP TABLE IF EXISTS inbox_replies;
+
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- =============================================================================
+-- Decision Options
+-- =============================================================================
+-- Decision messages can offer predefined options, stored as a JSON array of
+-- {"key", "label"} objects. choice is the key of the option the human chose
+-- when resolving the message.
+-- =============================================================================
+
+ALTER TABLE inbox_messages ADD COLUMN options TEXT;
+ALTER TABLE inbox_messages ADD COLUMN choice TEXT;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE inbox_messages DROP COLUMN choice;
+ALTER TABLE inbox_messages DROP COLUMN options;
+
+-- +goose StatementEnd
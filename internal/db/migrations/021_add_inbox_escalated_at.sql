@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- =============================================================================
+-- Inbox SLA Escalation
+-- =============================================================================
+-- escalated_at records when a pending message passed its response SLA and
+-- was escalated by 'wark inbox escalate', so it is only escalated once.
+-- =============================================================================
+
+ALTER TABLE inbox_messages ADD COLUMN escalated_at DATETIME;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE inbox_messages DROP COLUMN escalated_at;
+
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- =============================================================================
+-- Inbox SLA Restarts
+-- =============================================================================
+-- waiting_since records when a message last started waiting on a human
+-- again: when an agent replied to it or reopened it. A message's response
+-- SLA runs from waiting_since, or from created_at when it is unset.
+-- =============================================================================
+
+ALTER TABLE inbox_messages ADD COLUMN waiting_since DATETIME;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE inbox_messages DROP COLUMN waiting_since;
+
+-- +goose StatementEnd
@@ -0,0 +1,208 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/spetersoncode/wark/internal/models"
+)
+
+// MilestoneRepo provides database operations for milestones.
+type MilestoneRepo struct {
+	db DBTX
+}
+
+// NewMilestoneRepo creates a new MilestoneRepo.
+func NewMilestoneRepo(db DBTX) *MilestoneRepo {
+	return &MilestoneRepo{db: db}
+}
+
+// milestoneColumns selects a milestone with its project key and ticket
+// counts. The milestones table must be aliased as "m".
+const milestoneColumns = `
+	m.id, m.project_id, m.key, m.name, m.goal, m.target_date, m.status,
+	m.created_at, m.updated_at,
+	p.key AS project_key,
+	(SELECT COUNT(*) FROM tickets t WHERE t.milestone_id = m.id) AS ticket_count,
+	(SELECT COUNT(*) FROM tickets t WHERE t.milestone_id = m.id AND t.status = 'closed') AS closed_count
+`
+
+// Create creates a new milestone.
+func (r *MilestoneRepo) Create(m *models.Milestone) error {
+	if m.Status == "" {
+		m.Status = models.MilestoneStatusOpen
+	}
+	if err := m.Validate(); err != nil {
+		return fmt.Errorf("invalid milestone: %w", err)
+	}
+
+	query := `
+		INSERT INTO milestones (project_id, key, name, goal, target_date, status, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	now := time.Now()
+	nowStr := FormatTime(now)
+	result, err := r.db.Exec(query,
+		m.ProjectID, m.Key, m.Name, nullString(m.Goal), FormatTimePtr(m.TargetDate), m.Status, nowStr, nowStr,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create milestone: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get milestone id: %w", err)
+	}
+
+	m.ID = id
+	m.CreatedAt = now
+	m.UpdatedAt = now
+	return nil
+}
+
+// GetByID retrieves a milestone by ID.
+func (r *MilestoneRepo) GetByID(id int64) (*models.Milestone, error) {
+	query := `SELECT ` + milestoneColumns + `
+		FROM milestones m
+		JOIN projects p ON m.project_id = p.id
+		WHERE m.id = ?
+	`
+	return r.scanOne(r.db.QueryRow(query, id))
+}
+
+// GetByKey retrieves a milestone by project key and milestone key.
+func (r *MilestoneRepo) GetByKey(projectKey, key string) (*models.Milestone, error) {
+	query := `SELECT ` + milestoneColumns + `
+		FROM milestones m
+		JOIN projects p ON m.project_id = p.id
+		WHERE p.key = ? AND m.key = ?
+	`
+	return r.scanOne(r.db.QueryRow(query, projectKey, key))
+}
+
+// List retrieves milestones, optionally limited to one project. Milestones are
+// ordered by project, then target date (undated last), then key.
+func (r *MilestoneRepo) List(projectID *int64) ([]*models.Milestone, error) {
+	query := `SELECT ` + milestoneColumns + `
+		FROM milestones m
+		JOIN projects p ON m.project_id = p.id
+		WHERE 1=1
+	`
+	args := []interface{}{}
+	if projectID != nil {
+		query += " AND m.project_id = ?"
+		args = append(args, *projectID)
+	}
+	query += " ORDER BY p.key, m.target_date IS NULL, m.target_date, m.key"
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list milestones: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanMany(rows)
+}
+
+// Update updates a milestone's name, goal, target date, and status.
+func (r *MilestoneRepo) Update(m *models.Milestone) error {
+	if m.ID <= 0 {
+		return fmt.Errorf("milestone id is required")
+	}
+	if err := m.Validate(); err != nil {
+		return fmt.Errorf("invalid milestone: %w", err)
+	}
+
+	now := time.Now()
+	query := `
+		UPDATE milestones SET name = ?, goal = ?, target_date = ?, status = ?, updated_at = ?
+		WHERE id = ?
+	`
+	result, err := r.db.Exec(query,
+		m.Name, nullString(m.Goal), FormatTimePtr(m.TargetDate), m.Status, FormatTime(now), m.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update milestone: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("milestone not found")
+	}
+
+	m.UpdatedAt = now
+	return nil
+}
+
+// Delete deletes a milestone. Its tickets are unassigned, not deleted.
+func (r *MilestoneRepo) Delete(id int64) error {
+	result, err := r.db.Exec("DELETE FROM milestones WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete milestone: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("milestone not found")
+	}
+	return nil
+}
+
+func (r *MilestoneRepo) scanOne(row *sql.Row) (*models.Milestone, error) {
+	var m models.Milestone
+	var goal sql.NullString
+	var targetDate sql.NullTime
+
+	err := row.Scan(
+		&m.ID, &m.ProjectID, &m.Key, &m.Name, &goal, &targetDate, &m.Status,
+		&m.CreatedAt, &m.UpdatedAt,
+		&m.ProjectKey, &m.TicketCount, &m.ClosedCount,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan milestone: %w", err)
+	}
+
+	m.Goal = goal.String
+	if targetDate.Valid {
+		m.TargetDate = &targetDate.Time
+	}
+	return &m, nil
+}
+
+func (r *MilestoneRepo) scanMany(rows *sql.Rows) ([]*models.Milestone, error) {
+	var milestones []*models.Milestone
+	for rows.Next() {
+		var m models.Milestone
+		var goal sql.NullString
+		var targetDate sql.NullTime
+
+		err := rows.Scan(
+			&m.ID, &m.ProjectID, &m.Key, &m.Name, &goal, &targetDate, &m.Status,
+			&m.CreatedAt, &m.UpdatedAt,
+			&m.ProjectKey, &m.TicketCount, &m.ClosedCount,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan milestone: %w", err)
+		}
+
+		m.Goal = goal.String
+		if targetDate.Valid {
+			m.TargetDate = &targetDate.Time
+		}
+		milestones = append(milestones, &m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating milestones: %w", err)
+	}
+	return milestones, nil
+}
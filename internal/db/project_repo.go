@@ -10,11 +10,11 @@ import (
 
 // ProjectRepo provides database operations for projects.
 type ProjectRepo struct {
-	db *sql.DB
+	db DBTX
 }
 
 // NewProjectRepo creates a new ProjectRepo.
-func NewProjectRepo(db *sql.DB) *ProjectRepo {
+func NewProjectRepo(db DBTX) *ProjectRepo {
 	return &ProjectRepo{db: db}
 }
 
@@ -25,12 +25,13 @@ func (r *ProjectRepo) Create(p *models.Project) error {
 	}
 
 	query := `
-		INSERT INTO projects (key, name, description, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?)
+		INSERT INTO projects (key, name, description, wip_limit, wip_working_limit, wip_reviewing_limit, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 	`
 	now := time.Now()
 	nowStr := FormatTime(now)
-	result, err := r.db.Exec(query, p.Key, p.Name, p.Description, nowStr, nowStr)
+	result, err := r.db.Exec(query, p.Key, p.Name, p.Description,
+		p.WIPLimits.Total, p.WIPLimits.Working, p.WIPLimits.Reviewing, nowStr, nowStr)
 	if err != nil {
 		return fmt.Errorf("failed to create project: %w", err)
 	}
@@ -46,21 +47,24 @@ func (r *ProjectRepo) Create(p *models.Project) error {
 	return nil
 }
 
+// projectColumns selects a project in the order scanOne and scanMany read it.
+const projectColumns = `id, key, name, description, wip_limit, wip_working_limit, wip_reviewing_limit, created_at, updated_at`
+
 // GetByID retrieves a project by ID.
 func (r *ProjectRepo) GetByID(id int64) (*models.Project, error) {
-	query := `SELECT id, key, name, description, created_at, updated_at FROM projects WHERE id = ?`
+	query := `SELECT ` + projectColumns + ` FROM projects WHERE id = ?`
 	return r.scanOne(r.db.QueryRow(query, id))
 }
 
 // GetByKey retrieves a project by its key.
 func (r *ProjectRepo) GetByKey(key string) (*models.Project, error) {
-	query := `SELECT id, key, name, description, created_at, updated_at FROM projects WHERE key = ?`
+	query := `SELECT ` + projectColumns + ` FROM projects WHERE key = ?`
 	return r.scanOne(r.db.QueryRow(query, key))
 }
 
 // List retrieves all projects.
 func (r *ProjectRepo) List() ([]*models.Project, error) {
-	query := `SELECT id, key, name, description, created_at, updated_at FROM projects ORDER BY key`
+	query := `SELECT ` + projectColumns + ` FROM projects ORDER BY key`
 	rows, err := r.db.Query(query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list projects: %w", err)
@@ -78,9 +82,16 @@ func (r *ProjectRepo) Update(p *models.Project) error {
 	if p.Name == "" {
 		return fmt.Errorf("project name cannot be empty")
 	}
+	if err := p.WIPLimits.Validate(); err != nil {
+		return err
+	}
 
-	query := `UPDATE projects SET name = ?, description = ? WHERE id = ?`
-	result, err := r.db.Exec(query, p.Name, p.Description, p.ID)
+	query := `
+		UPDATE projects SET name = ?, description = ?, wip_limit = ?, wip_working_limit = ?, wip_reviewing_limit = ?
+		WHERE id = ?
+	`
+	result, err := r.db.Exec(query, p.Name, p.Description,
+		p.WIPLimits.Total, p.WIPLimits.Working, p.WIPLimits.Reviewing, p.ID)
 	if err != nil {
 		return fmt.Errorf("failed to update project: %w", err)
 	}
@@ -144,9 +155,38 @@ func (r *ProjectRepo) GetStats(projectID int64) (*models.ProjectStats, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to get project stats: %w", err)
 	}
+
+	stats.WIP, err = r.GetWIPUsage(projectID)
+	if err != nil {
+		return nil, err
+	}
 	return &stats, nil
 }
 
+// GetWIPUsage returns a project's working and reviewing ticket counts with
+// its WIP limits, or nil if the project doesn't exist.
+func (r *ProjectRepo) GetWIPUsage(projectID int64) (*models.WIPUsage, error) {
+	query := `
+		SELECT
+			p.wip_limit, p.wip_working_limit, p.wip_reviewing_limit,
+			(SELECT COUNT(*) FROM tickets WHERE project_id = p.id AND status = 'working'),
+			(SELECT COUNT(*) FROM tickets WHERE project_id = p.id AND status = 'reviewing')
+		FROM projects p
+		WHERE p.id = ?
+	`
+	var u models.WIPUsage
+	err := r.db.QueryRow(query, projectID).Scan(
+		&u.Limits.Total, &u.Limits.Working, &u.Limits.Reviewing, &u.Working, &u.Reviewing,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get WIP usage: %w", err)
+	}
+	return &u, nil
+}
+
 // Exists checks if a project with the given key exists.
 func (r *ProjectRepo) Exists(key string) (bool, error) {
 	query := `SELECT 1 FROM projects WHERE key = ? LIMIT 1`
@@ -164,7 +204,8 @@ func (r *ProjectRepo) Exists(key string) (bool, error) {
 func (r *ProjectRepo) scanOne(row *sql.Row) (*models.Project, error) {
 	var p models.Project
 	var desc sql.NullString
-	err := row.Scan(&p.ID, &p.Key, &p.Name, &desc, &p.CreatedAt, &p.UpdatedAt)
+	err := row.Scan(&p.ID, &p.Key, &p.Name, &desc,
+		&p.WIPLimits.Total, &p.WIPLimits.Working, &p.WIPLimits.Reviewing, &p.CreatedAt, &p.UpdatedAt)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -180,7 +221,8 @@ func (r *ProjectRepo) scanMany(rows *sql.Rows) ([]*models.Project, error) {
 	for rows.Next() {
 		var p models.Project
 		var desc sql.NullString
-		err := rows.Scan(&p.ID, &p.Key, &p.Name, &desc, &p.CreatedAt, &p.UpdatedAt)
+		err := rows.Scan(&p.ID, &p.Key, &p.Name, &desc,
+			&p.WIPLimits.Total, &p.WIPLimits.Working, &p.WIPLimits.Reviewing, &p.CreatedAt, &p.UpdatedAt)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan project: %w", err)
 		}
@@ -0,0 +1,77 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/spetersoncode/wark/internal/models"
+)
+
+// RecurringRepo provides database operations for recurring ticket runs.
+type RecurringRepo struct {
+	db DBTX
+}
+
+// NewRecurringRepo creates a new RecurringRepo.
+func NewRecurringRepo(db DBTX) *RecurringRepo {
+	return &RecurringRepo{db: db}
+}
+
+// Get retrieves a template's last run, or nil if it has never run.
+func (r *RecurringRepo) Get(name string) (*models.RecurringRun, error) {
+	var run models.RecurringRun
+	var ticketID sql.NullInt64
+	var ticketKey sql.NullString
+	err := r.db.QueryRow(`
+		SELECT r.name, r.last_run_at, r.last_ticket_id, p.key || '-' || t.number
+		FROM recurring_runs r
+		LEFT JOIN tickets t ON t.id = r.last_ticket_id
+		LEFT JOIN projects p ON p.id = t.project_id
+		WHERE r.name = ?
+	`, name).Scan(&run.Name, &run.LastRunAt, &ticketID, &ticketKey)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recurring run: %w", err)
+	}
+	if ticketID.Valid {
+		run.LastTicketID = &ticketID.Int64
+	}
+	run.LastTicketKey = ticketKey.String
+	return &run, nil
+}
+
+// Start records a template's first run at the given time. It returns false
+// if the template has already run.
+func (r *RecurringRepo) Start(name string, at time.Time) (bool, error) {
+	result, err := r.db.Exec(`INSERT OR IGNORE INTO recurring_runs (name, last_run_at) VALUES (?, ?)`,
+		name, FormatTime(at))
+	if err != nil {
+		return false, fmt.Errorf("failed to start recurring run: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// Advance moves a template's last run from prev to at, recording the ticket
+// it created. It returns false if the last run is no longer prev, meaning
+// another process ran the template first.
+func (r *RecurringRepo) Advance(name string, prev, at time.Time, ticketID int64) (bool, error) {
+	result, err := r.db.Exec(`
+		UPDATE recurring_runs SET last_run_at = ?, last_ticket_id = ?
+		WHERE name = ? AND last_run_at = ?
+	`, FormatTime(at), ticketID, name, FormatTime(prev))
+	if err != nil {
+		return false, fmt.Errorf("failed to advance recurring run: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rows > 0, nil
+}
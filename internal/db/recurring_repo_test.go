@@ -0,0 +1,50 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spetersoncode/wark/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecurringRepo(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	projectID := createTestProject(t, db)
+	ticketRepo := NewTicketRepo(db)
+	repo := NewRecurringRepo(db)
+
+	run, err := repo.Get("audit")
+	require.NoError(t, err)
+	assert.Nil(t, run, "a template that never ran has no run")
+
+	start := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	started, err := repo.Start("audit", start)
+	require.NoError(t, err)
+	assert.True(t, started)
+	started, err = repo.Start("audit", start.Add(time.Hour))
+	require.NoError(t, err)
+	assert.False(t, started, "a second start is ignored")
+
+	ticket := &models.Ticket{ProjectID: projectID, Title: "Audit", Status: models.StatusBacklog}
+	require.NoError(t, ticketRepo.Create(ticket))
+
+	next := start.Add(7 * 24 * time.Hour)
+	advanced, err := repo.Advance("audit", start, next, ticket.ID)
+	require.NoError(t, err)
+	assert.True(t, advanced)
+	advanced, err = repo.Advance("audit", start, next.Add(time.Minute), ticket.ID)
+	require.NoError(t, err)
+	assert.False(t, advanced, "advancing from a stale last run is refused")
+
+	run, err = repo.Get("audit")
+	require.NoError(t, err)
+	require.NotNil(t, run)
+	assert.True(t, next.Equal(run.LastRunAt))
+	require.NotNil(t, run.LastTicketID)
+	assert.Equal(t, ticket.ID, *run.LastTicketID)
+	assert.Equal(t, "TEST-1", run.LastTicketKey)
+}
@@ -10,11 +10,11 @@ import (
 
 // RoleRepo provides database operations for roles.
 type RoleRepo struct {
-	db *sql.DB
+	db DBTX
 }
 
 // NewRoleRepo creates a new RoleRepo.
-func NewRoleRepo(db *sql.DB) *RoleRepo {
+func NewRoleRepo(db DBTX) *RoleRepo {
 	return &RoleRepo{db: db}
 }
 
@@ -0,0 +1,174 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/spetersoncode/wark/internal/common"
+	"github.com/spetersoncode/wark/internal/models"
+)
+
+// Search sources identify which text a search result matched.
+const (
+	SearchSourceKey     = "key"     // The query was the ticket's key
+	SearchSourceTicket  = "ticket"  // Ticket title or description
+	SearchSourceComment = "comment" // A comment on the ticket
+	SearchSourceSummary = "summary" // A completion summary
+	SearchSourceInbox   = "inbox"   // An inbox message or its response
+)
+
+// SearchRepo provides full-text search over tickets, comments, completion
+// summaries and inbox messages, backed by the search_fts FTS5 index.
+type SearchRepo struct {
+	db DBTX
+}
+
+// NewSearchRepo creates a new SearchRepo.
+func NewSearchRepo(db DBTX) *SearchRepo {
+	return &SearchRepo{db: db}
+}
+
+// SearchOptions controls a full-text search.
+type SearchOptions struct {
+	ProjectKey string
+	Limit      int
+
+	// HighlightOpen and HighlightClose wrap matched terms in snippets.
+	HighlightOpen  string
+	HighlightClose string
+}
+
+// SearchResult is a ticket matching a search, with its best-matching text.
+type SearchResult struct {
+	Ticket  *models.Ticket `json:"ticket"`
+	Source  string         `json:"source"`
+	Snippet string         `json:"snippet"`
+	Score   float64        `json:"score"`
+}
+
+// Search finds tickets whose title, description, comments, completion
+// summaries or inbox messages match query. Each ticket appears once, with
+// the snippet of its best match; results are ordered by BM25 relevance
+// (title matches weigh more than body text). A query that is exactly a
+// ticket key puts that ticket first.
+func (r *SearchRepo) Search(query string, opts SearchOptions) ([]*SearchResult, error) {
+	results := []*SearchResult{}
+	if strings.TrimSpace(query) == "" {
+		return results, nil
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = 20
+	}
+
+	ticketRepo := NewTicketRepo(r.db)
+
+	var keyTicketID int64
+	if projectKey, number, err := common.ParseTicketKey(query); err == nil && projectKey != "" {
+		if opts.ProjectKey == "" || opts.ProjectKey == projectKey {
+			ticket, err := ticketRepo.GetByKey(projectKey, number)
+			if err != nil {
+				return nil, err
+			}
+			if ticket != nil {
+				keyTicketID = ticket.ID
+				results = append(results, &SearchResult{Ticket: ticket, Source: SearchSourceKey, Snippet: ticket.Title})
+			}
+		}
+	}
+
+	match := ftsQuery(query)
+	if match == "" {
+		return results, nil
+	}
+
+	sqlQuery := `
+		WITH hits AS (
+			SELECT d.ticket_id, d.source,
+				snippet(search_fts, -1, ?, ?, '…', 16) AS snippet,
+				bm25(search_fts, 10.0, 1.0) AS rank
+			FROM search_fts
+			JOIN search_documents d ON d.id = search_fts.rowid
+			WHERE search_fts MATCH ?
+		),
+		best AS (
+			SELECT ticket_id, source, snippet, rank,
+				ROW_NUMBER() OVER (PARTITION BY ticket_id ORDER BY rank) AS n
+			FROM hits
+		)
+		SELECT b.ticket_id, b.source, b.snippet, b.rank
+		FROM best b
+		JOIN tickets t ON t.id = b.ticket_id
+		JOIN projects p ON p.id = t.project_id
+		WHERE b.n = 1 AND b.ticket_id != ?
+	`
+	args := []interface{}{opts.HighlightOpen, opts.HighlightClose, match, keyTicketID}
+	if opts.ProjectKey != "" {
+		sqlQuery += " AND p.key = ?"
+		args = append(args, opts.ProjectKey)
+	}
+	sqlQuery += " ORDER BY b.rank LIMIT ?"
+	args = append(args, opts.Limit-len(results))
+
+	rows, err := r.db.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search: %w", err)
+	}
+
+	type hit struct {
+		ticketID int64
+		result   *SearchResult
+	}
+	var hits []hit
+	for rows.Next() {
+		var h hit
+		var rank float64
+		h.result = &SearchResult{}
+		if err := rows.Scan(&h.ticketID, &h.result.Source, &h.result.Snippet, &rank); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan search result: %w", err)
+		}
+		// bm25() is negative, lower is better; report a positive score.
+		h.result.Score = -rank
+		hits = append(hits, h)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, fmt.Errorf("error iterating search results: %w", err)
+	}
+	rows.Close()
+
+	for _, h := range hits {
+		ticket, err := ticketRepo.GetByID(h.ticketID)
+		if err != nil {
+			return nil, err
+		}
+		if ticket == nil {
+			continue
+		}
+		h.result.Ticket = ticket
+		results = append(results, h.result)
+	}
+
+	return results, nil
+}
+
+// ftsQuery turns free text into an FTS5 query: every term must match, terms
+// are quoted so punctuation is taken literally, and the last term matches
+// as a prefix so partially typed words still find results.
+func ftsQuery(query string) string {
+	terms := strings.Fields(query)
+	quoted := make([]string, 0, len(terms))
+	for _, term := range terms {
+		if strings.IndexFunc(term, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) < 0 {
+			continue // The tokenizer drops pure punctuation
+		}
+		term = strings.ReplaceAll(term, `"`, `""`)
+		quoted = append(quoted, `"`+term+`"`)
+	}
+	if len(quoted) == 0 {
+		return ""
+	}
+	quoted[len(quoted)-1] += "*"
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,114 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/spetersoncode/wark/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchRepo(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	projectID := createTestProject(t, db)
+	ticketRepo := NewTicketRepo(db)
+	activityRepo := NewActivityRepo(db)
+	inboxRepo := NewInboxRepo(db)
+	searchRepo := NewSearchRepo(db)
+
+	login := &models.Ticket{ProjectID: projectID, Title: "Add login page", Description: "Email and password form", Status: models.StatusReady}
+	require.NoError(t, ticketRepo.Create(login))
+	oauth := &models.Ticket{ProjectID: projectID, Title: "OAuth callbacks", Description: "Handle the login redirect", Status: models.StatusReady}
+	require.NoError(t, ticketRepo.Create(oauth))
+	cache := &models.Ticket{ProjectID: projectID, Title: "Cache headers", Status: models.StatusReady}
+	require.NoError(t, ticketRepo.Create(cache))
+
+	search := func(query string) []*SearchResult {
+		t.Helper()
+		results, err := searchRepo.Search(query, SearchOptions{HighlightOpen: "[", HighlightClose: "]"})
+		require.NoError(t, err)
+		return results
+	}
+	keys := func(results []*SearchResult) []string {
+		var keys []string
+		for _, r := range results {
+			keys = append(keys, r.Ticket.TicketKey)
+		}
+		return keys
+	}
+
+	t.Run("title matches rank above body matches", func(t *testing.T) {
+		results := search("login")
+		assert.Equal(t, []string{"TEST-1", "TEST-2"}, keys(results))
+		assert.Equal(t, SearchSourceTicket, results[0].Source)
+		assert.Contains(t, results[0].Snippet, "[login]")
+		assert.Greater(t, results[0].Score, results[1].Score)
+	})
+
+	t.Run("stems and prefixes", func(t *testing.T) {
+		assert.Equal(t, []string{"TEST-2"}, keys(search("callback")))
+		assert.Equal(t, []string{"TEST-3"}, keys(search("cach")))
+	})
+
+	t.Run("punctuation is taken literally", func(t *testing.T) {
+		assert.Empty(t, search(`"unbalanced AND (`))
+		assert.Empty(t, search("-"))
+	})
+
+	t.Run("ticket key comes first", func(t *testing.T) {
+		results := search("TEST-3")
+		require.NotEmpty(t, results)
+		assert.Equal(t, "TEST-3", results[0].Ticket.TicketKey)
+		assert.Equal(t, SearchSourceKey, results[0].Source)
+	})
+
+	t.Run("indexes comments and completion summaries", func(t *testing.T) {
+		require.NoError(t, activityRepo.LogAction(cache.ID, models.ActionComment, models.ActorTypeHuman, "", "Check the CDN vary header"))
+		require.NoError(t, activityRepo.LogAction(login.ID, models.ActionCompleted, models.ActorTypeAgent, "agent-1", "Added zxcvbn strength meter"))
+
+		results := search("cdn")
+		require.Len(t, results, 1)
+		assert.Equal(t, SearchSourceComment, results[0].Source)
+		assert.Equal(t, "TEST-3", results[0].Ticket.TicketKey)
+
+		results = search("zxcvbn")
+		require.Len(t, results, 1)
+		assert.Equal(t, SearchSourceSummary, results[0].Source)
+	})
+
+	t.Run("indexes inbox messages and responses", func(t *testing.T) {
+		msg := &models.InboxMessage{TicketID: oauth.ID, MessageType: models.MessageTypeQuestion, Content: "Which provider first?"}
+		require.NoError(t, inboxRepo.Create(msg))
+		assert.Equal(t, []string{"TEST-2"}, keys(search("provider")))
+
+		require.NoError(t, inboxRepo.Respond(msg.ID, "GitHub, then Google"))
+		results := search("github")
+		require.Len(t, results, 1)
+		assert.Equal(t, SearchSourceInbox, results[0].Source)
+	})
+
+	t.Run("follows edits and deletes", func(t *testing.T) {
+		cache.Title = "Tune caching proxy"
+		require.NoError(t, ticketRepo.Update(cache))
+		assert.Equal(t, []string{"TEST-3"}, keys(search("proxy")))
+		results := search("headers")
+		require.Len(t, results, 1)
+		assert.Equal(t, SearchSourceComment, results[0].Source, "old title should no longer match")
+
+		require.NoError(t, ticketRepo.Delete(cache.ID))
+		assert.Empty(t, search("proxy"))
+		assert.Empty(t, search("cdn"))
+	})
+
+	t.Run("filters by project and limit", func(t *testing.T) {
+		results, err := searchRepo.Search("login", SearchOptions{ProjectKey: "OTHER"})
+		require.NoError(t, err)
+		assert.Empty(t, results)
+
+		results, err = searchRepo.Search("login", SearchOptions{Limit: 1})
+		require.NoError(t, err)
+		assert.Len(t, results, 1)
+	})
+}
@@ -12,11 +12,11 @@ import (
 
 // TasksRepo provides database operations for ticket tasks.
 type TasksRepo struct {
-	db *sql.DB
+	db DBTX
 }
 
 // NewTasksRepo creates a new TasksRepo.
-func NewTasksRepo(db *sql.DB) *TasksRepo {
+func NewTasksRepo(db DBTX) *TasksRepo {
 	return &TasksRepo{db: db}
 }
 
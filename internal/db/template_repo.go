@@ -0,0 +1,117 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/spetersoncode/wark/internal/models"
+)
+
+// TemplateRepo provides database operations for ticket templates.
+type TemplateRepo struct {
+	db DBTX
+}
+
+// NewTemplateRepo creates a new TemplateRepo.
+func NewTemplateRepo(db DBTX) *TemplateRepo {
+	return &TemplateRepo{db: db}
+}
+
+const templateColumns = `tt.id, tt.name, tt.description, tt.priority, tt.complexity, tt.role_id, r.name,
+	tt.tasks, tt.created_at, tt.updated_at`
+
+const templateFrom = ` FROM ticket_templates tt LEFT JOIN roles r ON r.id = tt.role_id`
+
+// Create creates a new ticket template.
+func (r *TemplateRepo) Create(t *models.TicketTemplate) error {
+	if err := t.Validate(); err != nil {
+		return fmt.Errorf("invalid template: %w", err)
+	}
+	if t.Tasks == nil {
+		t.Tasks = []string{}
+	}
+	tasks, err := json.Marshal(t.Tasks)
+	if err != nil {
+		return fmt.Errorf("failed to encode template tasks: %w", err)
+	}
+
+	now := time.Now()
+	nowStr := FormatTime(now)
+	result, err := r.db.Exec(`
+		INSERT INTO ticket_templates (name, description, priority, complexity, role_id, tasks, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, t.Name, nullString(t.Description), nullString(string(t.Priority)), nullString(string(t.Complexity)),
+		t.RoleID, string(tasks), nowStr, nowStr)
+	if err != nil {
+		return fmt.Errorf("failed to create template: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get template id: %w", err)
+	}
+	t.ID = id
+	t.CreatedAt = now
+	t.UpdatedAt = now
+	return nil
+}
+
+// GetByName retrieves a template by name, or nil if there is none.
+func (r *TemplateRepo) GetByName(name string) (*models.TicketTemplate, error) {
+	rows, err := r.db.Query(`SELECT `+templateColumns+templateFrom+` WHERE tt.name = ?`, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template: %w", err)
+	}
+	defer rows.Close()
+
+	templates, err := r.scanMany(rows)
+	if err != nil || len(templates) == 0 {
+		return nil, err
+	}
+	return templates[0], nil
+}
+
+// List retrieves all templates ordered by name.
+func (r *TemplateRepo) List() ([]*models.TicketTemplate, error) {
+	rows, err := r.db.Query(`SELECT ` + templateColumns + templateFrom + ` ORDER BY tt.name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanMany(rows)
+}
+
+func (r *TemplateRepo) scanMany(rows *sql.Rows) ([]*models.TicketTemplate, error) {
+	templates := []*models.TicketTemplate{}
+	for rows.Next() {
+		var t models.TicketTemplate
+		var description, priority, complexity, roleName sql.NullString
+		var roleID sql.NullInt64
+		var tasks string
+
+		err := rows.Scan(&t.ID, &t.Name, &description, &priority, &complexity, &roleID, &roleName,
+			&tasks, &t.CreatedAt, &t.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan template: %w", err)
+		}
+
+		t.Description = description.String
+		t.Priority = models.Priority(priority.String)
+		t.Complexity = models.Complexity(complexity.String)
+		if roleID.Valid {
+			t.RoleID = &roleID.Int64
+		}
+		t.RoleName = roleName.String
+		if err := json.Unmarshal([]byte(tasks), &t.Tasks); err != nil {
+			return nil, fmt.Errorf("failed to decode tasks of template %s: %w", t.Name, err)
+		}
+		templates = append(templates, &t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating templates: %w", err)
+	}
+	return templates, nil
+}
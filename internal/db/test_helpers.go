@@ -28,6 +28,10 @@ func NewTestDB(t *testing.T) *DB {
 		t.Fatalf("failed to open test database: %v", err)
 	}
 
+	// Each connection to :memory: is a separate database, so pin the pool to
+	// one connection (matching Open) to keep migrations and transactions on it.
+	sqlDB.SetMaxOpenConns(1)
+
 	// Run migrations
 	if err := Migrate(sqlDB); err != nil {
 		sqlDB.Close()
@@ -50,6 +54,10 @@ func NewTestSqlDB(t *testing.T) *sql.DB {
 		t.Fatalf("failed to open test database: %v", err)
 	}
 
+	// Each connection to :memory: is a separate database, so pin the pool to
+	// one connection (matching Open) to keep migrations and transactions on it.
+	sqlDB.SetMaxOpenConns(1)
+
 	// Run migrations
 	if err := Migrate(sqlDB); err != nil {
 		sqlDB.Close()
@@ -3,6 +3,7 @@ package db
 import (
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/spetersoncode/wark/internal/models"
@@ -10,11 +11,11 @@ import (
 
 // TicketRepo provides database operations for tickets.
 type TicketRepo struct {
-	db *sql.DB
+	db DBTX
 }
 
 // NewTicketRepo creates a new TicketRepo.
-func NewTicketRepo(db *sql.DB) *TicketRepo {
+func NewTicketRepo(db DBTX) *TicketRepo {
 	return &TicketRepo{db: db}
 }
 
@@ -25,6 +26,19 @@ type TicketFilter struct {
 	Status       *models.Status
 	Priority     *models.Priority
 	Complexity   *models.Complexity
+	// MaxComplexity limits ListWorkable to tickets no larger than this.
+	MaxComplexity *models.Complexity
+	// Capability limits ListWorkable to tickets whose complexity maps to this
+	// capability level (fast, standard, powerful).
+	Capability string
+	// RoleName limits ListWorkable to tickets assigned this role.
+	RoleName     string
+	// Labels limits List and ListWorkable to tickets carrying every label.
+	Labels       []string
+	// MilestoneID or MilestoneKey limit List to tickets in a milestone.
+	// Milestone keys are per project, so pair MilestoneKey with a project.
+	MilestoneID  *int64
+	MilestoneKey string
 	Type         *models.TicketType
 	ParentID *int64
 	Workable bool
@@ -126,7 +140,8 @@ func (r *TicketRepo) AutoReleaseExpiredClaims() (int64, error) {
 	return int64(len(expired)), nil
 }
 
-// Create creates a new ticket.
+// Create creates a new ticket. CreatedAt defaults to now; a preset value
+// (e.g., from an import) is kept.
 func (r *TicketRepo) Create(t *models.Ticket) error {
 	// Set defaults - status must be set by caller based on dependency check
 	if t.Status == "" {
@@ -152,12 +167,16 @@ func (r *TicketRepo) Create(t *models.Ticket) error {
 	query := `
 		INSERT INTO tickets (
 			project_id, number, title, description, status, resolution, human_flag_reason,
-			priority, complexity, ticket_type, worktree, role_id, retry_count, max_retries,
-			parent_ticket_id, created_at, updated_at, completed_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			priority, complexity, ticket_type, worktree, role_id, milestone_id, retry_count, max_retries,
+			parent_ticket_id, created_at, updated_at, completed_at, not_before, due_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 	now := time.Now()
 	nowStr := FormatTime(now)
+	createdAt := now
+	if !t.CreatedAt.IsZero() {
+		createdAt = t.CreatedAt
+	}
 
 	// Number will be set by trigger if 0
 	number := t.Number
@@ -173,8 +192,9 @@ func (r *TicketRepo) Create(t *models.Ticket) error {
 
 	result, err := r.db.Exec(query,
 		t.ProjectID, number, t.Title, nullString(t.Description), t.Status, nullResolution(t.Resolution), nullString(t.HumanFlagReason),
-		t.Priority, t.Complexity, t.Type, nullString(t.Worktree), nullInt64(t.RoleID), t.RetryCount, t.MaxRetries,
-		nullInt64(t.ParentTicketID), nowStr, nowStr, FormatTimePtr(t.CompletedAt),
+		t.Priority, t.Complexity, t.Type, nullString(t.Worktree), nullInt64(t.RoleID), nullInt64(t.MilestoneID), t.RetryCount, t.MaxRetries,
+		nullInt64(t.ParentTicketID), FormatTime(createdAt), nowStr, FormatTimePtr(t.CompletedAt),
+		FormatTimePtr(t.NotBefore), FormatTimePtr(t.DueAt),
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create ticket: %w", err)
@@ -187,7 +207,7 @@ func (r *TicketRepo) Create(t *models.Ticket) error {
 
 	t.ID = id
 	t.Number = number
-	t.CreatedAt = now
+	t.CreatedAt = createdAt
 	t.UpdatedAt = now
 	return nil
 }
@@ -196,14 +216,17 @@ func (r *TicketRepo) Create(t *models.Ticket) error {
 func (r *TicketRepo) GetByID(id int64) (*models.Ticket, error) {
 	query := `
 		SELECT t.id, t.project_id, t.number, t.title, t.description, t.status,
-			t.resolution, t.human_flag_reason, t.priority, t.complexity, t.ticket_type, t.worktree, t.role_id,
+			t.resolution, t.human_flag_reason, t.priority, t.complexity, t.ticket_type, t.worktree, t.role_id, t.milestone_id,
 			t.retry_count, t.max_retries, t.parent_ticket_id,
-			t.created_at, t.updated_at, t.completed_at,
+			t.created_at, t.updated_at, t.completed_at, t.not_before, t.due_at,
 			p.key AS project_key,
-			r.name AS role_name
+			r.name AS role_name,
+			ms.key AS milestone_key,
+			(SELECT group_concat(label, ',' ORDER BY label) FROM ticket_labels WHERE ticket_id = t.id) AS labels
 		FROM tickets t
 		JOIN projects p ON t.project_id = p.id
 		LEFT JOIN roles r ON t.role_id = r.id
+		LEFT JOIN milestones ms ON t.milestone_id = ms.id
 		WHERE t.id = ?
 	`
 	return r.scanOne(r.db.QueryRow(query, id))
@@ -213,14 +236,17 @@ func (r *TicketRepo) GetByID(id int64) (*models.Ticket, error) {
 func (r *TicketRepo) GetByKey(projectKey string, number int) (*models.Ticket, error) {
 	query := `
 		SELECT t.id, t.project_id, t.number, t.title, t.description, t.status,
-			t.resolution, t.human_flag_reason, t.priority, t.complexity, t.ticket_type, t.worktree, t.role_id,
+			t.resolution, t.human_flag_reason, t.priority, t.complexity, t.ticket_type, t.worktree, t.role_id, t.milestone_id,
 			t.retry_count, t.max_retries, t.parent_ticket_id,
-			t.created_at, t.updated_at, t.completed_at,
+			t.created_at, t.updated_at, t.completed_at, t.not_before, t.due_at,
 			p.key AS project_key,
-			r.name AS role_name
+			r.name AS role_name,
+			ms.key AS milestone_key,
+			(SELECT group_concat(label, ',' ORDER BY label) FROM ticket_labels WHERE ticket_id = t.id) AS labels
 		FROM tickets t
 		JOIN projects p ON t.project_id = p.id
 		LEFT JOIN roles r ON t.role_id = r.id
+		LEFT JOIN milestones ms ON t.milestone_id = ms.id
 		WHERE p.key = ? AND t.number = ?
 	`
 	return r.scanOne(r.db.QueryRow(query, projectKey, number))
@@ -238,14 +264,17 @@ func (r *TicketRepo) List(filter TicketFilter) ([]*models.Ticket, error) {
 
 	query := `
 		SELECT t.id, t.project_id, t.number, t.title, t.description, t.status,
-			t.resolution, t.human_flag_reason, t.priority, t.complexity, t.ticket_type, t.worktree, t.role_id,
+			t.resolution, t.human_flag_reason, t.priority, t.complexity, t.ticket_type, t.worktree, t.role_id, t.milestone_id,
 			t.retry_count, t.max_retries, t.parent_ticket_id,
-			t.created_at, t.updated_at, t.completed_at,
+			t.created_at, t.updated_at, t.completed_at, t.not_before, t.due_at,
 			p.key AS project_key,
-			ro.name AS role_name
+			ro.name AS role_name,
+			ms.key AS milestone_key,
+			(SELECT group_concat(label, ',' ORDER BY label) FROM ticket_labels WHERE ticket_id = t.id) AS labels
 		FROM tickets t
 		JOIN projects p ON t.project_id = p.id
 		LEFT JOIN roles ro ON t.role_id = ro.id
+		LEFT JOIN milestones ms ON t.milestone_id = ms.id
 		WHERE 1=1
 	`
 	args := []interface{}{}
@@ -278,6 +307,19 @@ func (r *TicketRepo) List(filter TicketFilter) ([]*models.Ticket, error) {
 		query += " AND t.parent_ticket_id = ?"
 		args = append(args, *filter.ParentID)
 	}
+	if len(filter.Labels) > 0 {
+		clause, clauseArgs := labelsWhere(filter.Labels, "t")
+		query += clause
+		args = append(args, clauseArgs...)
+	}
+	if filter.MilestoneID != nil {
+		query += " AND t.milestone_id = ?"
+		args = append(args, *filter.MilestoneID)
+	}
+	if filter.MilestoneKey != "" {
+		query += " AND ms.key = ?"
+		args = append(args, filter.MilestoneKey)
+	}
 
 	query += ` ORDER BY
 		CASE t.priority
@@ -310,6 +352,8 @@ func (r *TicketRepo) List(filter TicketFilter) ([]*models.Ticket, error) {
 
 // ListWorkable retrieves all workable tickets (ready status with no unresolved dependencies).
 // A dependency is only resolved if its ticket is closed with 'completed' resolution.
+// Tickets with an active claim, with no retries left or with a not_before
+// still in the future are excluded. Overdue tickets sort one priority level higher.
 // It automatically releases any expired claims before querying, which may make
 // previously claimed tickets workable again.
 // Note: Epics are excluded from workable list - work through child tickets instead.
@@ -323,14 +367,17 @@ func (r *TicketRepo) ListWorkable(filter TicketFilter) ([]*models.Ticket, error)
 
 	query := `
 		SELECT t.id, t.project_id, t.number, t.title, t.description, t.status,
-			t.resolution, t.human_flag_reason, t.priority, t.complexity, t.ticket_type, t.worktree, t.role_id,
+			t.resolution, t.human_flag_reason, t.priority, t.complexity, t.ticket_type, t.worktree, t.role_id, t.milestone_id,
 			t.retry_count, t.max_retries, t.parent_ticket_id,
-			t.created_at, t.updated_at, t.completed_at,
+			t.created_at, t.updated_at, t.completed_at, t.not_before, t.due_at,
 			p.key AS project_key,
-			ro.name AS role_name
+			ro.name AS role_name,
+			ms.key AS milestone_key,
+			(SELECT group_concat(label, ',' ORDER BY label) FROM ticket_labels WHERE ticket_id = t.id) AS labels
 		FROM tickets t
 		JOIN projects p ON t.project_id = p.id
 		LEFT JOIN roles ro ON t.role_id = ro.id
+		LEFT JOIN milestones ms ON t.milestone_id = ms.id
 		WHERE t.status = 'ready'
 		AND t.ticket_type != 'epic'
 		AND NOT EXISTS (
@@ -339,8 +386,15 @@ func (r *TicketRepo) ListWorkable(filter TicketFilter) ([]*models.Ticket, error)
 			WHERE td.ticket_id = t.id
 			AND NOT (dep.status = 'closed' AND dep.resolution = 'completed')
 		)
+		AND t.retry_count < t.max_retries
+		AND NOT EXISTS (
+			SELECT 1 FROM claims c
+			WHERE c.ticket_id = t.id AND c.status = 'active'
+		)
+		AND (t.not_before IS NULL OR t.not_before <= ?)
 	`
-	args := []interface{}{}
+	now := NowRFC3339()
+	args := []interface{}{now}
 
 	if filter.ProjectID != nil {
 		query += " AND t.project_id = ?"
@@ -354,6 +408,37 @@ func (r *TicketRepo) ListWorkable(filter TicketFilter) ([]*models.Ticket, error)
 		query += " AND t.complexity = ?"
 		args = append(args, *filter.Complexity)
 	}
+	if filter.MaxComplexity != nil {
+		clause, clauseArgs := complexityIn(func(c models.Complexity) bool {
+			return c.Order() <= filter.MaxComplexity.Order()
+		})
+		query += clause
+		args = append(args, clauseArgs...)
+	}
+	if filter.Capability != "" {
+		clause, clauseArgs := complexityIn(func(c models.Complexity) bool {
+			return c.Capability() == filter.Capability
+		})
+		query += clause
+		args = append(args, clauseArgs...)
+	}
+	if filter.RoleName != "" {
+		query += " AND ro.name = ?"
+		args = append(args, filter.RoleName)
+	}
+	if len(filter.Labels) > 0 {
+		clause, clauseArgs := labelsWhere(filter.Labels, "t")
+		query += clause
+		args = append(args, clauseArgs...)
+	}
+	if filter.MilestoneID != nil {
+		query += " AND t.milestone_id = ?"
+		args = append(args, *filter.MilestoneID)
+	}
+	if filter.MilestoneKey != "" {
+		query += " AND ms.key = ?"
+		args = append(args, filter.MilestoneKey)
+	}
 
 	query += ` ORDER BY
 		CASE t.priority
@@ -362,9 +447,10 @@ func (r *TicketRepo) ListWorkable(filter TicketFilter) ([]*models.Ticket, error)
 			WHEN 'medium' THEN 3
 			WHEN 'low' THEN 4
 			WHEN 'lowest' THEN 5
-		END,
+		END - (CASE WHEN t.due_at < ? THEN 1 ELSE 0 END),
 		t.created_at
 	`
+	args = append(args, now)
 
 	if filter.Limit > 0 {
 		query += " LIMIT ?"
@@ -380,6 +466,58 @@ func (r *TicketRepo) ListWorkable(filter TicketFilter) ([]*models.Ticket, error)
 	return r.scanMany(rows)
 }
 
+// ListOverdue retrieves open tickets whose due date is before now, most
+// overdue first. If projectKey is set, only that project's tickets are listed.
+func (r *TicketRepo) ListOverdue(projectKey string, now time.Time) ([]*models.Ticket, error) {
+	query := `
+		SELECT t.id, t.project_id, t.number, t.title, t.description, t.status,
+			t.resolution, t.human_flag_reason, t.priority, t.complexity, t.ticket_type, t.worktree, t.role_id, t.milestone_id,
+			t.retry_count, t.max_retries, t.parent_ticket_id,
+			t.created_at, t.updated_at, t.completed_at, t.not_before, t.due_at,
+			p.key AS project_key,
+			ro.name AS role_name,
+			ms.key AS milestone_key,
+			(SELECT group_concat(label, ',' ORDER BY label) FROM ticket_labels WHERE ticket_id = t.id) AS labels
+		FROM tickets t
+		JOIN projects p ON t.project_id = p.id
+		LEFT JOIN roles ro ON t.role_id = ro.id
+		LEFT JOIN milestones ms ON t.milestone_id = ms.id
+		WHERE t.due_at IS NOT NULL AND t.due_at < ?
+		AND t.status != 'closed'
+	`
+	args := []interface{}{FormatTime(now)}
+	if projectKey != "" {
+		query += " AND p.key = ?"
+		args = append(args, projectKey)
+	}
+	query += " ORDER BY t.due_at, t.id"
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list overdue tickets: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanMany(rows)
+}
+
+// complexityIn builds an "AND t.complexity IN (...)" clause for the
+// complexities accepted by keep. If none are accepted the clause matches nothing.
+func complexityIn(keep func(models.Complexity) bool) (string, []interface{}) {
+	var placeholders []string
+	var args []interface{}
+	for _, c := range models.AllComplexities() {
+		if keep(c) {
+			placeholders = append(placeholders, "?")
+			args = append(args, c)
+		}
+	}
+	if len(placeholders) == 0 {
+		return " AND 0", nil
+	}
+	return " AND t.complexity IN (" + strings.Join(placeholders, ", ") + ")", args
+}
+
 // Update updates a ticket.
 func (r *TicketRepo) Update(t *models.Ticket) error {
 	if t.ID <= 0 {
@@ -389,15 +527,17 @@ func (r *TicketRepo) Update(t *models.Ticket) error {
 	query := `
 		UPDATE tickets SET
 			title = ?, description = ?, status = ?, resolution = ?, human_flag_reason = ?,
-			priority = ?, complexity = ?, ticket_type = ?, worktree = ?, role_id = ?,
-			retry_count = ?, max_retries = ?, parent_ticket_id = ?, completed_at = ?
+			priority = ?, complexity = ?, ticket_type = ?, worktree = ?, role_id = ?, milestone_id = ?,
+			retry_count = ?, max_retries = ?, parent_ticket_id = ?, completed_at = ?,
+			not_before = ?, due_at = ?
 		WHERE id = ?
 	`
 
 	result, err := r.db.Exec(query,
 		t.Title, nullString(t.Description), t.Status, nullResolution(t.Resolution), nullString(t.HumanFlagReason),
-		t.Priority, t.Complexity, t.Type, nullString(t.Worktree), nullInt64(t.RoleID),
+		t.Priority, t.Complexity, t.Type, nullString(t.Worktree), nullInt64(t.RoleID), nullInt64(t.MilestoneID),
 		t.RetryCount, t.MaxRetries, nullInt64(t.ParentTicketID), FormatTimePtr(t.CompletedAt),
+		FormatTimePtr(t.NotBefore), FormatTimePtr(t.DueAt),
 		t.ID,
 	)
 	if err != nil {
@@ -434,6 +574,24 @@ func (r *TicketRepo) UpdateStatus(id int64, status models.Status) error {
 	return nil
 }
 
+// TransitionStatus atomically moves a ticket from one status to another.
+// It returns false without error if the ticket was no longer in the from status,
+// e.g. because another worker changed it first.
+func (r *TicketRepo) TransitionStatus(id int64, from, to models.Status) (bool, error) {
+	query := `UPDATE tickets SET status = ? WHERE id = ? AND status = ?`
+	result, err := r.db.Exec(query, to, id, from)
+	if err != nil {
+		return false, fmt.Errorf("failed to update ticket status: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows == 1, nil
+}
+
 // IncrementRetryCount increments the retry count for a ticket.
 func (r *TicketRepo) IncrementRetryCount(id int64) error {
 	query := `UPDATE tickets SET retry_count = retry_count + 1 WHERE id = ?`
@@ -472,50 +630,6 @@ func (r *TicketRepo) GetEpicChildren(epicID int64) ([]*models.Ticket, error) {
 	return r.GetChildren(epicID)
 }
 
-// Search searches tickets by key, title, or description.
-func (r *TicketRepo) Search(query string, limit int) ([]*models.Ticket, error) {
-	if query == "" {
-		return []*models.Ticket{}, nil
-	}
-	if limit <= 0 {
-		limit = 20
-	}
-
-	// Search pattern for LIKE matching
-	pattern := "%" + query + "%"
-
-	sqlQuery := `
-		SELECT t.id, t.project_id, t.number, t.title, t.description, t.status,
-			t.resolution, t.human_flag_reason, t.priority, t.complexity, t.ticket_type, t.worktree, t.role_id,
-			t.retry_count, t.max_retries, t.parent_ticket_id,
-			t.created_at, t.updated_at, t.completed_at,
-			p.key AS project_key,
-			ro.name AS role_name
-		FROM tickets t
-		JOIN projects p ON t.project_id = p.id
-		LEFT JOIN roles ro ON t.role_id = ro.id
-		WHERE (p.key || '-' || t.number) LIKE ? COLLATE NOCASE
-		   OR t.title LIKE ? COLLATE NOCASE
-		   OR t.description LIKE ? COLLATE NOCASE
-		ORDER BY
-			CASE
-				WHEN (p.key || '-' || t.number) LIKE ? COLLATE NOCASE THEN 1
-				WHEN t.title LIKE ? COLLATE NOCASE THEN 2
-				ELSE 3
-			END,
-			t.updated_at DESC
-		LIMIT ?
-	`
-
-	rows, err := r.db.Query(sqlQuery, pattern, pattern, pattern, pattern, pattern, limit)
-	if err != nil {
-		return nil, fmt.Errorf("failed to search tickets: %w", err)
-	}
-	defer rows.Close()
-
-	return r.scanMany(rows)
-}
-
 // CountByStatus counts tickets by status for a project.
 func (r *TicketRepo) CountByStatus(projectID int64) (map[models.Status]int, error) {
 	query := `SELECT status, COUNT(*) FROM tickets WHERE project_id = ? GROUP BY status`
@@ -539,16 +653,16 @@ func (r *TicketRepo) CountByStatus(projectID int64) (map[models.Status]int, erro
 
 func (r *TicketRepo) scanOne(row *sql.Row) (*models.Ticket, error) {
 	var t models.Ticket
-	var desc, resolution, humanFlag, ticketType, worktree, roleName sql.NullString
-	var parentID, roleID sql.NullInt64
-	var completedAt sql.NullTime
+	var desc, resolution, humanFlag, ticketType, worktree, roleName, milestoneKey, labels sql.NullString
+	var parentID, roleID, milestoneID sql.NullInt64
+	var completedAt, notBefore, dueAt sql.NullTime
 
 	err := row.Scan(
 		&t.ID, &t.ProjectID, &t.Number, &t.Title, &desc, &t.Status,
-		&resolution, &humanFlag, &t.Priority, &t.Complexity, &ticketType, &worktree, &roleID,
+		&resolution, &humanFlag, &t.Priority, &t.Complexity, &ticketType, &worktree, &roleID, &milestoneID,
 		&t.RetryCount, &t.MaxRetries, &parentID,
-		&t.CreatedAt, &t.UpdatedAt, &completedAt,
-		&t.ProjectKey, &roleName,
+		&t.CreatedAt, &t.UpdatedAt, &completedAt, &notBefore, &dueAt,
+		&t.ProjectKey, &roleName, &milestoneKey, &labels,
 	)
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -570,6 +684,11 @@ func (r *TicketRepo) scanOne(row *sql.Row) (*models.Ticket, error) {
 	if roleName.Valid {
 		t.RoleName = roleName.String
 	}
+	if milestoneID.Valid {
+		t.MilestoneID = &milestoneID.Int64
+	}
+	t.MilestoneKey = milestoneKey.String
+	t.Labels = splitLabels(labels)
 	if resolution.Valid {
 		res := models.Resolution(resolution.String)
 		t.Resolution = &res
@@ -580,6 +699,8 @@ func (r *TicketRepo) scanOne(row *sql.Row) (*models.Ticket, error) {
 	if completedAt.Valid {
 		t.CompletedAt = &completedAt.Time
 	}
+	t.NotBefore = timePtr(notBefore)
+	t.DueAt = timePtr(dueAt)
 	t.TicketKey = fmt.Sprintf("%s-%d", t.ProjectKey, t.Number)
 	return &t, nil
 }
@@ -588,16 +709,16 @@ func (r *TicketRepo) scanMany(rows *sql.Rows) ([]*models.Ticket, error) {
 	var tickets []*models.Ticket
 	for rows.Next() {
 		var t models.Ticket
-		var desc, resolution, humanFlag, ticketType, worktree, roleName sql.NullString
-		var parentID, roleID sql.NullInt64
-		var completedAt sql.NullTime
+		var desc, resolution, humanFlag, ticketType, worktree, roleName, milestoneKey, labels sql.NullString
+		var parentID, roleID, milestoneID sql.NullInt64
+		var completedAt, notBefore, dueAt sql.NullTime
 
 		err := rows.Scan(
 			&t.ID, &t.ProjectID, &t.Number, &t.Title, &desc, &t.Status,
-			&resolution, &humanFlag, &t.Priority, &t.Complexity, &ticketType, &worktree, &roleID,
+			&resolution, &humanFlag, &t.Priority, &t.Complexity, &ticketType, &worktree, &roleID, &milestoneID,
 			&t.RetryCount, &t.MaxRetries, &parentID,
-			&t.CreatedAt, &t.UpdatedAt, &completedAt,
-			&t.ProjectKey, &roleName,
+			&t.CreatedAt, &t.UpdatedAt, &completedAt, &notBefore, &dueAt,
+			&t.ProjectKey, &roleName, &milestoneKey, &labels,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan ticket: %w", err)
@@ -616,6 +737,11 @@ func (r *TicketRepo) scanMany(rows *sql.Rows) ([]*models.Ticket, error) {
 		if roleName.Valid {
 			t.RoleName = roleName.String
 		}
+		if milestoneID.Valid {
+			t.MilestoneID = &milestoneID.Int64
+		}
+		t.MilestoneKey = milestoneKey.String
+		t.Labels = splitLabels(labels)
 		if resolution.Valid {
 			res := models.Resolution(resolution.String)
 			t.Resolution = &res
@@ -626,6 +752,8 @@ func (r *TicketRepo) scanMany(rows *sql.Rows) ([]*models.Ticket, error) {
 		if completedAt.Valid {
 			t.CompletedAt = &completedAt.Time
 		}
+		t.NotBefore = timePtr(notBefore)
+		t.DueAt = timePtr(dueAt)
 		t.TicketKey = fmt.Sprintf("%s-%d", t.ProjectKey, t.Number)
 		tickets = append(tickets, &t)
 	}
@@ -657,6 +785,13 @@ func nullTime(t *time.Time) sql.NullTime {
 	return sql.NullTime{Time: *t, Valid: true}
 }
 
+func timePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
 func nullResolution(r *models.Resolution) sql.NullString {
 	if r == nil {
 		return sql.NullString{}
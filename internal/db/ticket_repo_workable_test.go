@@ -0,0 +1,134 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spetersoncode/wark/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListWorkable_Filters(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	projectID := createTestProject(t, db)
+	ticketRepo := NewTicketRepo(db)
+
+	role := &models.Role{Name: "frontend", Description: "Frontend engineer", Instructions: "Build UI components."}
+	require.NoError(t, NewRoleRepo(db).Create(role))
+
+	create := func(title string, complexity models.Complexity, roleID *int64) *models.Ticket {
+		ticket := &models.Ticket{
+			ProjectID:  projectID,
+			Title:      title,
+			Status:     models.StatusReady,
+			Complexity: complexity,
+			RoleID:     roleID,
+		}
+		require.NoError(t, ticketRepo.Create(ticket))
+		return ticket
+	}
+	trivial := create("Trivial", models.ComplexityTrivial, &role.ID)
+	large := create("Large", models.ComplexityLarge, nil)
+	xlarge := create("XLarge", models.ComplexityXLarge, &role.ID)
+
+	ids := func(tickets []*models.Ticket) []int64 {
+		var out []int64
+		for _, t := range tickets {
+			out = append(out, t.ID)
+		}
+		return out
+	}
+
+	t.Run("capability", func(t *testing.T) {
+		tickets, err := ticketRepo.ListWorkable(TicketFilter{Capability: "fast"})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{trivial.ID}, ids(tickets))
+
+		tickets, err = ticketRepo.ListWorkable(TicketFilter{Capability: "powerful"})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{xlarge.ID}, ids(tickets))
+	})
+
+	t.Run("role", func(t *testing.T) {
+		tickets, err := ticketRepo.ListWorkable(TicketFilter{RoleName: "frontend"})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []int64{trivial.ID, xlarge.ID}, ids(tickets))
+	})
+
+	t.Run("combined", func(t *testing.T) {
+		maxLarge := models.ComplexityLarge
+		tickets, err := ticketRepo.ListWorkable(TicketFilter{RoleName: "frontend", MaxComplexity: &maxLarge})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{trivial.ID}, ids(tickets))
+
+		tickets, err = ticketRepo.ListWorkable(TicketFilter{Capability: "powerful", MaxComplexity: &maxLarge})
+		require.NoError(t, err)
+		assert.Empty(t, tickets)
+	})
+
+	t.Run("no filters", func(t *testing.T) {
+		tickets, err := ticketRepo.ListWorkable(TicketFilter{})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []int64{trivial.ID, large.ID, xlarge.ID}, ids(tickets))
+	})
+}
+
+func TestListWorkable_Schedule(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	projectID := createTestProject(t, db)
+	ticketRepo := NewTicketRepo(db)
+
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(24 * time.Hour)
+	create := func(title string, priority models.Priority, notBefore, dueAt *time.Time) *models.Ticket {
+		ticket := &models.Ticket{
+			ProjectID: projectID,
+			Title:     title,
+			Status:    models.StatusReady,
+			Priority:  priority,
+			NotBefore: notBefore,
+			DueAt:     dueAt,
+		}
+		require.NoError(t, ticketRepo.Create(ticket))
+		return ticket
+	}
+	high := create("High", models.PriorityHigh, nil, nil)
+	medium := create("Medium", models.PriorityMedium, nil, nil)
+	overdue := create("Overdue medium", models.PriorityMedium, nil, &past)
+	create("Deferred", models.PriorityHighest, &future, nil)
+	started := create("Started", models.PriorityLow, &past, &future)
+
+	tickets, err := ticketRepo.ListWorkable(TicketFilter{})
+	require.NoError(t, err)
+	var keys []int64
+	for _, ticket := range tickets {
+		keys = append(keys, ticket.ID)
+	}
+	// Overdue ranks one level up, ahead of the older medium ticket; the
+	// deferred ticket is left out until its not_before
+	assert.Equal(t, []int64{high.ID, overdue.ID, medium.ID, started.ID}, keys)
+
+	got, err := ticketRepo.GetByID(started.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got.NotBefore)
+	require.NotNil(t, got.DueAt)
+	assert.Equal(t, future.Unix(), got.DueAt.Unix())
+
+	list, err := ticketRepo.ListOverdue("", time.Now())
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, overdue.ID, list[0].ID)
+	assert.True(t, list[0].IsOverdue(time.Now()))
+
+	// Clearing the date through Update removes it
+	got.NotBefore = nil
+	require.NoError(t, ticketRepo.Update(got))
+	got, err = ticketRepo.GetByID(started.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got.NotBefore)
+}
@@ -0,0 +1,251 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/spetersoncode/wark/internal/models"
+)
+
+// WebhookRepo provides database operations for the webhook delivery queue.
+type WebhookRepo struct {
+	db DBTX
+}
+
+// NewWebhookRepo creates a new WebhookRepo.
+func NewWebhookRepo(db DBTX) *WebhookRepo {
+	return &WebhookRepo{db: db}
+}
+
+// DeliveryFilter defines filters for listing webhook deliveries.
+type DeliveryFilter struct {
+	Webhook string
+	Status  string
+	Limit   int
+}
+
+const deliveryColumns = `id, webhook, event_id, event_type, ticket_key, payload, status, attempts,
+	next_attempt_at, last_attempt_at, response_code, last_error, created_at, delivered_at`
+
+// Enqueue queues a delivery. It returns false if the webhook already has a
+// delivery for the event, so the same event is never queued twice.
+func (r *WebhookRepo) Enqueue(d *models.WebhookDelivery) (bool, error) {
+	now := time.Now()
+	if d.Status == "" {
+		d.Status = models.DeliveryStatusPending
+	}
+	if d.NextAttemptAt.IsZero() {
+		d.NextAttemptAt = now
+	}
+
+	result, err := r.db.Exec(`
+		INSERT OR IGNORE INTO webhook_deliveries
+			(webhook, event_id, event_type, ticket_key, payload, status, next_attempt_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, d.Webhook, d.EventID, d.EventType, nullString(d.TicketKey), d.Payload, d.Status,
+		FormatTime(d.NextAttemptAt), FormatTime(now))
+	if err != nil {
+		return false, fmt.Errorf("failed to enqueue delivery: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return false, nil
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return false, fmt.Errorf("failed to get delivery id: %w", err)
+	}
+	d.ID = id
+	d.CreatedAt = now
+	return true, nil
+}
+
+// GetByID retrieves a delivery by ID.
+func (r *WebhookRepo) GetByID(id int64) (*models.WebhookDelivery, error) {
+	rows, err := r.db.Query(`SELECT `+deliveryColumns+` FROM webhook_deliveries WHERE id = ?`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get delivery: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries, err := r.scanMany(rows)
+	if err != nil || len(deliveries) == 0 {
+		return nil, err
+	}
+	return deliveries[0], nil
+}
+
+// ListDue retrieves pending deliveries whose next attempt is due, oldest first.
+func (r *WebhookRepo) ListDue(now time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries
+		WHERE status = ? AND next_attempt_at <= ?
+		ORDER BY next_attempt_at, id`
+	args := []interface{}{models.DeliveryStatusPending, FormatTime(now)}
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanMany(rows)
+}
+
+// Claim takes a due delivery for sending by pushing its next attempt to
+// leaseUntil. It returns false if the delivery is no longer pending and due
+// at now, because another dispatcher claimed or sent it first.
+func (r *WebhookRepo) Claim(id int64, now, leaseUntil time.Time) (bool, error) {
+	res, err := r.db.Exec(`
+		UPDATE webhook_deliveries SET next_attempt_at = ?
+		WHERE id = ? AND status = ? AND next_attempt_at <= ?
+	`, FormatTime(leaseUntil), id, models.DeliveryStatusPending, FormatTime(now))
+	if err != nil {
+		return false, fmt.Errorf("failed to claim delivery: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim delivery: %w", err)
+	}
+	return n == 1, nil
+}
+
+// List retrieves deliveries matching the filter, newest first.
+func (r *WebhookRepo) List(filter DeliveryFilter) ([]*models.WebhookDelivery, error) {
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries WHERE 1=1`
+	args := []interface{}{}
+
+	if filter.Webhook != "" {
+		query += " AND webhook = ?"
+		args = append(args, filter.Webhook)
+	}
+	if filter.Status != "" {
+		query += " AND status = ?"
+		args = append(args, filter.Status)
+	}
+
+	query += " ORDER BY id DESC"
+
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanMany(rows)
+}
+
+// CountByStatus counts a webhook's deliveries by status.
+func (r *WebhookRepo) CountByStatus(webhook string) (map[string]int, error) {
+	rows, err := r.db.Query(`SELECT status, COUNT(*) FROM webhook_deliveries WHERE webhook = ? GROUP BY status`, webhook)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var status string
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan delivery count: %w", err)
+		}
+		counts[status] = count
+	}
+	return counts, rows.Err()
+}
+
+// RecordAttempt saves the outcome of a delivery attempt: its status,
+// attempt count, next attempt time, response code and error.
+func (r *WebhookRepo) RecordAttempt(d *models.WebhookDelivery) error {
+	if err := models.ValidateDeliveryStatus(d.Status); err != nil {
+		return err
+	}
+	var responseCode sql.NullInt64
+	if d.ResponseCode != 0 {
+		responseCode = sql.NullInt64{Int64: int64(d.ResponseCode), Valid: true}
+	}
+
+	_, err := r.db.Exec(`
+		UPDATE webhook_deliveries
+		SET status = ?, attempts = ?, next_attempt_at = ?, last_attempt_at = ?,
+			response_code = ?, last_error = ?, delivered_at = ?
+		WHERE id = ?
+	`, d.Status, d.Attempts, FormatTime(d.NextAttemptAt), FormatTimePtr(d.LastAttemptAt),
+		responseCode, nullString(d.LastError), FormatTimePtr(d.DeliveredAt), d.ID)
+	if err != nil {
+		return fmt.Errorf("failed to record delivery attempt: %w", err)
+	}
+	return nil
+}
+
+// Cursor returns the last activity log ID queued for webhooks. ok is false
+// if the dispatcher has never run.
+func (r *WebhookRepo) Cursor() (id int64, ok bool, err error) {
+	err = r.db.QueryRow(`SELECT last_event_id FROM webhook_cursor WHERE id = 1`).Scan(&id)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get webhook cursor: %w", err)
+	}
+	return id, true, nil
+}
+
+// SetCursor records the last activity log ID queued for webhooks.
+func (r *WebhookRepo) SetCursor(id int64) error {
+	_, err := r.db.Exec(`
+		INSERT INTO webhook_cursor (id, last_event_id) VALUES (1, ?)
+		ON CONFLICT (id) DO UPDATE SET last_event_id = excluded.last_event_id
+	`, id)
+	if err != nil {
+		return fmt.Errorf("failed to set webhook cursor: %w", err)
+	}
+	return nil
+}
+
+func (r *WebhookRepo) scanMany(rows *sql.Rows) ([]*models.WebhookDelivery, error) {
+	var deliveries []*models.WebhookDelivery
+	for rows.Next() {
+		var d models.WebhookDelivery
+		var ticketKey, lastError sql.NullString
+		var responseCode sql.NullInt64
+		var lastAttemptAt, deliveredAt sql.NullTime
+
+		err := rows.Scan(
+			&d.ID, &d.Webhook, &d.EventID, &d.EventType, &ticketKey, &d.Payload, &d.Status, &d.Attempts,
+			&d.NextAttemptAt, &lastAttemptAt, &responseCode, &lastError, &d.CreatedAt, &deliveredAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan delivery: %w", err)
+		}
+
+		d.TicketKey = ticketKey.String
+		d.LastError = lastError.String
+		d.ResponseCode = int(responseCode.Int64)
+		if lastAttemptAt.Valid {
+			d.LastAttemptAt = &lastAttemptAt.Time
+		}
+		if deliveredAt.Valid {
+			d.DeliveredAt = &deliveredAt.Time
+		}
+		deliveries = append(deliveries, &d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating deliveries: %w", err)
+	}
+	return deliveries, nil
+}
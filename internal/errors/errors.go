@@ -35,6 +35,16 @@ const (
 	// KindGeneral represents a general error that doesn't fit other categories.
 	// CLI exit code: 1, HTTP status: 500 Internal Server Error
 	KindGeneral
+
+	// KindWIPLimit represents work refused because a work-in-progress limit
+	// has been reached.
+	// CLI exit code: 7, HTTP status: 429 Too Many Requests
+	KindWIPLimit
+
+	// KindTimeout represents a wait that ended before what it waited for
+	// happened.
+	// CLI exit code: 8, HTTP status: 408 Request Timeout
+	KindTimeout
 )
 
 // String returns a human-readable name for the error kind.
@@ -52,6 +62,10 @@ func (k Kind) String() string {
 		return "Internal"
 	case KindGeneral:
 		return "General"
+	case KindWIPLimit:
+		return "WIPLimit"
+	case KindTimeout:
+		return "Timeout"
 	default:
 		return "Unknown"
 	}
@@ -94,6 +108,10 @@ func (e *Error) CLIExitCode() int {
 		return 5
 	case KindConcurrentConflict:
 		return 6
+	case KindWIPLimit:
+		return 7
+	case KindTimeout:
+		return 8
 	case KindGeneral:
 		return 1
 	default:
@@ -114,6 +132,10 @@ func (e *Error) HTTPStatus() int {
 		return http.StatusConflict // 409
 	case KindInternal:
 		return http.StatusInternalServerError // 500
+	case KindWIPLimit:
+		return http.StatusTooManyRequests // 429
+	case KindTimeout:
+		return http.StatusRequestTimeout // 408
 	case KindGeneral:
 		return http.StatusInternalServerError // 500
 	default:
@@ -170,6 +192,22 @@ func ConcurrentConflict(format string, args ...interface{}) *Error {
 	}
 }
 
+// WIPLimit creates an error for work refused by a work-in-progress limit.
+func WIPLimit(format string, args ...interface{}) *Error {
+	return &Error{
+		Kind:    KindWIPLimit,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// Timeout creates an error for a wait that timed out.
+func Timeout(format string, args ...interface{}) *Error {
+	return &Error{
+		Kind:    KindTimeout,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
 // Internal creates an error for internal/database errors.
 func Internal(format string, args ...interface{}) *Error {
 	return &Error{
@@ -17,6 +17,8 @@ func TestKindString(t *testing.T) {
 		{KindConcurrentConflict, "ConcurrentConflict"},
 		{KindInternal, "Internal"},
 		{KindGeneral, "General"},
+		{KindWIPLimit, "WIPLimit"},
+		{KindTimeout, "Timeout"},
 		{Kind(99), "Unknown"},
 	}
 
@@ -75,6 +77,8 @@ func TestCLIExitCode(t *testing.T) {
 		{"StateError", StateError("invalid state"), 4},
 		{"Internal", Internal("db error"), 5},
 		{"ConcurrentConflict", ConcurrentConflict("conflict"), 6},
+		{"WIPLimit", WIPLimit("limit reached"), 7},
+		{"Timeout", Timeout("timed out"), 8},
 		{"General", General("general error"), 1},
 	}
 
@@ -98,6 +102,8 @@ func TestHTTPStatus(t *testing.T) {
 		{"StateError", StateError("invalid state"), http.StatusUnprocessableEntity},
 		{"ConcurrentConflict", ConcurrentConflict("conflict"), http.StatusConflict},
 		{"Internal", Internal("db error"), http.StatusInternalServerError},
+		{"WIPLimit", WIPLimit("limit reached"), http.StatusTooManyRequests},
+		{"Timeout", Timeout("timed out"), http.StatusRequestTimeout},
 		{"General", General("general error"), http.StatusInternalServerError},
 	}
 
@@ -141,6 +147,18 @@ func TestConstructors(t *testing.T) {
 			kind:    KindConcurrentConflict,
 			message: "ticket already claimed by worker-1",
 		},
+		{
+			name:    "WIPLimit",
+			err:     WIPLimit("project %s is at its WIP limit", "PROJ"),
+			kind:    KindWIPLimit,
+			message: "project PROJ is at its WIP limit",
+		},
+		{
+			name:    "Timeout",
+			err:     Timeout("no response to message #%d", 12),
+			kind:    KindTimeout,
+			message: "no response to message #12",
+		},
 		{
 			name:    "Internal",
 			err:     Internal("database error"),
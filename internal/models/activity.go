@@ -21,6 +21,11 @@ type ActivityLog struct {
 	TicketKey string `json:"ticket_key,omitempty"`
 }
 
+// DetailImported is the details key marking an entry restored by an import.
+// Imported entries are history rather than something that just happened, so
+// they produce no events.
+const DetailImported = "imported"
+
 // Validate validates the activity log entry.
 func (a *ActivityLog) Validate() error {
 	if a.TicketID <= 0 {
@@ -12,7 +12,7 @@ type Claim struct {
 	ID         int64       `json:"id"`
 	ClaimID    string      `json:"claim_id"`            // External identifier (e.g., "claim_abc123")
 	TicketID   int64       `json:"ticket_id"`
-	WorkerID   string      `json:"worker_id,omitempty"` // Deprecated: kept for backward compatibility
+	WorkerID   string      `json:"worker_id,omitempty"` // Identity of the worker holding the claim
 	ClaimedAt  time.Time   `json:"claimed_at"`
 	ExpiresAt  time.Time   `json:"expires_at"`
 	ReleasedAt *time.Time  `json:"released_at,omitempty"`
@@ -63,30 +63,30 @@ func (c *Claim) TimeRemaining() time.Duration {
 	return remaining
 }
 
-// generateClaimID generates a unique claim identifier.
-func generateClaimID() string {
+// GenerateClaimID generates a unique claim identifier.
+func GenerateClaimID() string {
 	b := make([]byte, 4)
 	rand.Read(b)
 	return hex.EncodeToString(b)
 }
 
-// NewClaim creates a new claim for a ticket with the specified duration.
-// Generates a unique claim ID internally.
-func NewClaim(ticketID int64, duration time.Duration) *Claim {
+// NewClaim creates a new claim for a ticket held by workerID with the
+// specified duration. Generates a unique claim ID internally.
+func NewClaim(ticketID int64, workerID string, duration time.Duration) *Claim {
 	now := time.Now()
 	return &Claim{
-		ClaimID:   generateClaimID(),
+		ClaimID:   GenerateClaimID(),
 		TicketID:  ticketID,
+		WorkerID:  workerID,
 		ClaimedAt: now,
 		ExpiresAt: now.Add(duration),
 		Status:    ClaimStatusActive,
 	}
 }
 
-// NewClaimWithWorker creates a new claim with an optional worker ID for backward compatibility.
-// Deprecated: Use NewClaim instead.
-func NewClaimWithWorker(ticketID int64, workerID string, duration time.Duration) *Claim {
-	claim := NewClaim(ticketID, duration)
-	claim.WorkerID = workerID
-	return claim
+// IsHeldBy reports whether workerID may act on the claim. Claims without a
+// worker match any caller; a claim with a worker matches only that worker,
+// so a caller without an identity cannot act on it.
+func (c *Claim) IsHeldBy(workerID string) bool {
+	return c.WorkerID == "" || c.WorkerID == workerID
 }
@@ -180,6 +180,46 @@ func ParseComplexity(s string) (Complexity, error) {
 	return complexity, nil
 }
 
+// Order returns the size order for the complexity (lower is smaller).
+func (c Complexity) Order() int {
+	switch c {
+	case ComplexityTrivial:
+		return 1
+	case ComplexitySmall:
+		return 2
+	case ComplexityMedium:
+		return 3
+	case ComplexityLarge:
+		return 4
+	case ComplexityXLarge:
+		return 5
+	default:
+		return 99
+	}
+}
+
+// Estimate returns a relative effort estimate for the complexity, used to
+// weigh dependency chains. Unknown complexities count as medium.
+func (c Complexity) Estimate() int {
+	switch c {
+	case ComplexityTrivial:
+		return 1
+	case ComplexitySmall:
+		return 2
+	case ComplexityLarge:
+		return 5
+	case ComplexityXLarge:
+		return 8
+	default:
+		return 3
+	}
+}
+
+// AllComplexities returns every complexity from smallest to largest.
+func AllComplexities() []Complexity {
+	return []Complexity{ComplexityTrivial, ComplexitySmall, ComplexityMedium, ComplexityLarge, ComplexityXLarge}
+}
+
 // ShouldDecompose returns true if tickets of this complexity should be decomposed.
 func (c Complexity) ShouldDecompose() bool {
 	return c == ComplexityLarge || c == ComplexityXLarge
@@ -200,6 +240,16 @@ func (c Complexity) Capability() string {
 	}
 }
 
+// IsValidCapability returns true if capability is one of the levels returned
+// by Complexity.Capability: fast, standard, or powerful.
+func IsValidCapability(capability string) bool {
+	switch capability {
+	case "fast", "standard", "powerful":
+		return true
+	}
+	return false
+}
+
 // ClaimStatus represents the state of a claim on a ticket.
 type ClaimStatus string
 
@@ -285,6 +335,7 @@ const (
 	// Lifecycle actions
 	ActionCreated   Action = "created"
 	ActionClaimed   Action = "claimed"
+	ActionHeartbeat Action = "heartbeat"
 	ActionReleased  Action = "released"
 	ActionExpired   Action = "expired"
 	ActionCompleted Action = "completed"
@@ -320,7 +371,7 @@ const (
 // IsValid returns true if the action is valid.
 func (a Action) IsValid() bool {
 	switch a {
-	case ActionCreated, ActionClaimed, ActionReleased, ActionExpired,
+	case ActionCreated, ActionClaimed, ActionHeartbeat, ActionReleased, ActionExpired,
 		ActionCompleted, ActionAccepted, ActionRejected, ActionClosed, ActionReopened,
 		ActionDependencyAdded, ActionDependencyRemoved, ActionBlocked, ActionUnblocked,
 		ActionDecomposed, ActionChildCreated, ActionEscalated, ActionHumanResponded,
@@ -155,6 +155,15 @@ func TestParseComplexity(t *testing.T) {
 	}
 }
 
+func TestComplexityEstimate(t *testing.T) {
+	var last int
+	for _, c := range AllComplexities() {
+		assert.Greater(t, c.Estimate(), last, "%s should weigh more than the complexity below it", c)
+		last = c.Estimate()
+	}
+	assert.Equal(t, ComplexityMedium.Estimate(), Complexity("").Estimate())
+}
+
 func TestParseMessageType(t *testing.T) {
 	tests := []struct {
 		name    string
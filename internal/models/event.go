@@ -0,0 +1,126 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// EventType identifies a change that clients can subscribe to.
+type EventType string
+
+const (
+	EventTicketStatusChanged EventType = "ticket.status_changed"
+	EventClaimCreated        EventType = "claim.created"
+	EventClaimExpired        EventType = "claim.expired"
+	EventInboxMessage        EventType = "inbox.message"
+	EventInboxResponse       EventType = "inbox.response"
+	EventInboxOverdue        EventType = "inbox.overdue"
+	EventComment             EventType = "comment.created"
+)
+
+// AllEventTypes returns all event types.
+func AllEventTypes() []EventType {
+	return []EventType{
+		EventTicketStatusChanged,
+		EventClaimCreated,
+		EventClaimExpired,
+		EventInboxMessage,
+		EventInboxResponse,
+		EventInboxOverdue,
+		EventComment,
+	}
+}
+
+// IsValid returns true if the event type is valid.
+func (et EventType) IsValid() bool {
+	switch et {
+	case EventTicketStatusChanged, EventClaimCreated, EventClaimExpired,
+		EventInboxMessage, EventInboxResponse, EventInboxOverdue, EventComment:
+		return true
+	}
+	return false
+}
+
+// ParseEventTypes parses event types given as repeated and/or
+// comma-separated values.
+func ParseEventTypes(values []string) ([]EventType, error) {
+	var types []EventType
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			part = strings.TrimSpace(strings.ToLower(part))
+			if part == "" {
+				continue
+			}
+			et := EventType(part)
+			if !et.IsValid() {
+				return nil, fmt.Errorf("invalid event type: %s", part)
+			}
+			types = append(types, et)
+		}
+	}
+	return types, nil
+}
+
+// Event is a typed change derived from an activity log entry. Its ID is the
+// activity log ID, so events are ordered and a consumer can resume after the
+// last ID it saw.
+type Event struct {
+	ID        int64                  `json:"id"`
+	Type      EventType              `json:"type"`
+	TicketID  int64                  `json:"ticket_id"`
+	TicketKey string                 `json:"ticket_key"`
+	ActorType ActorType              `json:"actor_type"`
+	ActorID   string                 `json:"actor_id,omitempty"`
+	Summary   string                 `json:"summary,omitempty"`
+	Data      map[string]interface{} `json:"data,omitempty"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+// EventFromActivity converts an activity log entry to an event. It returns
+// false for activity that has no event type (e.g., heartbeats) and for
+// imported history.
+func EventFromActivity(a *ActivityLog) (*Event, bool) {
+	details, _ := a.GetDetails()
+	if details != nil && details[DetailImported] == true {
+		return nil, false
+	}
+
+	var eventType EventType
+	data := details
+	switch a.Action {
+	case ActionFieldChanged:
+		if details == nil || details["field"] != "status" {
+			return nil, false
+		}
+		eventType = EventTicketStatusChanged
+		data = map[string]interface{}{"from": details["old"], "to": details["new"]}
+	case ActionClaimed:
+		eventType = EventClaimCreated
+	case ActionExpired:
+		eventType = EventClaimExpired
+	case ActionEscalated:
+		eventType = EventInboxMessage
+		if details != nil && details["overdue"] == true {
+			eventType = EventInboxOverdue
+		}
+	case ActionHumanResponded:
+		eventType = EventInboxResponse
+	case ActionComment:
+		eventType = EventComment
+	default:
+		return nil, false
+	}
+
+	return &Event{
+		ID:        a.ID,
+		Type:      eventType,
+		TicketID:  a.TicketID,
+		TicketKey: a.TicketKey,
+		ActorType: a.ActorType,
+		ActorID:   a.ActorID,
+		Summary:   a.Summary,
+		Data:      data,
+		CreatedAt: a.CreatedAt,
+	}, true
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventFromActivity(t *testing.T) {
+	status := &ActivityLog{ID: 7, TicketID: 1, Action: ActionFieldChanged, ActorType: ActorTypeSystem,
+		Details: `{"field":"status","old":"ready","new":"working"}`}
+	event, ok := EventFromActivity(status)
+	require.True(t, ok)
+	assert.Equal(t, EventTicketStatusChanged, event.Type)
+	assert.Equal(t, int64(7), event.ID)
+	assert.Equal(t, map[string]interface{}{"from": "ready", "to": "working"}, event.Data)
+
+	priority := &ActivityLog{Action: ActionFieldChanged, Details: `{"field":"priority","old":"low","new":"high"}`}
+	_, ok = EventFromActivity(priority)
+	assert.False(t, ok, "non-status field changes are not events")
+
+	_, ok = EventFromActivity(&ActivityLog{Action: ActionHeartbeat})
+	assert.False(t, ok)
+
+	mapped := map[Action]EventType{
+		ActionClaimed:        EventClaimCreated,
+		ActionExpired:        EventClaimExpired,
+		ActionEscalated:      EventInboxMessage,
+		ActionHumanResponded: EventInboxResponse,
+		ActionComment:        EventComment,
+	}
+	for action, want := range mapped {
+		event, ok := EventFromActivity(&ActivityLog{Action: action})
+		require.True(t, ok, "action %s", action)
+		assert.Equal(t, want, event.Type)
+	}
+
+	overdue := &ActivityLog{Action: ActionEscalated, Details: `{"inbox_message_id":3,"overdue":true}`}
+	event, ok = EventFromActivity(overdue)
+	require.True(t, ok)
+	assert.Equal(t, EventInboxOverdue, event.Type)
+}
+
+func TestParseEventTypes(t *testing.T) {
+	types, err := ParseEventTypes([]string{"claim.created, CLAIM.EXPIRED", "comment.created"})
+	require.NoError(t, err)
+	assert.Equal(t, []EventType{EventClaimCreated, EventClaimExpired, EventComment}, types)
+
+	_, err = ParseEventTypes([]string{"ticket.deleted"})
+	assert.Error(t, err)
+}
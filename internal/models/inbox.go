@@ -2,6 +2,7 @@ package models
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -16,9 +17,120 @@ type InboxMessage struct {
 	RespondedAt *time.Time  `json:"responded_at,omitempty"`
 	CreatedAt   time.Time   `json:"created_at"`
 
+	// Options are the choices offered by a decision message; Choice is the
+	// key of the one a human chose.
+	Options []DecisionOption `json:"options,omitempty"`
+	Choice  string           `json:"choice,omitempty"`
+
+	// EscalatedAt is when the message passed its response SLA and was
+	// escalated as overdue.
+	EscalatedAt *time.Time `json:"escalated_at,omitempty"`
+
+	// WaitingSince is when an agent last replied to or reopened the
+	// message; its response SLA runs from then rather than CreatedAt.
+	WaitingSince *time.Time `json:"waiting_since,omitempty"`
+
 	// Computed fields (populated by queries)
-	TicketTitle string `json:"ticket_title,omitempty"`
-	TicketKey   string `json:"ticket_key,omitempty"`
+	TicketTitle    string   `json:"ticket_title,omitempty"`
+	TicketKey      string   `json:"ticket_key,omitempty"`
+	TicketPriority Priority `json:"ticket_priority,omitempty"`
+	ReplyCount     int      `json:"reply_count,omitempty"`
+
+	// DueAt and Overdue are set from the configured response SLAs for
+	// pending messages that have one.
+	DueAt   *time.Time `json:"due_at,omitempty"`
+	Overdue bool       `json:"overdue,omitempty"`
+}
+
+// DecisionOption is one of the choices offered by a decision message, such
+// as key "A" with label "use Postgres".
+type DecisionOption struct {
+	Key   string `json:"key"`
+	Label string `json:"label"`
+}
+
+// String returns the option as "KEY: label".
+func (o DecisionOption) String() string {
+	return o.Key + ": " + o.Label
+}
+
+// ParseDecisionOption parses an option written as "KEY: label".
+func ParseDecisionOption(s string) (DecisionOption, error) {
+	key, label, ok := strings.Cut(s, ":")
+	key = strings.TrimSpace(key)
+	label = strings.TrimSpace(label)
+	if !ok || key == "" || label == "" {
+		return DecisionOption{}, fmt.Errorf("invalid option %q (use \"KEY: label\")", s)
+	}
+	if strings.ContainsAny(key, " \t") {
+		return DecisionOption{}, fmt.Errorf("invalid option key %q: keys cannot contain spaces", key)
+	}
+	return DecisionOption{Key: key, Label: label}, nil
+}
+
+// ValidateDecisionOptions checks that a decision offers at least two
+// options with distinct keys.
+func ValidateDecisionOptions(options []DecisionOption) error {
+	if len(options) < 2 {
+		return fmt.Errorf("a decision needs at least two options")
+	}
+	seen := make(map[string]bool, len(options))
+	for _, o := range options {
+		if o.Key == "" || o.Label == "" {
+			return fmt.Errorf("options need a key and a label")
+		}
+		k := strings.ToLower(o.Key)
+		if seen[k] {
+			return fmt.Errorf("duplicate option key: %s", o.Key)
+		}
+		seen[k] = true
+	}
+	return nil
+}
+
+// Decision is a human's choice on a decision message, in the form agents
+// read from ticket show and the execution context.
+type Decision struct {
+	MessageID int64     `json:"message_id"`
+	Question  string    `json:"question"`
+	Choice    string    `json:"choice"`
+	Label     string    `json:"label"`
+	Response  string    `json:"response,omitempty"`
+	DecidedAt time.Time `json:"decided_at"`
+}
+
+// InboxReply is one reply in an inbox message's thread, from an agent or a
+// human.
+type InboxReply struct {
+	ID         int64     `json:"id"`
+	MessageID  int64     `json:"message_id"`
+	AuthorType ActorType `json:"author_type"`
+	Author     string    `json:"author,omitempty"`
+	Content    string    `json:"content"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Validate validates the reply fields.
+func (r *InboxReply) Validate() error {
+	if r.MessageID <= 0 {
+		return fmt.Errorf("message_id is required")
+	}
+	if r.AuthorType != ActorTypeHuman && r.AuthorType != ActorTypeAgent {
+		return fmt.Errorf("invalid author_type: %s", r.AuthorType)
+	}
+	if r.Content == "" {
+		return fmt.Errorf("content cannot be empty")
+	}
+	return nil
+}
+
+// InboxThread is an inbox message with its replies in order. The thread is
+// resolved once a human marks it so, which is when the message counts as
+// responded.
+type InboxThread struct {
+	Message  *InboxMessage `json:"message"`
+	Replies  []*InboxReply `json:"replies"`
+	Resolved bool          `json:"resolved"`
 }
 
 // Validate validates the inbox message fields.
@@ -32,9 +144,54 @@ func (m *InboxMessage) Validate() error {
 	if m.Content == "" {
 		return fmt.Errorf("content cannot be empty")
 	}
+	if len(m.Options) > 0 {
+		if m.MessageType != MessageTypeDecision {
+			return fmt.Errorf("options are only allowed on decision messages")
+		}
+		if err := ValidateDecisionOptions(m.Options); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// FindOption returns the option with the given key, compared without
+// regard to case, or nil if the message offers no such option.
+func (m *InboxMessage) FindOption(key string) *DecisionOption {
+	for i := range m.Options {
+		if strings.EqualFold(m.Options[i].Key, strings.TrimSpace(key)) {
+			return &m.Options[i]
+		}
+	}
+	return nil
+}
+
+// OptionKeys returns the keys of the message's options, in order.
+func (m *InboxMessage) OptionKeys() []string {
+	keys := make([]string, len(m.Options))
+	for i, o := range m.Options {
+		keys[i] = o.Key
+	}
+	return keys
+}
+
+// Decision returns the human's choice on the message, or nil if no option
+// has been chosen.
+func (m *InboxMessage) Decision() *Decision {
+	option := m.FindOption(m.Choice)
+	if m.Choice == "" || option == nil || m.RespondedAt == nil {
+		return nil
+	}
+	return &Decision{
+		MessageID: m.ID,
+		Question:  m.Content,
+		Choice:    option.Key,
+		Label:     option.Label,
+		Response:  m.Response,
+		DecidedAt: *m.RespondedAt,
+	}
+}
+
 // IsPending returns true if the message has not been responded to.
 func (m *InboxMessage) IsPending() bool {
 	return m.RespondedAt == nil
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDecisionOption(t *testing.T) {
+	o, err := ParseDecisionOption("A: use Postgres")
+	require.NoError(t, err)
+	assert.Equal(t, DecisionOption{Key: "A", Label: "use Postgres"}, o)
+	assert.Equal(t, "A: use Postgres", o.String())
+
+	// Only the first colon separates key and label
+	o, err = ParseDecisionOption("b:  wait: for v2 ")
+	require.NoError(t, err)
+	assert.Equal(t, DecisionOption{Key: "b", Label: "wait: for v2"}, o)
+
+	for _, s := range []string{"use Postgres", ": use Postgres", "A:", "option A: use Postgres"} {
+		_, err := ParseDecisionOption(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestValidateDecisionOptions(t *testing.T) {
+	assert.NoError(t, ValidateDecisionOptions([]DecisionOption{{"A", "Postgres"}, {"B", "SQLite"}}))
+	assert.Error(t, ValidateDecisionOptions([]DecisionOption{{"A", "Postgres"}}))
+	assert.ErrorContains(t, ValidateDecisionOptions([]DecisionOption{{"A", "Postgres"}, {"a", "SQLite"}}), "duplicate option key")
+
+	m := NewInboxMessage(1, MessageTypeQuestion, "Which database?", "")
+	m.Options = []DecisionOption{{"A", "Postgres"}, {"B", "SQLite"}}
+	assert.ErrorContains(t, m.Validate(), "only allowed on decision messages")
+	m.MessageType = MessageTypeDecision
+	assert.NoError(t, m.Validate())
+}
+
+func TestInboxMessage_Decision(t *testing.T) {
+	m := NewInboxMessage(1, MessageTypeDecision, "Which database?", "")
+	m.ID = 7
+	m.Options = []DecisionOption{{"A", "Postgres"}, {"B", "SQLite"}}
+	assert.Equal(t, "B", m.FindOption(" b ").Key)
+	assert.Nil(t, m.FindOption("C"))
+	assert.Nil(t, m.Decision())
+
+	now := time.Now()
+	m.Choice = "B"
+	m.Response = "B: SQLite"
+	m.RespondedAt = &now
+	d := m.Decision()
+	require.NotNil(t, d)
+	assert.Equal(t, &Decision{MessageID: 7, Question: "Which database?", Choice: "B", Label: "SQLite", Response: "B: SQLite", DecidedAt: now}, d)
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// labelRegex validates labels: lowercase alphanumeric plus ':', '.', '_', '/'
+// and '-', starting with a letter or number, up to 50 characters.
+var labelRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9:._/-]{0,49}$`)
+
+// NormalizeLabel trims and lowercases a label.
+func NormalizeLabel(label string) string {
+	return strings.ToLower(strings.TrimSpace(label))
+}
+
+// ValidateLabel validates a normalized label.
+func ValidateLabel(label string) error {
+	if label == "" {
+		return fmt.Errorf("label cannot be empty")
+	}
+	if !labelRegex.MatchString(label) {
+		return fmt.Errorf("invalid label %q: use up to 50 lowercase letters, numbers, ':', '.', '_', '/' or '-'", label)
+	}
+	return nil
+}
+
+// ParseLabels normalizes and validates a list of labels, dropping duplicates.
+// Each entry may itself be a comma-separated list.
+func ParseLabels(values []string) ([]string, error) {
+	var labels []string
+	seen := make(map[string]bool)
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			label := NormalizeLabel(part)
+			if err := ValidateLabel(label); err != nil {
+				return nil, err
+			}
+			if !seen[label] {
+				seen[label] = true
+				labels = append(labels, label)
+			}
+		}
+	}
+	return labels, nil
+}
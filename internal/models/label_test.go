@@ -0,0 +1,19 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLabels(t *testing.T) {
+	labels, err := ParseLabels([]string{" Area:API ", "needs-migration,area:api", "v1.2/x_y"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"area:api", "needs-migration", "v1.2/x_y"}, labels)
+
+	for _, bad := range []string{"", "two words", "-leading", "semi;colon"} {
+		_, err := ParseLabels([]string{bad})
+		assert.Error(t, err, "label %q should be rejected", bad)
+	}
+}
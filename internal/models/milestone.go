@@ -0,0 +1,85 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// Milestone status values.
+const (
+	MilestoneStatusOpen      = "open"
+	MilestoneStatusAchieved  = "achieved"
+	MilestoneStatusAbandoned = "abandoned"
+)
+
+// Milestone groups tickets within a project toward a goal and optional target date.
+// A ticket belongs to at most one milestone.
+type Milestone struct {
+	ID         int64      `json:"id"`
+	ProjectID  int64      `json:"project_id"`
+	Key        string     `json:"key"`
+	Name       string     `json:"name"`
+	Goal       string     `json:"goal,omitempty"`
+	TargetDate *time.Time `json:"target_date,omitempty"`
+	Status     string     `json:"status"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+
+	// Computed fields (populated by queries)
+	ProjectKey  string `json:"project_key,omitempty"`
+	TicketCount int    `json:"ticket_count"`
+	ClosedCount int    `json:"closed_count"`
+}
+
+// milestoneKeyRegex validates milestone keys (uppercase alphanumeric with underscores, 1-20 chars).
+var milestoneKeyRegex = regexp.MustCompile(`^[A-Z][A-Z0-9_]{0,19}$`)
+
+// ValidateMilestoneKey validates a milestone key.
+// Keys must be 1-20 characters, start with an uppercase letter, and contain
+// only uppercase letters, numbers, and underscores.
+func ValidateMilestoneKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("milestone key cannot be empty")
+	}
+	if !milestoneKeyRegex.MatchString(key) {
+		return fmt.Errorf("milestone key must be 1-20 uppercase alphanumeric characters or underscores, starting with a letter")
+	}
+	return nil
+}
+
+// ValidateMilestoneStatus validates a milestone status.
+func ValidateMilestoneStatus(status string) error {
+	switch status {
+	case MilestoneStatusOpen, MilestoneStatusAchieved, MilestoneStatusAbandoned:
+		return nil
+	}
+	return fmt.Errorf("invalid milestone status: %q (must be open, achieved, or abandoned)", status)
+}
+
+// Validate validates the milestone fields.
+func (m *Milestone) Validate() error {
+	if m.ProjectID <= 0 {
+		return fmt.Errorf("project_id is required")
+	}
+	if err := ValidateMilestoneKey(m.Key); err != nil {
+		return err
+	}
+	if m.Name == "" {
+		return fmt.Errorf("milestone name cannot be empty")
+	}
+	return ValidateMilestoneStatus(m.Status)
+}
+
+// IsOpen returns true if the milestone is still open.
+func (m *Milestone) IsOpen() bool {
+	return m.Status == MilestoneStatusOpen
+}
+
+// Progress returns the percentage of the milestone's tickets that are closed.
+func (m *Milestone) Progress() float64 {
+	if m.TicketCount == 0 {
+		return 0
+	}
+	return float64(m.ClosedCount) / float64(m.TicketCount) * 100
+}
@@ -12,10 +12,65 @@ type Project struct {
 	Key         string    `json:"key"`
 	Name        string    `json:"name"`
 	Description string    `json:"description,omitempty"`
+	WIPLimits   WIPLimits `json:"wip_limits"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
 
+// WIPLimits caps how many of a project's tickets may be in progress at once.
+// Total counts working and reviewing tickets together. Zero means no limit.
+type WIPLimits struct {
+	Total     int `json:"total,omitempty" yaml:"total,omitempty"`
+	Working   int `json:"working,omitempty" yaml:"working,omitempty"`
+	Reviewing int `json:"reviewing,omitempty" yaml:"reviewing,omitempty"`
+}
+
+// IsZero reports whether no limit is set.
+func (l WIPLimits) IsZero() bool {
+	return l.Total == 0 && l.Working == 0 && l.Reviewing == 0
+}
+
+// Validate checks that no limit is negative.
+func (l WIPLimits) Validate() error {
+	if l.Total < 0 || l.Working < 0 || l.Reviewing < 0 {
+		return fmt.Errorf("WIP limits cannot be negative")
+	}
+	return nil
+}
+
+// WIPUsage is a project's work in progress measured against its limits.
+type WIPUsage struct {
+	Working   int       `json:"working"`
+	Reviewing int       `json:"reviewing"`
+	Limits    WIPLimits `json:"limits"`
+}
+
+// InProgress returns the number of working and reviewing tickets.
+func (u *WIPUsage) InProgress() int {
+	return u.Working + u.Reviewing
+}
+
+// Exceeded returns which limit moving one more ticket into status would
+// break, or an empty string if none would.
+func (u *WIPUsage) Exceeded(status Status) string {
+	switch status {
+	case StatusWorking:
+		if u.Limits.Working > 0 && u.Working >= u.Limits.Working {
+			return fmt.Sprintf("%d of %d working", u.Working, u.Limits.Working)
+		}
+	case StatusReviewing:
+		if u.Limits.Reviewing > 0 && u.Reviewing >= u.Limits.Reviewing {
+			return fmt.Sprintf("%d of %d reviewing", u.Reviewing, u.Limits.Reviewing)
+		}
+	default:
+		return ""
+	}
+	if u.Limits.Total > 0 && u.InProgress() >= u.Limits.Total {
+		return fmt.Sprintf("%d of %d in progress", u.InProgress(), u.Limits.Total)
+	}
+	return ""
+}
+
 // ProjectStats holds statistics for a project.
 type ProjectStats struct {
 	TotalTickets         int `json:"total_tickets"`
@@ -26,6 +81,9 @@ type ProjectStats struct {
 	ReviewCount          int `json:"review_count"`
 	ClosedCompletedCount int `json:"closed_completed_count"`
 	ClosedOtherCount     int `json:"closed_other_count"`
+
+	// WIP is work in progress against the project's WIP limits.
+	WIP *WIPUsage `json:"wip,omitempty"`
 }
 
 // projectKeyRegex validates project keys (uppercase alphanumeric, 2-10 chars).
@@ -50,5 +108,5 @@ func (p *Project) Validate() error {
 	if p.Name == "" {
 		return fmt.Errorf("project name cannot be empty")
 	}
-	return nil
+	return p.WIPLimits.Validate()
 }
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// RecurringRun records when a recurring ticket template last ran and the
+// ticket it last created.
+type RecurringRun struct {
+	Name          string    `json:"name"`
+	LastRunAt     time.Time `json:"last_run_at"`
+	LastTicketID  *int64    `json:"last_ticket_id,omitempty"`
+	LastTicketKey string    `json:"last_ticket_key,omitempty"`
+}
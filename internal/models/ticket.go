@@ -33,6 +33,12 @@ type Ticket struct {
 	// Role (reference to a role for execution context)
 	RoleID *int64 `json:"role_id,omitempty"`
 
+	// Milestone (at most one, within the ticket's project)
+	MilestoneID *int64 `json:"milestone_id,omitempty"`
+
+	// Free-form labels (e.g., "area:api"), sorted
+	Labels []string `json:"labels,omitempty"`
+
 	// Retry tracking
 	RetryCount int `json:"retry_count"`
 	MaxRetries int `json:"max_retries"`
@@ -40,15 +46,20 @@ type Ticket struct {
 	// Hierarchy (for decomposition)
 	ParentTicketID *int64 `json:"parent_ticket_id,omitempty"`
 
+	// Scheduling: not workable before NotBefore, overdue after DueAt
+	NotBefore *time.Time `json:"not_before,omitempty"`
+	DueAt     *time.Time `json:"due_at,omitempty"`
+
 	// Timestamps
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
 	CompletedAt *time.Time `json:"completed_at,omitempty"`
 
 	// Computed fields (not stored in DB, populated by queries)
-	ProjectKey string `json:"project_key,omitempty"`
-	TicketKey  string `json:"ticket_key,omitempty"`
-	RoleName   string `json:"role_name,omitempty"`
+	ProjectKey   string `json:"project_key,omitempty"`
+	TicketKey    string `json:"ticket_key,omitempty"`
+	RoleName     string `json:"role_name,omitempty"`
+	MilestoneKey string `json:"milestone_key,omitempty"`
 }
 
 // Key returns the ticket key in the format PROJECT-NUMBER.
@@ -107,6 +118,16 @@ func (t *Ticket) IsWorkable() bool {
 	return t.Status.IsWorkable()
 }
 
+// IsDeferred returns true if the ticket may not be worked on until after now.
+func (t *Ticket) IsDeferred(now time.Time) bool {
+	return t.NotBefore != nil && t.NotBefore.After(now)
+}
+
+// IsOverdue returns true if the ticket is still open after its due date.
+func (t *Ticket) IsOverdue(now time.Time) bool {
+	return t.DueAt != nil && t.DueAt.Before(now) && !t.IsTerminal()
+}
+
 // IsTerminal returns true if the ticket is in a terminal state.
 func (t *Ticket) IsTerminal() bool {
 	return t.Status.IsTerminal()
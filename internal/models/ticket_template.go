@@ -0,0 +1,91 @@
+package models
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// TicketTemplate pre-fills new tickets: a description rendered with
+// text/template from variables given at creation, a priority, complexity and
+// role, and an initial task checklist. Empty fields leave the ticket's
+// defaults alone.
+type TicketTemplate struct {
+	ID          int64      `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	Priority    Priority   `json:"priority,omitempty"`
+	Complexity  Complexity `json:"complexity,omitempty"`
+	RoleID      *int64     `json:"role_id,omitempty"`
+	Tasks       []string   `json:"tasks"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+
+	// Computed fields (populated by queries)
+	RoleName string `json:"role,omitempty"`
+}
+
+// templateNameRegex validates template names (lowercase alphanumeric with hyphens, 2-50 chars).
+var templateNameRegex = regexp.MustCompile(`^[a-z][a-z0-9-]{1,49}$`)
+
+// ValidateTemplateName validates a ticket template name. Like role names,
+// template names are 2-50 lowercase letters, numbers and hyphens, starting
+// with a letter.
+func ValidateTemplateName(name string) error {
+	if name == "" {
+		return fmt.Errorf("template name cannot be empty")
+	}
+	if !templateNameRegex.MatchString(name) || strings.Contains(name, "--") {
+		return fmt.Errorf("template name must be 2-50 lowercase alphanumeric characters with hyphens, starting with a letter")
+	}
+	return nil
+}
+
+// Validate validates the template's name, levels, tasks and description
+// template syntax.
+func (t *TicketTemplate) Validate() error {
+	if err := ValidateTemplateName(t.Name); err != nil {
+		return err
+	}
+	if t.Priority != "" && !t.Priority.IsValid() {
+		return fmt.Errorf("invalid priority: %s", t.Priority)
+	}
+	if t.Complexity != "" && !t.Complexity.IsValid() {
+		return fmt.Errorf("invalid complexity: %s", t.Complexity)
+	}
+	for _, task := range t.Tasks {
+		if strings.TrimSpace(task) == "" {
+			return fmt.Errorf("template tasks cannot be empty")
+		}
+	}
+	_, err := t.parse()
+	return err
+}
+
+func (t *TicketTemplate) parse() (*template.Template, error) {
+	tmpl, err := template.New(t.Name).Option("missingkey=error").Parse(t.Description)
+	if err != nil {
+		return nil, fmt.Errorf("invalid description template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// Render renders the description template with vars, available as
+// {{.name}}. A variable the template uses but vars doesn't set is an error.
+func (t *TicketTemplate) Render(vars map[string]string) (string, error) {
+	tmpl, err := t.parse()
+	if err != nil {
+		return "", err
+	}
+	if vars == nil {
+		vars = map[string]string{}
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, vars); err != nil {
+		return "", fmt.Errorf("failed to render template %s: %w", t.Name, err)
+	}
+	return buf.String(), nil
+}
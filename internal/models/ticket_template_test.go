@@ -0,0 +1,34 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTicketTemplate_Render(t *testing.T) {
+	tmpl := &TicketTemplate{Name: "bug", Description: "Component: {{.component}}\n{{if .browser}}Browser: {{.browser}}{{end}}"}
+	require.NoError(t, tmpl.Validate())
+
+	got, err := tmpl.Render(map[string]string{"component": "auth", "browser": "Safari"})
+	require.NoError(t, err)
+	assert.Equal(t, "Component: auth\nBrowser: Safari", got)
+
+	_, err = tmpl.Render(map[string]string{"browser": "Safari"})
+	assert.ErrorContains(t, err, `map has no entry for key "component"`)
+}
+
+func TestTicketTemplate_Validate(t *testing.T) {
+	for _, tmpl := range []*TicketTemplate{
+		{Name: "B"},
+		{Name: "bug--fix"},
+		{Name: "bug", Priority: "urgent"},
+		{Name: "bug", Complexity: "huge"},
+		{Name: "bug", Tasks: []string{" "}},
+		{Name: "bug", Description: "{{.component"},
+	} {
+		assert.Error(t, tmpl.Validate(), "%+v", tmpl)
+	}
+	assert.NoError(t, (&TicketTemplate{Name: "bug", Priority: PriorityHigh, Tasks: []string{"Reproduce"}}).Validate())
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Webhook delivery status values.
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusDelivered = "delivered"
+	DeliveryStatusFailed    = "failed"
+)
+
+// ValidateDeliveryStatus validates a webhook delivery status.
+func ValidateDeliveryStatus(status string) error {
+	switch status {
+	case DeliveryStatusPending, DeliveryStatusDelivered, DeliveryStatusFailed:
+		return nil
+	}
+	return fmt.Errorf("invalid delivery status: %q (must be pending, delivered, or failed)", status)
+}
+
+// WebhookDelivery is one event queued for one webhook. Pending deliveries
+// are retried with backoff until they succeed or run out of attempts.
+type WebhookDelivery struct {
+	ID            int64      `json:"id"`
+	Webhook       string     `json:"webhook"`
+	EventID       int64      `json:"event_id"`
+	EventType     EventType  `json:"event_type"`
+	TicketKey     string     `json:"ticket_key,omitempty"`
+	Payload       string     `json:"payload"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	NextAttemptAt time.Time  `json:"next_attempt_at"`
+	LastAttemptAt *time.Time `json:"last_attempt_at,omitempty"`
+	ResponseCode  int        `json:"response_code,omitempty"`
+	LastError     string     `json:"last_error,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	DeliveredAt   *time.Time `json:"delivered_at,omitempty"`
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/spetersoncode/wark/internal/db"
+	"github.com/spetersoncode/wark/internal/models"
 )
 
 // AnalyticsResponse is the combined analytics response.
@@ -22,10 +23,11 @@ type AnalyticsResponse struct {
 
 // AnalyticsFilterResponse shows what filters were applied.
 type AnalyticsFilterResponse struct {
-	Project   string `json:"project,omitempty"`
-	Since     string `json:"since,omitempty"`
-	Until     string `json:"until,omitempty"`
-	TrendDays int    `json:"trend_days"`
+	Project   string   `json:"project,omitempty"`
+	Labels    []string `json:"labels,omitempty"`
+	Since     string   `json:"since,omitempty"`
+	Until     string   `json:"until,omitempty"`
+	TrendDays int      `json:"trend_days"`
 }
 
 // handleGetAnalytics returns all analytics metrics.
@@ -41,6 +43,16 @@ func (s *Server) handleGetAnalytics(w http.ResponseWriter, r *http.Request) {
 		filterResp.Project = filter.ProjectKey
 	}
 
+	if labelParams := r.URL.Query()["label"]; len(labelParams) > 0 {
+		labels, err := models.ParseLabels(labelParams)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		filter.Labels = labels
+		filterResp.Labels = labels
+	}
+
 	if sinceStr := r.URL.Query().Get("since"); sinceStr != "" {
 		if since, err := time.Parse("2006-01-02", sinceStr); err == nil {
 			filter.Since = &since
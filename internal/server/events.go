@@ -0,0 +1,131 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/spetersoncode/wark/internal/db"
+	"github.com/spetersoncode/wark/internal/models"
+)
+
+const (
+	// eventBatchSize caps how many activity entries are read per poll.
+	eventBatchSize = 200
+
+	// eventKeepAlive is how often an idle stream sends a comment line so
+	// proxies and clients don't time it out.
+	eventKeepAlive = 15 * time.Second
+)
+
+// handleEvents streams ticket and inbox changes as Server-Sent Events.
+//
+// Events are derived from activity log entries and carry the entry's ID, so
+// a client that reconnects with Last-Event-ID (or ?last_event_id=) receives
+// everything written since. Without one, the stream starts with new activity.
+// ?type= limits the stream to the given event types (repeatable or
+// comma-separated).
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	types, err := models.ParseEventTypes(r.URL.Query()["type"])
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	wanted := make(map[models.EventType]bool, len(types))
+	for _, t := range types {
+		wanted[t] = true
+	}
+
+	activityRepo := db.NewActivityRepo(s.config.DB)
+
+	lastID, err := lastEventID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if lastID < 0 {
+		if lastID, err = activityRepo.LatestID(); err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
+	// The stream outlives the server's WriteTimeout, so lift the deadline
+	// for this response. Recorders used in tests don't support it.
+	rc := http.NewResponseController(w)
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "retry: %d\n\n", (3 * time.Second).Milliseconds())
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	poll := time.NewTicker(s.config.EventPollInterval)
+	defer poll.Stop()
+	keepAlive := time.NewTicker(eventKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		entries, err := activityRepo.ListAfter(lastID, eventBatchSize)
+		if err != nil {
+			s.logger.Printf("events: failed to read activity log: %v", err)
+		}
+		for _, entry := range entries {
+			lastID = entry.ID
+			event, ok := models.EventFromActivity(entry)
+			if !ok || (len(wanted) > 0 && !wanted[event.Type]) {
+				continue
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+		}
+		if len(entries) > 0 {
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		}
+		// A full batch means more may be waiting; read again right away.
+		if len(entries) == eventBatchSize {
+			continue
+		}
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		case <-poll.C:
+		}
+	}
+}
+
+// lastEventID returns the activity ID a client has already seen, from the
+// Last-Event-ID header or last_event_id query parameter, or -1 if neither is
+// set.
+func lastEventID(r *http.Request) (int64, error) {
+	value := r.Header.Get("Last-Event-ID")
+	if value == "" {
+		value = r.URL.Query().Get("last_event_id")
+	}
+	if value == "" {
+		return -1, nil
+	}
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || id < 0 {
+		return 0, fmt.Errorf("invalid last event id: %s", value)
+	}
+	return id, nil
+}
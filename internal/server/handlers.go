@@ -2,9 +2,12 @@ package server
 
 import (
 	"encoding/json"
+	"html"
+	"io"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spetersoncode/wark/internal/common"
 	"github.com/spetersoncode/wark/internal/db"
@@ -24,42 +27,76 @@ type ProjectResponse struct {
 	CreatedAt   string                 `json:"created_at"`
 	UpdatedAt   string                 `json:"updated_at"`
 	Stats       *models.ProjectStats   `json:"stats,omitempty"`
+	Milestones  []MilestoneResponse    `json:"milestones,omitempty"`
+}
+
+// MilestoneResponse represents a milestone and its progress in API responses.
+type MilestoneResponse struct {
+	Key         string  `json:"key"`
+	Name        string  `json:"name"`
+	Goal        string  `json:"goal,omitempty"`
+	Status      string  `json:"status"`
+	TargetDate  string  `json:"target_date,omitempty"`
+	TicketCount int     `json:"ticket_count"`
+	ClosedCount int     `json:"closed_count"`
+	Progress    float64 `json:"progress"`
 }
 
 // TicketResponse represents a ticket in API responses.
+
 type TicketResponse struct {
-	ID              int64  `json:"id"`
-	Key             string `json:"ticket_key"`
-	ProjectKey      string `json:"project_key"`
-	Number          int    `json:"number"`
-	Title           string `json:"title"`
-	Description     string `json:"description,omitempty"`
-	Status          string `json:"status"`
-	HumanFlagReason string `json:"human_flag_reason,omitempty"`
-	Priority        string `json:"priority"`
-	Complexity      string `json:"complexity"`
-	Type            string `json:"type"`
-	Worktree        string `json:"worktree,omitempty"`
-	RetryCount      int    `json:"retry_count"`
-	MaxRetries      int    `json:"max_retries"`
-	ParentTicketID  *int64 `json:"parent_ticket_id,omitempty"`
-	CreatedAt       string `json:"created_at"`
-	UpdatedAt       string `json:"updated_at"`
-	CompletedAt     string `json:"completed_at,omitempty"`
+	ID              int64    `json:"id"`
+	Key             string   `json:"ticket_key"`
+	ProjectKey      string   `json:"project_key"`
+	Number          int      `json:"number"`
+	Title           string   `json:"title"`
+	Description     string   `json:"description,omitempty"`
+	Status          string   `json:"status"`
+	HumanFlagReason string   `json:"human_flag_reason,omitempty"`
+	Priority        string   `json:"priority"`
+	Complexity      string   `json:"complexity"`
+	Type            string   `json:"type"`
+	Worktree        string   `json:"worktree,omitempty"`
+	Labels          []string `json:"labels"`
+	MilestoneKey    string   `json:"milestone_key,omitempty"`
+	RetryCount      int      `json:"retry_count"`
+	MaxRetries      int      `json:"max_retries"`
+	ParentTicketID  *int64   `json:"parent_ticket_id,omitempty"`
+	NotBefore       string   `json:"not_before,omitempty"`
+	DueAt           string   `json:"due_at,omitempty"`
+	Overdue         bool     `json:"overdue,omitempty"`
+	CreatedAt       string   `json:"created_at"`
+	UpdatedAt       string   `json:"updated_at"`
+	CompletedAt     string   `json:"completed_at,omitempty"`
+}
+
+// SearchResultResponse is a ticket matching a search. Snippet is HTML-escaped
+// with matched terms wrapped in <mark>.
+type SearchResultResponse struct {
+	TicketResponse
+	Source  string  `json:"source"`
+	Snippet string  `json:"snippet"`
+	Score   float64 `json:"score"`
 }
 
 // InboxResponse represents an inbox message in API responses.
 type InboxResponse struct {
-	ID          int64  `json:"id"`
-	TicketID    int64  `json:"ticket_id"`
-	TicketKey   string `json:"ticket_key"`
-	TicketTitle string `json:"ticket_title"`
-	MessageType string `json:"message_type"`
-	Content     string `json:"content"`
-	FromAgent   string `json:"from_agent,omitempty"`
-	Response    string `json:"response,omitempty"`
-	RespondedAt string `json:"responded_at,omitempty"`
-	CreatedAt   string `json:"created_at"`
+	ID          int64                   `json:"id"`
+	TicketID    int64                   `json:"ticket_id"`
+	TicketKey   string                  `json:"ticket_key"`
+	TicketTitle string                  `json:"ticket_title"`
+	MessageType string                  `json:"message_type"`
+	Content     string                  `json:"content"`
+	FromAgent   string                  `json:"from_agent,omitempty"`
+	Options     []models.DecisionOption `json:"options,omitempty"`
+	Response    string                  `json:"response,omitempty"`
+	Choice      string                  `json:"choice,omitempty"`
+	RespondedAt string                  `json:"responded_at,omitempty"`
+	ReplyCount  int                     `json:"reply_count"`
+	CreatedAt   string                  `json:"created_at"`
+	DueAt       string                  `json:"due_at,omitempty"`
+	Overdue     bool                    `json:"overdue,omitempty"`
+	EscalatedAt string                  `json:"escalated_at,omitempty"`
 }
 
 // ClaimResponse represents a claim in API responses.
@@ -90,15 +127,18 @@ type ActivityResponse struct {
 
 // StatusResponse represents the status overview.
 type StatusResponse struct {
-	Workable       int                  `json:"workable"`
-	Working     int                  `json:"working"`
-	Review         int                  `json:"review"`
-	BlockedDeps    int                  `json:"blocked_deps"`
-	BlockedHuman   int                  `json:"blocked_human"`
-	PendingInbox   int                  `json:"pending_inbox"`
-	ExpiringSoon   []ExpiringSoonItem   `json:"expiring_soon"`
-	RecentActivity []ActivityItem       `json:"recent_activity"`
-	Project        string               `json:"project,omitempty"`
+	Workable       int                          `json:"workable"`
+	Working        int                          `json:"working"`
+	Review         int                          `json:"review"`
+	BlockedDeps    int                          `json:"blocked_deps"`
+	BlockedHuman   int                          `json:"blocked_human"`
+	PendingInbox   int                          `json:"pending_inbox"`
+	ExpiringSoon   []ExpiringSoonItem           `json:"expiring_soon"`
+	Overdue        []service.OverdueItem        `json:"overdue"`
+	OverdueInbox   []service.OverdueMessageItem `json:"overdue_inbox"`
+	RecentActivity []ActivityItem               `json:"recent_activity"`
+	Project        string                       `json:"project,omitempty"`
+	WIP            []service.ProjectWIP         `json:"wip,omitempty"`
 }
 
 // ExpiringSoonItem represents a claim expiring soon.
@@ -149,6 +189,21 @@ func writeSharedError(w http.ResponseWriter, err *errors.Error) {
 	})
 }
 
+// writeServiceError writes an error returned by a service, mapping ticket
+// and shared errors to their HTTP status.
+func writeServiceError(w http.ResponseWriter, err error) {
+	switch e := err.(type) {
+	case *errors.Error:
+		writeSharedError(w, e)
+	case *service.TicketError:
+		writeSharedError(w, &errors.Error{Kind: e.Kind(), Message: e.Message, Details: e.Details})
+	case *service.MilestoneError:
+		writeSharedError(w, &errors.Error{Kind: e.Kind(), Message: e.Message})
+	default:
+		writeError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
 // Project handlers
 
 func (s *Server) handleListProjects(w http.ResponseWriter, r *http.Request) {
@@ -190,7 +245,18 @@ func (s *Server) handleGetProject(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	writeJSON(w, http.StatusOK, projectToResponse(project))
+	milestones, err := db.NewMilestoneRepo(s.config.DB).List(&project.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	resp := projectToResponse(project)
+	for _, m := range milestones {
+		resp.Milestones = append(resp.Milestones, milestoneToResponse(m))
+	}
+
+	writeJSON(w, http.StatusOK, resp)
 }
 
 func (s *Server) handleGetProjectStats(w http.ResponseWriter, r *http.Request) {
@@ -220,6 +286,61 @@ func (s *Server) handleGetProjectStats(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, stats)
 }
 
+// handleGetProjectGraph returns a project's dependency graph. ?epic=KEY limits
+// it to an epic and its descendants; ?format=dot or mermaid returns text
+// instead of JSON.
+func (s *Server) handleGetProjectGraph(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "dot" && format != "mermaid" {
+		writeError(w, http.StatusBadRequest, "format must be json, dot or mermaid")
+		return
+	}
+
+	graph, err := service.NewTicketService(s.config.DB).Graph(service.GraphOptions{
+		ProjectKey: r.PathValue("key"),
+		EpicKey:    r.URL.Query().Get("epic"),
+	})
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	switch format {
+	case "dot":
+		w.Header().Set("Content-Type", "text/vnd.graphviz; charset=utf-8")
+		io.WriteString(w, graph.DOT())
+	case "mermaid":
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		io.WriteString(w, graph.Mermaid())
+	default:
+		writeJSON(w, http.StatusOK, graph)
+	}
+}
+
+// handleGetProjectCriticalPath returns the longest chain of open work in a
+// project, or in ?epic=KEY, and the tickets blocking the most work.
+func (s *Server) handleGetProjectCriticalPath(w http.ResponseWriter, r *http.Request) {
+	limit := 0
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		l, err := strconv.Atoi(limitStr)
+		if err != nil || l <= 0 {
+			writeError(w, http.StatusBadRequest, "limit must be a positive number")
+			return
+		}
+		limit = l
+	}
+
+	result, err := service.NewTicketService(s.config.DB).CriticalPath(service.GraphOptions{
+		ProjectKey: r.PathValue("key"),
+		EpicKey:    r.URL.Query().Get("epic"),
+	}, limit)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
 // Ticket handlers
 
 func (s *Server) handleSearchTickets(w http.ResponseWriter, r *http.Request) {
@@ -236,21 +357,44 @@ func (s *Server) handleSearchTickets(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	repo := db.NewTicketRepo(s.config.DB)
-	tickets, err := repo.Search(query, limit)
+	repo := db.NewSearchRepo(s.config.DB)
+	results, err := repo.Search(query, db.SearchOptions{
+		ProjectKey:     strings.ToUpper(r.URL.Query().Get("project")),
+		Limit:          limit,
+		HighlightOpen:  highlightOpen,
+		HighlightClose: highlightClose,
+	})
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	response := make([]TicketResponse, 0, len(tickets))
-	for _, t := range tickets {
-		response = append(response, ticketToResponse(t))
+	response := make([]SearchResultResponse, 0, len(results))
+	for _, res := range results {
+		response = append(response, SearchResultResponse{
+			TicketResponse: ticketToResponse(res.Ticket),
+			Source:         res.Source,
+			Snippet:        highlightHTML(res.Snippet),
+			Score:          res.Score,
+		})
 	}
 
 	writeJSON(w, http.StatusOK, response)
 }
 
+// Search snippets are highlighted with control characters that cannot occur
+// in ticket text, then HTML-escaped and given <mark> tags.
+const (
+	highlightOpen  = "\x02"
+	highlightClose = "\x03"
+)
+
+var highlightReplacer = strings.NewReplacer(highlightOpen, "<mark>", highlightClose, "</mark>")
+
+func highlightHTML(snippet string) string {
+	return highlightReplacer.Replace(html.EscapeString(snippet))
+}
+
 func (s *Server) handleListTickets(w http.ResponseWriter, r *http.Request) {
 	repo := db.NewTicketRepo(s.config.DB)
 
@@ -274,10 +418,21 @@ func (s *Server) handleListTickets(w http.ResponseWriter, r *http.Request) {
 		c := models.Complexity(complexity)
 		filter.Complexity = &c
 	}
-	// Note: milestone filter removed - milestones were deprecated in WARK-13
-	// if milestoneKey := r.URL.Query().Get("milestone"); milestoneKey != "" {
-	// 	filter.MilestoneKey = strings.ToUpper(milestoneKey)
-	// }
+	if milestoneKey := r.URL.Query().Get("milestone"); milestoneKey != "" {
+		if filter.ProjectKey == "" {
+			writeError(w, http.StatusBadRequest, "milestone filter requires project")
+			return
+		}
+		filter.MilestoneKey = strings.ToUpper(milestoneKey)
+	}
+	if labelParams := r.URL.Query()["label"]; len(labelParams) > 0 {
+		labels, err := models.ParseLabels(labelParams)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		filter.Labels = labels
+	}
 	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
 		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 {
 			filter.Limit = limit
@@ -359,6 +514,13 @@ func (s *Server) handleGetTicket(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Get decisions made on the ticket's inbox messages
+	decisions, err := db.NewInboxRepo(s.config.DB).ListDecisions(ticket.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
 	// Build response matching frontend expectations
 	ticketResp := ticketToResponse(ticket)
 	response := struct {
@@ -367,11 +529,13 @@ func (s *Server) handleGetTicket(w http.ResponseWriter, r *http.Request) {
 		Dependents   []TicketResponse    `json:"dependents"`
 		Claim        *ClaimResponse      `json:"claim,omitempty"`
 		History      []*ActivityResponse `json:"history"`
+		Decisions    []*models.Decision  `json:"decisions"`
 	}{
 		Ticket:       &ticketResp,
 		Dependencies: make([]TicketResponse, len(dependencies)),
 		Dependents:   make([]TicketResponse, len(dependents)),
 		History:      make([]*ActivityResponse, len(history)),
+		Decisions:    decisions,
 	}
 
 	for i, dep := range dependencies {
@@ -432,6 +596,99 @@ func (s *Server) handleGetTicketExecutionContext(w http.ResponseWriter, r *http.
 	writeJSON(w, http.StatusOK, ctx)
 }
 
+// ClaimNextResponse is the response for POST /api/tickets/next.
+// Ticket and Claim are null when no ticket was available.
+type ClaimNextResponse struct {
+	Ticket   *TicketResponse `json:"ticket"`
+	Claim    *ClaimResponse  `json:"claim"`
+	Worktree string          `json:"worktree,omitempty"`
+}
+
+func (s *Server) handleClaimNext(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Project       string   `json:"project"`
+		MaxComplexity string   `json:"max_complexity"`
+		Capability    string   `json:"capability"`
+		Role          string   `json:"role"`
+		Labels        []string `json:"labels"`
+		ByImpact      bool     `json:"by_impact"`
+		Policy        string   `json:"policy"`
+		WorkerID      string   `json:"worker_id"`
+		DurationMins  int      `json:"duration_mins"`
+	}
+	// An empty body claims with the defaults
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	filter := db.TicketFilter{
+		ProjectKey: strings.ToUpper(req.Project),
+	}
+	if req.MaxComplexity != "" {
+		c, err := models.ParseComplexity(req.MaxComplexity)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		filter.MaxComplexity = &c
+	}
+	if req.Capability != "" {
+		filter.Capability = strings.ToLower(req.Capability)
+		if !models.IsValidCapability(filter.Capability) {
+			writeError(w, http.StatusBadRequest, "invalid capability: must be fast, standard, or powerful")
+			return
+		}
+	}
+	filter.RoleName = req.Role
+	if len(req.Labels) > 0 {
+		labels, err := models.ParseLabels(req.Labels)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		filter.Labels = labels
+	}
+
+	cfg := s.config.Settings
+	if req.DurationMins <= 0 {
+		req.DurationMins = cfg.ClaimDuration
+	}
+	if req.Policy == "" {
+		req.Policy = cfg.Scheduling.Policy
+	}
+	policy, err := service.ParseSchedulingPolicy(req.Policy)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ticketService := service.NewTicketService(s.config.DB).
+		BreakTiesByImpact(req.ByImpact).
+		WithScheduling(service.SchedulingOptions{
+			Policy:        policy,
+			AgingInterval: time.Duration(cfg.Scheduling.AgingHours) * time.Hour,
+		})
+	result, err := ticketService.ClaimNext(filter, req.WorkerID, time.Duration(req.DurationMins)*time.Minute)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	response := ClaimNextResponse{}
+	if result != nil {
+		ticket := ticketToResponse(result.Ticket)
+		claim := claimToResponse(result.Claim)
+		claim.TicketKey = result.Ticket.TicketKey
+		claim.TicketTitle = result.Ticket.Title
+		response.Ticket = &ticket
+		response.Claim = &claim
+		response.Worktree = result.Branch
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
 // Inbox handlers
 
 func (s *Server) handleListInbox(w http.ResponseWriter, r *http.Request) {
@@ -462,8 +719,14 @@ func (s *Server) handleListInbox(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	s.slaPolicy.Apply(messages, time.Now())
+	overdueOnly := r.URL.Query().Get("overdue") == "true"
+
 	response := make([]InboxResponse, 0, len(messages))
 	for _, m := range messages {
+		if overdueOnly && !m.Overdue {
+			continue
+		}
 		response = append(response, inboxToResponse(m))
 	}
 
@@ -488,6 +751,7 @@ func (s *Server) handleGetInboxMessage(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusNotFound, "message not found")
 		return
 	}
+	s.slaPolicy.Apply([]*models.InboxMessage{message}, time.Now())
 
 	writeJSON(w, http.StatusOK, inboxToResponse(message))
 }
@@ -502,24 +766,28 @@ func (s *Server) handleRespondInbox(w http.ResponseWriter, r *http.Request) {
 
 	var req struct {
 		Response string `json:"response"`
+		Choice   string `json:"choice"`
+		Then     string `json:"then"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		writeError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
-	if req.Response == "" {
+	if req.Response == "" && req.Choice == "" {
 		writeError(w, http.StatusBadRequest, "response is required")
 		return
 	}
+	var then *service.RespondAction
+	if req.Then != "" {
+		if then, err = service.ParseRespondAction(req.Then); err != nil {
+			writeServiceError(w, err)
+			return
+		}
+	}
 
-	// Use InboxService for the respond operation
-	inboxRepo := db.NewInboxRepo(s.config.DB)
-	ticketRepo := db.NewTicketRepo(s.config.DB)
-	claimRepo := db.NewClaimRepo(s.config.DB)
-	activityRepo := db.NewActivityRepo(s.config.DB)
-
-	inboxService := service.NewInboxService(inboxRepo, ticketRepo, claimRepo, activityRepo)
-	result, err := inboxService.Respond(id, req.Response)
+	// Respond and apply the action, if any, in one transaction
+	ticketService := service.NewTicketService(s.config.DB)
+	result, err := ticketService.RespondAndAct(id, service.RespondInput{Response: req.Response, Choice: req.Choice}, then)
 	if err != nil {
 		// Convert shared errors to appropriate HTTP responses
 		if sharedErr, ok := err.(*errors.Error); ok {
@@ -604,6 +872,64 @@ func (s *Server) handleGetClaim(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, claimToResponse(claim))
 }
 
+// HeartbeatResponse is the response for POST /api/claims/{ticketKey}/heartbeat.
+type HeartbeatResponse struct {
+	Claim  ClaimResponse `json:"claim"`
+	Capped bool          `json:"capped"`
+}
+
+func (s *Server) handleClaimHeartbeat(w http.ResponseWriter, r *http.Request) {
+	ticketKey := strings.ToUpper(r.PathValue("ticketKey"))
+	projectKey, number, err := common.ParseTicketKey(ticketKey)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req struct {
+		ExtendMins int    `json:"extend_mins"`
+		WorkerID   string `json:"worker_id"`
+		Force      bool   `json:"force"`
+	}
+	// An empty body extends by the configured claim duration
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	ticketRepo := db.NewTicketRepo(s.config.DB)
+	ticket, err := ticketRepo.GetByKey(projectKey, number)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if ticket == nil {
+		writeError(w, http.StatusNotFound, "ticket not found")
+		return
+	}
+
+	cfg := s.config.Settings
+	if req.ExtendMins <= 0 {
+		req.ExtendMins = cfg.ClaimDuration
+	}
+
+	ticketService := service.NewTicketService(s.config.DB).ActingAs(req.WorkerID, req.Force)
+	result, err := ticketService.Heartbeat(ticket.ID,
+		time.Duration(req.ExtendMins)*time.Minute,
+		time.Duration(cfg.ClaimMaxLifetime)*time.Minute)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	claim := claimToResponse(result.Claim)
+	claim.TicketKey = ticket.TicketKey
+	claim.TicketTitle = ticket.Title
+	claim.MinutesRemaining = int(result.Claim.TimeRemaining().Minutes())
+
+	writeJSON(w, http.StatusOK, HeartbeatResponse{Claim: claim, Capped: result.Capped})
+}
+
 // Status handler
 
 func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
@@ -614,7 +940,9 @@ func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
 	claimRepo := db.NewClaimRepo(s.config.DB)
 	activityRepo := db.NewActivityRepo(s.config.DB)
 
-	statusService := service.NewStatusService(ticketRepo, inboxRepo, claimRepo, activityRepo)
+	statusService := service.NewStatusService(ticketRepo, inboxRepo, claimRepo, activityRepo).
+		WithProjectRepo(db.NewProjectRepo(s.config.DB)).
+		WithSLAPolicy(s.slaPolicy)
 	summary, err := statusService.GetSummary(projectKey)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
@@ -630,6 +958,9 @@ func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
 		BlockedHuman:   summary.BlockedHuman,
 		PendingInbox:   summary.PendingInbox,
 		Project:        summary.ProjectKey,
+		WIP:            summary.WIP,
+		Overdue:        summary.Overdue,
+		OverdueInbox:   summary.OverdueInbox,
 		ExpiringSoon:   []ExpiringSoonItem{},
 		RecentActivity: []ActivityItem{},
 	}
@@ -667,6 +998,22 @@ func projectToResponse(p *models.Project) ProjectResponse {
 	}
 }
 
+func milestoneToResponse(m *models.Milestone) MilestoneResponse {
+	resp := MilestoneResponse{
+		Key:         m.Key,
+		Name:        m.Name,
+		Goal:        m.Goal,
+		Status:      m.Status,
+		TicketCount: m.TicketCount,
+		ClosedCount: m.ClosedCount,
+		Progress:    m.Progress(),
+	}
+	if m.TargetDate != nil {
+		resp.TargetDate = m.TargetDate.Local().Format("2006-01-02")
+	}
+	return resp
+}
+
 func ticketToResponse(t *models.Ticket) TicketResponse {
 	resp := TicketResponse{
 		ID:              t.ID,
@@ -681,6 +1028,8 @@ func ticketToResponse(t *models.Ticket) TicketResponse {
 		Complexity:      string(t.Complexity),
 		Type:            string(t.Type),
 		Worktree:        t.Worktree,
+		Labels:          t.Labels,
+		MilestoneKey:    t.MilestoneKey,
 		RetryCount:      t.RetryCount,
 		MaxRetries:      t.MaxRetries,
 		ParentTicketID:  t.ParentTicketID,
@@ -690,6 +1039,16 @@ func ticketToResponse(t *models.Ticket) TicketResponse {
 	if t.CompletedAt != nil {
 		resp.CompletedAt = t.CompletedAt.Format("2006-01-02T15:04:05Z")
 	}
+	if t.NotBefore != nil {
+		resp.NotBefore = t.NotBefore.UTC().Format("2006-01-02T15:04:05Z")
+	}
+	if t.DueAt != nil {
+		resp.DueAt = t.DueAt.UTC().Format("2006-01-02T15:04:05Z")
+		resp.Overdue = t.IsOverdue(time.Now())
+	}
+	if resp.Labels == nil {
+		resp.Labels = []string{}
+	}
 	return resp
 }
 
@@ -702,12 +1061,22 @@ func inboxToResponse(m *models.InboxMessage) InboxResponse {
 		MessageType: string(m.MessageType),
 		Content:     m.Content,
 		FromAgent:   m.FromAgent,
+		Options:     m.Options,
 		Response:    m.Response,
+		Choice:      m.Choice,
+		ReplyCount:  m.ReplyCount,
 		CreatedAt:   m.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		Overdue:     m.Overdue,
 	}
 	if m.RespondedAt != nil {
 		resp.RespondedAt = m.RespondedAt.Format("2006-01-02T15:04:05Z")
 	}
+	if m.DueAt != nil {
+		resp.DueAt = m.DueAt.UTC().Format("2006-01-02T15:04:05Z")
+	}
+	if m.EscalatedAt != nil {
+		resp.EscalatedAt = m.EscalatedAt.UTC().Format("2006-01-02T15:04:05Z")
+	}
 	return resp
 }
 
@@ -719,12 +1088,12 @@ func claimToResponse(c *models.Claim) ClaimResponse {
 		TicketTitle:      c.TicketTitle,
 		WorkerID:         c.WorkerID,
 		Status:           string(c.Status),
-		ClaimedAt:        c.ClaimedAt.Format("2006-01-02T15:04:05Z"),
-		ExpiresAt:        c.ExpiresAt.Format("2006-01-02T15:04:05Z"),
+		ClaimedAt:        c.ClaimedAt.UTC().Format("2006-01-02T15:04:05Z"),
+		ExpiresAt:        c.ExpiresAt.UTC().Format("2006-01-02T15:04:05Z"),
 		MinutesRemaining: c.MinutesRemaining,
 	}
 	if c.ReleasedAt != nil {
-		resp.ReleasedAt = c.ReleasedAt.Format("2006-01-02T15:04:05Z")
+		resp.ReleasedAt = c.ReleasedAt.UTC().Format("2006-01-02T15:04:05Z")
 	}
 	return resp
 }
@@ -0,0 +1,178 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/spetersoncode/wark/internal/models"
+	"github.com/spetersoncode/wark/internal/service"
+)
+
+// Inbox thread handlers. Like 'wark inbox reply' and 'wark inbox thread',
+// they go through InboxService, so a reply moves the ticket exactly as it
+// does from the CLI.
+
+const (
+	// defaultInboxWait and maxInboxWait bound how long
+	// GET /api/inbox/{id}/wait holds a request open.
+	defaultInboxWait = 30 * time.Second
+	maxInboxWait     = 10 * time.Minute
+)
+
+// InboxReplyResponse represents a reply in an inbox thread.
+type InboxReplyResponse struct {
+	ID         int64  `json:"id"`
+	MessageID  int64  `json:"message_id"`
+	AuthorType string `json:"author_type"`
+	Author     string `json:"author,omitempty"`
+	Content    string `json:"content"`
+	CreatedAt  string `json:"created_at"`
+}
+
+// InboxThreadResponse is the response for GET /api/inbox/{id}/thread.
+type InboxThreadResponse struct {
+	Message  InboxResponse        `json:"message"`
+	Replies  []InboxReplyResponse `json:"replies"`
+	Resolved bool                 `json:"resolved"`
+}
+
+// InboxReplyResult is the response for POST /api/inbox/{id}/reply.
+type InboxReplyResult struct {
+	Message        InboxResponse       `json:"message"`
+	Reply          *InboxReplyResponse `json:"reply,omitempty"`
+	Resolved       bool                `json:"resolved"`
+	Reopened       bool                `json:"reopened"`
+	StatusChanged  bool                `json:"status_changed"`
+	PreviousStatus string              `json:"previous_status,omitempty"`
+	NewStatus      string              `json:"new_status,omitempty"`
+	ClaimReleased  bool                `json:"claim_released"`
+}
+
+func (s *Server) newInboxService() *service.InboxService {
+	return service.NewInboxServiceFromDB(s.config.DB)
+}
+
+func (s *Server) handleGetInboxThread(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid message ID")
+		return
+	}
+
+	thread, err := s.newInboxService().Thread(id)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	resp := InboxThreadResponse{
+		Message:  inboxToResponse(thread.Message),
+		Replies:  make([]InboxReplyResponse, 0, len(thread.Replies)),
+		Resolved: thread.Resolved,
+	}
+	for _, reply := range thread.Replies {
+		resp.Replies = append(resp.Replies, inboxReplyToResponse(reply))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleReplyInbox(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid message ID")
+		return
+	}
+
+	var req struct {
+		Content  string `json:"content"`
+		WorkerID string `json:"worker_id"`
+		Resolve  bool   `json:"resolve"`
+		Choice   string `json:"choice"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	result, err := s.newInboxService().Reply(id, service.ReplyInput{
+		Content:  req.Content,
+		WorkerID: req.WorkerID,
+		Resolve:  req.Resolve,
+		Choice:   req.Choice,
+	})
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	resp := InboxReplyResult{
+		Message:       inboxToResponse(result.Message),
+		Resolved:      result.Resolved,
+		Reopened:      result.Reopened,
+		StatusChanged: result.StatusChanged,
+		ClaimReleased: result.ClaimReleased,
+	}
+	if result.Reply != nil {
+		reply := inboxReplyToResponse(result.Reply)
+		resp.Reply = &reply
+	}
+	if result.StatusChanged {
+		resp.PreviousStatus = string(result.PreviousStatus)
+		resp.NewStatus = string(result.NewStatus)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// handleWaitInbox long-polls for a response to an inbox message. It answers
+// with the message as soon as it is resolved, or 408 Request Timeout after
+// ?timeout= (a Go duration such as 30s; default 30s, at most 10m), after
+// which the client can simply ask again. Responses are read from the
+// database, so they may come from any process.
+func (s *Server) handleWaitInbox(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid message ID")
+		return
+	}
+
+	timeout := defaultInboxWait
+	if v := r.URL.Query().Get("timeout"); v != "" {
+		if timeout, err = time.ParseDuration(v); err != nil || timeout <= 0 {
+			writeError(w, http.StatusBadRequest, "invalid timeout")
+			return
+		}
+		timeout = min(timeout, maxInboxWait)
+	}
+
+	// The wait can outlive the server's WriteTimeout, so lift the deadline
+	// for this response. Recorders used in tests don't support it.
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+
+	message, err := s.newInboxService().Wait(ctx, id, s.config.EventPollInterval)
+	if err != nil {
+		if r.Context().Err() != nil {
+			// The client went away; there is no one to answer
+			return
+		}
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, inboxToResponse(message))
+}
+
+func inboxReplyToResponse(r *models.InboxReply) InboxReplyResponse {
+	return InboxReplyResponse{
+		ID:         r.ID,
+		MessageID:  r.MessageID,
+		AuthorType: string(r.AuthorType),
+		Author:     r.Author,
+		Content:    r.Content,
+		CreatedAt:  r.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
@@ -2,6 +2,8 @@ package server
 
 import (
 	"net/http"
+
+	"github.com/spetersoncode/wark/internal/service"
 )
 
 // setupRoutes configures all HTTP routes for the server.
@@ -10,20 +12,53 @@ func (s *Server) setupRoutes() {
 	s.router.HandleFunc("GET /api/projects", s.handleListProjects)
 	s.router.HandleFunc("GET /api/projects/{key}", s.handleGetProject)
 	s.router.HandleFunc("GET /api/projects/{key}/stats", s.handleGetProjectStats)
+	s.router.HandleFunc("GET /api/projects/{key}/graph", s.handleGetProjectGraph)
+	s.router.HandleFunc("GET /api/projects/{key}/critical-path", s.handleGetProjectCriticalPath)
 
 	s.router.HandleFunc("GET /api/tickets", s.handleListTickets)
+	s.router.HandleFunc("POST /api/tickets", s.handleCreateTicket)
 	s.router.HandleFunc("GET /api/tickets/search", s.handleSearchTickets)
+	s.router.HandleFunc("POST /api/tickets/next", s.handleClaimNext)
 	s.router.HandleFunc("GET /api/tickets/{key}", s.handleGetTicket)
+	s.router.HandleFunc("PATCH /api/tickets/{key}", s.handleUpdateTicket)
 	s.router.HandleFunc("GET /api/tickets/{key}/execution-context", s.handleGetTicketExecutionContext)
 
+	// Ticket transitions
+	s.router.HandleFunc("POST /api/tickets/{key}/claim", s.handleClaimTicket)
+	s.router.HandleFunc("POST /api/tickets/{key}/release", s.handleReleaseTicket)
+	s.router.HandleFunc("POST /api/tickets/{key}/complete", s.handleCompleteTicket)
+	s.router.HandleFunc("POST /api/tickets/{key}/flag", s.handleFlagTicket)
+	s.router.HandleFunc("POST /api/tickets/{key}/reject", s.handleRejectTicket)
+	s.router.HandleFunc("POST /api/tickets/{key}/close", s.handleCloseTicket)
+	s.router.HandleFunc("POST /api/tickets/{key}/start", s.handleTicketTransition((*service.TicketService).Prioritize))
+	s.router.HandleFunc("POST /api/tickets/{key}/review", s.handleTicketTransition((*service.TicketService).StartReview))
+	s.router.HandleFunc("POST /api/tickets/{key}/accept", s.handleTicketTransition(acceptTicket))
+	s.router.HandleFunc("POST /api/tickets/{key}/reopen", s.handleTicketTransition((*service.TicketService).Reopen))
+	s.router.HandleFunc("POST /api/tickets/{key}/resume", s.handleTicketTransition((*service.TicketService).Resume))
+	s.router.HandleFunc("POST /api/tickets/{key}/later", s.handleLaterTicket)
+
+	s.router.HandleFunc("POST /api/tickets/{key}/dependencies", s.handleAddDependency)
+	s.router.HandleFunc("DELETE /api/tickets/{key}/dependencies/{depKey}", s.handleRemoveDependency)
+	s.router.HandleFunc("POST /api/tickets/{key}/comments", s.handleAddComment)
+
+	s.router.HandleFunc("GET /api/tickets/{key}/tasks", s.handleListTasks)
+	s.router.HandleFunc("POST /api/tickets/{key}/tasks", s.handleCreateTask)
+	s.router.HandleFunc("PATCH /api/tickets/{key}/tasks/{position}", s.handleUpdateTask)
+	s.router.HandleFunc("DELETE /api/tickets/{key}/tasks/{position}", s.handleDeleteTask)
+
 	s.router.HandleFunc("GET /api/inbox", s.handleListInbox)
 	s.router.HandleFunc("GET /api/inbox/{id}", s.handleGetInboxMessage)
 	s.router.HandleFunc("POST /api/inbox/{id}/respond", s.handleRespondInbox)
+	s.router.HandleFunc("GET /api/inbox/{id}/thread", s.handleGetInboxThread)
+	s.router.HandleFunc("POST /api/inbox/{id}/reply", s.handleReplyInbox)
+	s.router.HandleFunc("GET /api/inbox/{id}/wait", s.handleWaitInbox)
 
 	s.router.HandleFunc("GET /api/claims", s.handleListClaims)
 	s.router.HandleFunc("GET /api/claims/{ticketKey}", s.handleGetClaim)
+	s.router.HandleFunc("POST /api/claims/{ticketKey}/heartbeat", s.handleClaimHeartbeat)
 
 	s.router.HandleFunc("GET /api/status", s.handleStatus)
+	s.router.HandleFunc("GET /api/events", s.handleEvents)
 
 	s.router.HandleFunc("GET /api/analytics", s.handleGetAnalytics)
 
@@ -12,6 +12,9 @@ import (
 	"os/exec"
 	"runtime"
 	"time"
+
+	warkconfig "github.com/spetersoncode/wark/internal/config"
+	"github.com/spetersoncode/wark/internal/service"
 )
 
 // Config holds the server configuration.
@@ -30,6 +33,14 @@ type Config struct {
 
 	// Logger for server events (optional).
 	Logger *log.Logger
+
+	// EventPollInterval is how often /api/events checks the activity log
+	// for new entries, and /api/inbox/{id}/wait for a response (default 1s).
+	EventPollInterval time.Duration
+
+	// Settings is the wark configuration used for claim durations,
+	// scheduling and inbox SLAs (default: built-in defaults).
+	Settings *warkconfig.Config
 }
 
 // Server is the HTTP server for the wark web UI.
@@ -38,6 +49,7 @@ type Server struct {
 	httpServer *http.Server
 	router     *http.ServeMux
 	logger     *log.Logger
+	slaPolicy  *service.SLAPolicy
 }
 
 // New creates a new Server with the given configuration.
@@ -52,6 +64,16 @@ func New(config Config) (*Server, error) {
 	if config.Host == "" {
 		config.Host = "localhost"
 	}
+	if config.EventPollInterval == 0 {
+		config.EventPollInterval = time.Second
+	}
+	if config.Settings == nil {
+		config.Settings = warkconfig.DefaultConfig()
+	}
+	slaPolicy, err := service.ParseSLAPolicy(config.Settings.InboxSLA)
+	if err != nil {
+		return nil, err
+	}
 
 	logger := config.Logger
 	if logger == nil {
@@ -59,9 +81,10 @@ func New(config Config) (*Server, error) {
 	}
 
 	s := &Server{
-		config: config,
-		router: http.NewServeMux(),
-		logger: logger,
+		config:    config,
+		router:    http.NewServeMux(),
+		logger:    logger,
+		slaPolicy: slaPolicy,
 	}
 
 	// Set up routes
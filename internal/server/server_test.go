@@ -1,6 +1,7 @@
 package server
 
 import (
+	"bufio"
 	"context"
 	"database/sql"
 	"encoding/json"
@@ -8,13 +9,16 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/spetersoncode/wark/internal/common"
+	"github.com/spetersoncode/wark/internal/config"
 	"github.com/spetersoncode/wark/internal/db"
 	"github.com/spetersoncode/wark/internal/models"
+	"github.com/spetersoncode/wark/internal/service"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -84,6 +88,13 @@ func TestNew(t *testing.T) {
 		assert.Equal(t, 9000, srv.config.Port)
 		assert.Equal(t, "0.0.0.0", srv.config.Host)
 	})
+
+	t.Run("rejects invalid inbox SLAs", func(t *testing.T) {
+		settings := config.DefaultConfig()
+		settings.InboxSLA = []config.InboxSLAConfig{{Type: "gossip", Minutes: 60}}
+		_, err := New(Config{DB: testDB(t), Settings: settings})
+		assert.ErrorContains(t, err, "inbox SLA")
+	})
 }
 
 func TestHealthEndpoint(t *testing.T) {
@@ -180,6 +191,85 @@ func TestProjectEndpoints(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, 1, stats.TotalTickets)
 		assert.Equal(t, 1, stats.ReadyCount)
+		require.NotNil(t, stats.WIP)
+		assert.True(t, stats.WIP.Limits.IsZero())
+	})
+
+	t.Run("get project stats with WIP limits", func(t *testing.T) {
+		project.WIPLimits = models.WIPLimits{Total: 4, Working: 1}
+		require.NoError(t, db.NewProjectRepo(sqlDB).Update(project))
+		require.NoError(t, db.NewTicketRepo(sqlDB).Create(&models.Ticket{
+			ProjectID: project.ID,
+			Title:     "Working ticket",
+			Status:    models.StatusWorking,
+		}))
+
+		req := httptest.NewRequest("GET", "/api/projects/TEST/stats", nil)
+		rec := httptest.NewRecorder()
+
+		srv.router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var stats models.ProjectStats
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &stats))
+		require.NotNil(t, stats.WIP)
+		assert.Equal(t, 1, stats.WIP.Working)
+		assert.Equal(t, models.WIPLimits{Total: 4, Working: 1}, stats.WIP.Limits)
+
+		// Claiming another ticket is refused with 429
+		ticket := &models.Ticket{ProjectID: project.ID, Title: "Waiting", Status: models.StatusReady}
+		require.NoError(t, db.NewTicketRepo(sqlDB).Create(ticket))
+		req = httptest.NewRequest("POST", "/api/tickets/TEST-"+strconv.Itoa(ticket.Number)+"/claim", strings.NewReader(`{}`))
+		rec = httptest.NewRecorder()
+		srv.router.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.Contains(t, rec.Body.String(), "WIP limit")
+
+		project.WIPLimits = models.WIPLimits{}
+		require.NoError(t, db.NewProjectRepo(sqlDB).Update(project))
+	})
+
+	t.Run("get project with milestone progress", func(t *testing.T) {
+		milestone := &models.Milestone{ProjectID: project.ID, Key: "V1", Name: "Version 1"}
+		require.NoError(t, db.NewMilestoneRepo(sqlDB).Create(milestone))
+
+		ticketRepo := db.NewTicketRepo(sqlDB)
+		completed := models.ResolutionCompleted
+		require.NoError(t, ticketRepo.Create(&models.Ticket{
+			ProjectID:   project.ID,
+			Title:       "Open milestone ticket",
+			Status:      models.StatusReady,
+			MilestoneID: &milestone.ID,
+		}))
+		require.NoError(t, ticketRepo.Create(&models.Ticket{
+			ProjectID:   project.ID,
+			Title:       "Closed milestone ticket",
+			Status:      models.StatusClosed,
+			Resolution:  &completed,
+			MilestoneID: &milestone.ID,
+		}))
+
+		req := httptest.NewRequest("GET", "/api/projects/TEST", nil)
+		rec := httptest.NewRecorder()
+		srv.router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var p ProjectResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+		require.Len(t, p.Milestones, 1)
+		assert.Equal(t, "V1", p.Milestones[0].Key)
+		assert.Equal(t, 2, p.Milestones[0].TicketCount)
+		assert.Equal(t, 1, p.Milestones[0].ClosedCount)
+		assert.Equal(t, 50.0, p.Milestones[0].Progress)
+
+		req = httptest.NewRequest("GET", "/api/tickets?project=TEST&milestone=v1", nil)
+		rec = httptest.NewRecorder()
+		srv.router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var tickets []TicketResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &tickets))
+		assert.Len(t, tickets, 2)
 	})
 }
 
@@ -248,6 +338,53 @@ func TestTicketEndpoints(t *testing.T) {
 		assert.Len(t, tickets, 1)
 	})
 
+	t.Run("search tickets", func(t *testing.T) {
+		err := db.NewActivityRepo(sqlDB).LogAction(ticket.ID, models.ActionComment, models.ActorTypeHuman, "",
+			"Escape <b>tags</b> near the widget")
+		require.NoError(t, err)
+
+		req := httptest.NewRequest("GET", "/api/tickets/search?q=widget", nil)
+		rec := httptest.NewRecorder()
+		srv.router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var results []SearchResultResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &results))
+		require.Len(t, results, 1)
+		assert.Equal(t, "TEST-1", results[0].Key)
+		assert.Equal(t, "comment", results[0].Source)
+		assert.Contains(t, results[0].Snippet, "&lt;b&gt;tags&lt;/b&gt;")
+		assert.Contains(t, results[0].Snippet, "<mark>widget</mark>")
+	})
+
+	t.Run("list tickets with label filter", func(t *testing.T) {
+		_, err := db.NewLabelRepo(sqlDB).Add(ticket.ID, "area:api")
+		require.NoError(t, err)
+
+		req := httptest.NewRequest("GET", "/api/tickets?label=area:api", nil)
+		rec := httptest.NewRecorder()
+		srv.router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var tickets []TicketResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &tickets))
+		require.Len(t, tickets, 1)
+		assert.Equal(t, []string{"area:api"}, tickets[0].Labels)
+
+		req = httptest.NewRequest("GET", "/api/tickets?label=area:api&label=needs-migration", nil)
+		rec = httptest.NewRecorder()
+		srv.router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &tickets))
+		assert.Empty(t, tickets)
+
+		req = httptest.NewRequest("GET", "/api/tickets?label=Not%20Valid", nil)
+		rec = httptest.NewRecorder()
+		srv.router.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
 	t.Run("list tickets with complexity filter", func(t *testing.T) {
 		// Should match the ticket with medium complexity
 		req := httptest.NewRequest("GET", "/api/tickets?complexity=medium", nil)
@@ -534,6 +671,273 @@ func TestInboxEndpoints(t *testing.T) {
 
 		assert.Equal(t, http.StatusBadRequest, rec.Code)
 	})
+
+	t.Run("agent reply reopens the thread", func(t *testing.T) {
+		body := strings.NewReader(`{"content": "Does that cover retries?", "worker_id": "test-agent"}`)
+		req := httptest.NewRequest("POST", "/api/inbox/1/reply", body)
+		rec := httptest.NewRecorder()
+
+		srv.router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var result InboxReplyResult
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+		assert.True(t, result.Reopened)
+		assert.True(t, result.StatusChanged)
+		assert.Equal(t, "human", result.NewStatus)
+		require.NotNil(t, result.Reply)
+		assert.Equal(t, "agent", result.Reply.AuthorType)
+		assert.Empty(t, result.Message.RespondedAt)
+	})
+
+	t.Run("only a human resolves", func(t *testing.T) {
+		body := strings.NewReader(`{"worker_id": "test-agent", "resolve": true}`)
+		req := httptest.NewRequest("POST", "/api/inbox/1/reply", body)
+		rec := httptest.NewRecorder()
+
+		srv.router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("human reply resolves the thread", func(t *testing.T) {
+		body := strings.NewReader(`{"content": "Yes, three attempts", "resolve": true}`)
+		req := httptest.NewRequest("POST", "/api/inbox/1/reply", body)
+		rec := httptest.NewRecorder()
+
+		srv.router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var result InboxReplyResult
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+		assert.True(t, result.Resolved)
+		assert.Equal(t, "ready", result.NewStatus)
+		assert.Equal(t, "Yes, three attempts", result.Message.Response)
+	})
+
+	t.Run("get inbox thread", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/inbox/1/thread", nil)
+		rec := httptest.NewRecorder()
+
+		srv.router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var thread InboxThreadResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &thread))
+		assert.True(t, thread.Resolved)
+		assert.Equal(t, "Need help with this task", thread.Message.Content)
+		assert.Equal(t, 3, thread.Message.ReplyCount)
+		require.Len(t, thread.Replies, 3)
+		assert.Equal(t, "Here is my answer", thread.Replies[0].Content)
+		assert.Equal(t, "test-agent", thread.Replies[1].Author)
+		assert.Equal(t, "human", thread.Replies[2].AuthorType)
+
+		req = httptest.NewRequest("GET", "/api/inbox/99/thread", nil)
+		rec = httptest.NewRecorder()
+		srv.router.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestInboxDecisionEndpoints(t *testing.T) {
+	sqlDB := testDB(t)
+	srv := setupTestServer(t, sqlDB)
+
+	project := &models.Project{Key: "TEST", Name: "Test Project"}
+	require.NoError(t, db.NewProjectRepo(sqlDB).Create(project))
+	ticket := &models.Ticket{ProjectID: project.ID, Title: "Pick a database", Status: models.StatusHuman}
+	require.NoError(t, db.NewTicketRepo(sqlDB).Create(ticket))
+	message := models.NewInboxMessage(ticket.ID, models.MessageTypeDecision, "Which database?", "test-agent")
+	message.Options = []models.DecisionOption{{Key: "A", Label: "use Postgres"}, {Key: "B", Label: "stay on SQLite"}}
+	require.NoError(t, db.NewInboxRepo(sqlDB).Create(message))
+
+	t.Run("options are returned", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/inbox/1", nil)
+		rec := httptest.NewRecorder()
+		srv.router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var msg InboxResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &msg))
+		assert.Equal(t, message.Options, msg.Options)
+	})
+
+	t.Run("invalid choice", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/api/inbox/1/respond", strings.NewReader(`{"choice": "C"}`))
+		rec := httptest.NewRecorder()
+		srv.router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("choice is recorded", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/api/inbox/1/respond", strings.NewReader(`{"choice": "a"}`))
+		rec := httptest.NewRecorder()
+		srv.router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var msg InboxResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &msg))
+		assert.Equal(t, "A", msg.Choice)
+		assert.Equal(t, "A: use Postgres", msg.Response)
+	})
+
+	t.Run("ticket detail includes decisions", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/tickets/TEST-1", nil)
+		rec := httptest.NewRecorder()
+		srv.router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var detail struct {
+			Decisions []models.Decision `json:"decisions"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &detail))
+		require.Len(t, detail.Decisions, 1)
+		assert.Equal(t, "A", detail.Decisions[0].Choice)
+		assert.Equal(t, "use Postgres", detail.Decisions[0].Label)
+	})
+}
+
+func TestInboxRespondThenEndpoint(t *testing.T) {
+	sqlDB := testDB(t)
+	srv := setupTestServer(t, sqlDB)
+
+	project := &models.Project{Key: "TEST", Name: "Test Project"}
+	require.NoError(t, db.NewProjectRepo(sqlDB).Create(project))
+	ticketRepo := db.NewTicketRepo(sqlDB)
+	ticket := &models.Ticket{ProjectID: project.ID, Title: "Add caching", Status: models.StatusHuman, Priority: models.PriorityLow}
+	require.NoError(t, ticketRepo.Create(ticket))
+	message := models.NewInboxMessage(ticket.ID, models.MessageTypeQuestion, "Is this still needed?", "test-agent")
+	require.NoError(t, db.NewInboxRepo(sqlDB).Create(message))
+
+	respond := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/api/inbox/1/respond", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		srv.router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("invalid action", func(t *testing.T) {
+		rec := respond(`{"response": "Yes", "then": "escalate"}`)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("invalid transition", func(t *testing.T) {
+		rec := respond(`{"response": "Yes", "then": "reject"}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+
+	t.Run("action is applied", func(t *testing.T) {
+		rec := respond(`{"response": "Yes, urgently", "then": "priority:highest"}`)
+		require.Equal(t, http.StatusOK, rec.Code)
+		var msg InboxResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &msg))
+		assert.Equal(t, "Yes, urgently", msg.Response)
+
+		updated, err := ticketRepo.GetByID(ticket.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.StatusReady, updated.Status)
+		assert.Equal(t, models.PriorityHighest, updated.Priority)
+	})
+}
+
+func TestInboxWaitEndpoint(t *testing.T) {
+	sqlDB := testDB(t)
+	srv, err := New(Config{DB: sqlDB, EventPollInterval: 10 * time.Millisecond})
+	require.NoError(t, err)
+
+	project := &models.Project{Key: "TEST", Name: "Test Project"}
+	require.NoError(t, db.NewProjectRepo(sqlDB).Create(project))
+	ticket := &models.Ticket{ProjectID: project.ID, Title: "Waiting", Status: models.StatusHuman}
+	require.NoError(t, db.NewTicketRepo(sqlDB).Create(ticket))
+	inboxRepo := db.NewInboxRepo(sqlDB)
+	message := models.NewInboxMessage(ticket.ID, models.MessageTypeQuestion, "Which port?", "test-agent")
+	require.NoError(t, inboxRepo.Create(message))
+
+	t.Run("times out without a response", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/inbox/1/wait?timeout=50ms", nil)
+		rec := httptest.NewRecorder()
+		srv.router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusRequestTimeout, rec.Code)
+	})
+
+	t.Run("invalid timeout", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/inbox/1/wait?timeout=soon", nil)
+		rec := httptest.NewRecorder()
+		srv.router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("unknown message", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/inbox/999/wait?timeout=50ms", nil)
+		rec := httptest.NewRecorder()
+		srv.router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("returns the response", func(t *testing.T) {
+		require.NoError(t, inboxRepo.Respond(message.ID, "8080"))
+
+		req := httptest.NewRequest("GET", "/api/inbox/1/wait?timeout=5s", nil)
+		rec := httptest.NewRecorder()
+		srv.router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var msg InboxResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &msg))
+		assert.Equal(t, "8080", msg.Response)
+		assert.NotEmpty(t, msg.RespondedAt)
+	})
+}
+
+func TestInboxSLAEndpoints(t *testing.T) {
+	settings := config.DefaultConfig()
+	settings.InboxSLA = []config.InboxSLAConfig{{Type: "question", Minutes: 60}}
+
+	sqlDB := testDB(t)
+	srv, err := New(Config{DB: sqlDB, Settings: settings})
+	require.NoError(t, err)
+
+	project := &models.Project{Key: "TEST", Name: "Test Project"}
+	require.NoError(t, db.NewProjectRepo(sqlDB).Create(project))
+	ticket := &models.Ticket{ProjectID: project.ID, Title: "Waiting", Status: models.StatusHuman}
+	require.NoError(t, db.NewTicketRepo(sqlDB).Create(ticket))
+	inboxRepo := db.NewInboxRepo(sqlDB)
+	for _, content := range []string{"Asked long ago", "Asked just now"} {
+		require.NoError(t, inboxRepo.Create(models.NewInboxMessage(ticket.ID, models.MessageTypeQuestion, content, "test-agent")))
+	}
+	_, err = sqlDB.Exec(`UPDATE inbox_messages SET created_at = ? WHERE id = 1`, db.FormatTime(time.Now().Add(-2*time.Hour)))
+	require.NoError(t, err)
+
+	t.Run("overdue messages are marked", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/inbox?overdue=true", nil)
+		rec := httptest.NewRecorder()
+		srv.router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var messages []InboxResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &messages))
+		require.Len(t, messages, 1)
+		assert.Equal(t, int64(1), messages[0].ID)
+		assert.True(t, messages[0].Overdue)
+		assert.NotEmpty(t, messages[0].DueAt)
+	})
+
+	t.Run("status lists overdue messages", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/status", nil)
+		rec := httptest.NewRecorder()
+		srv.router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var status StatusResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+		assert.Equal(t, 2, status.PendingInbox)
+		require.Len(t, status.OverdueInbox, 1)
+		assert.Equal(t, int64(1), status.OverdueInbox[0].MessageID)
+	})
 }
 
 func TestClaimEndpoints(t *testing.T) {
@@ -614,6 +1018,150 @@ func TestClaimEndpoints(t *testing.T) {
 	})
 }
 
+func TestClaimNextEndpoint(t *testing.T) {
+	sqlDB := testDB(t)
+	srv := setupTestServer(t, sqlDB)
+
+	projectRepo := db.NewProjectRepo(sqlDB)
+	project := &models.Project{Key: "TEST", Name: "Test Project"}
+	require.NoError(t, projectRepo.Create(project))
+
+	ticketRepo := db.NewTicketRepo(sqlDB)
+	ticket := &models.Ticket{
+		ProjectID:  project.ID,
+		Title:      "Large Ticket",
+		Status:     models.StatusReady,
+		Complexity: models.ComplexityLarge,
+	}
+	require.NoError(t, ticketRepo.Create(ticket))
+
+	t.Run("no match for complexity filter", func(t *testing.T) {
+		body := strings.NewReader(`{"project": "test", "max_complexity": "small"}`)
+		req := httptest.NewRequest("POST", "/api/tickets/next", body)
+		rec := httptest.NewRecorder()
+
+		srv.router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var resp ClaimNextResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Nil(t, resp.Ticket)
+		assert.Nil(t, resp.Claim)
+	})
+
+	t.Run("claims next ticket", func(t *testing.T) {
+		body := strings.NewReader(`{"project": "TEST", "duration_mins": 15}`)
+		req := httptest.NewRequest("POST", "/api/tickets/next", body)
+		rec := httptest.NewRecorder()
+
+		srv.router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var resp ClaimNextResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		require.NotNil(t, resp.Ticket)
+		require.NotNil(t, resp.Claim)
+		assert.Equal(t, "TEST-1", resp.Ticket.Key)
+		assert.Equal(t, string(models.StatusWorking), resp.Ticket.Status)
+		assert.Equal(t, "TEST-1", resp.Claim.TicketKey)
+		assert.NotEmpty(t, resp.Worktree)
+	})
+
+	t.Run("nothing left to claim", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/api/tickets/next", nil)
+		rec := httptest.NewRecorder()
+
+		srv.router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var resp ClaimNextResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Nil(t, resp.Ticket)
+	})
+
+	t.Run("invalid complexity", func(t *testing.T) {
+		body := strings.NewReader(`{"max_complexity": "huge"}`)
+		req := httptest.NewRequest("POST", "/api/tickets/next", body)
+		rec := httptest.NewRecorder()
+
+		srv.router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("invalid policy", func(t *testing.T) {
+		body := strings.NewReader(`{"policy": "fifo"}`)
+		req := httptest.NewRequest("POST", "/api/tickets/next", body)
+		rec := httptest.NewRecorder()
+
+		srv.router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "invalid scheduling policy")
+	})
+}
+
+func TestClaimHeartbeatEndpoint(t *testing.T) {
+	sqlDB := testDB(t)
+	srv := setupTestServer(t, sqlDB)
+
+	projectRepo := db.NewProjectRepo(sqlDB)
+	project := &models.Project{Key: "TEST", Name: "Test Project"}
+	require.NoError(t, projectRepo.Create(project))
+
+	ticketRepo := db.NewTicketRepo(sqlDB)
+	ticket := &models.Ticket{ProjectID: project.ID, Title: "Claimed Ticket", Status: models.StatusWorking}
+	require.NoError(t, ticketRepo.Create(ticket))
+	unclaimed := &models.Ticket{ProjectID: project.ID, Title: "Unclaimed Ticket", Status: models.StatusReady}
+	require.NoError(t, ticketRepo.Create(unclaimed))
+
+	claim := models.NewClaim(ticket.ID, "agent-1", 5*time.Minute)
+	require.NoError(t, db.NewClaimRepo(sqlDB).Create(claim))
+
+	t.Run("extends claim", func(t *testing.T) {
+		body := strings.NewReader(`{"extend_mins": 45, "worker_id": "agent-1"}`)
+		req := httptest.NewRequest("POST", "/api/claims/TEST-1/heartbeat", body)
+		rec := httptest.NewRecorder()
+
+		srv.router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var resp HeartbeatResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, "TEST-1", resp.Claim.TicketKey)
+		assert.Equal(t, "agent-1", resp.Claim.WorkerID)
+		assert.InDelta(t, 45, resp.Claim.MinutesRemaining, 1)
+	})
+
+	t.Run("other worker is rejected", func(t *testing.T) {
+		body := strings.NewReader(`{"extend_mins": 45, "worker_id": "agent-2"}`)
+		req := httptest.NewRequest("POST", "/api/claims/TEST-1/heartbeat", body)
+		rec := httptest.NewRecorder()
+
+		srv.router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+
+	t.Run("no active claim", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/api/claims/TEST-2/heartbeat", nil)
+		rec := httptest.NewRecorder()
+
+		srv.router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+
+	t.Run("unknown ticket", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/api/claims/TEST-99/heartbeat", nil)
+		rec := httptest.NewRecorder()
+
+		srv.router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
 func TestStatusEndpoint(t *testing.T) {
 	sqlDB := testDB(t)
 	srv := setupTestServer(t, sqlDB)
@@ -786,3 +1334,367 @@ func TestFormatAge(t *testing.T) {
 func TestMain(m *testing.M) {
 	os.Exit(m.Run())
 }
+
+// sseEvent is one frame read from an event stream.
+type sseEvent struct {
+	ID   string
+	Type string
+	Data string
+}
+
+// readSSEEvents reads n events from an event stream, skipping comments and
+// the retry directive.
+func readSSEEvents(t *testing.T, reader *bufio.Reader, n int) []sseEvent {
+	t.Helper()
+
+	var events []sseEvent
+	var current sseEvent
+	for len(events) < n {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimRight(line, "\n")
+
+		switch {
+		case line == "":
+			if current.Type != "" {
+				events = append(events, current)
+			}
+			current = sseEvent{}
+		case strings.HasPrefix(line, "id: "):
+			current.ID = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			current.Type = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			current.Data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+	return events
+}
+
+func TestEventsEndpoint(t *testing.T) {
+	sqlDB := testDB(t)
+	srv, err := New(Config{DB: sqlDB, EventPollInterval: 10 * time.Millisecond})
+	require.NoError(t, err)
+	ts := httptest.NewServer(srv.router)
+	defer ts.Close()
+
+	project := &models.Project{Key: "EVT", Name: "Events"}
+	require.NoError(t, db.NewProjectRepo(sqlDB).Create(project))
+	ticketRepo := db.NewTicketRepo(sqlDB)
+	ticket := &models.Ticket{ProjectID: project.ID, Title: "Stream me", Status: models.StatusReady}
+	require.NoError(t, ticketRepo.Create(ticket))
+	activityRepo := db.NewActivityRepo(sqlDB)
+
+	openStream := func(t *testing.T, path, lastEventID string) (*bufio.Reader, func()) {
+		t.Helper()
+		ctx, cancel := context.WithCancel(context.Background())
+		req, err := http.NewRequestWithContext(ctx, "GET", ts.URL+path, nil)
+		require.NoError(t, err)
+		if lastEventID != "" {
+			req.Header.Set("Last-Event-ID", lastEventID)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+		return bufio.NewReader(resp.Body), func() {
+			cancel()
+			resp.Body.Close()
+		}
+	}
+
+	require.NoError(t, activityRepo.LogAction(ticket.ID, models.ActionComment, models.ActorTypeHuman, "", "First note"))
+	ticket.Status = models.StatusWorking
+	require.NoError(t, ticketRepo.Update(ticket))
+
+	t.Run("replays activity after Last-Event-ID", func(t *testing.T) {
+		reader, closeStream := openStream(t, "/api/events", "0")
+		defer closeStream()
+
+		events := readSSEEvents(t, reader, 2)
+		assert.Equal(t, "comment.created", events[0].Type)
+		assert.Equal(t, "ticket.status_changed", events[1].Type)
+
+		var event models.Event
+		require.NoError(t, json.Unmarshal([]byte(events[1].Data), &event))
+		assert.Equal(t, "EVT-1", event.TicketKey)
+		assert.Equal(t, "ready", event.Data["from"])
+		assert.Equal(t, "working", event.Data["to"])
+		assert.Equal(t, events[1].ID, strconv.FormatInt(event.ID, 10))
+	})
+
+	t.Run("streams new activity", func(t *testing.T) {
+		reader, closeStream := openStream(t, "/api/events?type=claim.created,claim.expired", "")
+		defer closeStream()
+
+		// Wait for the stream to start before writing
+		readRetry(t, reader)
+		require.NoError(t, activityRepo.LogAction(ticket.ID, models.ActionComment, models.ActorTypeHuman, "", "Filtered out"))
+		require.NoError(t, activityRepo.LogAction(ticket.ID, models.ActionClaimed, models.ActorTypeAgent, "agent-1", "Claimed"))
+
+		events := readSSEEvents(t, reader, 1)
+		assert.Equal(t, "claim.created", events[0].Type)
+	})
+
+	t.Run("rejects invalid parameters", func(t *testing.T) {
+		resp, err := http.Get(ts.URL + "/api/events?type=bogus")
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		resp, err = http.Get(ts.URL + "/api/events?last_event_id=abc")
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+// readRetry consumes the retry directive that opens an event stream.
+func readRetry(t *testing.T, reader *bufio.Reader) {
+	t.Helper()
+	line, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(line, "retry: "))
+	_, err = reader.ReadString('\n')
+	require.NoError(t, err)
+}
+
+func TestTicketWriteEndpoints(t *testing.T) {
+	sqlDB := testDB(t)
+	srv := setupTestServer(t, sqlDB)
+
+	projectRepo := db.NewProjectRepo(sqlDB)
+	require.NoError(t, projectRepo.Create(&models.Project{Key: "TEST", Name: "Test Project"}))
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		srv.router.ServeHTTP(rec, req)
+		return rec
+	}
+	decodeTicket := func(rec *httptest.ResponseRecorder) TicketResponse {
+		t.Helper()
+		var resp TicketResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp), rec.Body.String())
+		return resp
+	}
+
+	t.Run("create ticket", func(t *testing.T) {
+		rec := do("POST", "/api/tickets", `{"project": "test", "title": "Login page", "priority": "high", "labels": ["area:ui"]}`)
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+		resp := decodeTicket(rec)
+		assert.Equal(t, "TEST-1", resp.Key)
+		assert.Equal(t, "backlog", resp.Status)
+		assert.Equal(t, "high", resp.Priority)
+		assert.Equal(t, []string{"area:ui"}, resp.Labels)
+
+		rec = do("POST", "/api/tickets", `{"project": "TEST", "title": "Logout", "depends_on": ["1"]}`)
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+		assert.Equal(t, "blocked", decodeTicket(rec).Status)
+	})
+
+	t.Run("create validation errors", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, do("POST", "/api/tickets", `{"title": "No project"}`).Code)
+		assert.Equal(t, http.StatusBadRequest, do("POST", "/api/tickets", `{"project": "TEST", "title": "x", "priority": "urgent"}`).Code)
+		assert.Equal(t, http.StatusNotFound, do("POST", "/api/tickets", `{"project": "NOPE", "title": "x"}`).Code)
+		assert.Equal(t, http.StatusBadRequest, do("POST", "/api/tickets", `not json`).Code)
+	})
+
+	t.Run("patch ticket", func(t *testing.T) {
+		rec := do("PATCH", "/api/tickets/TEST-1", `{"title": "Login form", "complexity": "small"}`)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		resp := decodeTicket(rec)
+		assert.Equal(t, "Login form", resp.Title)
+		assert.Equal(t, "small", resp.Complexity)
+		assert.Equal(t, "high", resp.Priority, "omitted fields are unchanged")
+
+		rec = do("PATCH", "/api/tickets/TEST-2", `{"due_at": "2020-02-01T12:00:00Z"}`)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		resp = decodeTicket(rec)
+		assert.Equal(t, "2020-02-01T12:00:00Z", resp.DueAt)
+		assert.True(t, resp.Overdue)
+		rec = do("PATCH", "/api/tickets/TEST-2", `{"due_at": ""}`)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Empty(t, decodeTicket(rec).DueAt)
+		assert.Equal(t, http.StatusBadRequest, do("PATCH", "/api/tickets/TEST-2", `{"due_at": "soon"}`).Code)
+
+		assert.Equal(t, http.StatusNotFound, do("PATCH", "/api/tickets/TEST-99", `{"title": "x"}`).Code)
+	})
+
+	t.Run("workflow transitions", func(t *testing.T) {
+		// Backlog tickets can't be claimed until started
+		assert.Equal(t, http.StatusUnprocessableEntity, do("POST", "/api/tickets/TEST-1/claim", `{"worker_id": "agent-1"}`).Code)
+
+		rec := do("POST", "/api/tickets/TEST-1/start", "")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Equal(t, "ready", decodeTicket(rec).Status)
+
+		rec = do("POST", "/api/tickets/TEST-1/claim", `{"worker_id": "agent-1", "duration_mins": 30}`)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var claim ClaimNextResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &claim))
+		assert.Equal(t, "working", claim.Ticket.Status)
+		assert.Equal(t, "agent-1", claim.Claim.WorkerID)
+
+		// Another worker can't complete without force
+		rec = do("POST", "/api/tickets/TEST-1/complete", `{"worker_id": "agent-2"}`)
+		assert.Equal(t, http.StatusConflict, rec.Code)
+
+		// Nor can a caller that gives no worker ID
+		rec = do("POST", "/api/tickets/TEST-1/release", `{"reason": "Stuck"}`)
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		assert.Contains(t, rec.Body.String(), "no worker ID was given")
+
+		rec = do("POST", "/api/tickets/TEST-1/complete", `{"worker_id": "agent-1", "summary": "Done"}`)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Equal(t, "review", decodeTicket(rec).Status)
+
+		rec = do("POST", "/api/tickets/TEST-1/reject", `{"reason": "Missing tests"}`)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Equal(t, "ready", decodeTicket(rec).Status)
+
+		rec = do("POST", "/api/tickets/TEST-1/close", `{"resolution": "wont_do", "reason": "Descoped"}`)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Equal(t, "closed", decodeTicket(rec).Status)
+
+		assert.Equal(t, http.StatusBadRequest, do("POST", "/api/tickets/TEST-1/close", `{"resolution": "bogus"}`).Code)
+
+		rec = do("POST", "/api/tickets/TEST-1/reopen", "")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Equal(t, "ready", decodeTicket(rec).Status)
+
+		rec = do("POST", "/api/tickets/TEST-1/flag", `{"reason": "unclear_requirements", "message": "Which provider?"}`)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Equal(t, "human", decodeTicket(rec).Status)
+		assert.Equal(t, http.StatusBadRequest, do("POST", "/api/tickets/TEST-1/flag", `{"reason": "bogus", "message": "x"}`).Code)
+
+		assert.Equal(t, http.StatusBadRequest, do("POST", "/api/tickets/TEST-1/later", `{"until": "soon"}`).Code)
+		rec = do("POST", "/api/tickets/TEST-1/later", `{"until": "2020-01-01T00:00:00Z"}`)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		resp := decodeTicket(rec)
+		assert.Equal(t, "backlog", resp.Status)
+		assert.Equal(t, "2020-01-01T00:00:00Z", resp.NotBefore)
+	})
+
+	t.Run("dependencies", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, do("POST", "/api/tickets/TEST-1/start", "").Code)
+
+		// TEST-2 depends on TEST-1; the reverse would be a cycle
+		rec := do("POST", "/api/tickets/TEST-1/dependencies", `{"depends_on": "TEST-2"}`)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+
+		rec = do("POST", "/api/tickets", `{"project": "TEST", "title": "Schema"}`)
+		require.Equal(t, http.StatusCreated, rec.Code)
+		rec = do("POST", "/api/tickets/TEST-1/dependencies", `{"depends_on": "3"}`)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Equal(t, "blocked", decodeTicket(rec).Status)
+
+		rec = do("DELETE", "/api/tickets/TEST-1/dependencies/TEST-3", "")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Equal(t, "ready", decodeTicket(rec).Status)
+
+		assert.Equal(t, http.StatusNotFound, do("DELETE", "/api/tickets/TEST-1/dependencies/TEST-3", "").Code)
+	})
+
+	t.Run("tasks", func(t *testing.T) {
+		rec := do("POST", "/api/tickets/TEST-1/tasks", `{"description": "Write form"}`)
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+		rec = do("POST", "/api/tickets/TEST-1/tasks", `{"description": "Add validation"}`)
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+		assert.Equal(t, http.StatusBadRequest, do("POST", "/api/tickets/TEST-1/tasks", `{}`).Code)
+
+		rec = do("PATCH", "/api/tickets/TEST-1/tasks/0", `{"complete": true}`)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var task TaskResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &task))
+		assert.True(t, task.Complete)
+
+		assert.Equal(t, http.StatusNoContent, do("DELETE", "/api/tickets/TEST-1/tasks/1", "").Code)
+		assert.Equal(t, http.StatusNotFound, do("DELETE", "/api/tickets/TEST-1/tasks/1", "").Code)
+
+		rec = do("GET", "/api/tickets/TEST-1/tasks", "")
+		require.Equal(t, http.StatusOK, rec.Code)
+		var tasks []TaskResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &tasks))
+		require.Len(t, tasks, 1)
+		assert.Equal(t, "Write form", tasks[0].Description)
+	})
+
+	t.Run("comments", func(t *testing.T) {
+		rec := do("POST", "/api/tickets/TEST-1/comments", `{"message": "Needs design review", "worker_id": "agent-1"}`)
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+		var comment CommentResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &comment))
+		assert.Equal(t, "TEST-1", comment.TicketKey)
+		assert.Equal(t, "agent-1", comment.ActorID)
+		assert.Equal(t, "Needs design review", comment.Message)
+
+		assert.Equal(t, http.StatusBadRequest, do("POST", "/api/tickets/TEST-1/comments", `{"message": ""}`).Code)
+	})
+}
+
+func TestProjectGraphEndpoint(t *testing.T) {
+	sqlDB := testDB(t)
+	srv := setupTestServer(t, sqlDB)
+
+	project := &models.Project{Key: "GRAPH", Name: "Graph"}
+	require.NoError(t, db.NewProjectRepo(sqlDB).Create(project))
+	ticketRepo := db.NewTicketRepo(sqlDB)
+	first := &models.Ticket{ProjectID: project.ID, Title: "First", Status: models.StatusReady}
+	require.NoError(t, ticketRepo.Create(first))
+	second := &models.Ticket{ProjectID: project.ID, Title: "Second", Status: models.StatusBlocked}
+	require.NoError(t, ticketRepo.Create(second))
+	require.NoError(t, db.NewDependencyRepo(sqlDB).Add(second.ID, first.ID))
+
+	t.Run("json", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/projects/graph/graph", nil)
+		rec := httptest.NewRecorder()
+		srv.router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var graph service.Graph
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &graph))
+		assert.Len(t, graph.Nodes, 2)
+		assert.Equal(t, []service.GraphEdge{{From: "GRAPH-2", To: "GRAPH-1", Type: service.EdgeDependsOn}}, graph.Edges)
+	})
+
+	t.Run("mermaid", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/projects/GRAPH/graph?format=mermaid", nil)
+		rec := httptest.NewRecorder()
+		srv.router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Header().Get("Content-Type"), "text/plain")
+		assert.Contains(t, rec.Body.String(), "GRAPH_2 --> GRAPH_1")
+	})
+
+	t.Run("critical path", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/projects/GRAPH/critical-path", nil)
+		rec := httptest.NewRecorder()
+		srv.router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var result service.CriticalPath
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+		require.Len(t, result.Path, 2)
+		assert.Equal(t, "GRAPH-1", result.Path[0].Key)
+		require.Len(t, result.Impact, 1)
+		assert.Equal(t, 1, result.Impact[0].Dependents)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for path, status := range map[string]int{
+			"/api/projects/GRAPH/critical-path?limit=x": http.StatusBadRequest,
+			"/api/projects/NOPE/critical-path":          http.StatusNotFound,
+			"/api/projects/NOPE/graph":                  http.StatusNotFound,
+			"/api/projects/GRAPH/graph?format=png":      http.StatusBadRequest,
+			"/api/projects/GRAPH/graph?epic=GRAPH-1":    http.StatusBadRequest,
+		} {
+			req := httptest.NewRequest("GET", path, nil)
+			rec := httptest.NewRecorder()
+			srv.router.ServeHTTP(rec, req)
+			assert.Equal(t, status, rec.Code, path)
+		}
+	})
+}
@@ -0,0 +1,597 @@
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spetersoncode/wark/internal/common"
+	"github.com/spetersoncode/wark/internal/db"
+	"github.com/spetersoncode/wark/internal/models"
+	"github.com/spetersoncode/wark/internal/service"
+)
+
+// Ticket write handlers. Each calls the same TicketService method as the
+// equivalent CLI command, so the state machine, claim ownership and
+// dependency rules are enforced identically; service errors map to HTTP
+// status codes through their error kind.
+
+// CommentResponse is the response for POST /api/tickets/{key}/comments.
+type CommentResponse struct {
+	ID        int64  `json:"id"`
+	TicketKey string `json:"ticket_key"`
+	ActorType string `json:"actor_type"`
+	ActorID   string `json:"actor_id,omitempty"`
+	Message   string `json:"message"`
+	CreatedAt string `json:"created_at"`
+}
+
+// TaskResponse represents a ticket task in API responses.
+type TaskResponse struct {
+	ID          int64  `json:"id"`
+	Position    int    `json:"position"`
+	Description string `json:"description"`
+	Complete    bool   `json:"complete"`
+}
+
+// decodeBody decodes an optional JSON request body into v.
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil && err != io.EOF {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return false
+	}
+	return true
+}
+
+// ticketFromPath loads the ticket named by the {key} path value, writing an
+// error response and returning nil if it can't.
+func (s *Server) ticketFromPath(w http.ResponseWriter, r *http.Request) *models.Ticket {
+	return s.lookupTicket(w, r.PathValue("key"))
+}
+
+func (s *Server) lookupTicket(w http.ResponseWriter, key string) *models.Ticket {
+	projectKey, number, err := common.ParseTicketKey(key)
+	if err != nil || projectKey == "" {
+		writeError(w, http.StatusBadRequest, "invalid ticket key: "+key)
+		return nil
+	}
+
+	ticket, err := db.NewTicketRepo(s.config.DB).GetByKey(projectKey, number)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return nil
+	}
+	if ticket == nil {
+		writeError(w, http.StatusNotFound, "ticket not found")
+		return nil
+	}
+	return ticket
+}
+
+// writeTicket responds with the current state of a ticket after a change.
+func (s *Server) writeTicket(w http.ResponseWriter, status int, ticketID int64) {
+	ticket, err := service.NewTicketService(s.config.DB).GetTicketByID(ticketID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, status, ticketToResponse(ticket))
+}
+
+func (s *Server) handleCreateTicket(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Project     string            `json:"project"`
+		Title       string            `json:"title"`
+		Description string            `json:"description"`
+		Priority    string            `json:"priority"`
+		Complexity  string            `json:"complexity"`
+		Type        string            `json:"type"`
+		Parent      string            `json:"parent"`
+		DependsOn   []string          `json:"depends_on"`
+		Role        string            `json:"role"`
+		Milestone   string            `json:"milestone"`
+		Labels      []string          `json:"labels"`
+		NotBefore   string            `json:"not_before"`
+		DueAt       string            `json:"due_at"`
+		Template    string            `json:"template"`
+		Vars        map[string]string `json:"vars"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.Project == "" {
+		writeError(w, http.StatusBadRequest, "project is required")
+		return
+	}
+
+	ticket, err := service.NewTicketService(s.config.DB).Create(service.CreateTicketInput{
+		ProjectKey:   req.Project,
+		Title:        req.Title,
+		Description:  req.Description,
+		Priority:     req.Priority,
+		Complexity:   req.Complexity,
+		Type:         req.Type,
+		ParentKey:    req.Parent,
+		DependsOn:    req.DependsOn,
+		RoleName:     req.Role,
+		MilestoneKey: req.Milestone,
+		Labels:       req.Labels,
+		NotBefore:    req.NotBefore,
+		DueAt:        req.DueAt,
+		Template:     req.Template,
+		Vars:         req.Vars,
+	})
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, ticketToResponse(ticket))
+}
+
+func (s *Server) handleUpdateTicket(w http.ResponseWriter, r *http.Request) {
+	ticket := s.ticketFromPath(w, r)
+	if ticket == nil {
+		return
+	}
+
+	var req struct {
+		Title       *string `json:"title"`
+		Description *string `json:"description"`
+		Priority    *string `json:"priority"`
+		Complexity  *string `json:"complexity"`
+		Milestone   *string `json:"milestone"`
+		NotBefore   *string `json:"not_before"`
+		DueAt       *string `json:"due_at"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	updated, err := service.NewTicketService(s.config.DB).Update(ticket.ID, service.UpdateTicketInput{
+		Title:        req.Title,
+		Description:  req.Description,
+		Priority:     req.Priority,
+		Complexity:   req.Complexity,
+		MilestoneKey: req.Milestone,
+		NotBefore:    req.NotBefore,
+		DueAt:        req.DueAt,
+	})
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ticketToResponse(updated))
+}
+
+// Transition handlers
+
+func (s *Server) handleClaimTicket(w http.ResponseWriter, r *http.Request) {
+	ticket := s.ticketFromPath(w, r)
+	if ticket == nil {
+		return
+	}
+
+	var req struct {
+		WorkerID     string `json:"worker_id"`
+		DurationMins int    `json:"duration_mins"`
+	}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if req.DurationMins <= 0 {
+		req.DurationMins = s.config.Settings.ClaimDuration
+	}
+
+	result, err := service.NewTicketService(s.config.DB).Claim(ticket.ID, req.WorkerID,
+		time.Duration(req.DurationMins)*time.Minute)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	ticketResp := ticketToResponse(result.Ticket)
+	claim := claimToResponse(result.Claim)
+	claim.TicketKey = result.Ticket.TicketKey
+	claim.TicketTitle = result.Ticket.Title
+	writeJSON(w, http.StatusOK, ClaimNextResponse{Ticket: &ticketResp, Claim: &claim, Worktree: result.Branch})
+}
+
+func (s *Server) handleReleaseTicket(w http.ResponseWriter, r *http.Request) {
+	ticket := s.ticketFromPath(w, r)
+	if ticket == nil {
+		return
+	}
+
+	var req struct {
+		Reason   string `json:"reason"`
+		WorkerID string `json:"worker_id"`
+		Force    bool   `json:"force"`
+	}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	ticketService := service.NewTicketService(s.config.DB).ActingAs(req.WorkerID, req.Force)
+	if err := ticketService.Release(ticket.ID, req.Reason); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	s.writeTicket(w, http.StatusOK, ticket.ID)
+}
+
+func (s *Server) handleCompleteTicket(w http.ResponseWriter, r *http.Request) {
+	ticket := s.ticketFromPath(w, r)
+	if ticket == nil {
+		return
+	}
+
+	var req struct {
+		Summary    string `json:"summary"`
+		AutoAccept bool   `json:"auto_accept"`
+		WorkerID   string `json:"worker_id"`
+		Force      bool   `json:"force"`
+	}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	ticketService := service.NewTicketService(s.config.DB).ActingAs(req.WorkerID, req.Force)
+	if _, err := ticketService.Complete(ticket.ID, req.Summary, req.AutoAccept); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	s.writeTicket(w, http.StatusOK, ticket.ID)
+}
+
+func (s *Server) handleFlagTicket(w http.ResponseWriter, r *http.Request) {
+	ticket := s.ticketFromPath(w, r)
+	if ticket == nil {
+		return
+	}
+
+	var req struct {
+		Reason   string `json:"reason"`
+		Message  string `json:"message"`
+		WorkerID string `json:"worker_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.Message == "" {
+		writeError(w, http.StatusBadRequest, "message is required")
+		return
+	}
+	reason, err := models.ParseFlagReason(req.Reason)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := service.NewTicketService(s.config.DB).Flag(ticket.ID, reason, req.Message, req.WorkerID); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	s.writeTicket(w, http.StatusOK, ticket.ID)
+}
+
+func (s *Server) handleRejectTicket(w http.ResponseWriter, r *http.Request) {
+	ticket := s.ticketFromPath(w, r)
+	if ticket == nil {
+		return
+	}
+
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	if err := service.NewTicketService(s.config.DB).Reject(ticket.ID, req.Reason); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	s.writeTicket(w, http.StatusOK, ticket.ID)
+}
+
+func (s *Server) handleCloseTicket(w http.ResponseWriter, r *http.Request) {
+	ticket := s.ticketFromPath(w, r)
+	if ticket == nil {
+		return
+	}
+
+	var req struct {
+		Resolution string `json:"resolution"`
+		Reason     string `json:"reason"`
+	}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if req.Resolution == "" {
+		req.Resolution = string(models.ResolutionCompleted)
+	}
+
+	err := service.NewTicketService(s.config.DB).Close(ticket.ID, models.Resolution(strings.ToLower(req.Resolution)), req.Reason)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	s.writeTicket(w, http.StatusOK, ticket.ID)
+}
+
+// handleLaterTicket moves a ticket from human to backlog, optionally keeping
+// it out of workable lists until a date.
+func (s *Server) handleLaterTicket(w http.ResponseWriter, r *http.Request) {
+	ticket := s.ticketFromPath(w, r)
+	if ticket == nil {
+		return
+	}
+
+	var req struct {
+		Until string `json:"until"`
+	}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	var until *time.Time
+	if req.Until != "" {
+		t, err := common.ParseDate(req.Until)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		until = &t
+	}
+
+	if err := service.NewTicketService(s.config.DB).DeprioritizeUntil(ticket.ID, until); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	s.writeTicket(w, http.StatusOK, ticket.ID)
+}
+
+// handleTicketTransition returns a handler for transitions that take no
+// arguments beyond the ticket.
+func (s *Server) handleTicketTransition(transition func(svc *service.TicketService, ticketID int64) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ticket := s.ticketFromPath(w, r)
+		if ticket == nil {
+			return
+		}
+		if err := transition(service.NewTicketService(s.config.DB), ticket.ID); err != nil {
+			writeServiceError(w, err)
+			return
+		}
+		s.writeTicket(w, http.StatusOK, ticket.ID)
+	}
+}
+
+// Dependency handlers
+
+func (s *Server) handleAddDependency(w http.ResponseWriter, r *http.Request) {
+	ticket := s.ticketFromPath(w, r)
+	if ticket == nil {
+		return
+	}
+
+	var req struct {
+		DependsOn string `json:"depends_on"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	dep := s.lookupTicket(w, qualifyKey(req.DependsOn, ticket.ProjectKey))
+	if dep == nil {
+		return
+	}
+
+	updated, err := service.NewTicketService(s.config.DB).AddDependency(ticket.ID, dep.ID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, ticketToResponse(updated))
+}
+
+func (s *Server) handleRemoveDependency(w http.ResponseWriter, r *http.Request) {
+	ticket := s.ticketFromPath(w, r)
+	if ticket == nil {
+		return
+	}
+	dep := s.lookupTicket(w, qualifyKey(r.PathValue("depKey"), ticket.ProjectKey))
+	if dep == nil {
+		return
+	}
+
+	updated, err := service.NewTicketService(s.config.DB).RemoveDependency(ticket.ID, dep.ID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, ticketToResponse(updated))
+}
+
+// qualifyKey prefixes a bare ticket number with the given project key.
+func qualifyKey(key, projectKey string) string {
+	if _, err := strconv.Atoi(strings.TrimSpace(key)); err == nil {
+		return projectKey + "-" + strings.TrimSpace(key)
+	}
+	return key
+}
+
+// Comment handler
+
+func (s *Server) handleAddComment(w http.ResponseWriter, r *http.Request) {
+	ticket := s.ticketFromPath(w, r)
+	if ticket == nil {
+		return
+	}
+
+	var req struct {
+		Message  string `json:"message"`
+		WorkerID string `json:"worker_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	comment, err := service.NewTicketService(s.config.DB).Comment(ticket.ID, req.Message, req.WorkerID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, CommentResponse{
+		ID:        comment.ID,
+		TicketKey: comment.TicketKey,
+		ActorType: string(comment.ActorType),
+		ActorID:   comment.ActorID,
+		Message:   comment.Summary,
+		CreatedAt: comment.CreatedAt.Format(time.RFC3339),
+	})
+}
+
+// Task handlers
+
+func (s *Server) handleListTasks(w http.ResponseWriter, r *http.Request) {
+	ticket := s.ticketFromPath(w, r)
+	if ticket == nil {
+		return
+	}
+
+	tasks, err := db.NewTasksRepo(s.config.DB).ListTasks(r.Context(), ticket.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response := make([]TaskResponse, 0, len(tasks))
+	for _, t := range tasks {
+		response = append(response, taskToResponse(t))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) handleCreateTask(w http.ResponseWriter, r *http.Request) {
+	ticket := s.ticketFromPath(w, r)
+	if ticket == nil {
+		return
+	}
+
+	var req struct {
+		Description string `json:"description"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if strings.TrimSpace(req.Description) == "" {
+		writeError(w, http.StatusBadRequest, "description is required")
+		return
+	}
+
+	task, err := db.NewTasksRepo(s.config.DB).CreateTask(r.Context(), ticket.ID, req.Description)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, taskToResponse(task))
+}
+
+func (s *Server) handleUpdateTask(w http.ResponseWriter, r *http.Request) {
+	ticket := s.ticketFromPath(w, r)
+	if ticket == nil {
+		return
+	}
+	task := s.taskFromPath(w, r, ticket)
+	if task == nil {
+		return
+	}
+
+	var req struct {
+		Complete *bool `json:"complete"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	// Marking a task (in)complete is idempotent, as in the CLI
+	if req.Complete != nil && *req.Complete != task.Complete {
+		tasksRepo := db.NewTasksRepo(s.config.DB)
+		var err error
+		if *req.Complete {
+			err = tasksRepo.CompleteTask(r.Context(), task.ID)
+		} else {
+			err = tasksRepo.UncompleteTask(r.Context(), task.ID)
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		task.Complete = *req.Complete
+	}
+
+	writeJSON(w, http.StatusOK, taskToResponse(task))
+}
+
+func (s *Server) handleDeleteTask(w http.ResponseWriter, r *http.Request) {
+	ticket := s.ticketFromPath(w, r)
+	if ticket == nil {
+		return
+	}
+	task := s.taskFromPath(w, r, ticket)
+	if task == nil {
+		return
+	}
+
+	if err := db.NewTasksRepo(s.config.DB).RemoveTask(r.Context(), task.ID); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// taskFromPath loads the task at the {position} path value of a ticket.
+func (s *Server) taskFromPath(w http.ResponseWriter, r *http.Request, ticket *models.Ticket) *models.TicketTask {
+	position, err := strconv.Atoi(r.PathValue("position"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid task position")
+		return nil
+	}
+
+	task, err := db.NewTasksRepo(s.config.DB).GetByPosition(r.Context(), ticket.ID, position)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return nil
+	}
+	if task == nil {
+		writeError(w, http.StatusNotFound, "task not found")
+		return nil
+	}
+	return task
+}
+
+func taskToResponse(t *models.TicketTask) TaskResponse {
+	return TaskResponse{
+		ID:          t.ID,
+		Position:    t.Position,
+		Description: t.Description,
+		Complete:    t.Complete,
+	}
+}
+
+func acceptTicket(svc *service.TicketService, ticketID int64) error {
+	_, err := svc.Accept(ticketID)
+	return err
+}
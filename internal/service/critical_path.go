@@ -0,0 +1,205 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spetersoncode/wark/internal/db"
+	"github.com/spetersoncode/wark/internal/models"
+)
+
+// DefaultImpactLimit is the number of tickets ranked by CriticalPath when no
+// limit is given.
+const DefaultImpactLimit = 10
+
+// CriticalPath is the longest chain of open work in a project or epic, and
+// the open tickets that hold up the most other work.
+type CriticalPath struct {
+	Project string `json:"project,omitempty"`
+	Epic    string `json:"epic,omitempty"`
+	// Path runs from the first ticket to do to the last. It may include
+	// tickets outside the project or epic that its tickets depend on.
+	Path []PathTicket `json:"path"`
+	// Estimate is the sum of the path's complexity estimates.
+	Estimate int `json:"estimate"`
+	// OpenTickets is the number of open tickets in the project or epic.
+	OpenTickets int `json:"open_tickets"`
+	// Impact ranks open tickets by how many tickets they transitively block.
+	Impact []TicketImpact `json:"impact"`
+}
+
+// PathTicket is a ticket on the critical path.
+type PathTicket struct {
+	Key        string `json:"key"`
+	Title      string `json:"title"`
+	Status     string `json:"status"`
+	Complexity string `json:"complexity"`
+	Estimate   int    `json:"estimate"`
+}
+
+// TicketImpact is how much work an open ticket blocks.
+type TicketImpact struct {
+	Key    string `json:"key"`
+	Title  string `json:"title"`
+	Status string `json:"status"`
+	// Unblocks counts the open tickets that depend on this one directly;
+	// Dependents counts them transitively.
+	Unblocks   int `json:"unblocks"`
+	Dependents int `json:"dependents"`
+}
+
+// openGraph is the dependency graph between open, non-epic tickets. Epics
+// only group work, so they neither lengthen chains nor count as blocked.
+type openGraph struct {
+	tickets    map[int64]*models.Ticket
+	deps       map[int64][]int64
+	dependents map[int64][]int64
+
+	longest map[int64]int
+	next    map[int64]int64
+}
+
+func (s *TicketService) loadOpenGraph() (*openGraph, error) {
+	all, err := s.ticketRepo.List(db.TicketFilter{})
+	if err != nil {
+		return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to list tickets: %v", err), nil)
+	}
+	edges, err := s.depRepo.ListOpen()
+	if err != nil {
+		return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to list dependencies: %v", err), nil)
+	}
+
+	g := &openGraph{
+		tickets:    make(map[int64]*models.Ticket),
+		deps:       make(map[int64][]int64),
+		dependents: make(map[int64][]int64),
+		longest:    make(map[int64]int),
+		next:       make(map[int64]int64),
+	}
+	for _, t := range all {
+		if isOpenWork(t) {
+			g.tickets[t.ID] = t
+		}
+	}
+	for _, e := range edges {
+		if g.tickets[e.TicketID] == nil || g.tickets[e.DependsOnID] == nil {
+			continue
+		}
+		g.deps[e.TicketID] = append(g.deps[e.TicketID], e.DependsOnID)
+		g.dependents[e.DependsOnID] = append(g.dependents[e.DependsOnID], e.TicketID)
+	}
+	return g, nil
+}
+
+func isOpenWork(t *models.Ticket) bool {
+	return t.Status != models.StatusClosed && !t.IsEpic()
+}
+
+// chain returns the estimate of the longest dependency chain ending at id,
+// including id itself. Dependencies can't form cycles, so the recursion ends.
+func (g *openGraph) chain(id int64) int {
+	if n, ok := g.longest[id]; ok {
+		return n
+	}
+	best, bestDep := 0, int64(0)
+	for _, dep := range g.deps[id] {
+		if n := g.chain(dep); n > best || (n == best && bestDep != 0 && g.tickets[dep].TicketKey < g.tickets[bestDep].TicketKey) {
+			best, bestDep = n, dep
+		}
+	}
+	g.longest[id] = best + g.tickets[id].Complexity.Estimate()
+	if bestDep != 0 {
+		g.next[id] = bestDep
+	}
+	return g.longest[id]
+}
+
+// dependentCount counts the open tickets that transitively depend on id.
+func (g *openGraph) dependentCount(id int64) int {
+	seen := map[int64]bool{id: true}
+	queue := []int64{id}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, d := range g.dependents[current] {
+			if !seen[d] {
+				seen[d] = true
+				queue = append(queue, d)
+			}
+		}
+	}
+	return len(seen) - 1
+}
+
+// CriticalPath finds the longest chain of open work, weighted by complexity
+// estimate, among the tickets selected by opts, and ranks them by how many
+// open tickets they block. A limit of zero ranks DefaultImpactLimit tickets;
+// tickets that block nothing are left out of the ranking.
+func (s *TicketService) CriticalPath(opts GraphOptions, limit int) (*CriticalPath, error) {
+	if limit <= 0 {
+		limit = DefaultImpactLimit
+	}
+	tickets, graph, err := s.graphTickets(opts)
+	if err != nil {
+		return nil, err
+	}
+	g, err := s.loadOpenGraph()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CriticalPath{Project: graph.Project, Epic: graph.Epic, Path: []PathTicket{}, Impact: []TicketImpact{}}
+
+	// The path ends at whichever open ticket has the longest chain; tickets
+	// are ordered by key, so ties go to the lowest key
+	var end *models.Ticket
+	for _, t := range tickets {
+		if g.tickets[t.ID] == nil {
+			continue
+		}
+		result.OpenTickets++
+		if end == nil || g.chain(t.ID) > g.chain(end.ID) {
+			end = t
+		}
+
+		if n := g.dependentCount(t.ID); n > 0 {
+			result.Impact = append(result.Impact, TicketImpact{
+				Key:        t.TicketKey,
+				Title:      t.Title,
+				Status:     string(t.Status),
+				Unblocks:   len(g.dependents[t.ID]),
+				Dependents: n,
+			})
+		}
+	}
+
+	if end != nil {
+		result.Estimate = g.chain(end.ID)
+		for id, ok := end.ID, true; ok; id, ok = g.next[id] {
+			t := g.tickets[id]
+			result.Path = append(result.Path, PathTicket{
+				Key:        t.TicketKey,
+				Title:      t.Title,
+				Status:     string(t.Status),
+				Complexity: string(t.Complexity),
+				Estimate:   t.Complexity.Estimate(),
+			})
+		}
+		// Walked from the end back to the start
+		for i, j := 0, len(result.Path)-1; i < j; i, j = i+1, j-1 {
+			result.Path[i], result.Path[j] = result.Path[j], result.Path[i]
+		}
+	}
+
+	sort.SliceStable(result.Impact, func(i, j int) bool {
+		a, b := result.Impact[i], result.Impact[j]
+		if a.Dependents != b.Dependents {
+			return a.Dependents > b.Dependents
+		}
+		return a.Unblocks > b.Unblocks
+	})
+	if len(result.Impact) > limit {
+		result.Impact = result.Impact[:limit]
+	}
+	return result, nil
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/spetersoncode/wark/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTicketService_CriticalPath(t *testing.T) {
+	database, _, cleanup := testDB(t)
+	defer cleanup()
+	createTicketTestProject(t, database, "CP")
+	svc := NewTicketService(database.DB)
+
+	create := func(input CreateTicketInput) {
+		t.Helper()
+		input.ProjectKey = "CP"
+		_, err := svc.Create(input)
+		require.NoError(t, err)
+	}
+	// CP-2 -> CP-3 -> CP-4 is the longest chain (2 + 5 + 3); CP-2 -> CP-5 is
+	// shorter (2 + 3) but CP-2 blocks all three
+	create(CreateTicketInput{Title: "Epic", Type: "epic"})
+	create(CreateTicketInput{Title: "Schema", ParentKey: "CP-1", Complexity: "small"})
+	create(CreateTicketInput{Title: "Service", ParentKey: "CP-1", Complexity: "large", DependsOn: []string{"CP-2"}})
+	create(CreateTicketInput{Title: "API", ParentKey: "CP-1", DependsOn: []string{"CP-3"}})
+	create(CreateTicketInput{Title: "Migration", ParentKey: "CP-1", DependsOn: []string{"CP-2"}})
+	create(CreateTicketInput{Title: "Docs", ParentKey: "CP-1", Complexity: "trivial"})
+	create(CreateTicketInput{Title: "Outside the epic", DependsOn: []string{"CP-4"}})
+
+	result, err := svc.CriticalPath(GraphOptions{EpicKey: "CP-1"}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "CP-1", result.Epic)
+	assert.Equal(t, 5, result.OpenTickets)
+	assert.Equal(t, 10, result.Estimate)
+	keys := make([]string, len(result.Path))
+	for i, p := range result.Path {
+		keys[i] = p.Key
+	}
+	assert.Equal(t, []string{"CP-2", "CP-3", "CP-4"}, keys)
+
+	// Dependents outside the epic still count toward impact
+	require.Len(t, result.Impact, 3)
+	assert.Equal(t, TicketImpact{Key: "CP-2", Title: "Schema", Status: "backlog", Unblocks: 2, Dependents: 4}, result.Impact[0])
+	assert.Equal(t, "CP-3", result.Impact[1].Key)
+	assert.Equal(t, 2, result.Impact[1].Dependents)
+
+	limited, err := svc.CriticalPath(GraphOptions{ProjectKey: "CP"}, 1)
+	require.NoError(t, err)
+	assert.Len(t, limited.Impact, 1)
+	assert.Equal(t, 13, limited.Estimate, "the project-wide chain includes CP-7")
+
+	// Closing the start of the chain shortens it
+	require.NoError(t, svc.Close(mustTicketID(t, db.NewTicketRepo(database.DB), "CP", 2), "completed", ""))
+	result, err = svc.CriticalPath(GraphOptions{EpicKey: "CP-1"}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 8, result.Estimate)
+	assert.Equal(t, "CP-3", result.Path[0].Key)
+}
+
+func TestTicketService_NextWorkableByImpact(t *testing.T) {
+	database, _, cleanup := testDB(t)
+	defer cleanup()
+	createTicketTestProject(t, database, "TIE")
+	svc := NewTicketService(database.DB)
+	ticketRepo := db.NewTicketRepo(database.DB)
+
+	// TIE-1 is older, but TIE-2 blocks TIE-3
+	for _, input := range []CreateTicketInput{
+		{Title: "Older"},
+		{Title: "Blocker"},
+		{Title: "Blocked", DependsOn: []string{"TIE-2"}},
+	} {
+		input.ProjectKey = "TIE"
+		_, err := svc.Create(input)
+		require.NoError(t, err)
+	}
+	for _, n := range []int{1, 2} {
+		require.NoError(t, svc.Prioritize(mustTicketID(t, ticketRepo, "TIE", n)))
+	}
+
+	next, err := svc.NextWorkable(db.TicketFilter{ProjectKey: "TIE"})
+	require.NoError(t, err)
+	assert.Equal(t, "TIE-1", next.TicketKey)
+
+	ranked := svc.BreakTiesByImpact(true)
+	next, err = ranked.NextWorkable(db.TicketFilter{ProjectKey: "TIE"})
+	require.NoError(t, err)
+	assert.Equal(t, "TIE-2", next.TicketKey)
+
+	result, err := ranked.ClaimNext(db.TicketFilter{ProjectKey: "TIE"}, "agent", 0)
+	require.NoError(t, err)
+	assert.Equal(t, "TIE-2", result.Ticket.TicketKey)
+
+	// Priority still comes first
+	high := "high"
+	_, err = svc.Update(mustTicketID(t, ticketRepo, "TIE", 1), UpdateTicketInput{Priority: &high})
+	require.NoError(t, err)
+	require.NoError(t, ticketRepo.UpdateStatus(mustTicketID(t, ticketRepo, "TIE", 2), "ready"))
+	next, err = ranked.NextWorkable(db.TicketFilter{ProjectKey: "TIE"})
+	require.NoError(t, err)
+	assert.Equal(t, "TIE-1", next.TicketKey)
+}
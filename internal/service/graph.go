@@ -0,0 +1,299 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spetersoncode/wark/internal/db"
+	"github.com/spetersoncode/wark/internal/models"
+)
+
+// Graph edge types
+const (
+	// EdgeDependsOn points from a ticket to a ticket it depends on.
+	EdgeDependsOn = "depends_on"
+	// EdgeParent points from a child ticket to its parent.
+	EdgeParent = "parent"
+)
+
+// GraphOptions selects the tickets in a graph. With EpicKey, the graph holds
+// the epic and its descendants; otherwise every ticket in ProjectKey, or in
+// all projects when ProjectKey is empty.
+type GraphOptions struct {
+	ProjectKey string
+	EpicKey    string
+}
+
+// Graph is a ticket dependency graph.
+type Graph struct {
+	Project string      `json:"project,omitempty"`
+	Epic    string      `json:"epic,omitempty"`
+	Nodes   []GraphNode `json:"nodes"`
+	Edges   []GraphEdge `json:"edges"`
+}
+
+// GraphNode is a ticket in a graph. External nodes are outside the selected
+// tickets but linked to one of them by a dependency.
+type GraphNode struct {
+	Key        string `json:"key"`
+	Title      string `json:"title"`
+	Type       string `json:"type"`
+	Status     string `json:"status"`
+	Priority   string `json:"priority"`
+	Complexity string `json:"complexity"`
+	External   bool   `json:"external,omitempty"`
+}
+
+// GraphEdge links two tickets by key.
+type GraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Type string `json:"type"`
+}
+
+// Graph builds the dependency graph for a project or epic, with edges for
+// dependencies and parent links.
+func (s *TicketService) Graph(opts GraphOptions) (*Graph, error) {
+	tickets, graph, err := s.graphTickets(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int64]*models.Ticket, len(tickets))
+	ids := make([]int64, 0, len(tickets))
+	for _, t := range tickets {
+		byID[t.ID] = t
+		ids = append(ids, t.ID)
+		graph.Nodes = append(graph.Nodes, graphNode(t, false))
+	}
+
+	deps, err := s.depRepo.ListForTickets(ids)
+	if err != nil {
+		return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to list dependencies: %v", err), nil)
+	}
+	external := make(map[int64]*models.Ticket)
+	lookup := func(id int64) (*models.Ticket, error) {
+		if t, ok := byID[id]; ok {
+			return t, nil
+		}
+		if t, ok := external[id]; ok {
+			return t, nil
+		}
+		t, err := s.ticketRepo.GetByID(id)
+		if err != nil {
+			return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to get ticket: %v", err), nil)
+		}
+		external[id] = t
+		return t, nil
+	}
+	for _, d := range deps {
+		from, err := lookup(d.TicketID)
+		if err != nil {
+			return nil, err
+		}
+		to, err := lookup(d.DependsOnID)
+		if err != nil {
+			return nil, err
+		}
+		graph.Edges = append(graph.Edges, GraphEdge{From: from.TicketKey, To: to.TicketKey, Type: EdgeDependsOn})
+	}
+	for _, t := range tickets {
+		if t.ParentTicketID == nil {
+			continue
+		}
+		if parent, ok := byID[*t.ParentTicketID]; ok {
+			graph.Edges = append(graph.Edges, GraphEdge{From: t.TicketKey, To: parent.TicketKey, Type: EdgeParent})
+		}
+	}
+
+	externalNodes := make([]GraphNode, 0, len(external))
+	for _, t := range external {
+		externalNodes = append(externalNodes, graphNode(t, true))
+	}
+	sort.Slice(externalNodes, func(i, j int) bool { return externalNodes[i].Key < externalNodes[j].Key })
+	graph.Nodes = append(graph.Nodes, externalNodes...)
+
+	return graph, nil
+}
+
+// graphTickets returns the tickets selected by opts, ordered by key.
+func (s *TicketService) graphTickets(opts GraphOptions) ([]*models.Ticket, *Graph, error) {
+	graph := &Graph{Nodes: []GraphNode{}, Edges: []GraphEdge{}}
+	projectKey := strings.ToUpper(strings.TrimSpace(opts.ProjectKey))
+
+	if opts.EpicKey == "" {
+		graph.Project = projectKey
+		if projectKey != "" {
+			project, err := s.projectRepo.GetByKey(projectKey)
+			if err != nil {
+				return nil, nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to get project: %v", err), nil)
+			}
+			if project == nil {
+				return nil, nil, newTicketError(ErrCodeNotFound, fmt.Sprintf("project %s not found", projectKey), nil)
+			}
+		}
+		tickets, err := s.ticketRepo.List(db.TicketFilter{ProjectKey: projectKey})
+		if err != nil {
+			return nil, nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to list tickets: %v", err), nil)
+		}
+		sortByKey(tickets)
+		return tickets, graph, nil
+	}
+
+	epic, err := s.resolveKey(opts.EpicKey, projectKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !epic.IsEpic() {
+		return nil, nil, newTicketError(ErrCodeInvalidInput, fmt.Sprintf("%s is not an epic", epic.TicketKey), nil)
+	}
+	if projectKey != "" && epic.ProjectKey != projectKey {
+		return nil, nil, newTicketError(ErrCodeInvalidInput,
+			fmt.Sprintf("epic %s is not in project %s", epic.TicketKey, projectKey), nil)
+	}
+	graph.Project = epic.ProjectKey
+	graph.Epic = epic.TicketKey
+
+	// Walk down parent links; children of children are included too
+	tickets := []*models.Ticket{epic}
+	seen := map[int64]bool{epic.ID: true}
+	for i := 0; i < len(tickets); i++ {
+		children, err := s.ticketRepo.GetChildren(tickets[i].ID)
+		if err != nil {
+			return nil, nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to list children: %v", err), nil)
+		}
+		for _, c := range children {
+			if !seen[c.ID] {
+				seen[c.ID] = true
+				tickets = append(tickets, c)
+			}
+		}
+	}
+	sortByKey(tickets)
+	return tickets, graph, nil
+}
+
+// sortByKey orders tickets by project key, then number.
+func sortByKey(tickets []*models.Ticket) {
+	sort.Slice(tickets, func(i, j int) bool {
+		if tickets[i].ProjectKey != tickets[j].ProjectKey {
+			return tickets[i].ProjectKey < tickets[j].ProjectKey
+		}
+		return tickets[i].Number < tickets[j].Number
+	})
+}
+
+func graphNode(t *models.Ticket, external bool) GraphNode {
+	return GraphNode{
+		Key:        t.TicketKey,
+		Title:      t.Title,
+		Type:       string(t.Type),
+		Status:     string(t.Status),
+		Priority:   string(t.Priority),
+		Complexity: string(t.Complexity),
+		External:   external,
+	}
+}
+
+// graphStatusColors are the node fill colors used by DOT and Mermaid output.
+var graphStatusColors = map[string]string{
+	string(models.StatusBacklog):   "#e0e0e0",
+	string(models.StatusBlocked):   "#f4cccc",
+	string(models.StatusReady):     "#d9ead3",
+	string(models.StatusWorking):   "#cfe2f3",
+	string(models.StatusHuman):     "#fce5cd",
+	string(models.StatusReview):    "#fff2cc",
+	string(models.StatusReviewing): "#ffe599",
+	string(models.StatusClosed):    "#b7b7b7",
+}
+
+// graphLabelLen is the longest title shown in a DOT or Mermaid node.
+const graphLabelLen = 40
+
+func graphLabelTitle(title string) string {
+	runes := []rune(title)
+	if len(runes) <= graphLabelLen {
+		return title
+	}
+	return string(runes[:graphLabelLen-3]) + "..."
+}
+
+// DOT renders the graph in Graphviz DOT format. Nodes are filled by status;
+// dependency edges are solid and parent links dashed.
+func (g *Graph) DOT() string {
+	var b strings.Builder
+	name := g.Epic
+	if name == "" {
+		name = g.Project
+	}
+	if name == "" {
+		name = "wark"
+	}
+	fmt.Fprintf(&b, "digraph %s {\n", dotQuote(name))
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, style=\"rounded,filled\", fontname=\"Helvetica\"];\n")
+	for _, n := range g.Nodes {
+		style := ""
+		if n.External {
+			style = ", style=\"rounded,filled,dashed\""
+		}
+		fmt.Fprintf(&b, "  %s [label=%s, fillcolor=%s%s];\n",
+			dotQuote(n.Key), dotQuote(n.Key+"\n"+graphLabelTitle(n.Title)+"\n["+n.Status+"]"),
+			dotQuote(graphStatusColors[n.Status]), style)
+	}
+	for _, e := range g.Edges {
+		attrs := ""
+		if e.Type == EdgeParent {
+			attrs = " [style=dashed, arrowhead=empty]"
+		}
+		fmt.Fprintf(&b, "  %s -> %s%s;\n", dotQuote(e.From), dotQuote(e.To), attrs)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
+
+// Mermaid renders the graph as a Mermaid flowchart. Nodes are styled by
+// status through classDef; parent links are dotted.
+func (g *Graph) Mermaid() string {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	used := make(map[string]bool)
+	for _, n := range g.Nodes {
+		label := fmt.Sprintf("%s: %s", n.Key, graphLabelTitle(n.Title))
+		fmt.Fprintf(&b, "  %s[\"%s\"]\n", mermaidID(n.Key), strings.ReplaceAll(label, `"`, "#quot;"))
+		used[n.Status] = true
+	}
+	for _, e := range g.Edges {
+		arrow := "-->"
+		if e.Type == EdgeParent {
+			arrow = "-.->"
+		}
+		fmt.Fprintf(&b, "  %s %s %s\n", mermaidID(e.From), arrow, mermaidID(e.To))
+	}
+
+	statuses := make([]string, 0, len(used))
+	for status := range used {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+	for _, status := range statuses {
+		fmt.Fprintf(&b, "  classDef %s fill:%s,stroke:#666\n", status, graphStatusColors[status])
+	}
+	for _, n := range g.Nodes {
+		fmt.Fprintf(&b, "  class %s %s\n", mermaidID(n.Key), n.Status)
+	}
+	return b.String()
+}
+
+// mermaidID turns a ticket key into a Mermaid node ID.
+func mermaidID(key string) string {
+	return strings.ReplaceAll(key, "-", "_")
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/spetersoncode/wark/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTicketService_Graph(t *testing.T) {
+	database, _, cleanup := testDB(t)
+	defer cleanup()
+	createTicketTestProject(t, database, "GRA")
+	createTicketTestProject(t, database, "OTH")
+	svc := NewTicketService(database.DB)
+
+	// GRA-1 epic with children GRA-2 and GRA-3; GRA-3 depends on GRA-2 and OTH-1;
+	// GRA-4 stands alone
+	_, err := svc.Create(CreateTicketInput{ProjectKey: "GRA", Title: "Epic", Type: "epic"})
+	require.NoError(t, err)
+	_, err = svc.Create(CreateTicketInput{ProjectKey: "OTH", Title: "Elsewhere"})
+	require.NoError(t, err)
+	_, err = svc.Create(CreateTicketInput{ProjectKey: "GRA", Title: "First", ParentKey: "GRA-1"})
+	require.NoError(t, err)
+	_, err = svc.Create(CreateTicketInput{ProjectKey: "GRA", Title: `Second "quoted"`, ParentKey: "GRA-1", DependsOn: []string{"GRA-2", "OTH-1"}})
+	require.NoError(t, err)
+	_, err = svc.Create(CreateTicketInput{ProjectKey: "GRA", Title: "Loose"})
+	require.NoError(t, err)
+
+	t.Run("project", func(t *testing.T) {
+		graph, err := svc.Graph(GraphOptions{ProjectKey: "gra"})
+		require.NoError(t, err)
+		assert.Equal(t, "GRA", graph.Project)
+
+		keys := make([]string, len(graph.Nodes))
+		for i, n := range graph.Nodes {
+			keys[i] = n.Key
+		}
+		assert.Equal(t, []string{"GRA-1", "GRA-2", "GRA-3", "GRA-4", "OTH-1"}, keys)
+		assert.True(t, graph.Nodes[4].External)
+		assert.Equal(t, "blocked", graph.Nodes[2].Status)
+
+		assert.ElementsMatch(t, []GraphEdge{
+			{From: "GRA-3", To: "GRA-2", Type: EdgeDependsOn},
+			{From: "GRA-3", To: "OTH-1", Type: EdgeDependsOn},
+			{From: "GRA-2", To: "GRA-1", Type: EdgeParent},
+			{From: "GRA-3", To: "GRA-1", Type: EdgeParent},
+		}, graph.Edges)
+
+		dot := graph.DOT()
+		assert.Contains(t, dot, `digraph "GRA" {`)
+		assert.Contains(t, dot, `"GRA-3" -> "GRA-2";`)
+		assert.Contains(t, dot, `"GRA-2" -> "GRA-1" [style=dashed, arrowhead=empty];`)
+		assert.Contains(t, dot, `Second \"quoted\"`)
+		assert.Contains(t, dot, `fillcolor="#f4cccc"`)
+
+		mermaid := graph.Mermaid()
+		assert.Contains(t, mermaid, "flowchart LR")
+		assert.Contains(t, mermaid, "GRA_3 --> GRA_2")
+		assert.Contains(t, mermaid, "GRA_2 -.-> GRA_1")
+		assert.Contains(t, mermaid, "Second #quot;quoted#quot;")
+		assert.Contains(t, mermaid, "class GRA_3 blocked")
+	})
+
+	t.Run("epic", func(t *testing.T) {
+		graph, err := svc.Graph(GraphOptions{EpicKey: "GRA-1"})
+		require.NoError(t, err)
+		assert.Equal(t, "GRA-1", graph.Epic)
+		assert.Len(t, graph.Nodes, 4, "epic, two children and the external dependency")
+		for _, n := range graph.Nodes {
+			assert.NotEqual(t, "GRA-4", n.Key)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := svc.Graph(GraphOptions{ProjectKey: "NOPE"})
+		var te *TicketError
+		require.ErrorAs(t, err, &te)
+		assert.Equal(t, ErrCodeNotFound, te.Code)
+
+		_, err = svc.Graph(GraphOptions{EpicKey: "GRA-2"})
+		require.ErrorAs(t, err, &te)
+		assert.Equal(t, ErrCodeInvalidInput, te.Code)
+
+		_, err = svc.Graph(GraphOptions{ProjectKey: "OTH", EpicKey: "GRA-1"})
+		require.ErrorAs(t, err, &te)
+		assert.Equal(t, ErrCodeInvalidInput, te.Code)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		require.NoError(t, db.NewTicketRepo(database.DB).Delete(mustTicketID(t, db.NewTicketRepo(database.DB), "OTH", 1)))
+		graph, err := svc.Graph(GraphOptions{ProjectKey: "OTH"})
+		require.NoError(t, err)
+		assert.Empty(t, graph.Nodes)
+		assert.NotNil(t, graph.Edges)
+	})
+}
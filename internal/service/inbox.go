@@ -2,7 +2,11 @@
 package service
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/spetersoncode/wark/internal/db"
 	"github.com/spetersoncode/wark/internal/errors"
@@ -15,13 +19,16 @@ import (
 // 2. Transition ticket from 'human' → 'ready' if applicable
 // 3. Log activity
 type InboxService struct {
+	db           *sql.DB
 	inboxRepo    *db.InboxRepo
 	ticketRepo   *db.TicketRepo
 	claimRepo    *db.ClaimRepo
 	activityRepo *db.ActivityRepo
 }
 
-// NewInboxService creates a new InboxService.
+// NewInboxService creates a new InboxService from repositories. It runs
+// each operation directly against them, so it suits repositories that
+// already share a transaction; see NewInboxServiceFromDB.
 func NewInboxService(inboxRepo *db.InboxRepo, ticketRepo *db.TicketRepo, claimRepo *db.ClaimRepo, activityRepo *db.ActivityRepo) *InboxService {
 	return &InboxService{
 		inboxRepo:    inboxRepo,
@@ -31,6 +38,43 @@ func NewInboxService(inboxRepo *db.InboxRepo, ticketRepo *db.TicketRepo, claimRe
 	}
 }
 
+// NewInboxServiceFromDB creates an InboxService on database that runs each
+// operation in its own transaction, so a message, its ticket's move and its
+// activity log entries are committed together or not at all.
+func NewInboxServiceFromDB(database *sql.DB) *InboxService {
+	s := NewInboxService(
+		db.NewInboxRepo(database),
+		db.NewTicketRepo(database),
+		db.NewClaimRepo(database),
+		db.NewActivityRepo(database),
+	)
+	s.db = database
+	return s
+}
+
+// inTx runs fn against a copy of the service whose repositories share a
+// single transaction. A service built without a database runs fn directly.
+func (s *InboxService) inTx(fn func(tx *InboxService) error) error {
+	if s.db == nil {
+		return fn(s)
+	}
+	err := db.WithTx(s.db, func(tx *sql.Tx) error {
+		return fn(NewInboxService(
+			db.NewInboxRepo(tx),
+			db.NewTicketRepo(tx),
+			db.NewClaimRepo(tx),
+			db.NewActivityRepo(tx),
+		))
+	})
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(*errors.Error); ok {
+		return err
+	}
+	return errors.WrapInternal(err, "inbox transaction failed")
+}
+
 // RespondResult contains the result of responding to an inbox message.
 type RespondResult struct {
 	Message       *models.InboxMessage
@@ -48,13 +92,42 @@ type SendResult struct {
 	ClaimReleased  bool
 }
 
+// RespondInput holds a human's response to an inbox message.
+type RespondInput struct {
+	Response string
+	// Choice is the key of the chosen option. It is required for decision
+	// messages with options, and the response defaults to the option.
+	Choice string
+}
+
 // Respond records a response to an inbox message and handles ticket state transitions.
-// It performs the 3-step flow:
+// The response is added to the message's thread as a human reply and
+// resolves the thread. It performs the 3-step flow:
 // 1. Record response in DB via inboxRepo.Respond()
 // 2. Transition ticket from 'human' → 'ready' if applicable
 // 3. Log activity via activityRepo
 func (s *InboxService) Respond(messageID int64, response string) (*RespondResult, error) {
-	if response == "" {
+	return s.RespondWith(messageID, RespondInput{Response: response})
+}
+
+// RespondWith is Respond with a choice for decision messages.
+func (s *InboxService) RespondWith(messageID int64, input RespondInput) (*RespondResult, error) {
+	var result *RespondResult
+	err := s.inTx(func(tx *InboxService) error {
+		var err error
+		result, err = tx.respond(messageID, input, true)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// respond records a response. With resume unset the ticket is left in
+// 'human' for the caller to move; see TicketService.RespondAndAct.
+func (s *InboxService) respond(messageID int64, input RespondInput, resume bool) (*RespondResult, error) {
+	if input.Response == "" && input.Choice == "" {
 		return nil, errors.InvalidArgs("response is required")
 	}
 
@@ -72,10 +145,58 @@ func (s *InboxService) Respond(messageID int64, response string) (*RespondResult
 		return nil, errors.StateError("message #%d has already been responded to", messageID)
 	}
 
+	option, err := chooseOption(message, input.Choice)
+	if err != nil {
+		return nil, err
+	}
+	response := input.Response
+	if response == "" {
+		response = option.String()
+	}
+
+	reply := &models.InboxReply{MessageID: messageID, AuthorType: models.ActorTypeHuman, Content: response}
+	if err := s.inboxRepo.AddReply(reply); err != nil {
+		return nil, errors.WrapInternal(err, "failed to record reply")
+	}
+	return s.resolve(message, response, reply, option, resume)
+}
+
+// chooseOption validates a choice against the message's options. A
+// message with options must be resolved with one of them; one without
+// takes no choice. It returns nil when there is nothing to choose.
+func chooseOption(message *models.InboxMessage, choice string) (*models.DecisionOption, error) {
+	choice = strings.TrimSpace(choice)
+	if len(message.Options) == 0 {
+		if choice != "" {
+			return nil, errors.InvalidArgs("message #%d has no options to choose from", message.ID)
+		}
+		return nil, nil
+	}
+	keys := strings.Join(message.OptionKeys(), ", ")
+	if choice == "" {
+		return nil, errors.InvalidArgs("message #%d is a decision: choose one of %s", message.ID, keys)
+	}
+	option := message.FindOption(choice)
+	if option == nil {
+		return nil, errors.InvalidArgs("invalid choice %s for message #%d: choose one of %s", choice, message.ID, keys)
+	}
+	return option, nil
+}
+
+// resolve records response, and the chosen option if any, as the answer to
+// message, moves its ticket from 'human' to 'ready' if resume is set and
+// logs the response. reply is the human reply that carries the response, or
+// nil if it is already in the thread.
+func (s *InboxService) resolve(message *models.InboxMessage, response string, reply *models.InboxReply, option *models.DecisionOption, resume bool) (*RespondResult, error) {
 	// Step 2: Record response
-	if err := s.inboxRepo.Respond(messageID, response); err != nil {
+	if err := s.inboxRepo.Respond(message.ID, response); err != nil {
 		return nil, errors.WrapInternal(err, "failed to record response")
 	}
+	if option != nil {
+		if err := s.inboxRepo.SetChoice(message.ID, option.Key); err != nil {
+			return nil, errors.WrapInternal(err, "failed to record choice")
+		}
+	}
 
 	// Step 3: Get the associated ticket
 	ticket, err := s.ticketRepo.GetByID(message.TicketID)
@@ -91,7 +212,7 @@ func (s *InboxService) Respond(messageID int64, response string) (*RespondResult
 	}
 
 	// Step 4: If ticket.Status == StatusHuman, transition to Ready
-	if ticket != nil && ticket.Status == models.StatusHuman {
+	if resume && ticket != nil && ticket.Status == models.StatusHuman {
 		result.PreviousStatus = ticket.Status
 		ticket.Status = models.StatusReady
 		ticket.RetryCount = 0          // Reset retry count on human response
@@ -104,41 +225,290 @@ func (s *InboxService) Respond(messageID int64, response string) (*RespondResult
 	}
 
 	// Step 5: Log activity
+	details := map[string]interface{}{
+		"inbox_message_id": message.ID,
+		"message_type":     string(message.MessageType),
+		"message":          message.Content,
+		"response":         response,
+		"resolved":         true,
+	}
+	if reply != nil {
+		details["reply_id"] = reply.ID
+	}
+	if option != nil {
+		details["choice"] = option.Key
+	}
 	if err := s.activityRepo.LogActionWithDetails(
 		message.TicketID,
 		models.ActionHumanResponded,
 		models.ActorTypeHuman,
 		"",
 		"Responded to message",
-		map[string]interface{}{
-			"inbox_message_id": messageID,
-			"message_type":     string(message.MessageType),
-			"message":          message.Content,
-			"response":         response,
-		},
+		details,
 	); err != nil {
 		return nil, errors.WrapInternal(err, "failed to log activity")
 	}
 
 	// Step 6: Also log as a comment so human feedback appears in ticket comment history
-	commentText := fmt.Sprintf("[%s] %s", message.MessageType, response)
-	if err := s.activityRepo.LogAction(
-		message.TicketID,
-		models.ActionComment,
-		models.ActorTypeHuman,
-		"",
-		commentText,
-	); err != nil {
-		return nil, errors.WrapInternal(err, "failed to log comment")
+	if reply != nil {
+		commentText := fmt.Sprintf("[%s] %s", message.MessageType, response)
+		if err := s.activityRepo.LogAction(
+			message.TicketID,
+			models.ActionComment,
+			models.ActorTypeHuman,
+			"",
+			commentText,
+		); err != nil {
+			return nil, errors.WrapInternal(err, "failed to log comment")
+		}
 	}
 
 	// Reload message to get updated responded_at
-	message, _ = s.inboxRepo.GetByID(messageID)
+	message, _ = s.inboxRepo.GetByID(message.ID)
 	result.Message = message
 
 	return result, nil
 }
 
+// ReplyInput holds a reply to an inbox message's thread. A reply with a
+// WorkerID is from that agent; one without is from a human.
+type ReplyInput struct {
+	Content  string
+	WorkerID string
+	// Resolve marks the thread resolved, returning the ticket to 'ready'.
+	// Only a human can resolve a thread; with no Content, the latest human
+	// reply becomes the response.
+	Resolve bool
+	// Choice is the key of the chosen option when resolving a decision
+	// message with options.
+	Choice string
+}
+
+// ReplyResult contains the result of replying to an inbox message.
+type ReplyResult struct {
+	Message        *models.InboxMessage
+	Reply          *models.InboxReply // nil when resolving without new content
+	Resolved       bool
+	Reopened       bool
+	StatusChanged  bool
+	PreviousStatus models.Status
+	NewStatus      models.Status
+	ClaimReleased  bool
+}
+
+// Reply adds a reply to an inbox message's thread.
+//
+// A human reply leaves the ticket where it is unless it resolves the thread.
+// An agent reply asks a follow-up: it reopens a resolved thread and, for
+// message types that need a response, escalates the ticket back to 'human'
+// like Send.
+func (s *InboxService) Reply(messageID int64, input ReplyInput) (*ReplyResult, error) {
+	var result *ReplyResult
+	err := s.inTx(func(tx *InboxService) error {
+		var err error
+		result, err = tx.reply(messageID, input)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *InboxService) reply(messageID int64, input ReplyInput) (*ReplyResult, error) {
+	content := strings.TrimSpace(input.Content)
+	agent := input.WorkerID != ""
+	if input.Resolve && agent {
+		return nil, errors.InvalidArgs("only a human can resolve a thread")
+	}
+	if content == "" && !input.Resolve {
+		return nil, errors.InvalidArgs("reply is required")
+	}
+	if input.Choice != "" && !input.Resolve {
+		return nil, errors.InvalidArgs("a choice can only be made when resolving")
+	}
+
+	message, err := s.inboxRepo.GetByID(messageID)
+	if err != nil {
+		return nil, errors.WrapInternal(err, "failed to get message")
+	}
+	if message == nil {
+		return nil, errors.NotFound("message #%d not found", messageID)
+	}
+	if input.Resolve && message.RespondedAt != nil {
+		return nil, errors.StateError("message #%d is already resolved", messageID)
+	}
+	var option *models.DecisionOption
+	if input.Resolve {
+		if option, err = chooseOption(message, input.Choice); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &ReplyResult{Message: message}
+
+	response := content
+	if content != "" {
+		authorType := models.ActorTypeHuman
+		if agent {
+			authorType = models.ActorTypeAgent
+		}
+		result.Reply = &models.InboxReply{
+			MessageID:  messageID,
+			AuthorType: authorType,
+			Author:     input.WorkerID,
+			Content:    content,
+		}
+		if err := s.inboxRepo.AddReply(result.Reply); err != nil {
+			return nil, errors.WrapInternal(err, "failed to record reply")
+		}
+	} else {
+		// Resolving without new content answers with the latest human reply
+		replies, err := s.inboxRepo.ListReplies(messageID)
+		if err != nil {
+			return nil, errors.WrapInternal(err, "failed to list replies")
+		}
+		for _, r := range replies {
+			if r.AuthorType == models.ActorTypeHuman {
+				response = r.Content
+			}
+		}
+		if response == "" && option != nil {
+			response = option.String()
+		}
+		if response == "" {
+			return nil, errors.InvalidArgs("response is required: the thread has no human reply")
+		}
+	}
+
+	if input.Resolve {
+		resolved, err := s.resolve(message, response, result.Reply, option, true)
+		if err != nil {
+			return nil, err
+		}
+		result.Message = resolved.Message
+		result.Resolved = true
+		result.StatusChanged = resolved.TicketUpdated
+		result.PreviousStatus = resolved.PreviousStatus
+		result.NewStatus = resolved.NewStatus
+		return result, nil
+	}
+
+	ticket, err := s.ticketRepo.GetByID(message.TicketID)
+	if err != nil {
+		return nil, errors.WrapInternal(err, "failed to get ticket")
+	}
+	if ticket == nil {
+		return nil, errors.NotFound("ticket not found")
+	}
+	result.PreviousStatus = ticket.Status
+	result.NewStatus = ticket.Status
+
+	if !agent {
+		if err := s.activityRepo.LogActionWithDetails(
+			ticket.ID,
+			models.ActionHumanResponded,
+			models.ActorTypeHuman,
+			"",
+			fmt.Sprintf("Replied to message #%d", messageID),
+			map[string]interface{}{
+				"inbox_message_id": messageID,
+				"message_type":     string(message.MessageType),
+				"reply_id":         result.Reply.ID,
+				"response":         content,
+				"resolved":         false,
+			},
+		); err != nil {
+			return nil, errors.WrapInternal(err, "failed to log activity")
+		}
+		if err := s.activityRepo.LogAction(
+			ticket.ID,
+			models.ActionComment,
+			models.ActorTypeHuman,
+			"",
+			fmt.Sprintf("[%s] %s", message.MessageType, content),
+		); err != nil {
+			return nil, errors.WrapInternal(err, "failed to log comment")
+		}
+		return result, nil
+	}
+
+	// An agent's follow-up reopens the thread and waits on a human again
+	if message.RespondedAt != nil {
+		if err := s.inboxRepo.Reopen(messageID); err != nil {
+			return nil, errors.WrapInternal(err, "failed to reopen message")
+		}
+		result.Reopened = true
+	}
+	claim, err := s.claimRepo.GetActiveByTicketID(ticket.ID)
+	if err != nil {
+		return nil, errors.WrapInternal(err, "failed to get claim")
+	}
+	result.StatusChanged, result.ClaimReleased, err = s.escalate(ticket, message.MessageType, claim)
+	if err != nil {
+		return nil, err
+	}
+	result.NewStatus = ticket.Status
+
+	summary := fmt.Sprintf("Replied to message #%d", messageID)
+	if result.StatusChanged {
+		summary = fmt.Sprintf("Escalated: %s → %s", result.PreviousStatus, result.NewStatus)
+	}
+	details := map[string]interface{}{
+		"message_type":     string(message.MessageType),
+		"inbox_message_id": messageID,
+		"reply_id":         result.Reply.ID,
+		"message":          content,
+	}
+	if result.Reopened {
+		details["reopened"] = true
+	}
+	if result.StatusChanged {
+		details["previous_status"] = string(result.PreviousStatus)
+		details["new_status"] = string(result.NewStatus)
+	}
+	if result.ClaimReleased {
+		details["claim_released"] = true
+	}
+	if err := s.activityRepo.LogActionWithDetails(
+		ticket.ID,
+		models.ActionEscalated,
+		models.ActorTypeAgent,
+		input.WorkerID,
+		summary,
+		details,
+	); err != nil {
+		return nil, errors.WrapInternal(err, "failed to log activity")
+	}
+
+	if result.Message, err = s.inboxRepo.GetByID(messageID); err != nil {
+		return nil, errors.WrapInternal(err, "failed to get message")
+	}
+	return result, nil
+}
+
+// Thread returns an inbox message with its replies in order.
+func (s *InboxService) Thread(messageID int64) (*models.InboxThread, error) {
+	message, err := s.inboxRepo.GetByID(messageID)
+	if err != nil {
+		return nil, errors.WrapInternal(err, "failed to get message")
+	}
+	if message == nil {
+		return nil, errors.NotFound("message #%d not found", messageID)
+	}
+
+	replies, err := s.inboxRepo.ListReplies(messageID)
+	if err != nil {
+		return nil, errors.WrapInternal(err, "failed to list replies")
+	}
+
+	return &models.InboxThread{
+		Message:  message,
+		Replies:  replies,
+		Resolved: message.RespondedAt != nil,
+	}, nil
+}
+
 // Send creates an inbox message and handles ticket escalation.
 // It performs:
 // 1. Validate ticket exists
@@ -147,6 +517,25 @@ func (s *InboxService) Respond(messageID int64, response string) (*RespondResult
 // 4. Release any active claim
 // 5. Log activity
 func (s *InboxService) Send(ticketID int64, msgType models.MessageType, content, workerID string) (*SendResult, error) {
+	return s.SendWithOptions(ticketID, msgType, content, workerID, nil)
+}
+
+// SendWithOptions is Send for a decision message offering options, which
+// the human's response must then choose from.
+func (s *InboxService) SendWithOptions(ticketID int64, msgType models.MessageType, content, workerID string, options []models.DecisionOption) (*SendResult, error) {
+	var result *SendResult
+	err := s.inTx(func(tx *InboxService) error {
+		var err error
+		result, err = tx.send(ticketID, msgType, content, workerID, options)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *InboxService) send(ticketID int64, msgType models.MessageType, content, workerID string, options []models.DecisionOption) (*SendResult, error) {
 	if content == "" {
 		return nil, errors.InvalidArgs("message content is required")
 	}
@@ -155,6 +544,15 @@ func (s *InboxService) Send(ticketID int64, msgType models.MessageType, content,
 		return nil, errors.InvalidArgs("invalid message type: %s", msgType)
 	}
 
+	if len(options) > 0 {
+		if msgType != models.MessageTypeDecision {
+			return nil, errors.InvalidArgs("options are only allowed on decision messages")
+		}
+		if err := models.ValidateDecisionOptions(options); err != nil {
+			return nil, errors.InvalidArgs("%s", err.Error())
+		}
+	}
+
 	// Step 1: Validate ticket exists
 	ticket, err := s.ticketRepo.GetByID(ticketID)
 	if err != nil {
@@ -165,7 +563,10 @@ func (s *InboxService) Send(ticketID int64, msgType models.MessageType, content,
 	}
 
 	// Step 2: Get active claim (needed for worker ID and release)
-	claim, _ := s.claimRepo.GetActiveByTicketID(ticket.ID)
+	claim, err := s.claimRepo.GetActiveByTicketID(ticket.ID)
+	if err != nil {
+		return nil, errors.WrapInternal(err, "failed to get claim")
+	}
 
 	// Use provided worker ID or get from current claim
 	actualWorkerID := workerID
@@ -175,6 +576,7 @@ func (s *InboxService) Send(ticketID int64, msgType models.MessageType, content,
 
 	// Step 3: Create inbox message
 	inboxMsg := models.NewInboxMessage(ticket.ID, msgType, content, actualWorkerID)
+	inboxMsg.Options = options
 	if err := s.inboxRepo.Create(inboxMsg); err != nil {
 		return nil, errors.WrapInternal(err, "failed to create message")
 	}
@@ -187,40 +589,12 @@ func (s *InboxService) Send(ticketID int64, msgType models.MessageType, content,
 		ClaimReleased:  false,
 	}
 
-	// Step 4: Transition ticket to human status (escalation flow)
-	// Only escalate for message types that require a response
-	if msgType.RequiresResponse() && ticket.Status != models.StatusHuman && ticket.Status != models.StatusClosed {
-		result.PreviousStatus = ticket.Status
-		ticket.Status = models.StatusHuman
-		if err := s.ticketRepo.Update(ticket); err != nil {
-			return nil, errors.WrapInternal(err, "failed to update ticket status")
-		}
-		result.StatusChanged = true
-		result.NewStatus = ticket.Status
-	}
-
-	// Step 5: Release any active claim (only if status changed to human)
-	if result.StatusChanged && claim != nil {
-		if err := s.claimRepo.Release(claim.ID, models.ClaimStatusReleased); err != nil {
-			// Log warning but don't fail - message was already sent
-			// In production, you'd want proper logging here
-			_ = err
-		} else {
-			result.ClaimReleased = true
-			// Log the claim release as a separate activity for visibility
-			s.activityRepo.LogActionWithDetails(
-				ticket.ID,
-				models.ActionReleased,
-				models.ActorTypeAgent,
-				claim.WorkerID,
-				"Claim released (escalation)",
-				map[string]interface{}{
-					"worker_id": claim.WorkerID,
-					"reason":    "escalation",
-				},
-			)
-		}
+	// Steps 4-5: Transition ticket to human status and release any active claim
+	result.StatusChanged, result.ClaimReleased, err = s.escalate(ticket, msgType, claim)
+	if err != nil {
+		return nil, err
 	}
+	result.NewStatus = ticket.Status
 
 	// Step 6: Log activity
 	summary := fmt.Sprintf("Sent %s message", msgType)
@@ -232,6 +606,9 @@ func (s *InboxService) Send(ticketID int64, msgType models.MessageType, content,
 		"inbox_message_id": inboxMsg.ID,
 		"message":          content,
 	}
+	if len(options) > 0 {
+		activityDetails["options"] = inboxMsg.OptionKeys()
+	}
 	if result.StatusChanged {
 		activityDetails["previous_status"] = string(result.PreviousStatus)
 		activityDetails["new_status"] = string(result.NewStatus)
@@ -252,3 +629,87 @@ func (s *InboxService) Send(ticketID int64, msgType models.MessageType, content,
 
 	return result, nil
 }
+
+// LatestMessage returns the message to wait on for a ticket: its newest
+// pending message, or its newest message if none is pending.
+func (s *InboxService) LatestMessage(ticketID int64) (*models.InboxMessage, error) {
+	for _, pending := range []bool{true, false} {
+		messages, err := s.inboxRepo.List(db.InboxFilter{TicketID: &ticketID, Pending: pending, Limit: 1})
+		if err != nil {
+			return nil, errors.WrapInternal(err, "failed to list messages")
+		}
+		if len(messages) > 0 {
+			return messages[0], nil
+		}
+	}
+	return nil, errors.NotFound("ticket has no inbox messages")
+}
+
+// Wait blocks until an inbox message is resolved and returns it. It polls
+// the database every interval, so it sees a response recorded by any
+// process. It returns a timeout error if ctx reaches its deadline first, and
+// ctx.Err() unchanged if ctx is canceled.
+func (s *InboxService) Wait(ctx context.Context, messageID int64, interval time.Duration) (*models.InboxMessage, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		message, err := s.inboxRepo.GetByID(messageID)
+		if err != nil {
+			return nil, errors.WrapInternal(err, "failed to get message")
+		}
+		if message == nil {
+			return nil, errors.NotFound("message #%d not found", messageID)
+		}
+		if message.RespondedAt != nil {
+			return message, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if ctx.Err() != context.DeadlineExceeded {
+				return nil, ctx.Err()
+			}
+			return nil, errors.Timeout("timed out waiting for a response to message #%d", messageID)
+		case <-ticker.C:
+		}
+	}
+}
+
+// escalate moves the ticket to 'human' for message types that require a
+// response (escalation flow) and releases its active claim, if any. Callers
+// run it in the same transaction as the message it escalates, so a failed
+// release or log entry undoes the whole escalation.
+func (s *InboxService) escalate(ticket *models.Ticket, msgType models.MessageType, claim *models.Claim) (statusChanged, claimReleased bool, err error) {
+	// Only escalate for message types that require a response
+	if !msgType.RequiresResponse() || ticket.Status == models.StatusHuman || ticket.Status == models.StatusClosed {
+		return false, false, nil
+	}
+	ticket.Status = models.StatusHuman
+	if err := s.ticketRepo.Update(ticket); err != nil {
+		return false, false, errors.WrapInternal(err, "failed to update ticket status")
+	}
+
+	// Release any active claim (only if status changed to human)
+	if claim == nil {
+		return true, false, nil
+	}
+	if err := s.claimRepo.Release(claim.ID, models.ClaimStatusReleased); err != nil {
+		return false, false, errors.WrapInternal(err, "failed to release claim")
+	}
+	// Log the claim release as a separate activity for visibility
+	if err := s.activityRepo.LogActionWithDetails(
+		ticket.ID,
+		models.ActionReleased,
+		models.ActorTypeAgent,
+		claim.WorkerID,
+		"Claim released (escalation)",
+		map[string]interface{}{
+			"worker_id": claim.WorkerID,
+			"reason":    "escalation",
+		},
+	); err != nil {
+		return false, false, errors.WrapInternal(err, "failed to log claim release")
+	}
+	return true, true, nil
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spetersoncode/wark/internal/errors"
+	"github.com/spetersoncode/wark/internal/models"
+)
+
+// RespondActionType is what a response does to its ticket besides
+// returning it to 'ready'.
+type RespondActionType string
+
+const (
+	// RespondActionClose closes the ticket with a resolution.
+	RespondActionClose RespondActionType = "close"
+	// RespondActionDeprioritize moves the ticket from 'human' to 'backlog'.
+	RespondActionDeprioritize RespondActionType = "deprioritize"
+	// RespondActionPriority changes the ticket's priority; the ticket still
+	// returns to 'ready'.
+	RespondActionPriority RespondActionType = "priority"
+	// RespondActionAccept accepts a ticket in review.
+	RespondActionAccept RespondActionType = "accept"
+	// RespondActionReject rejects a ticket in review, with the response as
+	// the reason.
+	RespondActionReject RespondActionType = "reject"
+)
+
+// RespondAction is an action applied to a ticket along with a response to
+// one of its inbox messages. See TicketService.RespondAndAct.
+type RespondAction struct {
+	Type       RespondActionType `json:"type"`
+	Resolution models.Resolution `json:"resolution,omitempty"`
+	Priority   models.Priority   `json:"priority,omitempty"`
+}
+
+// ParseRespondAction parses an action such as "close:wont_do",
+// "deprioritize", "priority:high", "accept" or "reject".
+func ParseRespondAction(s string) (*RespondAction, error) {
+	name, arg, hasArg := strings.Cut(strings.TrimSpace(s), ":")
+	action := &RespondAction{Type: RespondActionType(strings.ToLower(name))}
+	switch action.Type {
+	case RespondActionClose:
+		resolution, err := models.ParseResolution(arg)
+		if err != nil {
+			return nil, errors.InvalidArgs("invalid action %q: %v", s, err)
+		}
+		action.Resolution = resolution
+	case RespondActionPriority:
+		priority, err := models.ParsePriority(arg)
+		if err != nil {
+			return nil, errors.InvalidArgs("invalid action %q: %v", s, err)
+		}
+		action.Priority = priority
+	case RespondActionDeprioritize, RespondActionAccept, RespondActionReject:
+		if hasArg {
+			return nil, errors.InvalidArgs("invalid action %q: %s takes no argument", s, action.Type)
+		}
+	default:
+		return nil, errors.InvalidArgs("invalid action %q (valid: close:<resolution>, deprioritize, priority:<level>, accept, reject)", s)
+	}
+	return action, nil
+}
+
+// String returns the action in the form ParseRespondAction accepts.
+func (a *RespondAction) String() string {
+	switch a.Type {
+	case RespondActionClose:
+		return fmt.Sprintf("%s:%s", a.Type, a.Resolution)
+	case RespondActionPriority:
+		return fmt.Sprintf("%s:%s", a.Type, a.Priority)
+	}
+	return string(a.Type)
+}
+
+// resumes reports whether the response should still return the ticket
+// from 'human' to 'ready' before the action. The other actions move the
+// ticket themselves and expect to find it where the response left it.
+func (a *RespondAction) resumes() bool {
+	return a.Type == RespondActionPriority
+}
+
+// RespondAndAct responds to an inbox message like InboxService.RespondWith
+// and applies then, if not nil, to the message's ticket in the same
+// transaction. The action goes through the same checks as the matching
+// ticket command, so closing a closed ticket or accepting one that is not in
+// review fails and the response is not recorded either. Errors are shared
+// errors, as from InboxService.
+func (s *TicketService) RespondAndAct(messageID int64, input RespondInput, then *RespondAction) (*RespondResult, error) {
+	var result *RespondResult
+	err := s.inTx(func(tx *TicketService) error {
+		inbox := NewInboxService(tx.inboxRepo, tx.ticketRepo, tx.claimRepo, tx.activityRepo)
+		var err error
+		result, err = inbox.respond(messageID, input, then == nil || then.resumes())
+		if err != nil || then == nil {
+			return err
+		}
+		return tx.act(result, then)
+	})
+	if err != nil {
+		if svcErr, ok := err.(*TicketError); ok {
+			return nil, &errors.Error{Kind: svcErr.Kind(), Message: svcErr.Message, Details: svcErr.Details}
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+// act applies a response action to the responded message's ticket and
+// updates result with the ticket's final status.
+func (s *TicketService) act(result *RespondResult, then *RespondAction) error {
+	ticketID := result.Message.TicketID
+	if !then.resumes() {
+		ticket, err := s.GetTicketByID(ticketID)
+		if err != nil {
+			return err
+		}
+		result.PreviousStatus = ticket.Status
+	}
+
+	response := result.Message.Response
+	var err error
+	switch then.Type {
+	case RespondActionClose:
+		err = s.close(ticketID, then.Resolution, response)
+	case RespondActionDeprioritize:
+		err = s.deprioritize(ticketID, nil)
+	case RespondActionPriority:
+		priority := string(then.Priority)
+		_, err = s.update(ticketID, UpdateTicketInput{Priority: &priority})
+	case RespondActionAccept:
+		_, err = s.accept(ticketID)
+	case RespondActionReject:
+		err = s.reject(ticketID, response)
+	default:
+		err = newTicketError(ErrCodeInvalidInput, fmt.Sprintf("unknown action: %s", then.Type), nil)
+	}
+	if err != nil {
+		return err
+	}
+
+	ticket, err := s.GetTicketByID(ticketID)
+	if err != nil {
+		return err
+	}
+	result.NewStatus = ticket.Status
+	result.TicketUpdated = result.NewStatus != result.PreviousStatus
+	return nil
+}
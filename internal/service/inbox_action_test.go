@@ -0,0 +1,147 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/spetersoncode/wark/internal/db"
+	"github.com/spetersoncode/wark/internal/errors"
+	"github.com/spetersoncode/wark/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRespondAction(t *testing.T) {
+	action, err := ParseRespondAction("close:wont-do")
+	require.NoError(t, err)
+	assert.Equal(t, RespondActionClose, action.Type)
+	assert.Equal(t, models.ResolutionWontDo, action.Resolution)
+	assert.Equal(t, "close:wont_do", action.String())
+
+	action, err = ParseRespondAction("Priority:HIGH")
+	require.NoError(t, err)
+	assert.Equal(t, models.PriorityHigh, action.Priority)
+
+	for _, s := range []string{"deprioritize", "accept", "reject"} {
+		action, err := ParseRespondAction(s)
+		require.NoError(t, err, s)
+		assert.Equal(t, s, action.String())
+	}
+
+	for _, s := range []string{"", "close", "close:done", "priority:urgent", "accept:now", "resume"} {
+		_, err := ParseRespondAction(s)
+		assert.True(t, errors.Is(err, errors.KindInvalidArgs), "%q: %v", s, err)
+	}
+}
+
+func TestTicketService_RespondAndAct(t *testing.T) {
+	database, _, cleanup := testDB(t)
+	defer cleanup()
+
+	projectRepo := db.NewProjectRepo(database.DB)
+	ticketRepo := db.NewTicketRepo(database.DB)
+	inboxRepo := db.NewInboxRepo(database.DB)
+
+	project := &models.Project{Key: "TEST", Name: "Test Project"}
+	require.NoError(t, projectRepo.Create(project))
+
+	svc := NewTicketService(database.DB)
+
+	// ask creates a ticket in status with a pending message of msgType.
+	ask := func(status models.Status, msgType models.MessageType) (*models.Ticket, *models.InboxMessage) {
+		ticket := &models.Ticket{ProjectID: project.ID, Title: "Ticket", Status: status, Priority: models.PriorityMedium}
+		require.NoError(t, ticketRepo.Create(ticket))
+		msg := models.NewInboxMessage(ticket.ID, msgType, "What now?", "agent-1")
+		require.NoError(t, inboxRepo.Create(msg))
+		return ticket, msg
+	}
+	action := func(s string) *RespondAction {
+		action, err := ParseRespondAction(s)
+		require.NoError(t, err)
+		return action
+	}
+	reload := func(id int64) *models.Ticket {
+		ticket, err := ticketRepo.GetByID(id)
+		require.NoError(t, err)
+		return ticket
+	}
+
+	t.Run("without an action the ticket returns to ready", func(t *testing.T) {
+		ticket, msg := ask(models.StatusHuman, models.MessageTypeQuestion)
+		result, err := svc.RespondAndAct(msg.ID, RespondInput{Response: "Carry on"}, nil)
+		require.NoError(t, err)
+		assert.True(t, result.TicketUpdated)
+		assert.Equal(t, models.StatusReady, reload(ticket.ID).Status)
+	})
+
+	t.Run("close with a resolution", func(t *testing.T) {
+		ticket, msg := ask(models.StatusHuman, models.MessageTypeQuestion)
+		result, err := svc.RespondAndAct(msg.ID, RespondInput{Response: "Not worth it"}, action("close:wont_do"))
+		require.NoError(t, err)
+		assert.Equal(t, models.StatusHuman, result.PreviousStatus)
+		assert.Equal(t, models.StatusClosed, result.NewStatus)
+		assert.NotNil(t, result.Message.RespondedAt)
+
+		updated := reload(ticket.ID)
+		assert.Equal(t, models.StatusClosed, updated.Status)
+		require.NotNil(t, updated.Resolution)
+		assert.Equal(t, models.ResolutionWontDo, *updated.Resolution)
+	})
+
+	t.Run("deprioritize to backlog", func(t *testing.T) {
+		ticket, msg := ask(models.StatusHuman, models.MessageTypeDecision)
+		result, err := svc.RespondAndAct(msg.ID, RespondInput{Response: "Later"}, action("deprioritize"))
+		require.NoError(t, err)
+		assert.Equal(t, models.StatusBacklog, result.NewStatus)
+		assert.Equal(t, models.StatusBacklog, reload(ticket.ID).Status)
+	})
+
+	t.Run("reprioritize and return to ready", func(t *testing.T) {
+		ticket, msg := ask(models.StatusHuman, models.MessageTypeQuestion)
+		result, err := svc.RespondAndAct(msg.ID, RespondInput{Response: "Urgent now"}, action("priority:highest"))
+		require.NoError(t, err)
+		assert.Equal(t, models.StatusReady, result.NewStatus)
+
+		updated := reload(ticket.ID)
+		assert.Equal(t, models.StatusReady, updated.Status)
+		assert.Equal(t, models.PriorityHighest, updated.Priority)
+	})
+
+	t.Run("accept a review", func(t *testing.T) {
+		ticket, msg := ask(models.StatusReview, models.MessageTypeReview)
+		result, err := svc.RespondAndAct(msg.ID, RespondInput{Response: "LGTM"}, action("accept"))
+		require.NoError(t, err)
+		assert.Equal(t, models.StatusReview, result.PreviousStatus)
+		assert.Equal(t, models.StatusClosed, result.NewStatus)
+
+		updated := reload(ticket.ID)
+		require.NotNil(t, updated.Resolution)
+		assert.Equal(t, models.ResolutionCompleted, *updated.Resolution)
+	})
+
+	t.Run("reject a review with the response as reason", func(t *testing.T) {
+		ticket, msg := ask(models.StatusReview, models.MessageTypeReview)
+		_, err := svc.RespondAndAct(msg.ID, RespondInput{Response: "Missing tests"}, action("reject"))
+		require.NoError(t, err)
+
+		updated := reload(ticket.ID)
+		assert.Equal(t, models.StatusReady, updated.Status)
+		assert.Equal(t, 1, updated.RetryCount)
+	})
+
+	t.Run("an invalid transition rolls back the response", func(t *testing.T) {
+		ticket, msg := ask(models.StatusHuman, models.MessageTypeQuestion)
+		_, err := svc.RespondAndAct(msg.ID, RespondInput{Response: "Ship it"}, action("accept"))
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.KindStateError), "%v", err)
+
+		assert.Equal(t, models.StatusHuman, reload(ticket.ID).Status)
+		stored, err := inboxRepo.GetByID(msg.ID)
+		require.NoError(t, err)
+		assert.Nil(t, stored.RespondedAt)
+	})
+
+	t.Run("inbox errors are passed through", func(t *testing.T) {
+		_, err := svc.RespondAndAct(99999, RespondInput{Response: "Hello"}, action("deprioritize"))
+		assert.True(t, errors.Is(err, errors.KindNotFound), "%v", err)
+	})
+}
@@ -0,0 +1,268 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spetersoncode/wark/internal/common"
+	"github.com/spetersoncode/wark/internal/config"
+	"github.com/spetersoncode/wark/internal/errors"
+	"github.com/spetersoncode/wark/internal/models"
+)
+
+// SLARule is how long pending messages of a type, on tickets of a
+// priority, may wait for a response. An empty type or priority matches any.
+type SLARule struct {
+	Type     models.MessageType
+	Priority models.Priority
+	Within   time.Duration
+}
+
+// specificity ranks rules so a type match beats a priority match.
+func (r SLARule) specificity() int {
+	n := 0
+	if r.Type != "" {
+		n += 2
+	}
+	if r.Priority != "" {
+		n++
+	}
+	return n
+}
+
+func (r SLARule) matches(m *models.InboxMessage) bool {
+	return (r.Type == "" || r.Type == m.MessageType) &&
+		(r.Priority == "" || r.Priority == m.TicketPriority)
+}
+
+func (r SLARule) describe() string {
+	switch {
+	case r.Type != "" && r.Priority != "":
+		return fmt.Sprintf("%s messages on %s tickets", r.Type, r.Priority)
+	case r.Type != "":
+		return fmt.Sprintf("%s messages", r.Type)
+	case r.Priority != "":
+		return fmt.Sprintf("messages on %s tickets", r.Priority)
+	}
+	return "all messages"
+}
+
+// SLAPolicy holds the response SLAs for inbox messages. A nil policy has
+// no SLAs, so no message is ever overdue.
+type SLAPolicy struct {
+	rules []SLARule
+}
+
+// ParseSLAPolicy validates inbox SLA config: types and priorities must be
+// valid, minutes positive, and no two tables may cover the same type and
+// priority.
+func ParseSLAPolicy(cfgs []config.InboxSLAConfig) (*SLAPolicy, error) {
+	policy := &SLAPolicy{}
+	seen := make(map[SLARule]bool)
+	for _, cfg := range cfgs {
+		var rule SLARule
+		if cfg.Type != "" {
+			msgType, err := models.ParseMessageType(cfg.Type)
+			if err != nil {
+				return nil, fmt.Errorf("inbox SLA: %w", err)
+			}
+			rule.Type = msgType
+		}
+		if cfg.Priority != "" {
+			priority, err := models.ParsePriority(cfg.Priority)
+			if err != nil {
+				return nil, fmt.Errorf("inbox SLA: %w", err)
+			}
+			rule.Priority = priority
+		}
+		if seen[rule] {
+			return nil, fmt.Errorf("duplicate inbox SLA for %s", rule.describe())
+		}
+		seen[rule] = true
+		if cfg.Minutes <= 0 {
+			return nil, fmt.Errorf("inbox SLA for %s: minutes must be positive", rule.describe())
+		}
+		rule.Within = time.Duration(cfg.Minutes) * time.Minute
+		policy.rules = append(policy.rules, rule)
+	}
+	return policy, nil
+}
+
+// Rule returns the SLA that applies to a message, the most specific match
+// winning, or false if none does.
+func (p *SLAPolicy) Rule(m *models.InboxMessage) (SLARule, bool) {
+	if p == nil {
+		return SLARule{}, false
+	}
+	var best SLARule
+	found := false
+	for _, rule := range p.rules {
+		if rule.matches(m) && (!found || rule.specificity() > best.specificity()) {
+			best = rule
+			found = true
+		}
+	}
+	return best, found
+}
+
+// Apply sets DueAt and Overdue on the pending messages an SLA applies to.
+// The SLA runs from when a message was sent, or from when an agent last
+// replied to or reopened it.
+func (p *SLAPolicy) Apply(messages []*models.InboxMessage, now time.Time) {
+	for _, m := range messages {
+		if m.RespondedAt != nil {
+			continue
+		}
+		rule, ok := p.Rule(m)
+		if !ok {
+			continue
+		}
+		since := m.CreatedAt
+		if m.WaitingSince != nil {
+			since = *m.WaitingSince
+		}
+		dueAt := since.Add(rule.Within)
+		m.DueAt = &dueAt
+		m.Overdue = now.After(dueAt)
+	}
+}
+
+// OverdueEscalation is what escalating one overdue message did.
+type OverdueEscalation struct {
+	MessageID      int64              `json:"message_id"`
+	TicketKey      string             `json:"ticket_key"`
+	MessageType    models.MessageType `json:"message_type"`
+	DueAt          time.Time          `json:"due_at"`
+	StatusChanged  bool               `json:"status_changed"`
+	PreviousStatus models.Status      `json:"previous_status,omitempty"`
+	NewStatus      models.Status      `json:"new_status,omitempty"`
+	ClaimReleased  bool               `json:"claim_released"`
+	Error          string             `json:"error,omitempty"`
+}
+
+// EscalateOverdueResult summarizes one run of EscalateOverdue.
+type EscalateOverdueResult struct {
+	Pending     int                  `json:"pending"`
+	Overdue     int                  `json:"overdue"`
+	Escalated   int                  `json:"escalated"`
+	Errors      int                  `json:"errors"`
+	Escalations []*OverdueEscalation `json:"escalations"`
+	DryRun      bool                 `json:"dry_run"`
+}
+
+// EscalateOverdue escalates pending messages that have passed their SLA
+// and were not escalated before. Only message types that need a response
+// are escalated, moving their ticket to 'human' and releasing its claim;
+// overdue info and review messages are counted but left alone. Each
+// escalation is logged with "overdue" set, which emits an inbox.overdue
+// event.
+func (s *InboxService) EscalateOverdue(policy *SLAPolicy, now time.Time, dryRun bool) (*EscalateOverdueResult, error) {
+	pending, err := s.inboxRepo.ListPending()
+	if err != nil {
+		return nil, errors.WrapInternal(err, "failed to list pending messages")
+	}
+	policy.Apply(pending, now)
+
+	result := &EscalateOverdueResult{
+		Pending:     len(pending),
+		Escalations: []*OverdueEscalation{},
+		DryRun:      dryRun,
+	}
+	// Oldest first, so the longest-waiting messages are escalated first
+	for i := len(pending) - 1; i >= 0; i-- {
+		m := pending[i]
+		if !m.Overdue {
+			continue
+		}
+		result.Overdue++
+		if m.EscalatedAt != nil || !m.RequiresResponse() {
+			continue
+		}
+
+		escalation := &OverdueEscalation{
+			MessageID:   m.ID,
+			TicketKey:   m.TicketKey,
+			MessageType: m.MessageType,
+			DueAt:       *m.DueAt,
+		}
+		result.Escalations = append(result.Escalations, escalation)
+		if dryRun {
+			continue
+		}
+		err := s.inTx(func(tx *InboxService) error {
+			return tx.escalateOverdue(m, escalation, now)
+		})
+		if err != nil {
+			escalation.Error = err.Error()
+			result.Errors++
+			continue
+		}
+		result.Escalated++
+	}
+	return result, nil
+}
+
+// escalateOverdue escalates one overdue message. It runs in a single
+// transaction, so a message is only marked escalated, and never retried,
+// once its ticket has moved and the escalation is logged.
+func (s *InboxService) escalateOverdue(m *models.InboxMessage, escalation *OverdueEscalation, now time.Time) error {
+	ticket, err := s.ticketRepo.GetByID(m.TicketID)
+	if err != nil {
+		return fmt.Errorf("failed to get ticket: %w", err)
+	}
+	if ticket == nil {
+		return fmt.Errorf("ticket not found")
+	}
+
+	claim, err := s.claimRepo.GetActiveByTicketID(ticket.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get claim: %w", err)
+	}
+	escalation.PreviousStatus = ticket.Status
+	escalation.StatusChanged, escalation.ClaimReleased, err = s.escalate(ticket, m.MessageType, claim)
+	if err != nil {
+		return err
+	}
+	escalation.NewStatus = ticket.Status
+
+	summary := fmt.Sprintf("Inbox message #%d overdue by %s", m.ID, common.FormatDuration(now.Sub(*m.DueAt)))
+	details := map[string]interface{}{
+		"message_type":     string(m.MessageType),
+		"inbox_message_id": m.ID,
+		"overdue":          true,
+		"due_at":           m.DueAt.UTC().Format(time.RFC3339),
+	}
+	if escalation.StatusChanged {
+		summary = fmt.Sprintf("%s - escalated: %s → %s", summary, escalation.PreviousStatus, escalation.NewStatus)
+		details["previous_status"] = string(escalation.PreviousStatus)
+		details["new_status"] = string(escalation.NewStatus)
+	}
+	if escalation.ClaimReleased {
+		details["claim_released"] = true
+	}
+	if err := s.activityRepo.LogActionWithDetails(ticket.ID, models.ActionEscalated, models.ActorTypeSystem, "", summary, details); err != nil {
+		return fmt.Errorf("failed to log activity: %w", err)
+	}
+	return s.inboxRepo.MarkEscalated(m.ID)
+}
+
+// RunEscalateOverdueDaemon escalates overdue messages every interval until
+// ctx is canceled.
+func (s *InboxService) RunEscalateOverdueDaemon(ctx context.Context, policy *SLAPolicy, interval time.Duration, callback func(*EscalateOverdueResult, error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		result, err := s.EscalateOverdue(policy, time.Now(), false)
+		if callback != nil {
+			callback(result, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spetersoncode/wark/internal/config"
+	"github.com/spetersoncode/wark/internal/db"
+	"github.com/spetersoncode/wark/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSLAPolicy(t *testing.T) {
+	policy, err := ParseSLAPolicy([]config.InboxSLAConfig{
+		{Minutes: 480},
+		{Priority: "highest", Minutes: 60},
+		{Type: "question", Minutes: 240},
+		{Type: "question", Priority: "highest", Minutes: 30},
+	})
+	require.NoError(t, err)
+
+	within := func(msgType models.MessageType, priority models.Priority) time.Duration {
+		rule, ok := policy.Rule(&models.InboxMessage{MessageType: msgType, TicketPriority: priority})
+		require.True(t, ok)
+		return rule.Within
+	}
+	assert.Equal(t, 30*time.Minute, within(models.MessageTypeQuestion, models.PriorityHighest))
+	assert.Equal(t, 240*time.Minute, within(models.MessageTypeQuestion, models.PriorityLow))
+	assert.Equal(t, 60*time.Minute, within(models.MessageTypeReview, models.PriorityHighest))
+	assert.Equal(t, 480*time.Minute, within(models.MessageTypeInfo, models.PriorityMedium))
+
+	var none *SLAPolicy
+	_, ok := none.Rule(&models.InboxMessage{MessageType: models.MessageTypeQuestion})
+	assert.False(t, ok)
+
+	invalid := [][]config.InboxSLAConfig{
+		{{Type: "memo", Minutes: 60}},
+		{{Priority: "urgent", Minutes: 60}},
+		{{Type: "question"}},
+		{{Type: "question", Minutes: 60}, {Type: "QUESTION", Minutes: 30}},
+	}
+	for _, cfgs := range invalid {
+		_, err := ParseSLAPolicy(cfgs)
+		assert.Error(t, err, "%+v", cfgs)
+	}
+}
+
+func TestInboxService_EscalateOverdue(t *testing.T) {
+	database, _, cleanup := testDB(t)
+	defer cleanup()
+
+	projectRepo := db.NewProjectRepo(database.DB)
+	ticketRepo := db.NewTicketRepo(database.DB)
+	inboxRepo := db.NewInboxRepo(database.DB)
+	claimRepo := db.NewClaimRepo(database.DB)
+	activityRepo := db.NewActivityRepo(database.DB)
+
+	project := &models.Project{Key: "TEST", Name: "Test Project"}
+	require.NoError(t, projectRepo.Create(project))
+	ticket := &models.Ticket{ProjectID: project.ID, Title: "Deploy", Status: models.StatusWorking, Priority: models.PriorityHigh}
+	require.NoError(t, ticketRepo.Create(ticket))
+	claim := models.NewClaim(ticket.ID, "agent-1", time.Hour)
+	require.NoError(t, claimRepo.Create(claim))
+
+	service := NewInboxService(inboxRepo, ticketRepo, claimRepo, activityRepo)
+	// Created directly, so the ticket is still working and claimed
+	info := models.NewInboxMessage(ticket.ID, models.MessageTypeInfo, "Deploying to staging", "agent-1")
+	require.NoError(t, inboxRepo.Create(info))
+	question := models.NewInboxMessage(ticket.ID, models.MessageTypeQuestion, "Which region?", "agent-1")
+	require.NoError(t, inboxRepo.Create(question))
+	decision := models.NewInboxMessage(ticket.ID, models.MessageTypeDecision, "Keep the old endpoint?", "agent-1")
+	require.NoError(t, inboxRepo.Create(decision))
+
+	policy, err := ParseSLAPolicy([]config.InboxSLAConfig{
+		{Type: "info", Minutes: 60},
+		{Type: "question", Minutes: 60},
+		{Type: "decision", Minutes: 240},
+	})
+	require.NoError(t, err)
+
+	t.Run("nothing is overdue yet", func(t *testing.T) {
+		result, err := service.EscalateOverdue(policy, time.Now(), false)
+		require.NoError(t, err)
+		assert.Equal(t, 3, result.Pending)
+		assert.Zero(t, result.Overdue)
+		assert.Empty(t, result.Escalations)
+	})
+
+	later := time.Now().Add(2 * time.Hour)
+
+	t.Run("dry run changes nothing", func(t *testing.T) {
+		result, err := service.EscalateOverdue(policy, later, true)
+		require.NoError(t, err)
+		require.Len(t, result.Escalations, 1)
+		assert.Equal(t, question.ID, result.Escalations[0].MessageID)
+		assert.Zero(t, result.Escalated)
+
+		msg, err := inboxRepo.GetByID(question.ID)
+		require.NoError(t, err)
+		assert.Nil(t, msg.EscalatedAt)
+	})
+
+	t.Run("overdue message is escalated", func(t *testing.T) {
+		result, err := service.EscalateOverdue(policy, later, false)
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Overdue)
+		assert.Equal(t, 1, result.Escalated)
+		require.Len(t, result.Escalations, 1)
+		escalation := result.Escalations[0]
+		assert.True(t, escalation.StatusChanged)
+		assert.Equal(t, models.StatusWorking, escalation.PreviousStatus)
+		assert.Equal(t, models.StatusHuman, escalation.NewStatus)
+		assert.True(t, escalation.ClaimReleased)
+
+		msg, err := inboxRepo.GetByID(question.ID)
+		require.NoError(t, err)
+		assert.NotNil(t, msg.EscalatedAt)
+		msg, err = inboxRepo.GetByID(info.ID)
+		require.NoError(t, err)
+		assert.Nil(t, msg.EscalatedAt, "info messages don't need a response")
+		updated, err := ticketRepo.GetByID(ticket.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.StatusHuman, updated.Status)
+
+		activities, err := activityRepo.ListByTicket(ticket.ID, 1)
+		require.NoError(t, err)
+		require.Len(t, activities, 1)
+		event, ok := models.EventFromActivity(activities[0])
+		require.True(t, ok)
+		assert.Equal(t, models.EventInboxOverdue, event.Type)
+	})
+
+	t.Run("messages are escalated once", func(t *testing.T) {
+		result, err := service.EscalateOverdue(policy, later, false)
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Overdue)
+		assert.Empty(t, result.Escalations)
+
+		result, err = service.EscalateOverdue(policy, time.Now().Add(5*time.Hour), false)
+		require.NoError(t, err)
+		require.Len(t, result.Escalations, 1)
+		assert.Equal(t, decision.ID, result.Escalations[0].MessageID)
+		assert.False(t, result.Escalations[0].StatusChanged, "ticket is already in human")
+	})
+}
+
+func TestInboxService_EscalateOverdueRollsBack(t *testing.T) {
+	database, _, cleanup := testDB(t)
+	defer cleanup()
+
+	projectRepo := db.NewProjectRepo(database.DB)
+	ticketRepo := db.NewTicketRepo(database.DB)
+	inboxRepo := db.NewInboxRepo(database.DB)
+	claimRepo := db.NewClaimRepo(database.DB)
+
+	project := &models.Project{Key: "TEST", Name: "Test Project"}
+	require.NoError(t, projectRepo.Create(project))
+	ticket := &models.Ticket{ProjectID: project.ID, Title: "Deploy", Status: models.StatusWorking}
+	require.NoError(t, ticketRepo.Create(ticket))
+	require.NoError(t, claimRepo.Create(models.NewClaim(ticket.ID, "agent-1", time.Hour)))
+	msg := models.NewInboxMessage(ticket.ID, models.MessageTypeQuestion, "Which region?", "agent-1")
+	require.NoError(t, inboxRepo.Create(msg))
+
+	policy, err := ParseSLAPolicy([]config.InboxSLAConfig{{Minutes: 60}})
+	require.NoError(t, err)
+	later := time.Now().Add(2 * time.Hour)
+	service := NewInboxServiceFromDB(database.DB)
+
+	_, err = database.Exec(`CREATE TRIGGER fail_escalation BEFORE INSERT ON activity_log
+		WHEN NEW.action = 'escalated' BEGIN SELECT RAISE(ABORT, 'log failed'); END`)
+	require.NoError(t, err)
+
+	result, err := service.EscalateOverdue(policy, later, false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Errors)
+	assert.Zero(t, result.Escalated)
+
+	stored, err := inboxRepo.GetByID(msg.ID)
+	require.NoError(t, err)
+	assert.Nil(t, stored.EscalatedAt, "a failed escalation is retried")
+	updated, err := ticketRepo.GetByID(ticket.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusWorking, updated.Status)
+	claim, err := claimRepo.GetActiveByTicketID(ticket.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, claim)
+
+	_, err = database.Exec(`DROP TRIGGER fail_escalation`)
+	require.NoError(t, err)
+	result, err = service.EscalateOverdue(policy, later, false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Escalated)
+	updated, err = ticketRepo.GetByID(ticket.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusHuman, updated.Status)
+}
+
+func TestInboxService_EscalateOverdueAfterReopen(t *testing.T) {
+	database, _, cleanup := testDB(t)
+	defer cleanup()
+
+	projectRepo := db.NewProjectRepo(database.DB)
+	ticketRepo := db.NewTicketRepo(database.DB)
+	inboxRepo := db.NewInboxRepo(database.DB)
+
+	project := &models.Project{Key: "TEST", Name: "Test Project"}
+	require.NoError(t, projectRepo.Create(project))
+	ticket := &models.Ticket{ProjectID: project.ID, Title: "Deploy", Status: models.StatusWorking}
+	require.NoError(t, ticketRepo.Create(ticket))
+
+	service := NewInboxServiceFromDB(database.DB)
+	sent, err := service.Send(ticket.ID, models.MessageTypeQuestion, "Which region?", "agent-1")
+	require.NoError(t, err)
+	_, err = database.Exec(`UPDATE inbox_messages SET created_at = ? WHERE id = ?`, db.FormatTime(time.Now().Add(-2*time.Hour)), sent.Message.ID)
+	require.NoError(t, err)
+
+	policy, err := ParseSLAPolicy([]config.InboxSLAConfig{{Minutes: 60}})
+	require.NoError(t, err)
+
+	result, err := service.EscalateOverdue(policy, time.Now(), false)
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Escalated)
+
+	_, err = service.Respond(sent.Message.ID, "us-east-1")
+	require.NoError(t, err)
+	reply, err := service.Reply(sent.Message.ID, ReplyInput{Content: "That region is full, another?", WorkerID: "agent-1"})
+	require.NoError(t, err)
+	require.True(t, reply.Reopened)
+
+	msg, err := inboxRepo.GetByID(sent.Message.ID)
+	require.NoError(t, err)
+	assert.Nil(t, msg.EscalatedAt)
+	require.NotNil(t, msg.WaitingSince)
+
+	// The SLA runs from the reopen, not from when the message was sent
+	result, err = service.EscalateOverdue(policy, time.Now(), false)
+	require.NoError(t, err)
+	assert.Zero(t, result.Overdue)
+
+	result, err = service.EscalateOverdue(policy, time.Now().Add(2*time.Hour), false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Escalated)
+	require.Len(t, result.Escalations, 1)
+	assert.Equal(t, sent.Message.ID, result.Escalations[0].MessageID)
+}
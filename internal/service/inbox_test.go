@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -421,3 +422,303 @@ func TestInboxService_Send_UsesClaimWorkerID(t *testing.T) {
 	// Verify the message has worker ID from claim
 	assert.Equal(t, "worker-from-claim", result.Message.FromAgent)
 }
+
+func TestInboxService_Thread(t *testing.T) {
+	database, _, cleanup := testDB(t)
+	defer cleanup()
+
+	projectRepo := db.NewProjectRepo(database.DB)
+	ticketRepo := db.NewTicketRepo(database.DB)
+	inboxRepo := db.NewInboxRepo(database.DB)
+	claimRepo := db.NewClaimRepo(database.DB)
+	activityRepo := db.NewActivityRepo(database.DB)
+
+	project := &models.Project{Key: "TEST", Name: "Test Project"}
+	require.NoError(t, projectRepo.Create(project))
+	ticket := &models.Ticket{ProjectID: project.ID, Title: "Threaded Ticket", Status: models.StatusWorking}
+	require.NoError(t, ticketRepo.Create(ticket))
+
+	service := NewInboxService(inboxRepo, ticketRepo, claimRepo, activityRepo)
+	sent, err := service.Send(ticket.ID, models.MessageTypeQuestion, "REST or GraphQL?", "agent-1")
+	require.NoError(t, err)
+	msgID := sent.Message.ID
+
+	t.Run("human reply keeps the ticket waiting", func(t *testing.T) {
+		result, err := service.Reply(msgID, ReplyInput{Content: "Which clients need it?"})
+		require.NoError(t, err)
+		assert.False(t, result.Resolved)
+		assert.False(t, result.StatusChanged)
+		assert.Equal(t, models.ActorTypeHuman, result.Reply.AuthorType)
+
+		updated, _ := ticketRepo.GetByID(ticket.ID)
+		assert.Equal(t, models.StatusHuman, updated.Status)
+		msg, _ := inboxRepo.GetByID(msgID)
+		assert.True(t, msg.IsPending())
+	})
+
+	t.Run("agent reply adds to the thread", func(t *testing.T) {
+		result, err := service.Reply(msgID, ReplyInput{Content: "Only the mobile app", WorkerID: "agent-1"})
+		require.NoError(t, err)
+		assert.Equal(t, models.ActorTypeAgent, result.Reply.AuthorType)
+		assert.Equal(t, "agent-1", result.Reply.Author)
+		assert.False(t, result.Reopened)
+	})
+
+	t.Run("only a human can resolve", func(t *testing.T) {
+		_, err := service.Reply(msgID, ReplyInput{WorkerID: "agent-1", Resolve: true})
+		require.Error(t, err)
+		assert.Equal(t, errors.KindInvalidArgs, err.(*errors.Error).Kind)
+
+		_, err = service.Reply(msgID, ReplyInput{})
+		require.Error(t, err)
+	})
+
+	t.Run("resolving returns the ticket to ready", func(t *testing.T) {
+		result, err := service.Reply(msgID, ReplyInput{Resolve: true})
+		require.NoError(t, err)
+		assert.True(t, result.Resolved)
+		assert.Nil(t, result.Reply)
+		assert.True(t, result.StatusChanged)
+		assert.Equal(t, models.StatusReady, result.NewStatus)
+		// The latest human reply becomes the response
+		assert.Equal(t, "Which clients need it?", result.Message.Response)
+		assert.NotNil(t, result.Message.RespondedAt)
+
+		_, err = service.Reply(msgID, ReplyInput{Resolve: true, Content: "Again"})
+		require.Error(t, err)
+		assert.Equal(t, errors.KindStateError, err.(*errors.Error).Kind)
+	})
+
+	t.Run("agent follow-up reopens the thread", func(t *testing.T) {
+		result, err := service.Reply(msgID, ReplyInput{Content: "And the web client?", WorkerID: "agent-1"})
+		require.NoError(t, err)
+		assert.True(t, result.Reopened)
+		assert.True(t, result.StatusChanged)
+		assert.Equal(t, models.StatusHuman, result.NewStatus)
+		assert.True(t, result.Message.IsPending())
+		assert.Empty(t, result.Message.Response)
+	})
+
+	t.Run("respond resolves with a human reply", func(t *testing.T) {
+		result, err := service.Respond(msgID, "Web too, use GraphQL")
+		require.NoError(t, err)
+		assert.True(t, result.TicketUpdated)
+
+		thread, err := service.Thread(msgID)
+		require.NoError(t, err)
+		assert.True(t, thread.Resolved)
+		assert.Equal(t, "REST or GraphQL?", thread.Message.Content)
+		assert.Equal(t, 4, thread.Message.ReplyCount)
+		require.Len(t, thread.Replies, 4)
+		var contents []string
+		for _, r := range thread.Replies {
+			contents = append(contents, r.Content)
+		}
+		assert.Equal(t, []string{
+			"Which clients need it?",
+			"Only the mobile app",
+			"And the web client?",
+			"Web too, use GraphQL",
+		}, contents)
+	})
+
+	t.Run("missing message", func(t *testing.T) {
+		_, err := service.Thread(9999)
+		require.Error(t, err)
+		assert.Equal(t, errors.KindNotFound, err.(*errors.Error).Kind)
+	})
+}
+
+func TestInboxService_ReplyRollsBack(t *testing.T) {
+	database, _, cleanup := testDB(t)
+	defer cleanup()
+
+	projectRepo := db.NewProjectRepo(database.DB)
+	ticketRepo := db.NewTicketRepo(database.DB)
+	inboxRepo := db.NewInboxRepo(database.DB)
+	claimRepo := db.NewClaimRepo(database.DB)
+
+	project := &models.Project{Key: "TEST", Name: "Test Project"}
+	require.NoError(t, projectRepo.Create(project))
+	ticket := &models.Ticket{ProjectID: project.ID, Title: "Threaded Ticket", Status: models.StatusHuman}
+	require.NoError(t, ticketRepo.Create(ticket))
+	msg := models.NewInboxMessage(ticket.ID, models.MessageTypeQuestion, "REST or GraphQL?", "agent-1")
+	require.NoError(t, inboxRepo.Create(msg))
+
+	service := NewInboxServiceFromDB(database.DB)
+	_, err := service.Respond(msg.ID, "REST")
+	require.NoError(t, err)
+
+	// Back to work, then fail the agent follow-up's last write
+	ticket, err = ticketRepo.GetByID(ticket.ID)
+	require.NoError(t, err)
+	ticket.Status = models.StatusWorking
+	require.NoError(t, ticketRepo.Update(ticket))
+	require.NoError(t, claimRepo.Create(models.NewClaim(ticket.ID, "agent-1", time.Hour)))
+	_, err = database.Exec(`CREATE TRIGGER fail_escalation BEFORE INSERT ON activity_log
+		WHEN NEW.action = 'escalated' BEGIN SELECT RAISE(ABORT, 'log failed'); END`)
+	require.NoError(t, err)
+
+	_, err = service.Reply(msg.ID, ReplyInput{Content: "Which version?", WorkerID: "agent-1"})
+	require.Error(t, err)
+
+	thread, err := service.Thread(msg.ID)
+	require.NoError(t, err)
+	assert.True(t, thread.Resolved, "thread is not reopened")
+	assert.Len(t, thread.Replies, 1, "the follow-up is not recorded")
+	updated, err := ticketRepo.GetByID(ticket.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusWorking, updated.Status)
+	claim, err := claimRepo.GetActiveByTicketID(ticket.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, claim, "the claim is not released")
+}
+
+func TestInboxService_DecisionOptions(t *testing.T) {
+	database, _, cleanup := testDB(t)
+	defer cleanup()
+
+	projectRepo := db.NewProjectRepo(database.DB)
+	ticketRepo := db.NewTicketRepo(database.DB)
+	inboxRepo := db.NewInboxRepo(database.DB)
+	claimRepo := db.NewClaimRepo(database.DB)
+	activityRepo := db.NewActivityRepo(database.DB)
+
+	project := &models.Project{Key: "TEST", Name: "Test Project"}
+	require.NoError(t, projectRepo.Create(project))
+	ticket := &models.Ticket{ProjectID: project.ID, Title: "Pick a database", Status: models.StatusWorking}
+	require.NoError(t, ticketRepo.Create(ticket))
+
+	service := NewInboxService(inboxRepo, ticketRepo, claimRepo, activityRepo)
+	options := []models.DecisionOption{{Key: "A", Label: "use Postgres"}, {Key: "B", Label: "stay on SQLite"}}
+
+	_, err := service.SendWithOptions(ticket.ID, models.MessageTypeQuestion, "Which database?", "agent-1", options)
+	require.Error(t, err)
+	_, err = service.SendWithOptions(ticket.ID, models.MessageTypeDecision, "Which database?", "agent-1", options[:1])
+	require.Error(t, err)
+
+	sent, err := service.SendWithOptions(ticket.ID, models.MessageTypeDecision, "Which database?", "agent-1", options)
+	require.NoError(t, err)
+	msg, err := inboxRepo.GetByID(sent.Message.ID)
+	require.NoError(t, err)
+	assert.Equal(t, options, msg.Options)
+
+	t.Run("a choice is required and validated", func(t *testing.T) {
+		_, err := service.Respond(msg.ID, "Postgres please")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "choose one of A, B")
+
+		_, err = service.RespondWith(msg.ID, RespondInput{Choice: "C"})
+		require.Error(t, err)
+		assert.Equal(t, errors.KindInvalidArgs, err.(*errors.Error).Kind)
+
+		_, err = service.Reply(msg.ID, ReplyInput{Content: "Leaning B", Choice: "B"})
+		require.Error(t, err)
+	})
+
+	t.Run("choosing resolves with the option", func(t *testing.T) {
+		result, err := service.RespondWith(msg.ID, RespondInput{Choice: "b"})
+		require.NoError(t, err)
+		assert.True(t, result.TicketUpdated)
+		assert.Equal(t, "B", result.Message.Choice)
+		assert.Equal(t, "B: stay on SQLite", result.Message.Response)
+
+		decisions, err := inboxRepo.ListDecisions(ticket.ID)
+		require.NoError(t, err)
+		require.Len(t, decisions, 1)
+		assert.Equal(t, "B", decisions[0].Choice)
+		assert.Equal(t, "stay on SQLite", decisions[0].Label)
+
+		ctx, err := NewTicketService(database.DB).GetExecutionContext(ticket.ID)
+		require.NoError(t, err)
+		require.Len(t, ctx.Decisions, 1)
+		assert.Equal(t, "Which database?", ctx.Decisions[0].Question)
+	})
+
+	t.Run("reopening clears the choice", func(t *testing.T) {
+		_, err := service.Reply(msg.ID, ReplyInput{Content: "Even at 10x load?", WorkerID: "agent-1"})
+		require.NoError(t, err)
+		decisions, err := inboxRepo.ListDecisions(ticket.ID)
+		require.NoError(t, err)
+		assert.Empty(t, decisions)
+
+		result, err := service.Reply(msg.ID, ReplyInput{Content: "No, then Postgres", Resolve: true, Choice: "A"})
+		require.NoError(t, err)
+		assert.Equal(t, "A", result.Message.Choice)
+		assert.Equal(t, "No, then Postgres", result.Message.Response)
+	})
+
+	t.Run("messages without options take no choice", func(t *testing.T) {
+		plain, err := service.Send(ticket.ID, models.MessageTypeQuestion, "Tabs or spaces?", "agent-1")
+		require.NoError(t, err)
+		_, err = service.RespondWith(plain.Message.ID, RespondInput{Choice: "A"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no options")
+	})
+}
+
+func TestInboxService_Wait(t *testing.T) {
+	database, _, cleanup := testDB(t)
+	defer cleanup()
+
+	projectRepo := db.NewProjectRepo(database.DB)
+	ticketRepo := db.NewTicketRepo(database.DB)
+	inboxRepo := db.NewInboxRepo(database.DB)
+	claimRepo := db.NewClaimRepo(database.DB)
+	activityRepo := db.NewActivityRepo(database.DB)
+
+	project := &models.Project{Key: "TEST", Name: "Test Project"}
+	require.NoError(t, projectRepo.Create(project))
+	ticket := &models.Ticket{ProjectID: project.ID, Title: "Waiting", Status: models.StatusWorking}
+	require.NoError(t, ticketRepo.Create(ticket))
+
+	service := NewInboxService(inboxRepo, ticketRepo, claimRepo, activityRepo)
+
+	_, err := service.LatestMessage(ticket.ID)
+	require.Error(t, err)
+	assert.Equal(t, errors.KindNotFound, errors.GetKind(err))
+
+	sent, err := service.Send(ticket.ID, models.MessageTypeQuestion, "Which port?", "agent-1")
+	require.NoError(t, err)
+	latest, err := service.LatestMessage(ticket.ID)
+	require.NoError(t, err)
+	assert.Equal(t, sent.Message.ID, latest.ID)
+
+	t.Run("times out without a response", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+		defer cancel()
+
+		_, err := service.Wait(ctx, sent.Message.ID, 5*time.Millisecond)
+		require.Error(t, err)
+		assert.Equal(t, errors.KindTimeout, errors.GetKind(err))
+	})
+
+	t.Run("cancellation is not a timeout", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := service.Wait(ctx, sent.Message.ID, 5*time.Millisecond)
+		assert.Equal(t, context.Canceled, err)
+	})
+
+	t.Run("unknown message", func(t *testing.T) {
+		_, err := service.Wait(context.Background(), 999, 5*time.Millisecond)
+		require.Error(t, err)
+		assert.Equal(t, errors.KindNotFound, errors.GetKind(err))
+	})
+
+	t.Run("returns once a human responds", func(t *testing.T) {
+		go func() {
+			time.Sleep(20 * time.Millisecond)
+			_, _ = service.Respond(sent.Message.ID, "8080")
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		msg, err := service.Wait(ctx, sent.Message.ID, 5*time.Millisecond)
+		require.NoError(t, err)
+		require.NotNil(t, msg.RespondedAt)
+		assert.Equal(t, "8080", msg.Response)
+	})
+}
@@ -0,0 +1,321 @@
+package service
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spetersoncode/wark/internal/db"
+	"github.com/spetersoncode/wark/internal/errors"
+	"github.com/spetersoncode/wark/internal/models"
+)
+
+// MilestoneService provides business logic for milestones: creating and
+// updating them, moving them through open/achieved/abandoned, and assigning
+// tickets to them.
+type MilestoneService struct {
+	db            *sql.DB
+	milestoneRepo *db.MilestoneRepo
+	projectRepo   *db.ProjectRepo
+	ticketRepo    *db.TicketRepo
+}
+
+// NewMilestoneService creates a new MilestoneService.
+func NewMilestoneService(database *sql.DB) *MilestoneService {
+	return &MilestoneService{
+		db:            database,
+		milestoneRepo: db.NewMilestoneRepo(database),
+		projectRepo:   db.NewProjectRepo(database),
+		ticketRepo:    db.NewTicketRepo(database),
+	}
+}
+
+// MilestoneError represents a domain-specific error from the milestone service.
+type MilestoneError struct {
+	Code    string
+	Message string
+}
+
+func (e *MilestoneError) Error() string {
+	return e.Message
+}
+
+// Kind maps the error code to the shared error kind, which determines the
+// CLI exit code and HTTP status.
+func (e *MilestoneError) Kind() errors.Kind {
+	switch e.Code {
+	case ErrCodeMilestoneNotFound, ErrCodeProjectNotFound, ErrCodeNotFound:
+		return errors.KindNotFound
+	case ErrCodeInvalidKey, ErrCodeInvalidName, ErrCodeInvalidStatus:
+		return errors.KindInvalidArgs
+	case ErrCodeMilestoneExists, ErrCodeMilestoneClosed:
+		return errors.KindStateError
+	default:
+		return errors.KindInternal
+	}
+}
+
+// Error codes for milestone operations
+const (
+	ErrCodeMilestoneNotFound = "MILESTONE_NOT_FOUND"
+	ErrCodeMilestoneExists   = "MILESTONE_EXISTS"
+	ErrCodeMilestoneClosed   = "MILESTONE_CLOSED"
+	ErrCodeProjectNotFound   = "PROJECT_NOT_FOUND"
+	ErrCodeInvalidKey        = "INVALID_KEY"
+	ErrCodeInvalidName       = "INVALID_NAME"
+	ErrCodeInvalidStatus     = "INVALID_STATUS"
+)
+
+func newMilestoneError(code, format string, args ...interface{}) *MilestoneError {
+	return &MilestoneError{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// CreateInput holds the fields for a new milestone.
+type CreateInput struct {
+	ProjectKey string
+	Key        string
+	Name       string
+	Goal       string
+	TargetDate *time.Time
+}
+
+// UpdateInput holds the milestone fields to change; nil fields are left as is.
+type UpdateInput struct {
+	Name       *string
+	Goal       *string
+	TargetDate *time.Time
+	Status     *string
+}
+
+// Create creates an open milestone in a project.
+func (s *MilestoneService) Create(input CreateInput) (*models.Milestone, error) {
+	if err := models.ValidateMilestoneKey(input.Key); err != nil {
+		return nil, newMilestoneError(ErrCodeInvalidKey, "%v", err)
+	}
+	if strings.TrimSpace(input.Name) == "" {
+		return nil, newMilestoneError(ErrCodeInvalidName, "milestone name cannot be empty")
+	}
+
+	project, err := s.getProject(input.ProjectKey)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.milestoneRepo.GetByKey(project.Key, input.Key)
+	if err != nil {
+		return nil, newMilestoneError(ErrCodeDatabase, "failed to check milestone: %v", err)
+	}
+	if existing != nil {
+		return nil, newMilestoneError(ErrCodeMilestoneExists, "milestone %s already exists in %s", input.Key, project.Key)
+	}
+
+	m := &models.Milestone{
+		ProjectID:  project.ID,
+		Key:        input.Key,
+		Name:       input.Name,
+		Goal:       input.Goal,
+		TargetDate: input.TargetDate,
+		Status:     models.MilestoneStatusOpen,
+		ProjectKey: project.Key,
+	}
+	if err := s.milestoneRepo.Create(m); err != nil {
+		return nil, newMilestoneError(ErrCodeDatabase, "failed to create milestone: %v", err)
+	}
+	return m, nil
+}
+
+// Get retrieves a milestone by ID.
+func (s *MilestoneService) Get(id int64) (*models.Milestone, error) {
+	m, err := s.milestoneRepo.GetByID(id)
+	if err != nil {
+		return nil, newMilestoneError(ErrCodeDatabase, "failed to get milestone: %v", err)
+	}
+	if m == nil {
+		return nil, newMilestoneError(ErrCodeMilestoneNotFound, "milestone %d not found", id)
+	}
+	return m, nil
+}
+
+// GetByKey retrieves a milestone by project key and milestone key.
+func (s *MilestoneService) GetByKey(projectKey, key string) (*models.Milestone, error) {
+	m, err := s.milestoneRepo.GetByKey(projectKey, key)
+	if err != nil {
+		return nil, newMilestoneError(ErrCodeDatabase, "failed to get milestone: %v", err)
+	}
+	if m == nil {
+		return nil, newMilestoneError(ErrCodeMilestoneNotFound, "milestone %s not found in %s", key, projectKey)
+	}
+	return m, nil
+}
+
+// List retrieves milestones for a project, or for all projects if projectKey is empty.
+func (s *MilestoneService) List(projectKey string) ([]*models.Milestone, error) {
+	var projectID *int64
+	if projectKey != "" {
+		project, err := s.getProject(projectKey)
+		if err != nil {
+			return nil, err
+		}
+		projectID = &project.ID
+	}
+
+	milestones, err := s.milestoneRepo.List(projectID)
+	if err != nil {
+		return nil, newMilestoneError(ErrCodeDatabase, "failed to list milestones: %v", err)
+	}
+	return milestones, nil
+}
+
+// Update applies the non-nil fields of input to a milestone.
+func (s *MilestoneService) Update(id int64, input UpdateInput) (*models.Milestone, error) {
+	m, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		if strings.TrimSpace(*input.Name) == "" {
+			return nil, newMilestoneError(ErrCodeInvalidName, "milestone name cannot be empty")
+		}
+		m.Name = *input.Name
+	}
+	if input.Goal != nil {
+		m.Goal = *input.Goal
+	}
+	if input.TargetDate != nil {
+		m.TargetDate = input.TargetDate
+	}
+	if input.Status != nil {
+		if err := models.ValidateMilestoneStatus(*input.Status); err != nil {
+			return nil, newMilestoneError(ErrCodeInvalidStatus, "%v", err)
+		}
+		m.Status = *input.Status
+	}
+
+	if err := s.milestoneRepo.Update(m); err != nil {
+		return nil, newMilestoneError(ErrCodeDatabase, "failed to update milestone: %v", err)
+	}
+	return m, nil
+}
+
+// Delete deletes a milestone. Its tickets are unassigned, not deleted.
+func (s *MilestoneService) Delete(id int64) error {
+	if _, err := s.Get(id); err != nil {
+		return err
+	}
+	if err := s.milestoneRepo.Delete(id); err != nil {
+		return newMilestoneError(ErrCodeDatabase, "failed to delete milestone: %v", err)
+	}
+	return nil
+}
+
+// Achieve marks a milestone as achieved.
+func (s *MilestoneService) Achieve(id int64) (*models.Milestone, error) {
+	return s.setStatus(id, models.MilestoneStatusAchieved)
+}
+
+// Abandon marks a milestone as abandoned.
+func (s *MilestoneService) Abandon(id int64) (*models.Milestone, error) {
+	return s.setStatus(id, models.MilestoneStatusAbandoned)
+}
+
+// Reopen marks a milestone as open again.
+func (s *MilestoneService) Reopen(id int64) (*models.Milestone, error) {
+	return s.setStatus(id, models.MilestoneStatusOpen)
+}
+
+func (s *MilestoneService) setStatus(id int64, status string) (*models.Milestone, error) {
+	return s.Update(id, UpdateInput{Status: &status})
+}
+
+// GetLinkedTickets returns the tickets assigned to a milestone.
+func (s *MilestoneService) GetLinkedTickets(id int64) ([]*models.Ticket, error) {
+	if _, err := s.Get(id); err != nil {
+		return nil, err
+	}
+	tickets, err := s.ticketRepo.List(db.TicketFilter{MilestoneID: &id})
+	if err != nil {
+		return nil, newMilestoneError(ErrCodeDatabase, "failed to list milestone tickets: %v", err)
+	}
+	if tickets == nil {
+		tickets = []*models.Ticket{}
+	}
+	return tickets, nil
+}
+
+// Assign puts a ticket in the milestone with the given key in the ticket's
+// project, replacing any previous milestone. An empty key unassigns the
+// ticket. Only open milestones accept tickets.
+func (s *MilestoneService) Assign(ticketID int64, milestoneKey string) (*models.Ticket, error) {
+	var ticket *models.Ticket
+	err := db.WithTx(s.db, func(tx *sql.Tx) error {
+		ticketRepo := db.NewTicketRepo(tx)
+		var err error
+		ticket, err = ticketRepo.GetByID(ticketID)
+		if err != nil {
+			return newMilestoneError(ErrCodeDatabase, "failed to get ticket: %v", err)
+		}
+		if ticket == nil {
+			return newMilestoneError(ErrCodeNotFound, "ticket not found")
+		}
+
+		oldKey := ticket.MilestoneKey
+		if milestoneKey == "" {
+			ticket.MilestoneID = nil
+		} else {
+			m, err := db.NewMilestoneRepo(tx).GetByKey(ticket.ProjectKey, milestoneKey)
+			if err != nil {
+				return newMilestoneError(ErrCodeDatabase, "failed to get milestone: %v", err)
+			}
+			if m == nil {
+				return newMilestoneError(ErrCodeMilestoneNotFound, "milestone %s not found in %s", milestoneKey, ticket.ProjectKey)
+			}
+			if !m.IsOpen() {
+				return newMilestoneError(ErrCodeMilestoneClosed, "milestone %s is %s", m.Key, m.Status)
+			}
+			ticket.MilestoneID = &m.ID
+		}
+		ticket.MilestoneKey = milestoneKey
+		if oldKey == milestoneKey {
+			return nil
+		}
+
+		if err := ticketRepo.Update(ticket); err != nil {
+			return newMilestoneError(ErrCodeDatabase, "failed to update ticket: %v", err)
+		}
+
+		summary := fmt.Sprintf("Milestone: %s → %s", displayMilestone(oldKey), displayMilestone(milestoneKey))
+		err = db.NewActivityRepo(tx).LogActionWithDetails(ticket.ID, models.ActionFieldChanged, models.ActorTypeHuman, "",
+			summary, map[string]interface{}{"field": "milestone", "old": oldKey, "new": milestoneKey})
+		if err != nil {
+			return newMilestoneError(ErrCodeDatabase, "failed to log activity: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		if _, ok := err.(*MilestoneError); ok {
+			return nil, err
+		}
+		return nil, newMilestoneError(ErrCodeDatabase, "%v", err)
+	}
+	return ticket, nil
+}
+
+func displayMilestone(key string) string {
+	if key == "" {
+		return "(none)"
+	}
+	return key
+}
+
+func (s *MilestoneService) getProject(projectKey string) (*models.Project, error) {
+	project, err := s.projectRepo.GetByKey(projectKey)
+	if err != nil {
+		return nil, newMilestoneError(ErrCodeDatabase, "failed to get project: %v", err)
+	}
+	if project == nil {
+		return nil, newMilestoneError(ErrCodeProjectNotFound, "project %s not found", projectKey)
+	}
+	return project, nil
+}
@@ -481,6 +481,72 @@ func TestMilestoneService_GetLinkedTickets(t *testing.T) {
 	})
 }
 
+func TestMilestoneService_Assign(t *testing.T) {
+	database := setupMilestoneTestDB(t)
+	defer database.Close()
+
+	createTestProjectForService(t, database, "PROJ")
+	svc := NewMilestoneService(database)
+
+	v1, _ := svc.Create(CreateInput{ProjectKey: "PROJ", Key: "V1", Name: "Version 1"})
+	old, _ := svc.Create(CreateInput{ProjectKey: "PROJ", Key: "OLD", Name: "Old"})
+	if _, err := svc.Abandon(old.ID); err != nil {
+		t.Fatalf("Abandon failed: %v", err)
+	}
+
+	project, _ := db.NewProjectRepo(database).GetByKey("PROJ")
+	ticket := &models.Ticket{ProjectID: project.ID, Title: "Ship it", Status: models.StatusReady}
+	if err := db.NewTicketRepo(database).Create(ticket); err != nil {
+		t.Fatalf("failed to create ticket: %v", err)
+	}
+
+	t.Run("assigns ticket to open milestone", func(t *testing.T) {
+		updated, err := svc.Assign(ticket.ID, "V1")
+		if err != nil {
+			t.Fatalf("Assign failed: %v", err)
+		}
+		if updated.MilestoneID == nil || *updated.MilestoneID != v1.ID {
+			t.Errorf("expected milestone id %d, got %v", v1.ID, updated.MilestoneID)
+		}
+
+		m, _ := svc.Get(v1.ID)
+		if m.TicketCount != 1 || m.ClosedCount != 0 {
+			t.Errorf("expected progress 0/1, got %d/%d", m.ClosedCount, m.TicketCount)
+		}
+	})
+
+	t.Run("rejects closed milestone", func(t *testing.T) {
+		_, err := svc.Assign(ticket.ID, "OLD")
+		me, ok := err.(*MilestoneError)
+		if !ok || me.Code != ErrCodeMilestoneClosed {
+			t.Errorf("expected code %s, got %v", ErrCodeMilestoneClosed, err)
+		}
+	})
+
+	t.Run("rejects unknown milestone", func(t *testing.T) {
+		_, err := svc.Assign(ticket.ID, "NOPE")
+		me, ok := err.(*MilestoneError)
+		if !ok || me.Code != ErrCodeMilestoneNotFound {
+			t.Errorf("expected code %s, got %v", ErrCodeMilestoneNotFound, err)
+		}
+	})
+
+	t.Run("clears milestone with empty key", func(t *testing.T) {
+		updated, err := svc.Assign(ticket.ID, "")
+		if err != nil {
+			t.Fatalf("Assign failed: %v", err)
+		}
+		if updated.MilestoneID != nil {
+			t.Errorf("expected no milestone, got %d", *updated.MilestoneID)
+		}
+
+		tickets, _ := svc.GetLinkedTickets(v1.ID)
+		if len(tickets) != 0 {
+			t.Errorf("expected 0 linked tickets, got %d", len(tickets))
+		}
+	})
+}
+
 func TestMilestoneKeyValidation(t *testing.T) {
 	tests := []struct {
 		key     string
@@ -0,0 +1,301 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spetersoncode/wark/internal/common"
+	"github.com/spetersoncode/wark/internal/models"
+)
+
+// Plan describes a set of tickets to create together. Tickets refer to each
+// other by alias, since their keys aren't known until they exist; a reference
+// that isn't an alias in the plan is taken to be an existing ticket key.
+type Plan struct {
+	Project string `json:"project" yaml:"project"`
+	// Parent is the default parent (alias or key) for tickets that don't set
+	// their own. Epics in the plan are never given a default parent.
+	Parent string `json:"parent,omitempty" yaml:"parent,omitempty"`
+	// Milestone is the default milestone for tickets that don't set their own.
+	Milestone string       `json:"milestone,omitempty" yaml:"milestone,omitempty"`
+	Tickets   []PlanTicket `json:"tickets" yaml:"tickets"`
+}
+
+// PlanTicket is a ticket in a plan.
+type PlanTicket struct {
+	Alias       string   `json:"alias" yaml:"alias"`
+	Title       string   `json:"title" yaml:"title"`
+	Description string   `json:"description,omitempty" yaml:"description,omitempty"`
+	Type        string   `json:"type,omitempty" yaml:"type,omitempty"`
+	Priority    string   `json:"priority,omitempty" yaml:"priority,omitempty"`
+	Complexity  string   `json:"complexity,omitempty" yaml:"complexity,omitempty"`
+	Role        string   `json:"role,omitempty" yaml:"role,omitempty"`
+	Milestone   string   `json:"milestone,omitempty" yaml:"milestone,omitempty"`
+	Parent      string   `json:"parent,omitempty" yaml:"parent,omitempty"`
+	DependsOn   []string `json:"depends_on,omitempty" yaml:"depends_on,omitempty"`
+	Labels      []string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Tasks       []string `json:"tasks,omitempty" yaml:"tasks,omitempty"`
+}
+
+// PlanResult is the outcome of applying a plan.
+type PlanResult struct {
+	DryRun  bool              `json:"dry_run"`
+	Project string            `json:"project"`
+	Aliases map[string]string `json:"aliases"`
+	// Tickets are listed in creation order: parents and dependencies first.
+	Tickets []*PlannedTicket `json:"tickets"`
+}
+
+// PlannedTicket is a ticket created (or, in a dry run, that would be created)
+// by a plan. Parent and DependsOn hold ticket keys.
+type PlannedTicket struct {
+	Alias     string   `json:"alias"`
+	Key       string   `json:"key"`
+	Title     string   `json:"title"`
+	Type      string   `json:"type"`
+	Status    string   `json:"status"`
+	Parent    string   `json:"parent,omitempty"`
+	DependsOn []string `json:"depends_on,omitempty"`
+	Tasks     int      `json:"tasks,omitempty"`
+}
+
+// ApplyPlan creates every ticket in a plan, with its tasks, dependencies and
+// parent, in a single transaction. If any ticket fails, nothing is created. A
+// dry run goes through the same steps and rolls back, so the returned keys
+// and statuses are the ones a real run would produce.
+func (s *TicketService) ApplyPlan(plan *Plan, dryRun bool) (*PlanResult, error) {
+	order, err := plan.order()
+	if err != nil {
+		return nil, err
+	}
+
+	var result *PlanResult
+	err = s.inTx(func(tx *TicketService) error {
+		var err error
+		if result, err = tx.applyPlan(plan, order); err != nil {
+			return err
+		}
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && err != errDryRun {
+		return nil, err
+	}
+	result.DryRun = dryRun
+	return result, nil
+}
+
+func (s *TicketService) applyPlan(plan *Plan, order []int) (*PlanResult, error) {
+	projectKey := strings.ToUpper(strings.TrimSpace(plan.Project))
+	result := &PlanResult{Project: projectKey, Aliases: make(map[string]string, len(order))}
+	keys := make(map[string]string, len(order))
+	ctx := context.Background()
+
+	resolve := func(ref string) string {
+		if key, ok := keys[planAlias(ref)]; ok {
+			return key
+		}
+		return strings.ToUpper(strings.TrimSpace(ref))
+	}
+
+	for _, i := range order {
+		pt := plan.Tickets[i]
+		input := CreateTicketInput{
+			ProjectKey:   projectKey,
+			Title:        pt.Title,
+			Description:  pt.Description,
+			Priority:     pt.Priority,
+			Complexity:   pt.Complexity,
+			Type:         pt.Type,
+			RoleName:     pt.Role,
+			MilestoneKey: pt.Milestone,
+			Labels:       pt.Labels,
+		}
+		if input.MilestoneKey == "" {
+			input.MilestoneKey = plan.Milestone
+		}
+		if parent := plan.parentOf(pt); parent != "" {
+			input.ParentKey = resolve(parent)
+		}
+		for _, dep := range pt.DependsOn {
+			input.DependsOn = append(input.DependsOn, resolve(dep))
+		}
+
+		ticket, err := s.create(input)
+		if err != nil {
+			return nil, planTicketError(pt.Alias, err)
+		}
+		for _, task := range pt.Tasks {
+			if _, err := s.tasksRepo.CreateTask(ctx, ticket.ID, task); err != nil {
+				return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("%s: failed to add task: %v", pt.Alias, err), nil)
+			}
+		}
+
+		keys[planAlias(pt.Alias)] = ticket.TicketKey
+		result.Aliases[pt.Alias] = ticket.TicketKey
+		result.Tickets = append(result.Tickets, &PlannedTicket{
+			Alias:     pt.Alias,
+			Key:       ticket.TicketKey,
+			Title:     ticket.Title,
+			Type:      string(ticket.Type),
+			Status:    string(ticket.Status),
+			Parent:    input.ParentKey,
+			DependsOn: input.DependsOn,
+			Tasks:     len(pt.Tasks),
+		})
+	}
+
+	return result, nil
+}
+
+// planTicketError prefixes a ticket creation error with the ticket's alias.
+func planTicketError(alias string, err error) error {
+	if te, ok := err.(*TicketError); ok {
+		return newTicketError(te.Code, fmt.Sprintf("%s: %s", alias, te.Message), te.Details)
+	}
+	return err
+}
+
+// planAlias normalizes an alias for lookup; aliases are case-insensitive.
+func planAlias(alias string) string {
+	return strings.ToLower(strings.TrimSpace(alias))
+}
+
+// parentOf returns the parent reference for a ticket, falling back to the
+// plan's default parent.
+func (p *Plan) parentOf(t PlanTicket) string {
+	if t.Parent != "" {
+		return t.Parent
+	}
+	if p.Parent == "" || planAlias(p.Parent) == planAlias(t.Alias) ||
+		strings.EqualFold(strings.TrimSpace(t.Type), string(models.TicketTypeEpic)) {
+		return ""
+	}
+	return p.Parent
+}
+
+// order validates the plan and returns ticket indexes in an order where every
+// ticket comes after its parent and dependencies. All problems are reported
+// together.
+func (p *Plan) order() ([]int, error) {
+	var problems []string
+	add := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if strings.TrimSpace(p.Project) == "" {
+		add("project is required")
+	}
+	if len(p.Tickets) == 0 {
+		add("plan has no tickets")
+	}
+
+	index := make(map[string]int, len(p.Tickets))
+	for i, t := range p.Tickets {
+		alias := planAlias(t.Alias)
+		switch {
+		case alias == "":
+			add("ticket %d: alias is required", i+1)
+		case strings.ContainsAny(alias, " \t,"):
+			add("ticket %d: alias %q cannot contain spaces or commas", i+1, t.Alias)
+		default:
+			if _, dup := index[alias]; dup {
+				add("%s: duplicate alias", t.Alias)
+			}
+			index[alias] = i
+		}
+		if strings.TrimSpace(t.Title) == "" {
+			add("%s: title is required", t.Alias)
+		}
+		for _, task := range t.Tasks {
+			if strings.TrimSpace(task) == "" {
+				add("%s: task description cannot be empty", t.Alias)
+			}
+		}
+	}
+
+	// Edges point from a ticket to the plan tickets it needs created first
+	edges := make([][]int, len(p.Tickets))
+	checkRef := func(t PlanTicket, i int, ref, what string) {
+		if j, ok := index[planAlias(ref)]; ok {
+			if j == i {
+				add("%s: cannot be its own %s", t.Alias, what)
+				return
+			}
+			edges[i] = append(edges[i], j)
+			return
+		}
+		if !isFullTicketKey(ref) {
+			add("%s: %s %q is neither an alias in this plan nor a ticket key", t.Alias, what, ref)
+		}
+	}
+	if _, ok := index[planAlias(p.Parent)]; p.Parent != "" && !ok && !isFullTicketKey(p.Parent) {
+		add("parent %q is neither an alias in this plan nor a ticket key", p.Parent)
+	}
+	for i, t := range p.Tickets {
+		if parent := p.parentOf(t); parent != "" {
+			checkRef(t, i, parent, "parent")
+		}
+		for _, dep := range t.DependsOn {
+			checkRef(t, i, dep, "dependency")
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, newTicketError(ErrCodeInvalidInput, "invalid plan: "+strings.Join(problems, "; "), nil)
+	}
+
+	// Depth-first topological sort, keeping document order where possible
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make([]int, len(p.Tickets))
+	order := make([]int, 0, len(p.Tickets))
+	var path []string
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch state[i] {
+		case done:
+			return nil
+		case visiting:
+			cycle := append(path, p.Tickets[i].Alias)
+			for j, alias := range cycle {
+				if planAlias(alias) == planAlias(p.Tickets[i].Alias) {
+					cycle = cycle[j:]
+					break
+				}
+			}
+			return newTicketError(ErrCodeInvalidInput,
+				fmt.Sprintf("invalid plan: circular dependency: %s", strings.Join(cycle, " -> ")), nil)
+		}
+		state[i] = visiting
+		path = append(path, p.Tickets[i].Alias)
+		for _, j := range edges[i] {
+			if err := visit(j); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[i] = done
+		order = append(order, i)
+		return nil
+	}
+	for i := range p.Tickets {
+		if err := visit(i); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// isFullTicketKey reports whether s is a ticket key with a project, such as
+// WEBAPP-42.
+func isFullTicketKey(s string) bool {
+	projectKey, _, err := common.ParseTicketKey(s)
+	return err == nil && projectKey != ""
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/spetersoncode/wark/internal/db"
+	"github.com/spetersoncode/wark/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTicketService_ApplyPlan(t *testing.T) {
+	database, _, cleanup := testDB(t)
+	defer cleanup()
+	createTicketTestProject(t, database, "PLAN")
+	require.NoError(t, db.NewRoleRepo(database.DB).Create(&models.Role{Name: "architect", Description: "Designs systems", Instructions: "Design it"}))
+	svc := NewTicketService(database.DB)
+
+	existing, err := svc.Create(CreateTicketInput{ProjectKey: "PLAN", Title: "Existing groundwork"})
+	require.NoError(t, err)
+
+	plan := &Plan{
+		Project: "plan",
+		Parent:  "auth",
+		Tickets: []PlanTicket{
+			// Listed before what it depends on; the plan orders creation
+			{Alias: "login", Title: "Login form", DependsOn: []string{"schema", "PLAN-1"}, Tasks: []string{"Form", "Validation"}},
+			{Alias: "auth", Title: "Authentication", Type: "epic"},
+			{Alias: "schema", Title: "User schema", Complexity: "large", Role: "architect", Priority: "high"},
+		},
+	}
+
+	t.Run("dry run previews keys and creates nothing", func(t *testing.T) {
+		result, err := svc.ApplyPlan(plan, true)
+		require.NoError(t, err)
+		assert.True(t, result.DryRun)
+		assert.Equal(t, map[string]string{"auth": "PLAN-2", "schema": "PLAN-3", "login": "PLAN-4"}, result.Aliases)
+
+		require.Len(t, result.Tickets, 3)
+		login := result.Tickets[2]
+		assert.Equal(t, "login", login.Alias)
+		assert.Equal(t, "PLAN-2", login.Parent)
+		assert.Equal(t, []string{"PLAN-3", "PLAN-1"}, login.DependsOn)
+		assert.Equal(t, string(models.StatusBlocked), login.Status)
+		assert.Equal(t, 2, login.Tasks)
+
+		ticket, err := db.NewTicketRepo(database.DB).GetByKey("PLAN", 2)
+		require.NoError(t, err)
+		assert.Nil(t, ticket)
+	})
+
+	t.Run("apply creates the graph", func(t *testing.T) {
+		result, err := svc.ApplyPlan(plan, false)
+		require.NoError(t, err)
+		assert.False(t, result.DryRun)
+		assert.Equal(t, "PLAN-4", result.Aliases["login"])
+
+		ticketRepo := db.NewTicketRepo(database.DB)
+		schema, err := ticketRepo.GetByKey("PLAN", 3)
+		require.NoError(t, err)
+		assert.Equal(t, models.ComplexityLarge, schema.Complexity)
+		assert.Equal(t, models.PriorityHigh, schema.Priority)
+		assert.Equal(t, "architect", schema.RoleName)
+		assert.Equal(t, "PLAN-2-authentication", schema.Worktree)
+
+		login, err := ticketRepo.GetByKey("PLAN", 4)
+		require.NoError(t, err)
+		assert.Equal(t, models.StatusBlocked, login.Status)
+		deps, err := db.NewDependencyRepo(database.DB).GetDependencies(login.ID)
+		require.NoError(t, err)
+		assert.Len(t, deps, 2)
+		assert.Contains(t, []int64{deps[0].ID, deps[1].ID}, existing.ID)
+
+		tasks, err := db.NewTasksRepo(database.DB).ListTasks(t.Context(), login.ID)
+		require.NoError(t, err)
+		require.Len(t, tasks, 2)
+		assert.Equal(t, "Form", tasks[0].Description)
+	})
+}
+
+func TestTicketService_ApplyPlanErrors(t *testing.T) {
+	database, _, cleanup := testDB(t)
+	defer cleanup()
+	createTicketTestProject(t, database, "PLAN")
+	svc := NewTicketService(database.DB)
+
+	requireInvalid := func(t *testing.T, plan *Plan, contains ...string) {
+		t.Helper()
+		_, err := svc.ApplyPlan(plan, false)
+		var te *TicketError
+		require.ErrorAs(t, err, &te)
+		assert.Equal(t, ErrCodeInvalidInput, te.Code)
+		for _, s := range contains {
+			assert.Contains(t, te.Message, s)
+		}
+	}
+
+	requireInvalid(t, &Plan{}, "project is required", "plan has no tickets")
+	requireInvalid(t, &Plan{Project: "PLAN", Tickets: []PlanTicket{
+		{Alias: "a", Title: "A", DependsOn: []string{"missing"}},
+		{Alias: "a", Title: ""},
+	}}, `dependency "missing" is neither`, "a: duplicate alias", "a: title is required")
+	requireInvalid(t, &Plan{Project: "PLAN", Tickets: []PlanTicket{
+		{Alias: "a", Title: "A", DependsOn: []string{"b"}},
+		{Alias: "b", Title: "B", DependsOn: []string{"c"}},
+		{Alias: "c", Title: "C", DependsOn: []string{"a"}},
+	}}, "circular dependency: a -> b -> c -> a")
+
+	// A failure part way through rolls back the tickets already created
+	_, err := svc.ApplyPlan(&Plan{Project: "PLAN", Tickets: []PlanTicket{
+		{Alias: "first", Title: "First"},
+		{Alias: "second", Title: "Second", DependsOn: []string{"first"}, Role: "nobody"},
+	}}, false)
+	var te *TicketError
+	require.ErrorAs(t, err, &te)
+	assert.Equal(t, ErrCodeNotFound, te.Code)
+	assert.Contains(t, te.Message, "second: role 'nobody' not found")
+
+	ticket, err := db.NewTicketRepo(database.DB).GetByKey("PLAN", 1)
+	require.NoError(t, err)
+	assert.Nil(t, ticket)
+}
@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spetersoncode/wark/internal/common"
+	"github.com/spetersoncode/wark/internal/config"
+)
+
+// RecurringTemplate is a ticket created on a cron schedule.
+type RecurringTemplate struct {
+	config.RecurringConfig
+	cron *common.Cron
+}
+
+// Recurring run actions
+const (
+	// RecurringStarted is a template's first run, which only records the
+	// time it starts counting from.
+	RecurringStarted = "started"
+	// RecurringCreated means the template was due and created a ticket.
+	RecurringCreated = "created"
+	// RecurringWaiting means the template is not due yet.
+	RecurringWaiting = "waiting"
+	// RecurringFailed means the template was due but its ticket could not be
+	// created. It is retried on the next run.
+	RecurringFailed = "failed"
+)
+
+// errRecurringRaced rolls back a ticket created by a template that another
+// process ran first.
+var errRecurringRaced = stderrors.New("recurring ticket already run")
+
+// RecurringStatus is a recurring template's state, and what a run did with it.
+type RecurringStatus struct {
+	Name       string     `json:"name"`
+	Schedule   string     `json:"schedule"`
+	Project    string     `json:"project"`
+	Title      string     `json:"title"`
+	Action     string     `json:"action,omitempty"`
+	Ticket     string     `json:"ticket,omitempty"`
+	Error      string     `json:"error,omitempty"`
+	LastRunAt  *time.Time `json:"last_run_at,omitempty"`
+	LastTicket string     `json:"last_ticket,omitempty"`
+	NextRunAt  *time.Time `json:"next_run_at,omitempty"`
+}
+
+// RecurringResult summarizes one run of the recurring templates.
+type RecurringResult struct {
+	Created   int                `json:"created"`
+	Failed    int                `json:"failed"`
+	Templates []*RecurringStatus `json:"templates"`
+}
+
+// ParseRecurring validates recurring ticket config: every template needs a
+// unique name, a valid schedule, a project and a title.
+func ParseRecurring(cfgs []config.RecurringConfig) ([]*RecurringTemplate, error) {
+	templates := make([]*RecurringTemplate, 0, len(cfgs))
+	seen := make(map[string]bool)
+	for _, cfg := range cfgs {
+		if cfg.Name == "" {
+			return nil, fmt.Errorf("recurring ticket %q has no name", cfg.Title)
+		}
+		if seen[cfg.Name] {
+			return nil, fmt.Errorf("duplicate recurring ticket name: %s", cfg.Name)
+		}
+		seen[cfg.Name] = true
+		if cfg.Project == "" {
+			return nil, fmt.Errorf("recurring ticket %s has no project", cfg.Name)
+		}
+		if strings.TrimSpace(cfg.Title) == "" {
+			return nil, fmt.Errorf("recurring ticket %s has no title", cfg.Name)
+		}
+		cron, err := common.ParseCron(cfg.Schedule)
+		if err != nil {
+			return nil, fmt.Errorf("recurring ticket %s: %w", cfg.Name, err)
+		}
+		if _, _, err := parseLevels(cfg.Priority, cfg.Complexity); err != nil {
+			return nil, fmt.Errorf("recurring ticket %s: %w", cfg.Name, err)
+		}
+		templates = append(templates, &RecurringTemplate{RecurringConfig: cfg, cron: cron})
+	}
+	return templates, nil
+}
+
+// RecurringStatuses reports when each template last ran and when it is
+// next due, without running anything.
+func (s *TicketService) RecurringStatuses(templates []*RecurringTemplate, now time.Time) ([]*RecurringStatus, error) {
+	statuses := make([]*RecurringStatus, 0, len(templates))
+	for _, t := range templates {
+		status, _, err := s.recurringStatus(t, now)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// RunRecurring creates a ticket for each template that has come due since it
+// last ran. However many intervals were missed, a template creates one
+// ticket per run. A template's first run only records the time, so it
+// creates its first ticket at the next scheduled time.
+//
+// Each template runs in its own transaction and records its run only if no
+// other process ran it first, so concurrent runs never create a ticket twice.
+// A template whose ticket can't be created is reported as failed and retried
+// on the next run.
+func (s *TicketService) RunRecurring(templates []*RecurringTemplate, now time.Time) (*RecurringResult, error) {
+	result := &RecurringResult{Templates: make([]*RecurringStatus, 0, len(templates))}
+	for _, t := range templates {
+		var status *RecurringStatus
+		err := s.inTx(func(tx *TicketService) error {
+			var err error
+			status, err = tx.runRecurring(t, now)
+			return err
+		})
+		if err == errRecurringRaced {
+			if status, _, err = s.recurringStatus(t, now); err != nil {
+				return nil, err
+			}
+			status.Action = RecurringWaiting
+		} else if err != nil {
+			te, ok := err.(*TicketError)
+			if !ok || te.Code == ErrCodeDatabase {
+				return nil, err
+			}
+			if status, _, err = s.recurringStatus(t, now); err != nil {
+				return nil, err
+			}
+			status.Action = RecurringFailed
+			status.Error = te.Message
+		}
+		switch status.Action {
+		case RecurringCreated:
+			result.Created++
+		case RecurringFailed:
+			result.Failed++
+		}
+		result.Templates = append(result.Templates, status)
+	}
+	return result, nil
+}
+
+func (s *TicketService) runRecurring(t *RecurringTemplate, now time.Time) (*RecurringStatus, error) {
+	status, due, err := s.recurringStatus(t, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if status.LastRunAt == nil {
+		if _, err := s.recurringRepo.Start(t.Name, now); err != nil {
+			return nil, newTicketError(ErrCodeDatabase, err.Error(), nil)
+		}
+		next := t.cron.Next(now)
+		status.Action = RecurringStarted
+		status.LastRunAt = &now
+		status.NextRunAt = &next
+		return status, nil
+	}
+	if !due {
+		status.Action = RecurringWaiting
+		return status, nil
+	}
+
+	ticket, err := s.create(CreateTicketInput{
+		ProjectKey:  t.Project,
+		Title:       t.Title,
+		Description: t.Description,
+		RoleName:    t.Role,
+		Priority:    t.Priority,
+		Complexity:  t.Complexity,
+	})
+	if err != nil {
+		return nil, recurringError(t, err)
+	}
+	if t.Ready {
+		if err := s.prioritize(ticket.ID); err != nil {
+			return nil, recurringError(t, err)
+		}
+	}
+	advanced, err := s.recurringRepo.Advance(t.Name, *status.LastRunAt, now, ticket.ID)
+	if err != nil {
+		return nil, newTicketError(ErrCodeDatabase, err.Error(), nil)
+	}
+	if !advanced {
+		return nil, errRecurringRaced
+	}
+
+	next := t.cron.Next(now)
+	status.Action = RecurringCreated
+	status.Ticket = ticket.TicketKey
+	status.LastRunAt = &now
+	status.LastTicket = ticket.TicketKey
+	status.NextRunAt = &next
+	return status, nil
+}
+
+// recurringStatus reports a template's last run and next due time, and
+// whether it is due at now.
+func (s *TicketService) recurringStatus(t *RecurringTemplate, now time.Time) (*RecurringStatus, bool, error) {
+	status := &RecurringStatus{
+		Name:     t.Name,
+		Schedule: t.Schedule,
+		Project:  strings.ToUpper(t.Project),
+		Title:    t.Title,
+	}
+	run, err := s.recurringRepo.Get(t.Name)
+	if err != nil {
+		return nil, false, newTicketError(ErrCodeDatabase, err.Error(), nil)
+	}
+	if run == nil {
+		next := t.cron.Next(now)
+		status.NextRunAt = &next
+		return status, false, nil
+	}
+
+	last := run.LastRunAt.In(now.Location())
+	next := t.cron.Next(last)
+	status.LastRunAt = &last
+	status.LastTicket = run.LastTicketKey
+	status.NextRunAt = &next
+	return status, !next.After(now), nil
+}
+
+func recurringError(t *RecurringTemplate, err error) error {
+	if te, ok := err.(*TicketError); ok {
+		return newTicketError(te.Code, fmt.Sprintf("recurring ticket %s: %s", t.Name, te.Message), te.Details)
+	}
+	return err
+}
+
+// RunRecurringDaemon runs the recurring templates every interval until ctx
+// is canceled.
+func (s *TicketService) RunRecurringDaemon(ctx context.Context, templates []*RecurringTemplate, interval time.Duration, callback func(*RecurringResult, error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		result, err := s.RunRecurring(templates, time.Now())
+		if callback != nil {
+			callback(result, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spetersoncode/wark/internal/config"
+	"github.com/spetersoncode/wark/internal/db"
+	"github.com/spetersoncode/wark/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRecurring(t *testing.T) {
+	valid := config.RecurringConfig{Name: "audit", Schedule: "@weekly", Project: "REC", Title: "Audit"}
+	templates, err := ParseRecurring([]config.RecurringConfig{valid})
+	require.NoError(t, err)
+	require.Len(t, templates, 1)
+
+	for _, tt := range []struct {
+		cfgs []config.RecurringConfig
+		want string
+	}{
+		{[]config.RecurringConfig{{Schedule: "@weekly", Project: "REC", Title: "Audit"}}, "has no name"},
+		{[]config.RecurringConfig{valid, valid}, "duplicate recurring ticket name: audit"},
+		{[]config.RecurringConfig{{Name: "audit", Schedule: "@weekly", Title: "Audit"}}, "has no project"},
+		{[]config.RecurringConfig{{Name: "audit", Schedule: "@weekly", Project: "REC"}}, "has no title"},
+		{[]config.RecurringConfig{{Name: "audit", Schedule: "weekly", Project: "REC", Title: "Audit"}}, "invalid cron"},
+		{[]config.RecurringConfig{{Name: "audit", Schedule: "@weekly", Project: "REC", Title: "Audit", Priority: "urgent"}}, "invalid priority"},
+	} {
+		_, err := ParseRecurring(tt.cfgs)
+		assert.ErrorContains(t, err, tt.want)
+	}
+}
+
+func TestTicketService_RunRecurring(t *testing.T) {
+	database, _, cleanup := testDB(t)
+	defer cleanup()
+	createTicketTestProject(t, database, "REC")
+	svc := NewTicketService(database.DB)
+	ticketRepo := db.NewTicketRepo(database.DB)
+
+	templates, err := ParseRecurring([]config.RecurringConfig{
+		{Name: "audit", Schedule: "0 9 * * MON", Project: "rec", Title: "Audit dependencies",
+			Description: "Run the audit", Complexity: "small", Ready: true},
+		{Name: "missing", Schedule: "@daily", Project: "NOPE", Title: "Nowhere"},
+	})
+	require.NoError(t, err)
+
+	// Friday; the first run only starts the clock
+	friday := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	result, err := svc.RunRecurring(templates, friday)
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Created)
+	require.Len(t, result.Templates, 2)
+	assert.Equal(t, RecurringStarted, result.Templates[0].Action)
+	assert.Equal(t, time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC), *result.Templates[0].NextRunAt)
+
+	// Not due before Monday 9:00
+	result, err = svc.RunRecurring(templates[:1], friday.Add(48*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, RecurringWaiting, result.Templates[0].Action)
+
+	// Three weeks later, three missed Mondays create one ticket
+	later := friday.Add(21 * 24 * time.Hour)
+	result, err = svc.RunRecurring(templates, later)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Created)
+	assert.Equal(t, 1, result.Failed)
+	audit := result.Templates[0]
+	assert.Equal(t, RecurringCreated, audit.Action)
+	assert.Equal(t, "REC-1", audit.Ticket)
+	assert.Equal(t, time.Date(2026, 11, 9, 9, 0, 0, 0, time.UTC), *audit.NextRunAt)
+	assert.Equal(t, RecurringFailed, result.Templates[1].Action)
+	assert.Contains(t, result.Templates[1].Error, "recurring ticket missing: project NOPE not found")
+
+	ticket, err := ticketRepo.GetByKey("REC", 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Audit dependencies", ticket.Title)
+	assert.Equal(t, "Run the audit", ticket.Description)
+	assert.Equal(t, models.ComplexitySmall, ticket.Complexity)
+	assert.Equal(t, models.StatusReady, ticket.Status)
+
+	// Running again at the same time is a no-op
+	result, err = svc.RunRecurring(templates[:1], later)
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Created)
+
+	statuses, err := svc.RecurringStatuses(templates[:1], later)
+	require.NoError(t, err)
+	assert.Equal(t, "REC-1", statuses[0].LastTicket)
+	assert.Equal(t, later, *statuses[0].LastRunAt)
+	assert.Empty(t, statuses[0].Action)
+
+	tickets, err := ticketRepo.List(db.TicketFilter{ProjectKey: "REC"})
+	require.NoError(t, err)
+	assert.Len(t, tickets, 1)
+}
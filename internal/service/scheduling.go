@@ -0,0 +1,249 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spetersoncode/wark/internal/db"
+	"github.com/spetersoncode/wark/internal/models"
+)
+
+// SchedulingPolicy decides which workable ticket ClaimNext picks first.
+type SchedulingPolicy string
+
+// Scheduling policies
+const (
+	// PolicyPriority picks the highest priority ticket, oldest first.
+	PolicyPriority SchedulingPolicy = "priority"
+	// PolicyAging raises a ticket's priority by one level for every aging
+	// interval it has waited, so low priority work is not starved.
+	PolicyAging SchedulingPolicy = "aging"
+	// PolicyImpact picks the ticket that transitively blocks the most open work.
+	PolicyImpact SchedulingPolicy = "impact"
+	// PolicySmallest picks the least complex ticket.
+	PolicySmallest SchedulingPolicy = "smallest"
+	// PolicyRoundRobin picks from the project whose tickets were claimed least
+	// recently, so every project gets a turn.
+	PolicyRoundRobin SchedulingPolicy = "round-robin"
+)
+
+// DefaultAgingInterval is how long a ticket waits under PolicyAging before it
+// gains a priority level.
+const DefaultAgingInterval = 24 * time.Hour
+
+// overdueBoost is the priority levels an overdue ticket gains, matching the
+// order ListWorkable returns candidates in.
+const overdueBoost = 1
+
+// maxAgingBoost caps the levels a ticket can gain by waiting, so that the
+// lowest priority ticket at most draws level with the highest.
+const maxAgingBoost = 4
+
+// SchedulingPolicies lists the policies in the order they are documented.
+func SchedulingPolicies() []SchedulingPolicy {
+	return []SchedulingPolicy{PolicyPriority, PolicyAging, PolicyImpact, PolicySmallest, PolicyRoundRobin}
+}
+
+// ParseSchedulingPolicy parses a policy name, case-insensitively. An empty
+// name is PolicyPriority.
+func ParseSchedulingPolicy(name string) (SchedulingPolicy, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return PolicyPriority, nil
+	}
+	for _, p := range SchedulingPolicies() {
+		if string(p) == name {
+			return p, nil
+		}
+	}
+	names := make([]string, 0, len(SchedulingPolicies()))
+	for _, p := range SchedulingPolicies() {
+		names = append(names, string(p))
+	}
+	return "", fmt.Errorf("invalid scheduling policy: %s (must be %s)", name, strings.Join(names, ", "))
+}
+
+// SchedulingOptions configures how ClaimNext and NextWorkable order candidates.
+type SchedulingOptions struct {
+	Policy SchedulingPolicy
+	// AgingInterval is used by PolicyAging; zero means DefaultAgingInterval.
+	AgingInterval time.Duration
+}
+
+// ScheduledTicket is a workable ticket with the score its policy gave it.
+// Higher scores are picked first.
+type ScheduledTicket struct {
+	Ticket *models.Ticket   `json:"ticket"`
+	Policy SchedulingPolicy `json:"policy"`
+	Score  float64          `json:"score"`
+	Reason string           `json:"reason"`
+}
+
+// policyScore is a candidate's score and a short explanation of it.
+type policyScore struct {
+	value  float64
+	reason string
+}
+
+// Schedule lists the workable tickets matching filter in the order the
+// service's policy would pick them, leaving out projects at their WIP limit.
+// Candidates with equal scores keep ListWorkable order (priority, then oldest
+// first), after the impact tiebreak if BreakTiesByImpact is set.
+func (s *TicketService) Schedule(filter db.TicketFilter) ([]*ScheduledTicket, error) {
+	policy := s.scheduling.Policy
+	if policy == "" {
+		policy = PolicyPriority
+	}
+	candidates, err := s.ticketRepo.ListWorkable(filter)
+	if err != nil {
+		return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to list workable tickets: %v", err), nil)
+	}
+	if candidates, err = s.withinWIPLimits(candidates); err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	scores, err := s.scoreCandidates(policy, candidates)
+	if err != nil {
+		return nil, err
+	}
+	impact := make(map[int64]int)
+	if s.impactTiebreak && len(candidates) > 1 {
+		g, err := s.loadOpenGraph()
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range candidates {
+			impact[t.ID] = g.dependentCount(t.ID)
+		}
+	}
+
+	scheduled := make([]*ScheduledTicket, len(candidates))
+	for i, t := range candidates {
+		scheduled[i] = &ScheduledTicket{Ticket: t, Policy: policy, Score: scores[i].value, Reason: scores[i].reason}
+	}
+	sort.SliceStable(scheduled, func(i, j int) bool {
+		a, b := scheduled[i], scheduled[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		return impact[a.Ticket.ID] > impact[b.Ticket.ID]
+	})
+	return scheduled, nil
+}
+
+func (s *TicketService) scoreCandidates(policy SchedulingPolicy, candidates []*models.Ticket) ([]policyScore, error) {
+	scores := make([]policyScore, len(candidates))
+	switch policy {
+	case PolicyPriority:
+		now := time.Now()
+		for i, t := range candidates {
+			scores[i] = policyScore{priorityScore(t, now), priorityReason(t, now)}
+		}
+
+	case PolicyAging:
+		interval := s.scheduling.AgingInterval
+		if interval <= 0 {
+			interval = DefaultAgingInterval
+		}
+		now := time.Now()
+		for i, t := range candidates {
+			waited := now.Sub(t.CreatedAt)
+			boost := float64(waited) / float64(interval)
+			if boost < 0 {
+				boost = 0
+			}
+			if boost > maxAgingBoost {
+				boost = maxAgingBoost
+			}
+			scores[i] = policyScore{priorityScore(t, now) + boost,
+				fmt.Sprintf("%s, +%.2f for waiting %s", priorityReason(t, now), boost, formatWait(waited))}
+		}
+
+	case PolicyImpact:
+		g, err := s.loadOpenGraph()
+		if err != nil {
+			return nil, err
+		}
+		for i, t := range candidates {
+			n := g.dependentCount(t.ID)
+			scores[i] = policyScore{float64(n), fmt.Sprintf("blocks %d open ticket(s)", n)}
+		}
+
+	case PolicySmallest:
+		for i, t := range candidates {
+			scores[i] = policyScore{float64(6 - t.Complexity.Order()), fmt.Sprintf("%s complexity", t.Complexity)}
+		}
+
+	case PolicyRoundRobin:
+		last, err := s.claimRepo.LastClaimByProject()
+		if err != nil {
+			return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to get last claims: %v", err), nil)
+		}
+		// Rank the candidates' projects: never claimed first, then least
+		// recently claimed, then by key
+		var projects []*models.Ticket
+		seen := make(map[int64]bool)
+		for _, t := range candidates {
+			if !seen[t.ProjectID] {
+				seen[t.ProjectID] = true
+				projects = append(projects, t)
+			}
+		}
+		sort.SliceStable(projects, func(i, j int) bool {
+			a, aOK := last[projects[i].ProjectID]
+			b, bOK := last[projects[j].ProjectID]
+			if aOK != bOK {
+				return !aOK
+			}
+			if !a.Equal(b) {
+				return a.Before(b)
+			}
+			return projects[i].ProjectKey < projects[j].ProjectKey
+		})
+		rank := make(map[int64]float64, len(projects))
+		for i, p := range projects {
+			rank[p.ProjectID] = float64(len(projects) - i)
+		}
+		for i, t := range candidates {
+			reason := fmt.Sprintf("%s not picked yet", t.ProjectKey)
+			if at, ok := last[t.ProjectID]; ok {
+				reason = fmt.Sprintf("%s last picked %s ago", t.ProjectKey, formatWait(time.Since(at)))
+			}
+			scores[i] = policyScore{rank[t.ProjectID], reason}
+		}
+
+	default:
+		return nil, newTicketError(ErrCodeInvalidInput, fmt.Sprintf("invalid scheduling policy: %s", policy), nil)
+	}
+	return scores, nil
+}
+
+// priorityScore maps highest priority to 5 and lowest to 1, raised by
+// overdueBoost if the ticket is overdue.
+func priorityScore(t *models.Ticket, now time.Time) float64 {
+	score := float64(6 - t.Priority.Order())
+	if t.IsOverdue(now) {
+		score += overdueBoost
+	}
+	return score
+}
+
+func priorityReason(t *models.Ticket, now time.Time) string {
+	if t.IsOverdue(now) {
+		return fmt.Sprintf("%s priority, overdue", t.Priority)
+	}
+	return fmt.Sprintf("%s priority", t.Priority)
+}
+
+// formatWait formats a duration in whole hours, or minutes under an hour.
+func formatWait(d time.Duration) string {
+	if d < time.Hour {
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	return fmt.Sprintf("%dh", int(d.Hours()))
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spetersoncode/wark/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSchedulingPolicy(t *testing.T) {
+	p, err := ParseSchedulingPolicy("")
+	require.NoError(t, err)
+	assert.Equal(t, PolicyPriority, p)
+
+	p, err = ParseSchedulingPolicy(" Round-Robin ")
+	require.NoError(t, err)
+	assert.Equal(t, PolicyRoundRobin, p)
+
+	_, err = ParseSchedulingPolicy("fifo")
+	assert.ErrorContains(t, err, "invalid scheduling policy: fifo")
+}
+
+func TestTicketService_SchedulingPolicies(t *testing.T) {
+	database, _, cleanup := testDB(t)
+	defer cleanup()
+	createTicketTestProject(t, database, "SCH")
+	svc := NewTicketService(database.DB)
+	ticketRepo := db.NewTicketRepo(database.DB)
+
+	for _, input := range []CreateTicketInput{
+		{Title: "Old and low", Priority: "low", Complexity: "large"},
+		{Title: "Urgent", Priority: "high"},
+		{Title: "Tiny", Complexity: "trivial"},
+		{Title: "Blocker", Priority: "low", Complexity: "small"},
+		{Title: "Blocked", DependsOn: []string{"SCH-4"}},
+	} {
+		input.ProjectKey = "SCH"
+		_, err := svc.Create(input)
+		require.NoError(t, err)
+	}
+	for _, n := range []int{1, 2, 3, 4} {
+		require.NoError(t, svc.Prioritize(mustTicketID(t, ticketRepo, "SCH", n)))
+	}
+	_, err := database.Exec("UPDATE tickets SET created_at = ? WHERE id = ?",
+		db.FormatTime(time.Now().Add(-72*time.Hour)), mustTicketID(t, ticketRepo, "SCH", 1))
+	require.NoError(t, err)
+
+	next := func(opts SchedulingOptions) *ScheduledTicket {
+		t.Helper()
+		scheduled, err := svc.WithScheduling(opts).NextScheduled(db.TicketFilter{ProjectKey: "SCH"})
+		require.NoError(t, err)
+		require.NotNil(t, scheduled)
+		assert.Equal(t, opts.Policy, scheduled.Policy)
+		return scheduled
+	}
+
+	got := next(SchedulingOptions{Policy: PolicyPriority})
+	assert.Equal(t, "SCH-2", got.Ticket.TicketKey)
+	assert.Equal(t, 4.0, got.Score)
+	assert.Equal(t, "high priority", got.Reason)
+
+	// Three days at 24h per level lifts low (2) above high (4)
+	got = next(SchedulingOptions{Policy: PolicyAging, AgingInterval: 24 * time.Hour})
+	assert.Equal(t, "SCH-1", got.Ticket.TicketKey)
+	assert.InDelta(t, 5.0, got.Score, 0.01)
+	assert.Contains(t, got.Reason, "waiting 72h")
+
+	// With a longer interval it has not waited enough
+	got = next(SchedulingOptions{Policy: PolicyAging, AgingInterval: 7 * 24 * time.Hour})
+	assert.Equal(t, "SCH-2", got.Ticket.TicketKey)
+
+	got = next(SchedulingOptions{Policy: PolicyImpact})
+	assert.Equal(t, "SCH-4", got.Ticket.TicketKey)
+	assert.Equal(t, "blocks 1 open ticket(s)", got.Reason)
+
+	got = next(SchedulingOptions{Policy: PolicySmallest})
+	assert.Equal(t, "SCH-3", got.Ticket.TicketKey)
+	assert.Equal(t, "trivial complexity", got.Reason)
+
+	// The whole list is ordered by score, ties in priority order
+	scheduled, err := svc.WithScheduling(SchedulingOptions{Policy: PolicySmallest}).Schedule(db.TicketFilter{ProjectKey: "SCH"})
+	require.NoError(t, err)
+	keys := make([]string, len(scheduled))
+	for i, s := range scheduled {
+		keys[i] = s.Ticket.TicketKey
+	}
+	assert.Equal(t, []string{"SCH-3", "SCH-4", "SCH-2", "SCH-1"}, keys)
+}
+
+func TestTicketService_RoundRobin(t *testing.T) {
+	database, _, cleanup := testDB(t)
+	defer cleanup()
+	createTicketTestProject(t, database, "AAA")
+	createTicketTestProject(t, database, "BBB")
+	svc := NewTicketService(database.DB).WithScheduling(SchedulingOptions{Policy: PolicyRoundRobin})
+
+	for _, key := range []string{"AAA", "AAA", "AAA", "BBB", "BBB"} {
+		ticket, err := svc.Create(CreateTicketInput{ProjectKey: key, Title: "Work"})
+		require.NoError(t, err)
+		require.NoError(t, svc.Prioritize(ticket.ID))
+	}
+
+	// Neither project has been picked, so the first by key goes first
+	scheduled, err := svc.NextScheduled(db.TicketFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, "AAA-1", scheduled.Ticket.TicketKey)
+	assert.Equal(t, "AAA not picked yet", scheduled.Reason)
+
+	var claimed []string
+	for i := 0; i < 4; i++ {
+		result, err := svc.ClaimNext(db.TicketFilter{}, "agent", time.Hour)
+		require.NoError(t, err)
+		claimed = append(claimed, result.Ticket.TicketKey)
+		// Claims are timestamped to the second; keep them apart
+		_, err = database.Exec("UPDATE claims SET claimed_at = ? WHERE id = ?",
+			db.FormatTime(time.Now().Add(time.Duration(i-10)*time.Minute)), result.Claim.ID)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []string{"AAA-1", "BBB-1", "AAA-2", "BBB-2"}, claimed)
+
+	last, err := db.NewClaimRepo(database.DB).LastClaimByProject()
+	require.NoError(t, err)
+	assert.Len(t, last, 2)
+
+	scheduled, err = svc.NextScheduled(db.TicketFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, "AAA-3", scheduled.Ticket.TicketKey)
+	assert.Contains(t, scheduled.Reason, "AAA last picked")
+}
@@ -2,6 +2,7 @@
 package service
 
 import (
+	"sort"
 	"strings"
 	"time"
 
@@ -16,6 +17,8 @@ type StatusService struct {
 	inboxRepo    *db.InboxRepo
 	claimRepo    *db.ClaimRepo
 	activityRepo *db.ActivityRepo
+	projectRepo  *db.ProjectRepo
+	slaPolicy    *SLAPolicy
 }
 
 // NewStatusService creates a new StatusService.
@@ -28,6 +31,22 @@ func NewStatusService(ticketRepo *db.TicketRepo, inboxRepo *db.InboxRepo, claimR
 	}
 }
 
+// WithProjectRepo returns a copy of the service that also reports WIP usage
+// for projects with WIP limits.
+func (s *StatusService) WithProjectRepo(projectRepo *db.ProjectRepo) *StatusService {
+	withProjects := *s
+	withProjects.projectRepo = projectRepo
+	return &withProjects
+}
+
+// WithSLAPolicy returns a copy of the service that reports pending inbox
+// messages past their response SLA.
+func (s *StatusService) WithSLAPolicy(policy *SLAPolicy) *StatusService {
+	withSLA := *s
+	withSLA.slaPolicy = policy
+	return &withSLA
+}
+
 // ExpiringSoonItem represents a claim that will expire soon.
 type ExpiringSoonItem struct {
 	TicketKey   string    `json:"ticket_key"`
@@ -36,6 +55,27 @@ type ExpiringSoonItem struct {
 	MinutesLeft int       `json:"minutes_left"`
 }
 
+// OverdueItem represents an open ticket past its due date.
+type OverdueItem struct {
+	TicketKey string    `json:"ticket_key"`
+	Title     string    `json:"title"`
+	Status    string    `json:"status"`
+	Priority  string    `json:"priority"`
+	DueAt     time.Time `json:"due_at"`
+	Age       string    `json:"age"`
+}
+
+// OverdueMessageItem represents a pending inbox message past its response SLA.
+type OverdueMessageItem struct {
+	MessageID   int64     `json:"message_id"`
+	TicketKey   string    `json:"ticket_key"`
+	MessageType string    `json:"message_type"`
+	Content     string    `json:"content"`
+	DueAt       time.Time `json:"due_at"`
+	Age         string    `json:"age"`
+	Escalated   bool      `json:"escalated"`
+}
+
 // ActivityItem represents a recent activity entry.
 type ActivityItem struct {
 	TicketKey string `json:"ticket_key"`
@@ -46,15 +86,18 @@ type ActivityItem struct {
 
 // StatusSummary contains aggregated status counts and lists.
 type StatusSummary struct {
-	Workable       int                `json:"workable"`
-	Working     int                `json:"working"`
-	Review         int                `json:"review"`
-	BlockedDeps    int                `json:"blocked_deps"`
-	BlockedHuman   int                `json:"blocked_human"`
-	PendingInbox   int                `json:"pending_inbox"`
-	ExpiringSoon   []ExpiringSoonItem `json:"expiring_soon"`
-	RecentActivity []ActivityItem     `json:"recent_activity"`
-	ProjectKey     string             `json:"project_key,omitempty"`
+	Workable       int                  `json:"workable"`
+	Working        int                  `json:"working"`
+	Review         int                  `json:"review"`
+	BlockedDeps    int                  `json:"blocked_deps"`
+	BlockedHuman   int                  `json:"blocked_human"`
+	PendingInbox   int                  `json:"pending_inbox"`
+	ExpiringSoon   []ExpiringSoonItem   `json:"expiring_soon"`
+	Overdue        []OverdueItem        `json:"overdue"`
+	OverdueInbox   []OverdueMessageItem `json:"overdue_inbox"`
+	RecentActivity []ActivityItem       `json:"recent_activity"`
+	WIP            []ProjectWIP         `json:"wip,omitempty"`
+	ProjectKey     string               `json:"project_key,omitempty"`
 }
 
 // GetSummary returns an aggregated status summary for the given project key.
@@ -63,6 +106,8 @@ func (s *StatusService) GetSummary(projectKey string) (*StatusSummary, error) {
 	summary := &StatusSummary{
 		ProjectKey:     strings.ToUpper(projectKey),
 		ExpiringSoon:   []ExpiringSoonItem{},
+		Overdue:        []OverdueItem{},
+		OverdueInbox:   []OverdueMessageItem{},
 		RecentActivity: []ActivityItem{},
 	}
 
@@ -124,6 +169,26 @@ func (s *StatusService) GetSummary(projectKey string) (*StatusSummary, error) {
 	}
 	if pending, err := s.inboxRepo.List(inboxFilter); err == nil {
 		summary.PendingInbox = len(pending)
+
+		// Messages past their SLA, most overdue first
+		s.slaPolicy.Apply(pending, time.Now())
+		sort.SliceStable(pending, func(i, j int) bool {
+			return pending[i].DueAt != nil && (pending[j].DueAt == nil || pending[i].DueAt.Before(*pending[j].DueAt))
+		})
+		for _, m := range pending {
+			if !m.Overdue {
+				continue
+			}
+			summary.OverdueInbox = append(summary.OverdueInbox, OverdueMessageItem{
+				MessageID:   m.ID,
+				TicketKey:   m.TicketKey,
+				MessageType: string(m.MessageType),
+				Content:     m.Content,
+				DueAt:       *m.DueAt,
+				Age:         common.FormatAge(*m.DueAt),
+				Escalated:   m.EscalatedAt != nil,
+			})
+		}
 	}
 
 	// Get claims expiring soon (within 30 minutes)
@@ -143,6 +208,25 @@ func (s *StatusService) GetSummary(projectKey string) (*StatusSummary, error) {
 		}
 	}
 
+	// Get open tickets past their due date, most overdue first
+	if overdue, err := s.ticketRepo.ListOverdue(summary.ProjectKey, time.Now()); err == nil {
+		for _, t := range overdue {
+			summary.Overdue = append(summary.Overdue, OverdueItem{
+				TicketKey: t.TicketKey,
+				Title:     t.Title,
+				Status:    string(t.Status),
+				Priority:  string(t.Priority),
+				DueAt:     *t.DueAt,
+				Age:       common.FormatAge(*t.DueAt),
+			})
+		}
+	}
+
+	// WIP usage for projects with limits
+	if wip, err := s.WIPUsage(summary.ProjectKey); err == nil {
+		summary.WIP = wip
+	}
+
 	// Get recent activity
 	activityFilter := db.ActivityFilter{
 		Limit: 5,
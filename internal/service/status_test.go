@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/spetersoncode/wark/internal/common"
+	"github.com/spetersoncode/wark/internal/config"
 	"github.com/spetersoncode/wark/internal/db"
 	"github.com/spetersoncode/wark/internal/models"
 	"github.com/stretchr/testify/assert"
@@ -242,6 +243,81 @@ func TestStatusService_ExpiringSoon(t *testing.T) {
 	assert.Equal(t, "worker-1", summary.ExpiringSoon[0].WorkerID)
 }
 
+func TestStatusService_Overdue(t *testing.T) {
+	database, _, cleanup := testDB(t)
+	defer cleanup()
+
+	projectRepo := db.NewProjectRepo(database.DB)
+	ticketRepo := db.NewTicketRepo(database.DB)
+
+	project := &models.Project{Key: "TEST", Name: "Test"}
+	require.NoError(t, projectRepo.Create(project))
+
+	completed := models.ResolutionCompleted
+	lastWeek := time.Now().Add(-7 * 24 * time.Hour)
+	yesterday := time.Now().Add(-24 * time.Hour)
+	tomorrow := time.Now().Add(24 * time.Hour)
+	for _, ticket := range []*models.Ticket{
+		{ProjectID: project.ID, Title: "Due yesterday", Status: models.StatusWorking, DueAt: &yesterday},
+		{ProjectID: project.ID, Title: "Due last week", Status: models.StatusReady, DueAt: &lastWeek},
+		{ProjectID: project.ID, Title: "Due tomorrow", Status: models.StatusReady, DueAt: &tomorrow},
+		{ProjectID: project.ID, Title: "Closed late", Status: models.StatusClosed, Resolution: &completed, DueAt: &lastWeek},
+	} {
+		require.NoError(t, ticketRepo.Create(ticket))
+	}
+
+	statusService := NewStatusService(ticketRepo, db.NewInboxRepo(database.DB),
+		db.NewClaimRepo(database.DB), db.NewActivityRepo(database.DB))
+	summary, err := statusService.GetSummary("")
+	require.NoError(t, err)
+
+	require.Len(t, summary.Overdue, 2)
+	assert.Equal(t, "TEST-2", summary.Overdue[0].TicketKey, "most overdue first")
+	assert.Equal(t, "7d ago", summary.Overdue[0].Age)
+	assert.Equal(t, "TEST-1", summary.Overdue[1].TicketKey)
+	assert.Equal(t, "working", summary.Overdue[1].Status)
+
+	summary, err = statusService.GetSummary("OTHER")
+	require.NoError(t, err)
+	assert.Empty(t, summary.Overdue)
+}
+
+func TestStatusService_OverdueInbox(t *testing.T) {
+	database, _, cleanup := testDB(t)
+	defer cleanup()
+
+	projectRepo := db.NewProjectRepo(database.DB)
+	ticketRepo := db.NewTicketRepo(database.DB)
+	inboxRepo := db.NewInboxRepo(database.DB)
+
+	project := &models.Project{Key: "TEST", Name: "Test"}
+	require.NoError(t, projectRepo.Create(project))
+	ticket := &models.Ticket{ProjectID: project.ID, Title: "Waiting", Status: models.StatusHuman}
+	require.NoError(t, ticketRepo.Create(ticket))
+
+	for _, content := range []string{"Asked this morning", "Asked just now"} {
+		require.NoError(t, inboxRepo.Create(models.NewInboxMessage(ticket.ID, models.MessageTypeQuestion, content, "agent-1")))
+	}
+	_, err := database.Exec(`UPDATE inbox_messages SET created_at = ? WHERE id = 1`, db.FormatTime(time.Now().Add(-3*time.Hour)))
+	require.NoError(t, err)
+
+	statusService := NewStatusService(ticketRepo, inboxRepo, db.NewClaimRepo(database.DB), db.NewActivityRepo(database.DB))
+	summary, err := statusService.GetSummary("")
+	require.NoError(t, err)
+	assert.Equal(t, 2, summary.PendingInbox)
+	assert.Empty(t, summary.OverdueInbox, "no SLAs configured")
+
+	policy, err := ParseSLAPolicy([]config.InboxSLAConfig{{Type: "question", Minutes: 60}})
+	require.NoError(t, err)
+	summary, err = statusService.WithSLAPolicy(policy).GetSummary("")
+	require.NoError(t, err)
+	require.Len(t, summary.OverdueInbox, 1)
+	assert.Equal(t, int64(1), summary.OverdueInbox[0].MessageID)
+	assert.Equal(t, "TEST-1", summary.OverdueInbox[0].TicketKey)
+	assert.Equal(t, "2h ago", summary.OverdueInbox[0].Age)
+	assert.False(t, summary.OverdueInbox[0].Escalated)
+}
+
 func TestFormatAge(t *testing.T) {
 	tests := []struct {
 		name     string
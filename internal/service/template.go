@@ -0,0 +1,125 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spetersoncode/wark/internal/models"
+)
+
+// CreateTemplateInput holds the fields for a new ticket template. Empty
+// priority, complexity and role leave those of tickets created from it alone.
+type CreateTemplateInput struct {
+	Name        string
+	Description string
+	Priority    string
+	Complexity  string
+	RoleName    string
+	Tasks       []string
+}
+
+// CreateTemplate creates a ticket template. The description is checked as a
+// text/template but not rendered until a ticket is created from it.
+func (s *TicketService) CreateTemplate(input CreateTemplateInput) (*models.TicketTemplate, error) {
+	t := &models.TicketTemplate{
+		Name:        input.Name,
+		Description: input.Description,
+		Tasks:       input.Tasks,
+	}
+	if input.Priority != "" {
+		p, err := models.ParsePriority(input.Priority)
+		if err != nil {
+			return nil, newTicketError(ErrCodeInvalidInput, err.Error(), nil)
+		}
+		t.Priority = p
+	}
+	if input.Complexity != "" {
+		c, err := models.ParseComplexity(input.Complexity)
+		if err != nil {
+			return nil, newTicketError(ErrCodeInvalidInput, err.Error(), nil)
+		}
+		t.Complexity = c
+	}
+	if err := t.Validate(); err != nil {
+		return nil, newTicketError(ErrCodeInvalidInput, err.Error(), nil)
+	}
+
+	existing, err := s.templateRepo.GetByName(t.Name)
+	if err != nil {
+		return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to check template: %v", err), nil)
+	}
+	if existing != nil {
+		return nil, newTicketError(ErrCodeInvalidState, fmt.Sprintf("template %s already exists", t.Name), nil)
+	}
+
+	if input.RoleName != "" {
+		role, err := s.roleRepo.GetByName(input.RoleName)
+		if err != nil {
+			return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to get role: %v", err), nil)
+		}
+		if role == nil {
+			return nil, newTicketError(ErrCodeNotFound, fmt.Sprintf("role '%s' not found", input.RoleName), nil)
+		}
+		t.RoleID = &role.ID
+		t.RoleName = role.Name
+	}
+
+	if err := s.templateRepo.Create(t); err != nil {
+		return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to create template: %v", err), nil)
+	}
+	return t, nil
+}
+
+// GetTemplate returns the ticket template with the given name.
+func (s *TicketService) GetTemplate(name string) (*models.TicketTemplate, error) {
+	t, err := s.templateRepo.GetByName(strings.ToLower(name))
+	if err != nil {
+		return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to get template: %v", err), nil)
+	}
+	if t == nil {
+		return nil, newTicketError(ErrCodeNotFound, fmt.Sprintf("template %s not found", name), nil)
+	}
+	return t, nil
+}
+
+// ListTemplates returns all ticket templates ordered by name.
+func (s *TicketService) ListTemplates() ([]*models.TicketTemplate, error) {
+	templates, err := s.templateRepo.List()
+	if err != nil {
+		return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to list templates: %v", err), nil)
+	}
+	return templates, nil
+}
+
+// applyTemplate fills the input's empty description, priority, complexity
+// and role from its template, and returns the template's tasks.
+func (s *TicketService) applyTemplate(input *CreateTicketInput) ([]string, error) {
+	if input.Template == "" {
+		if len(input.Vars) > 0 {
+			return nil, newTicketError(ErrCodeInvalidInput, "template variables need a template", nil)
+		}
+		return nil, nil
+	}
+
+	t, err := s.GetTemplate(input.Template)
+	if err != nil {
+		return nil, err
+	}
+	if input.Description == "" {
+		description, err := t.Render(input.Vars)
+		if err != nil {
+			return nil, newTicketError(ErrCodeInvalidInput, err.Error(), nil)
+		}
+		input.Description = description
+	}
+	if input.Priority == "" {
+		input.Priority = string(t.Priority)
+	}
+	if input.Complexity == "" {
+		input.Complexity = string(t.Complexity)
+	}
+	if input.RoleName == "" {
+		input.RoleName = t.RoleName
+	}
+	return t.Tasks, nil
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spetersoncode/wark/internal/db"
+	"github.com/spetersoncode/wark/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTicketService_Templates(t *testing.T) {
+	database, _, cleanup := testDB(t)
+	defer cleanup()
+	createTicketTestProject(t, database, "TPL")
+	require.NoError(t, db.NewRoleRepo(database.DB).Create(&models.Role{Name: "worker", Description: "Does the work", Instructions: "Do it"}))
+	svc := NewTicketService(database.DB)
+
+	tmpl, err := svc.CreateTemplate(CreateTemplateInput{
+		Name:        "bug",
+		Description: "Component: {{.component}}",
+		Priority:    "high",
+		Complexity:  "small",
+		RoleName:    "worker",
+		Tasks:       []string{"Reproduce", "Write a failing test", "Fix"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "worker", tmpl.RoleName)
+
+	_, err = svc.CreateTemplate(CreateTemplateInput{Name: "bug"})
+	requireTicketErrorCode(t, err, ErrCodeInvalidState)
+	_, err = svc.CreateTemplate(CreateTemplateInput{Name: "spike", Description: "{{.broken"})
+	requireTicketErrorCode(t, err, ErrCodeInvalidInput)
+	_, err = svc.CreateTemplate(CreateTemplateInput{Name: "spike", RoleName: "nobody"})
+	requireTicketErrorCode(t, err, ErrCodeNotFound)
+
+	templates, err := svc.ListTemplates()
+	require.NoError(t, err)
+	require.Len(t, templates, 1)
+	assert.Equal(t, []string{"Reproduce", "Write a failing test", "Fix"}, templates[0].Tasks)
+
+	ticket, err := svc.Create(CreateTicketInput{
+		ProjectKey: "TPL",
+		Title:      "Login fails",
+		Template:   "bug",
+		Vars:       map[string]string{"component": "auth"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Component: auth", ticket.Description)
+	assert.Equal(t, models.PriorityHigh, ticket.Priority)
+	assert.Equal(t, models.ComplexitySmall, ticket.Complexity)
+	require.NotNil(t, ticket.RoleID)
+	assert.Equal(t, *tmpl.RoleID, *ticket.RoleID)
+	tasks, err := db.NewTasksRepo(database.DB).ListTasks(context.Background(), ticket.ID)
+	require.NoError(t, err)
+	require.Len(t, tasks, 3)
+	assert.Equal(t, "Reproduce", tasks[0].Description)
+
+	// Fields given explicitly win over the template
+	ticket, err = svc.Create(CreateTicketInput{
+		ProjectKey:  "TPL",
+		Title:       "Crash",
+		Description: "Stack trace attached",
+		Priority:    "low",
+		Template:    "bug",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Stack trace attached", ticket.Description)
+	assert.Equal(t, models.PriorityLow, ticket.Priority)
+	assert.Equal(t, models.ComplexitySmall, ticket.Complexity)
+
+	// A variable the description needs must be given, and nothing is created
+	_, err = svc.Create(CreateTicketInput{ProjectKey: "TPL", Title: "No vars", Template: "bug"})
+	requireTicketErrorCode(t, err, ErrCodeInvalidInput)
+	_, err = svc.Create(CreateTicketInput{ProjectKey: "TPL", Title: "Unknown", Template: "feature"})
+	requireTicketErrorCode(t, err, ErrCodeNotFound)
+	_, err = svc.Create(CreateTicketInput{ProjectKey: "TPL", Title: "Vars only", Vars: map[string]string{"a": "b"}})
+	requireTicketErrorCode(t, err, ErrCodeInvalidInput)
+
+	tickets, err := db.NewTicketRepo(database.DB).List(db.TicketFilter{ProjectKey: "TPL"})
+	require.NoError(t, err)
+	assert.Len(t, tickets, 2)
+}
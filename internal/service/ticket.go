@@ -4,6 +4,7 @@ package service
 import (
 	"context"
 	"database/sql"
+	stderrors "errors"
 	"fmt"
 	"strings"
 	"time"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/spetersoncode/wark/internal/config"
 	"github.com/spetersoncode/wark/internal/db"
+	"github.com/spetersoncode/wark/internal/errors"
 	"github.com/spetersoncode/wark/internal/models"
 	"github.com/spetersoncode/wark/internal/state"
 	"github.com/spetersoncode/wark/internal/tasks"
@@ -20,32 +22,85 @@ import (
 // It coordinates between repositories, state machine, and dependency resolver
 // to implement claim, release, complete, accept, reject, flag, close, reopen, and promote operations.
 type TicketService struct {
-	db           *sql.DB
-	ticketRepo   *db.TicketRepo
-	claimRepo    *db.ClaimRepo
-	depRepo      *db.DependencyRepo
-	tasksRepo    *db.TasksRepo
-	activityRepo *db.ActivityRepo
-	inboxRepo    *db.InboxRepo
-	depResolver  *tasks.DependencyResolver
-	stateMachine *state.Machine
+	db            *sql.DB
+	ticketRepo    *db.TicketRepo
+	claimRepo     *db.ClaimRepo
+	depRepo       *db.DependencyRepo
+	tasksRepo     *db.TasksRepo
+	activityRepo  *db.ActivityRepo
+	inboxRepo     *db.InboxRepo
+	projectRepo   *db.ProjectRepo
+	roleRepo      *db.RoleRepo
+	milestoneRepo *db.MilestoneRepo
+	labelRepo     *db.LabelRepo
+	recurringRepo *db.RecurringRepo
+	templateRepo  *db.TemplateRepo
+	depResolver   *tasks.DependencyResolver
+	stateMachine  *state.Machine
+
+	// workerID and force identify the caller for claim ownership checks;
+	// see ActingAs.
+	workerID string
+	force    bool
+
+	// impactTiebreak orders equal-priority candidates in ClaimNext and
+	// NextWorkable by how much open work they block; see BreakTiesByImpact.
+	impactTiebreak bool
+
+	// scheduling picks the policy ClaimNext and NextWorkable order
+	// candidates by; see WithScheduling.
+	scheduling SchedulingOptions
 }
 
 // NewTicketService creates a new TicketService with all required dependencies.
 func NewTicketService(database *sql.DB) *TicketService {
 	return &TicketService{
-		db:           database,
-		ticketRepo:   db.NewTicketRepo(database),
-		claimRepo:    db.NewClaimRepo(database),
-		depRepo:      db.NewDependencyRepo(database),
-		tasksRepo:    db.NewTasksRepo(database),
-		activityRepo: db.NewActivityRepo(database),
-		inboxRepo:    db.NewInboxRepo(database),
-		depResolver:  tasks.NewDependencyResolver(database),
-		stateMachine: state.NewMachine(),
+		db:            database,
+		ticketRepo:    db.NewTicketRepo(database),
+		claimRepo:     db.NewClaimRepo(database),
+		depRepo:       db.NewDependencyRepo(database),
+		tasksRepo:     db.NewTasksRepo(database),
+		activityRepo:  db.NewActivityRepo(database),
+		inboxRepo:     db.NewInboxRepo(database),
+		projectRepo:   db.NewProjectRepo(database),
+		roleRepo:      db.NewRoleRepo(database),
+		milestoneRepo: db.NewMilestoneRepo(database),
+		labelRepo:     db.NewLabelRepo(database),
+		recurringRepo: db.NewRecurringRepo(database),
+		templateRepo:  db.NewTemplateRepo(database),
+		depResolver:   tasks.NewDependencyResolver(database),
+		stateMachine:  state.NewMachine(),
 	}
 }
 
+// ActingAs returns a copy of the service that performs claim operations on
+// behalf of workerID. Release, Complete and Heartbeat then refuse a claim
+// held by a different worker unless force is set. An empty workerID only
+// matches claims that have no worker.
+func (s *TicketService) ActingAs(workerID string, force bool) *TicketService {
+	acting := *s
+	acting.workerID = workerID
+	acting.force = force
+	return &acting
+}
+
+// BreakTiesByImpact returns a copy of the service whose ClaimNext and
+// NextWorkable prefer, among tickets of equal priority, the one that
+// transitively blocks the most open tickets.
+func (s *TicketService) BreakTiesByImpact(enabled bool) *TicketService {
+	ranked := *s
+	ranked.impactTiebreak = enabled
+	return &ranked
+}
+
+// WithScheduling returns a copy of the service whose ClaimNext and
+// NextWorkable order candidates by opts.Policy instead of strict priority.
+func (s *TicketService) WithScheduling(opts SchedulingOptions) *TicketService {
+	scheduled := *s
+	scheduled.scheduling = opts
+	return &scheduled
+}
+
 // ClaimResult contains the result of claiming a ticket.
 type ClaimResult struct {
 	Ticket     *models.Ticket     `json:"ticket"`
@@ -81,27 +136,106 @@ func (e *TicketError) Error() string {
 	return e.Message
 }
 
+// Kind maps the error code to the shared error kind, which determines the
+// CLI exit code and HTTP status.
+func (e *TicketError) Kind() errors.Kind {
+	switch e.Code {
+	case ErrCodeNotFound:
+		return errors.KindNotFound
+	case ErrCodeInvalidState, ErrCodeUnresolvedDeps, ErrCodeIncompleteTasks:
+		return errors.KindStateError
+	case ErrCodeAlreadyClaimed, ErrCodeClaimNotOwned:
+		return errors.KindConcurrentConflict
+	case ErrCodeWIPLimit:
+		return errors.KindWIPLimit
+	case ErrCodeInvalidReason, ErrCodeInvalidResolution, ErrCodeInvalidInput:
+		return errors.KindInvalidArgs
+	default:
+		return errors.KindInternal
+	}
+}
+
 // Error codes for ticket operations
 const (
 	ErrCodeNotFound           = "NOT_FOUND"
 	ErrCodeInvalidState       = "INVALID_STATE"
 	ErrCodeAlreadyClaimed     = "ALREADY_CLAIMED"
+	ErrCodeClaimNotOwned      = "CLAIM_NOT_OWNED"
 	ErrCodeUnresolvedDeps     = "UNRESOLVED_DEPS"
 	ErrCodeIncompleteTasks    = "INCOMPLETE_TASKS"
 	ErrCodeInvalidReason      = "INVALID_REASON"
 	ErrCodeInvalidResolution  = "INVALID_RESOLUTION"
+	ErrCodeInvalidInput       = "INVALID_INPUT"
 	ErrCodeDatabase           = "DATABASE_ERROR"
+	ErrCodeWIPLimit           = "WIP_LIMIT"
 )
 
 func newTicketError(code, message string, details map[string]interface{}) *TicketError {
 	return &TicketError{Code: code, Message: message, Details: details}
 }
 
-// Claim acquires a time-limited claim on a ticket.
+// errDryRun rolls back a dry run's transaction once every change has been
+// applied. Callers that return it from a transaction treat it as success.
+var errDryRun = stderrors.New("dry run")
+
+// inTx runs fn against a copy of the service whose repositories share a single
+// transaction, so an operation's reads, writes and activity log entries are
+// committed together or not at all. Ticket and shared errors, errDryRun and
+// errRecurringRaced are returned as they are; anything else is reported as a
+// database error.
+func (s *TicketService) inTx(fn func(tx *TicketService) error) error {
+	err := db.WithTx(s.db, func(tx *sql.Tx) error {
+		return fn(&TicketService{
+			db:            s.db,
+			ticketRepo:    db.NewTicketRepo(tx),
+			claimRepo:     db.NewClaimRepo(tx),
+			depRepo:       db.NewDependencyRepo(tx),
+			tasksRepo:     db.NewTasksRepo(tx),
+			activityRepo:  db.NewActivityRepo(tx),
+			inboxRepo:     db.NewInboxRepo(tx),
+			projectRepo:   db.NewProjectRepo(tx),
+			roleRepo:      db.NewRoleRepo(tx),
+			milestoneRepo: db.NewMilestoneRepo(tx),
+			labelRepo:     db.NewLabelRepo(tx),
+			recurringRepo: db.NewRecurringRepo(tx),
+			templateRepo:  db.NewTemplateRepo(tx),
+			depResolver:   tasks.NewDependencyResolver(tx),
+			stateMachine:  s.stateMachine,
+			workerID:      s.workerID,
+			force:         s.force,
+
+			impactTiebreak: s.impactTiebreak,
+			scheduling:     s.scheduling,
+		})
+	})
+	if err == nil || err == errDryRun || err == errRecurringRaced {
+		return err
+	}
+	switch err.(type) {
+	case *TicketError, *errors.Error:
+		return err
+	}
+	return newTicketError(ErrCodeDatabase, err.Error(), nil)
+}
+
+// Claim acquires a time-limited claim on a ticket for workerID.
 // The ticket must be in ready or review status. Review claims don't change ticket status.
 // Epics cannot be claimed directly - work through child tasks instead.
 // Returns ClaimResult with ticket, claim, worktree name, and task info.
-func (s *TicketService) Claim(ticketID int64, duration time.Duration) (*ClaimResult, error) {
+func (s *TicketService) Claim(ticketID int64, workerID string, duration time.Duration) (*ClaimResult, error) {
+	var result *ClaimResult
+	err := s.inTx(func(tx *TicketService) error {
+		var err error
+		result, err = tx.claim(ticketID, workerID, duration)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *TicketService) claim(ticketID int64, workerID string, duration time.Duration) (*ClaimResult, error) {
 	ticket, err := s.ticketRepo.GetByID(ticketID)
 	if err != nil {
 		return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to get ticket: %v", err), nil)
@@ -138,10 +272,15 @@ func (s *TicketService) Claim(ticketID int64, duration time.Duration) (*ClaimRes
 		return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to check existing claims: %v", err), nil)
 	}
 	if existingClaim != nil {
+		holder := existingClaim.WorkerID
+		if holder == "" {
+			holder = "claim " + existingClaim.ClaimID
+		}
 		return nil, newTicketError(ErrCodeAlreadyClaimed,
-			fmt.Sprintf("ticket is already claimed by %s (expires: %s)", existingClaim.WorkerID, existingClaim.ExpiresAt.Format("15:04:05")),
+			fmt.Sprintf("ticket is already claimed by %s (expires: %s)", holder, existingClaim.ExpiresAt.Format("15:04:05")),
 			map[string]interface{}{
 				"worker_id":  existingClaim.WorkerID,
+				"claim_id":   existingClaim.ClaimID,
 				"expires_at": existingClaim.ExpiresAt,
 			})
 	}
@@ -157,8 +296,100 @@ func (s *TicketService) Claim(ticketID int64, duration time.Duration) (*ClaimRes
 		}
 	}
 
+	// Move ready tickets to working with a conditional update so a concurrent
+	// claimer that got there first makes this one fail instead of both winning
+	if !isReviewClaim {
+		if err := s.checkWIPLimit(ticket, models.StatusWorking); err != nil {
+			return nil, err
+		}
+		ok, err := s.ticketRepo.TransitionStatus(ticket.ID, models.StatusReady, models.StatusWorking)
+		if err != nil {
+			return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to update ticket status: %v", err), nil)
+		}
+		if !ok {
+			return nil, newTicketError(ErrCodeAlreadyClaimed, "ticket already claimed", nil)
+		}
+		ticket.Status = models.StatusWorking
+	}
+
+	claimType := "Claimed"
+	if isReviewClaim {
+		claimType = "Claimed for review"
+	}
+	return s.acquireClaim(ticket, workerID, duration, isReviewClaim, claimType)
+}
+
+// ClaimNext picks the next workable ticket matching filter and claims it for
+// workerID in a single transaction. Candidates are ordered by the scheduling
+// policy; each is taken with a conditional ready → working update, so when
+// two workers race for the same ticket the loser moves on to the next
+// candidate. Returns a nil result when no ticket matches.
+func (s *TicketService) ClaimNext(filter db.TicketFilter, workerID string, duration time.Duration) (*ClaimResult, error) {
+	var result *ClaimResult
+	err := s.inTx(func(tx *TicketService) error {
+		var err error
+		result, err = tx.claimNext(filter, workerID, duration)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *TicketService) claimNext(filter db.TicketFilter, workerID string, duration time.Duration) (*ClaimResult, error) {
+	// Every candidate is needed in case the first ones are taken by others
+	filter.Limit = 0
+	candidates, err := s.Schedule(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, candidate := range candidates {
+		ticket := candidate.Ticket
+		ok, err := s.ticketRepo.TransitionStatus(ticket.ID, models.StatusReady, models.StatusWorking)
+		if err != nil {
+			return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to update ticket status: %v", err), nil)
+		}
+		if !ok {
+			continue
+		}
+		ticket.Status = models.StatusWorking
+		return s.acquireClaim(ticket, workerID, duration, false, "Claimed via 'ticket next'")
+	}
+
+	return nil, nil
+}
+
+// NextWorkable returns the ticket ClaimNext would pick for filter without
+// claiming it, or nil if none matches.
+func (s *TicketService) NextWorkable(filter db.TicketFilter) (*models.Ticket, error) {
+	next, err := s.NextScheduled(filter)
+	if err != nil || next == nil {
+		return nil, err
+	}
+	return next.Ticket, nil
+}
+
+// NextScheduled is NextWorkable with the policy's score for the ticket.
+func (s *TicketService) NextScheduled(filter db.TicketFilter) (*ScheduledTicket, error) {
+	// Every candidate is needed to rank them and skip projects at their WIP limit
+	filter.Limit = 0
+	scheduled, err := s.Schedule(filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(scheduled) == 0 {
+		return nil, nil
+	}
+	return scheduled[0], nil
+}
+
+// acquireClaim creates the claim row for a ticket whose status has already
+// been updated, logs the claim and assembles the ClaimResult.
+func (s *TicketService) acquireClaim(ticket *models.Ticket, workerID string, duration time.Duration, isReviewClaim bool, claimType string) (*ClaimResult, error) {
 	// Create claim (generates claim ID internally)
-	claim := models.NewClaim(ticket.ID, duration)
+	claim := models.NewClaim(ticket.ID, workerID, duration)
 	if err := s.claimRepo.Create(claim); err != nil {
 		// Handle race condition: another agent claimed between check and insert
 		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
@@ -167,36 +398,31 @@ func (s *TicketService) Claim(ticketID int64, duration time.Duration) (*ClaimRes
 		return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to create claim: %v", err), nil)
 	}
 
-	// Update ticket status (only for ready tickets, review stays at review)
-	if !isReviewClaim {
-		ticket.Status = models.StatusWorking
-		if err := s.ticketRepo.Update(ticket); err != nil {
-			// Rollback claim
-			s.claimRepo.Release(claim.ID, models.ClaimStatusReleased)
-			return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to update ticket status: %v", err), nil)
-		}
-	}
-
 	// Log activity with state transition details
-	claimType := "Claimed"
 	fromStatus := string(models.StatusReady)
 	toStatus := string(models.StatusWorking)
 	if isReviewClaim {
-		claimType = "Claimed for review"
 		fromStatus = string(models.StatusReview)
 		toStatus = string(models.StatusReview)
 	}
 	durationMins := int(duration.Minutes())
-	s.activityRepo.LogActionWithDetails(ticket.ID, models.ActionClaimed, models.ActorTypeAgent, claim.ClaimID,
+	actorID := workerID
+	if actorID == "" {
+		actorID = claim.ClaimID
+	}
+	if err := s.activityRepo.LogActionWithDetails(ticket.ID, models.ActionClaimed, models.ActorTypeAgent, actorID,
 		fmt.Sprintf("%s (expires in %dm)", claimType, durationMins),
 		map[string]interface{}{
 			"claim_id":      claim.ClaimID,
+			"worker_id":     workerID,
 			"duration_mins": durationMins,
 			"expires_at":    claim.ExpiresAt.Format(time.RFC3339),
 			"review_claim":  isReviewClaim,
 			"from_status":   fromStatus,
 			"to_status":     toStatus,
-		})
+		}); err != nil {
+		return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to log activity: %v", err), nil)
+	}
 
 	// Generate worktree name if needed
 	// For tasks with epic parent, inherit worktree from epic
@@ -231,10 +457,103 @@ func (s *TicketService) Claim(ticketID int64, duration time.Duration) (*ClaimRes
 	return result, nil
 }
 
+// HeartbeatResult contains the result of renewing a claim.
+type HeartbeatResult struct {
+	Ticket *models.Ticket `json:"ticket"`
+	Claim  *models.Claim  `json:"claim"`
+	Capped bool           `json:"capped"` // Expiry was limited by the max lifetime
+}
+
+// Heartbeat renews the active claim on a ticket so it expires extend from now.
+// A claim is never shortened. If maxLifetime is positive, the expiry is capped
+// at that long after the claim was taken, and a claim already at the cap can no
+// longer be renewed. The claim must be held by the acting worker (see ActingAs).
+func (s *TicketService) Heartbeat(ticketID int64, extend, maxLifetime time.Duration) (*HeartbeatResult, error) {
+	var result *HeartbeatResult
+	err := s.inTx(func(tx *TicketService) error {
+		var err error
+		result, err = tx.heartbeat(ticketID, extend, maxLifetime)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *TicketService) heartbeat(ticketID int64, extend, maxLifetime time.Duration) (*HeartbeatResult, error) {
+	ticket, err := s.ticketRepo.GetByID(ticketID)
+	if err != nil {
+		return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to get ticket: %v", err), nil)
+	}
+	if ticket == nil {
+		return nil, newTicketError(ErrCodeNotFound, "ticket not found", nil)
+	}
+
+	claim, err := s.claimRepo.GetActiveByTicketID(ticket.ID)
+	if err != nil {
+		return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to get claim: %v", err), nil)
+	}
+	if claim == nil {
+		return nil, newTicketError(ErrCodeInvalidState, "no active claim found for ticket", nil)
+	}
+	if err := s.checkClaimOwner(claim); err != nil {
+		return nil, err
+	}
+
+	previousExpiry := claim.ExpiresAt
+	expiresAt := time.Now().Add(extend)
+	capped := false
+	if maxLifetime > 0 {
+		limit := claim.ClaimedAt.Add(maxLifetime)
+		if expiresAt.After(limit) {
+			expiresAt = limit
+			capped = true
+		}
+	}
+	if !expiresAt.After(previousExpiry) {
+		if capped {
+			return nil, newTicketError(ErrCodeInvalidState,
+				fmt.Sprintf("claim has reached its maximum lifetime (%dm)", int(maxLifetime.Minutes())),
+				map[string]interface{}{
+					"claimed_at": claim.ClaimedAt,
+					"expires_at": claim.ExpiresAt,
+				})
+		}
+		expiresAt = previousExpiry
+	}
+
+	if err := s.claimRepo.Extend(claim.ID, expiresAt); err != nil {
+		return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to extend claim: %v", err), nil)
+	}
+	claim.ExpiresAt = expiresAt
+
+	if err := s.activityRepo.LogActionWithDetails(ticket.ID, models.ActionHeartbeat, models.ActorTypeAgent, s.actorFor(claim),
+		fmt.Sprintf("Heartbeat: claim extended to %s", expiresAt.Local().Format("15:04:05")),
+		map[string]interface{}{
+			"claim_id":            claim.ClaimID,
+			"extend_mins":         int(extend.Minutes()),
+			"previous_expires_at": previousExpiry.Format(time.RFC3339),
+			"expires_at":          expiresAt.Format(time.RFC3339),
+			"capped":              capped,
+		}); err != nil {
+		return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to log activity: %v", err), nil)
+	}
+
+	return &HeartbeatResult{Ticket: ticket, Claim: claim, Capped: capped}, nil
+}
+
 // Release releases a claimed ticket back to the ready queue.
-// The ticket must be in working status with an active claim.
+// The ticket must be in working status with an active claim held by the
+// acting worker (see ActingAs).
 // If retry count reaches max retries, the ticket is escalated to human status.
 func (s *TicketService) Release(ticketID int64, reason string) error {
+	return s.inTx(func(tx *TicketService) error {
+		return tx.release(ticketID, reason)
+	})
+}
+
+func (s *TicketService) release(ticketID int64, reason string) error {
 	ticket, err := s.ticketRepo.GetByID(ticketID)
 	if err != nil {
 		return newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to get ticket: %v", err), nil)
@@ -258,6 +577,9 @@ func (s *TicketService) Release(ticketID int64, reason string) error {
 	if claim == nil {
 		return newTicketError(ErrCodeInvalidState, "no active claim found for ticket", nil)
 	}
+	if err := s.checkClaimOwner(claim); err != nil {
+		return err
+	}
 
 	// Release claim
 	if err := s.claimRepo.Release(claim.ID, models.ClaimStatusReleased); err != nil {
@@ -290,7 +612,7 @@ func (s *TicketService) Release(ticketID int64, reason string) error {
 			activitySummary = fmt.Sprintf("Released: %s - escalated to human (retry %d/%d)", reason, ticket.RetryCount, ticket.MaxRetries)
 		}
 	}
-	s.activityRepo.LogActionWithDetails(ticket.ID, models.ActionReleased, models.ActorTypeAgent, claim.WorkerID,
+	if err := s.activityRepo.LogActionWithDetails(ticket.ID, models.ActionReleased, models.ActorTypeAgent, s.actorFor(claim),
 		activitySummary,
 		map[string]interface{}{
 			"reason":            reason,
@@ -299,7 +621,9 @@ func (s *TicketService) Release(ticketID int64, reason string) error {
 			"escalated":         escalateToHuman,
 			"from_status":       string(models.StatusWorking),
 			"to_status":         string(ticket.Status),
-		})
+		}); err != nil {
+		return newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to log activity: %v", err), nil)
+	}
 
 	// Create inbox message if escalated
 	if escalateToHuman {
@@ -308,16 +632,58 @@ func (s *TicketService) Release(ticketID int64, reason string) error {
 			escalationMsg = fmt.Sprintf("Ticket released %d times (max retries reached). Last release reason: %s", ticket.RetryCount, reason)
 		}
 		inboxMsg := models.NewInboxMessage(ticket.ID, models.MessageTypeEscalation, escalationMsg, claim.WorkerID)
-		s.inboxRepo.Create(inboxMsg) // Best effort - don't fail if this errors
+		if err := s.inboxRepo.Create(inboxMsg); err != nil {
+			return newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to create inbox message: %v", err), nil)
+		}
 	}
 
 	return nil
 }
 
+// checkClaimOwner fails with CLAIM_NOT_OWNED when claim is held by a worker
+// other than the one the service is acting as, unless force is set.
+func (s *TicketService) checkClaimOwner(claim *models.Claim) error {
+	if s.force || claim.IsHeldBy(s.workerID) {
+		return nil
+	}
+	message := fmt.Sprintf("ticket is claimed by %s, not %s", claim.WorkerID, s.workerID)
+	if s.workerID == "" {
+		message = fmt.Sprintf("ticket is claimed by %s and no worker ID was given", claim.WorkerID)
+	}
+	return newTicketError(ErrCodeClaimNotOwned, message,
+		map[string]interface{}{
+			"worker_id": claim.WorkerID,
+			"claim_id":  claim.ClaimID,
+		})
+}
+
+// actorFor returns the worker to record in the activity log for an operation
+// on claim: the acting worker if known, otherwise the claim's holder.
+func (s *TicketService) actorFor(claim *models.Claim) string {
+	if s.workerID != "" {
+		return s.workerID
+	}
+	return claim.WorkerID
+}
+
 // Complete marks a ticket as complete and moves it to review status.
 // If autoAccept is true, the ticket is immediately closed with completed resolution.
-// All tasks must be complete before the ticket can be completed.
+// All tasks must be complete before the ticket can be completed, and an active
+// claim must be held by the acting worker (see ActingAs).
 func (s *TicketService) Complete(ticketID int64, summary string, autoAccept bool) (*CompleteResult, error) {
+	var result *CompleteResult
+	err := s.inTx(func(tx *TicketService) error {
+		var err error
+		result, err = tx.complete(ticketID, summary, autoAccept)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *TicketService) complete(ticketID int64, summary string, autoAccept bool) (*CompleteResult, error) {
 	ticket, err := s.ticketRepo.GetByID(ticketID)
 	if err != nil {
 		return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to get ticket: %v", err), nil)
@@ -333,11 +699,17 @@ func (s *TicketService) Complete(ticketID int64, summary string, autoAccept bool
 			map[string]interface{}{"current_status": ticket.Status})
 	}
 
-	// Get active claim for logging
-	claim, _ := s.claimRepo.GetActiveByTicketID(ticket.ID)
-	workerID := ""
+	// Get active claim for ownership check and logging
+	claim, err := s.claimRepo.GetActiveByTicketID(ticket.ID)
+	if err != nil {
+		return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to get claim: %v", err), nil)
+	}
+	workerID := s.workerID
 	if claim != nil {
-		workerID = claim.WorkerID
+		if err := s.checkClaimOwner(claim); err != nil {
+			return nil, err
+		}
+		workerID = s.actorFor(claim)
 	}
 
 	// Check if ticket has incomplete tasks - block completion if so
@@ -369,7 +741,9 @@ func (s *TicketService) Complete(ticketID int64, summary string, autoAccept bool
 
 	// Complete the claim
 	if claim != nil {
-		s.claimRepo.Release(claim.ID, models.ClaimStatusCompleted)
+		if err := s.claimRepo.Release(claim.ID, models.ClaimStatusCompleted); err != nil {
+			return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to release claim: %v", err), nil)
+		}
 	}
 
 	// Determine final status
@@ -403,7 +777,7 @@ func (s *TicketService) Complete(ticketID int64, summary string, autoAccept bool
 			activitySummary = fmt.Sprintf("%s - %s", activitySummary, summary)
 		}
 	}
-	s.activityRepo.LogActionWithDetails(ticket.ID, models.ActionCompleted, models.ActorTypeAgent, workerID,
+	if err := s.activityRepo.LogActionWithDetails(ticket.ID, models.ActionCompleted, models.ActorTypeAgent, workerID,
 		activitySummary,
 		map[string]interface{}{
 			"summary":     summary,
@@ -411,7 +785,9 @@ func (s *TicketService) Complete(ticketID int64, summary string, autoAccept bool
 			"tasks_total": taskCounts.Total,
 			"from_status": string(models.StatusWorking),
 			"to_status":   string(finalStatus),
-		})
+		}); err != nil {
+		return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to log activity: %v", err), nil)
+	}
 
 	result := &CompleteResult{
 		Ticket:       ticket,
@@ -419,7 +795,9 @@ func (s *TicketService) Complete(ticketID int64, summary string, autoAccept bool
 	}
 
 	if autoAccept {
-		s.activityRepo.LogAction(ticket.ID, models.ActionAccepted, models.ActorTypeSystem, "", "Auto-accepted")
+		if err := s.activityRepo.LogAction(ticket.ID, models.ActionAccepted, models.ActorTypeSystem, "", "Auto-accepted"); err != nil {
+			return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to log activity: %v", err), nil)
+		}
 
 		// Run dependency resolution when ticket is done
 		resResult, err := s.depResolver.OnTicketCompleted(ticket.ID, true)
@@ -435,6 +813,19 @@ func (s *TicketService) Complete(ticketID int64, summary string, autoAccept bool
 // Accept accepts completed work and closes the ticket with completed resolution.
 // The ticket must be in review status and have no incomplete tasks.
 func (s *TicketService) Accept(ticketID int64) (*AcceptResult, error) {
+	var result *AcceptResult
+	err := s.inTx(func(tx *TicketService) error {
+		var err error
+		result, err = tx.accept(ticketID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *TicketService) accept(ticketID int64) (*AcceptResult, error) {
 	ticket, err := s.ticketRepo.GetByID(ticketID)
 	if err != nil {
 		return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to get ticket: %v", err), nil)
@@ -486,13 +877,15 @@ func (s *TicketService) Accept(ticketID int64) (*AcceptResult, error) {
 	}
 
 	// Log activity with state transition details
-	s.activityRepo.LogActionWithDetails(ticket.ID, models.ActionAccepted, models.ActorTypeHuman, "",
+	if err := s.activityRepo.LogActionWithDetails(ticket.ID, models.ActionAccepted, models.ActorTypeHuman, "",
 		"Work accepted",
 		map[string]interface{}{
 			"from_status": string(fromStatus),
 			"to_status":   string(models.StatusClosed),
 			"resolution":  string(resolution),
-		})
+		}); err != nil {
+		return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to log activity: %v", err), nil)
+	}
 
 	result := &AcceptResult{
 		Ticket: ticket,
@@ -512,6 +905,12 @@ func (s *TicketService) Accept(ticketID int64) (*AcceptResult, error) {
 // The ticket must be in review status. Reason is required.
 // If retry count reaches max retries, the ticket is escalated to human status.
 func (s *TicketService) Reject(ticketID int64, reason string) error {
+	return s.inTx(func(tx *TicketService) error {
+		return tx.reject(ticketID, reason)
+	})
+}
+
+func (s *TicketService) reject(ticketID int64, reason string) error {
 	if reason == "" {
 		return newTicketError(ErrCodeInvalidReason, "reason is required for rejection", nil)
 	}
@@ -535,7 +934,9 @@ func (s *TicketService) Reject(ticketID int64, reason string) error {
 	// Release any active claim so ticket can be picked up fresh
 	claim, _ := s.claimRepo.GetActiveByTicketID(ticket.ID)
 	if claim != nil {
-		s.claimRepo.Release(claim.ID, models.ClaimStatusReleased)
+		if err := s.claimRepo.Release(claim.ID, models.ClaimStatusReleased); err != nil {
+			return newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to release claim: %v", err), nil)
+		}
 	}
 
 	// Increment retry count and determine new status
@@ -562,7 +963,7 @@ func (s *TicketService) Reject(ticketID int64, reason string) error {
 	if escalateToHuman {
 		activitySummary = fmt.Sprintf("Rejected: %s - escalated to human (retry %d/%d)", reason, ticket.RetryCount, ticket.MaxRetries)
 	}
-	s.activityRepo.LogActionWithDetails(ticket.ID, models.ActionRejected, models.ActorTypeHuman, "",
+	if err := s.activityRepo.LogActionWithDetails(ticket.ID, models.ActionRejected, models.ActorTypeHuman, "",
 		activitySummary,
 		map[string]interface{}{
 			"reason":      reason,
@@ -571,13 +972,17 @@ func (s *TicketService) Reject(ticketID int64, reason string) error {
 			"escalated":   escalateToHuman,
 			"from_status": string(fromStatus),
 			"to_status":   string(ticket.Status),
-		})
+		}); err != nil {
+		return newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to log activity: %v", err), nil)
+	}
 
 	// Create inbox message if escalated
 	if escalateToHuman {
 		escalationMsg := fmt.Sprintf("Ticket rejected %d times (max retries reached). Rejection reason: %s", ticket.RetryCount, reason)
 		inboxMsg := models.NewInboxMessage(ticket.ID, models.MessageTypeEscalation, escalationMsg, "")
-		s.inboxRepo.Create(inboxMsg) // Best effort - don't fail if this errors
+		if err := s.inboxRepo.Create(inboxMsg); err != nil {
+			return newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to create inbox message: %v", err), nil)
+		}
 	}
 
 	return nil
@@ -586,6 +991,12 @@ func (s *TicketService) Reject(ticketID int64, reason string) error {
 // Flag flags a ticket for human attention and moves it to human status.
 // The ticket must be in ready or working status.
 func (s *TicketService) Flag(ticketID int64, reason models.FlagReason, message string, workerID string) error {
+	return s.inTx(func(tx *TicketService) error {
+		return tx.flag(ticketID, reason, message, workerID)
+	})
+}
+
+func (s *TicketService) flag(ticketID int64, reason models.FlagReason, message string, workerID string) error {
 	if message == "" {
 		return newTicketError(ErrCodeInvalidReason, "message is required", nil)
 	}
@@ -617,7 +1028,9 @@ func (s *TicketService) Flag(ticketID int64, reason models.FlagReason, message s
 			workerID = claim.WorkerID
 		}
 		// Release the claim
-		s.claimRepo.Release(claim.ID, models.ClaimStatusReleased)
+		if err := s.claimRepo.Release(claim.ID, models.ClaimStatusReleased); err != nil {
+			return newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to release claim: %v", err), nil)
+		}
 	}
 
 	// Update ticket status
@@ -641,7 +1054,7 @@ func (s *TicketService) Flag(ticketID int64, reason models.FlagReason, message s
 	}
 
 	// Log activity with state transition details
-	s.activityRepo.LogActionWithDetails(ticket.ID, models.ActionEscalated, models.ActorTypeAgent, workerID,
+	if err := s.activityRepo.LogActionWithDetails(ticket.ID, models.ActionEscalated, models.ActorTypeAgent, workerID,
 		fmt.Sprintf("Flagged: %s", reason),
 		map[string]interface{}{
 			"reason":           string(reason),
@@ -649,7 +1062,9 @@ func (s *TicketService) Flag(ticketID int64, reason models.FlagReason, message s
 			"inbox_message_id": inboxMsg.ID,
 			"from_status":      string(previousStatus),
 			"to_status":        string(models.StatusHuman),
-		})
+		}); err != nil {
+		return newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to log activity: %v", err), nil)
+	}
 
 	return nil
 }
@@ -657,6 +1072,12 @@ func (s *TicketService) Flag(ticketID int64, reason models.FlagReason, message s
 // Close closes a ticket with the specified resolution.
 // Any active claim is released. The ticket must not already be closed.
 func (s *TicketService) Close(ticketID int64, resolution models.Resolution, reason string) error {
+	return s.inTx(func(tx *TicketService) error {
+		return tx.close(ticketID, resolution, reason)
+	})
+}
+
+func (s *TicketService) close(ticketID int64, resolution models.Resolution, reason string) error {
 	if !resolution.IsValid() {
 		return newTicketError(ErrCodeInvalidResolution,
 			fmt.Sprintf("invalid resolution: %s (must be completed, wont_do, duplicate, invalid, or obsolete)", resolution),
@@ -681,7 +1102,9 @@ func (s *TicketService) Close(ticketID int64, resolution models.Resolution, reas
 	// Release any active claim
 	claim, _ := s.claimRepo.GetActiveByTicketID(ticket.ID)
 	if claim != nil {
-		s.claimRepo.Release(claim.ID, models.ClaimStatusReleased)
+		if err := s.claimRepo.Release(claim.ID, models.ClaimStatusReleased); err != nil {
+			return newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to release claim: %v", err), nil)
+		}
 	}
 
 	// Capture previous status for logging
@@ -701,14 +1124,16 @@ func (s *TicketService) Close(ticketID int64, resolution models.Resolution, reas
 	if reason != "" {
 		closeSummary = fmt.Sprintf("Closed (%s): %s", resolution, reason)
 	}
-	s.activityRepo.LogActionWithDetails(ticket.ID, models.ActionClosed, models.ActorTypeHuman, "",
+	if err := s.activityRepo.LogActionWithDetails(ticket.ID, models.ActionClosed, models.ActorTypeHuman, "",
 		closeSummary,
 		map[string]interface{}{
 			"resolution":  string(resolution),
 			"reason":      reason,
 			"from_status": string(previousStatus),
 			"to_status":   string(models.StatusClosed),
-		})
+		}); err != nil {
+		return newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to log activity: %v", err), nil)
+	}
 
 	return nil
 }
@@ -716,6 +1141,12 @@ func (s *TicketService) Close(ticketID int64, resolution models.Resolution, reas
 // Reopen reopens a closed ticket.
 // The ticket will be set to ready or blocked status depending on dependencies.
 func (s *TicketService) Reopen(ticketID int64) error {
+	return s.inTx(func(tx *TicketService) error {
+		return tx.reopen(ticketID)
+	})
+}
+
+func (s *TicketService) reopen(ticketID int64) error {
 	ticket, err := s.ticketRepo.GetByID(ticketID)
 	if err != nil {
 		return newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to get ticket: %v", err), nil)
@@ -761,9 +1192,11 @@ func (s *TicketService) Reopen(ticketID int64) error {
 	if previousResolution != nil {
 		details["previous_resolution"] = string(*previousResolution)
 	}
-	s.activityRepo.LogActionWithDetails(ticket.ID, models.ActionReopened, models.ActorTypeHuman, "",
+	if err := s.activityRepo.LogActionWithDetails(ticket.ID, models.ActionReopened, models.ActorTypeHuman, "",
 		fmt.Sprintf("Reopened: %s → %s", previousStatus, newStatus),
-		details)
+		details); err != nil {
+		return newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to log activity: %v", err), nil)
+	}
 
 	return nil
 }
@@ -779,6 +1212,12 @@ type ResumeResult struct {
 // This is used when a human has responded and the ticket should return to the ready queue.
 // It transitions the ticket from human to ready (not claimed - must be claimed separately).
 func (s *TicketService) Resume(ticketID int64) error {
+	return s.inTx(func(tx *TicketService) error {
+		return tx.resume(ticketID)
+	})
+}
+
+func (s *TicketService) resume(ticketID int64) error {
 	ticket, err := s.ticketRepo.GetByID(ticketID)
 	if err != nil {
 		return newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to get ticket: %v", err), nil)
@@ -809,13 +1248,15 @@ func (s *TicketService) Resume(ticketID int64) error {
 	}
 
 	// Log activity with state transition details
-	s.activityRepo.LogActionWithDetails(ticket.ID, models.ActionHumanResponded, models.ActorTypeHuman, "",
+	if err := s.activityRepo.LogActionWithDetails(ticket.ID, models.ActionHumanResponded, models.ActorTypeHuman, "",
 		"Human responded, ticket ready for reevaluation",
 		map[string]interface{}{
 			"previous_flag_reason": previousReason,
 			"from_status":          string(models.StatusHuman),
 			"to_status":            string(models.StatusReady),
-		})
+		}); err != nil {
+		return newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to log activity: %v", err), nil)
+	}
 
 	return nil
 }
@@ -823,6 +1264,18 @@ func (s *TicketService) Resume(ticketID int64) error {
 // Deprioritize moves a ticket from human to backlog status.
 // This is used when a human decides the ticket should be done later.
 func (s *TicketService) Deprioritize(ticketID int64) error {
+	return s.DeprioritizeUntil(ticketID, nil)
+}
+
+// DeprioritizeUntil is Deprioritize that also sets the ticket's not_before, so
+// it stays out of workable lists until then even once it is started again.
+func (s *TicketService) DeprioritizeUntil(ticketID int64, until *time.Time) error {
+	return s.inTx(func(tx *TicketService) error {
+		return tx.deprioritize(ticketID, until)
+	})
+}
+
+func (s *TicketService) deprioritize(ticketID int64, until *time.Time) error {
 	ticket, err := s.ticketRepo.GetByID(ticketID)
 	if err != nil {
 		return newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to get ticket: %v", err), nil)
@@ -847,18 +1300,28 @@ func (s *TicketService) Deprioritize(ticketID int64) error {
 	previousReason := ticket.HumanFlagReason
 	ticket.Status = models.StatusBacklog
 	ticket.HumanFlagReason = "" // Clear the flag reason
+	if until != nil {
+		ticket.NotBefore = until
+	}
 	if err := s.ticketRepo.Update(ticket); err != nil {
 		return newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to update ticket status: %v", err), nil)
 	}
 
 	// Log activity with state transition details
-	s.activityRepo.LogActionWithDetails(ticket.ID, models.ActionFieldChanged, models.ActorTypeHuman, "",
-		"Human deprioritized ticket to backlog",
-		map[string]interface{}{
-			"previous_flag_reason": previousReason,
-			"from_status":          string(models.StatusHuman),
-			"to_status":            string(models.StatusBacklog),
-		})
+	summary := "Human deprioritized ticket to backlog"
+	details := map[string]interface{}{
+		"previous_flag_reason": previousReason,
+		"from_status":          string(models.StatusHuman),
+		"to_status":            string(models.StatusBacklog),
+	}
+	if until != nil {
+		summary += " until " + until.Local().Format("2006-01-02 15:04")
+		details["not_before"] = db.FormatTime(*until)
+	}
+	if err := s.activityRepo.LogActionWithDetails(ticket.ID, models.ActionFieldChanged, models.ActorTypeHuman, "",
+		summary, details); err != nil {
+		return newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to log activity: %v", err), nil)
+	}
 
 	return nil
 }
@@ -941,12 +1404,16 @@ type ExecutionContext struct {
 	Role         string `json:"role,omitempty"`
 	Model        string `json:"model"`
 	Capability   string `json:"capability"`
+	// Decisions are the options humans chose on the ticket's decision
+	// messages, oldest first.
+	Decisions []*models.Decision `json:"decisions,omitempty"`
 }
 
 // GetExecutionContext returns the full execution context for a ticket.
 // This includes role instructions, the model to use based on complexity,
-// and the capability level. This is the primary method for execution harnesses
-// to determine how to work on a ticket.
+// the capability level, and the decisions humans have made on the ticket.
+// This is the primary method for execution harnesses to determine how to
+// work on a ticket.
 func (s *TicketService) GetExecutionContext(ticketID int64) (*ExecutionContext, error) {
 	ticket, err := s.ticketRepo.GetByID(ticketID)
 	if err != nil {
@@ -1003,11 +1470,22 @@ func (s *TicketService) GetExecutionContext(ticketID int64) (*ExecutionContext,
 		}
 	}
 
+	ctx.Decisions, err = s.inboxRepo.ListDecisions(ticket.ID)
+	if err != nil {
+		return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to get decisions: %v", err), nil)
+	}
+
 	return ctx, nil
 }
 
 // Prioritize moves a ticket from backlog to ready status.
 func (s *TicketService) Prioritize(ticketID int64) error {
+	return s.inTx(func(tx *TicketService) error {
+		return tx.prioritize(ticketID)
+	})
+}
+
+func (s *TicketService) prioritize(ticketID int64) error {
 	ticket, err := s.ticketRepo.GetByID(ticketID)
 	if err != nil {
 		return newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to get ticket: %v", err), nil)
@@ -1046,18 +1524,26 @@ func (s *TicketService) Prioritize(ticketID int64) error {
 	}
 
 	// Log activity
-	s.activityRepo.LogActionWithDetails(ticket.ID, action, models.ActorTypeHuman, "",
+	if err := s.activityRepo.LogActionWithDetails(ticket.ID, action, models.ActorTypeHuman, "",
 		summary,
 		map[string]interface{}{
 			"from_status": "backlog",
 			"to_status":   string(newStatus),
-		})
+		}); err != nil {
+		return newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to log activity: %v", err), nil)
+	}
 
 	return nil
 }
 
 // StartReview moves a ticket from review to reviewing status.
 func (s *TicketService) StartReview(ticketID int64) error {
+	return s.inTx(func(tx *TicketService) error {
+		return tx.startReview(ticketID)
+	})
+}
+
+func (s *TicketService) startReview(ticketID int64) error {
 	ticket, err := s.ticketRepo.GetByID(ticketID)
 	if err != nil {
 		return newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to get ticket: %v", err), nil)
@@ -1072,6 +1558,9 @@ func (s *TicketService) StartReview(ticketID int64) error {
 			fmt.Sprintf("ticket must be in review status to start review (current: %s)", ticket.Status),
 			map[string]interface{}{"current_status": ticket.Status})
 	}
+	if err := s.checkWIPLimit(ticket, models.StatusReviewing); err != nil {
+		return err
+	}
 
 	// Update ticket
 	ticket.Status = models.StatusReviewing
@@ -1080,12 +1569,14 @@ func (s *TicketService) StartReview(ticketID int64) error {
 	}
 
 	// Log activity
-	s.activityRepo.LogActionWithDetails(ticket.ID, models.ActionFieldChanged, models.ActorTypeHuman, "",
+	if err := s.activityRepo.LogActionWithDetails(ticket.ID, models.ActionFieldChanged, models.ActorTypeHuman, "",
 		"Review started: review → reviewing",
 		map[string]interface{}{
 			"from_status": "review",
 			"to_status":   "reviewing",
-		})
+		}); err != nil {
+		return newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to log activity: %v", err), nil)
+	}
 
 	return nil
 }
@@ -0,0 +1,562 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spetersoncode/wark/internal/common"
+	"github.com/spetersoncode/wark/internal/db"
+	"github.com/spetersoncode/wark/internal/models"
+)
+
+// CreateTicketInput holds the fields for creating a ticket. Priority,
+// complexity and type default to medium, medium and task. Ticket keys may be
+// given as a bare number, which is resolved within ProjectKey. NotBefore and
+// DueAt are dates (YYYY-MM-DD) or RFC 3339 timestamps. Template names a ticket
+// template whose description, rendered with Vars, priority, complexity and
+// role fill in fields left empty, and whose tasks are added to the ticket.
+type CreateTicketInput struct {
+	ProjectKey   string
+	Title        string
+	Description  string
+	Priority     string
+	Complexity   string
+	Type         string
+	ParentKey    string
+	DependsOn    []string
+	RoleName     string
+	MilestoneKey string
+	Labels       []string
+	NotBefore    string
+	DueAt        string
+	Template     string
+	Vars         map[string]string
+}
+
+// UpdateTicketInput holds the fields to change on a ticket. Nil fields are
+// left unchanged; an empty MilestoneKey removes the ticket from its milestone,
+// and an empty NotBefore or DueAt clears that date.
+type UpdateTicketInput struct {
+	Title        *string
+	Description  *string
+	Priority     *string
+	Complexity   *string
+	MilestoneKey *string
+	NotBefore    *string
+	DueAt        *string
+}
+
+// Create creates a ticket in backlog, or blocked if it depends on unresolved
+// tickets. Epics get their own worktree name; children of an epic share it.
+func (s *TicketService) Create(input CreateTicketInput) (*models.Ticket, error) {
+	var ticket *models.Ticket
+	err := s.inTx(func(tx *TicketService) error {
+		var err error
+		ticket, err = tx.create(input)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ticket, nil
+}
+
+func (s *TicketService) create(input CreateTicketInput) (*models.Ticket, error) {
+	projectKey := strings.ToUpper(input.ProjectKey)
+	project, err := s.projectRepo.GetByKey(projectKey)
+	if err != nil {
+		return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to get project: %v", err), nil)
+	}
+	if project == nil {
+		return nil, newTicketError(ErrCodeNotFound, fmt.Sprintf("project %s not found", projectKey), nil)
+	}
+
+	if strings.TrimSpace(input.Title) == "" {
+		return nil, newTicketError(ErrCodeInvalidInput, "title is required", nil)
+	}
+	tasks, err := s.applyTemplate(&input)
+	if err != nil {
+		return nil, err
+	}
+	priority, complexity, err := parseLevels(input.Priority, input.Complexity)
+	if err != nil {
+		return nil, err
+	}
+	ticketType := models.TicketTypeTask
+	if input.Type != "" {
+		if ticketType, err = models.ParseTicketType(input.Type); err != nil {
+			return nil, newTicketError(ErrCodeInvalidInput, err.Error(), nil)
+		}
+	}
+	labels, err := models.ParseLabels(input.Labels)
+	if err != nil {
+		return nil, newTicketError(ErrCodeInvalidInput, err.Error(), nil)
+	}
+	notBefore, err := parseScheduleDate("not_before", input.NotBefore)
+	if err != nil {
+		return nil, err
+	}
+	dueAt, err := parseScheduleDate("due_at", input.DueAt)
+	if err != nil {
+		return nil, err
+	}
+
+	ticket := &models.Ticket{
+		ProjectID:   project.ID,
+		Title:       input.Title,
+		Description: input.Description,
+		Priority:    priority,
+		Complexity:  complexity,
+		Type:        ticketType,
+		Status:      models.StatusBacklog,
+		NotBefore:   notBefore,
+		DueAt:       dueAt,
+	}
+
+	if input.RoleName != "" {
+		role, err := s.roleRepo.GetByName(input.RoleName)
+		if err != nil {
+			return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to get role: %v", err), nil)
+		}
+		if role == nil {
+			return nil, newTicketError(ErrCodeNotFound, fmt.Sprintf("role '%s' not found", input.RoleName), nil)
+		}
+		ticket.RoleID = &role.ID
+	}
+
+	if input.MilestoneKey != "" {
+		milestone, err := s.openMilestone(projectKey, input.MilestoneKey)
+		if err != nil {
+			return nil, err
+		}
+		ticket.MilestoneID = &milestone.ID
+		ticket.MilestoneKey = milestone.Key
+	}
+
+	var parent *models.Ticket
+	if input.ParentKey != "" {
+		if parent, err = s.resolveKey(input.ParentKey, projectKey); err != nil {
+			return nil, err
+		}
+		ticket.ParentTicketID = &parent.ID
+	}
+
+	if err := s.ticketRepo.Create(ticket); err != nil {
+		return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to create ticket: %v", err), nil)
+	}
+
+	if ticket.IsEpic() {
+		ticket.Worktree = GenerateWorktreeName(projectKey, ticket.Number, ticket.Title)
+	} else if parent != nil && parent.IsEpic() {
+		ticket.Worktree = parent.Worktree
+	}
+
+	for _, depKey := range input.DependsOn {
+		dep, err := s.resolveKey(depKey, projectKey)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.depRepo.Add(ticket.ID, dep.ID); err != nil {
+			return nil, newTicketError(ErrCodeInvalidInput,
+				fmt.Sprintf("cannot depend on %s: %v", dep.TicketKey, err), nil)
+		}
+	}
+
+	// ON create: if has_open_deps → blocked, else → backlog
+	hasUnresolved, err := s.depRepo.HasUnresolvedDependencies(ticket.ID)
+	if err != nil {
+		return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to check dependencies: %v", err), nil)
+	}
+	if hasUnresolved {
+		ticket.Status = models.StatusBlocked
+	}
+	if ticket.Worktree != "" || hasUnresolved {
+		if err := s.ticketRepo.Update(ticket); err != nil {
+			return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to update ticket: %v", err), nil)
+		}
+	}
+	if hasUnresolved {
+		if err := s.activityRepo.LogAction(ticket.ID, models.ActionBlocked, models.ActorTypeSystem, "",
+			"Blocked by unresolved dependencies"); err != nil {
+			return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to log activity: %v", err), nil)
+		}
+	}
+
+	for _, label := range labels {
+		if _, err := s.labelRepo.Add(ticket.ID, label); err != nil {
+			return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to add label: %v", err), nil)
+		}
+	}
+
+	for _, task := range tasks {
+		if _, err := s.tasksRepo.CreateTask(context.Background(), ticket.ID, task); err != nil {
+			return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to add task: %v", err), nil)
+		}
+	}
+
+	return s.GetTicketByID(ticket.ID)
+}
+
+// Update changes a ticket's title, description, priority, complexity,
+// milestone or schedule dates. Each value that actually changes is logged as
+// a field change.
+func (s *TicketService) Update(ticketID int64, input UpdateTicketInput) (*models.Ticket, error) {
+	var ticket *models.Ticket
+	err := s.inTx(func(tx *TicketService) error {
+		var err error
+		ticket, err = tx.update(ticketID, input)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ticket, nil
+}
+
+func (s *TicketService) update(ticketID int64, input UpdateTicketInput) (*models.Ticket, error) {
+	ticket, err := s.GetTicketByID(ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	type change struct {
+		summary  string
+		field    string
+		old, new string
+	}
+	var changes []change
+	// Priority and complexity changes are logged by database triggers
+	changed := false
+
+	if input.Title != nil && *input.Title != ticket.Title {
+		if strings.TrimSpace(*input.Title) == "" {
+			return nil, newTicketError(ErrCodeInvalidInput, "title cannot be empty", nil)
+		}
+		changes = append(changes, change{fmt.Sprintf("Title: %s → %s", ticket.Title, *input.Title), "title", ticket.Title, *input.Title})
+		ticket.Title = *input.Title
+	}
+	if input.Description != nil && *input.Description != ticket.Description {
+		changes = append(changes, change{summary: "Description updated"})
+		ticket.Description = *input.Description
+	}
+	if input.Priority != nil {
+		priority, err := models.ParsePriority(*input.Priority)
+		if err != nil {
+			return nil, newTicketError(ErrCodeInvalidInput, err.Error(), nil)
+		}
+		if priority != ticket.Priority {
+			changed = true
+			ticket.Priority = priority
+		}
+	}
+	if input.Complexity != nil {
+		complexity, err := models.ParseComplexity(*input.Complexity)
+		if err != nil {
+			return nil, newTicketError(ErrCodeInvalidInput, err.Error(), nil)
+		}
+		if complexity != ticket.Complexity {
+			changed = true
+			ticket.Complexity = complexity
+		}
+	}
+	if input.MilestoneKey != nil {
+		key := strings.ToUpper(strings.TrimSpace(*input.MilestoneKey))
+		if key != ticket.MilestoneKey {
+			if key == "" {
+				ticket.MilestoneID = nil
+			} else {
+				milestone, err := s.openMilestone(ticket.ProjectKey, key)
+				if err != nil {
+					return nil, err
+				}
+				ticket.MilestoneID = &milestone.ID
+			}
+			changes = append(changes, change{fmt.Sprintf("Milestone: %s → %s", displayMilestone(ticket.MilestoneKey), displayMilestone(key)), "milestone", ticket.MilestoneKey, key})
+			ticket.MilestoneKey = key
+		}
+	}
+	for _, date := range []struct {
+		field, label string
+		value        *string
+		target       **time.Time
+	}{
+		{"not_before", "Not before", input.NotBefore, &ticket.NotBefore},
+		{"due_at", "Due", input.DueAt, &ticket.DueAt},
+	} {
+		if date.value == nil {
+			continue
+		}
+		parsed, err := parseScheduleDate(date.field, *date.value)
+		if err != nil {
+			return nil, err
+		}
+		from, to := displayDate(*date.target), displayDate(parsed)
+		if from != to {
+			changes = append(changes, change{fmt.Sprintf("%s: %s → %s", date.label, from, to), date.field, from, to})
+			*date.target = parsed
+		}
+	}
+
+	if len(changes) == 0 && !changed {
+		return ticket, nil
+	}
+	if err := s.ticketRepo.Update(ticket); err != nil {
+		return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to update ticket: %v", err), nil)
+	}
+	for _, c := range changes {
+		var err error
+		if c.field == "" {
+			err = s.activityRepo.LogAction(ticket.ID, models.ActionFieldChanged, models.ActorTypeHuman, "", c.summary)
+		} else {
+			err = s.activityRepo.LogActionWithDetails(ticket.ID, models.ActionFieldChanged, models.ActorTypeHuman, "", c.summary,
+				map[string]interface{}{"field": c.field, "old": c.old, "new": c.new})
+		}
+		if err != nil {
+			return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to log activity: %v", err), nil)
+		}
+	}
+
+	return s.GetTicketByID(ticket.ID)
+}
+
+// AddDependency makes a ticket depend on another. A ticket that is being
+// worked on or waiting becomes blocked if the new dependency is unresolved;
+// backlog tickets stay in the backlog.
+func (s *TicketService) AddDependency(ticketID, dependsOnID int64) (*models.Ticket, error) {
+	var ticket *models.Ticket
+	err := s.inTx(func(tx *TicketService) error {
+		var err error
+		ticket, err = tx.addDependency(ticketID, dependsOnID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ticket, nil
+}
+
+func (s *TicketService) addDependency(ticketID, dependsOnID int64) (*models.Ticket, error) {
+	ticket, err := s.GetTicketByID(ticketID)
+	if err != nil {
+		return nil, err
+	}
+	dep, err := s.GetTicketByID(dependsOnID)
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := s.depRepo.Exists(ticket.ID, dep.ID)
+	if err != nil {
+		return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to check dependency: %v", err), nil)
+	}
+	if exists {
+		return nil, newTicketError(ErrCodeInvalidInput,
+			fmt.Sprintf("%s already depends on %s", ticket.TicketKey, dep.TicketKey), nil)
+	}
+	if err := s.depRepo.Add(ticket.ID, dep.ID); err != nil {
+		return nil, newTicketError(ErrCodeInvalidInput,
+			fmt.Sprintf("cannot depend on %s: %v", dep.TicketKey, err), nil)
+	}
+	if err := s.activityRepo.LogAction(ticket.ID, models.ActionDependencyAdded, models.ActorTypeHuman, "",
+		fmt.Sprintf("Added dependency: %s", dep.TicketKey)); err != nil {
+		return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to log activity: %v", err), nil)
+	}
+
+	if err := s.syncBlockedStatus(ticket); err != nil {
+		return nil, err
+	}
+	return s.GetTicketByID(ticket.ID)
+}
+
+// RemoveDependency removes a dependency. A blocked ticket whose remaining
+// dependencies are all resolved becomes ready.
+func (s *TicketService) RemoveDependency(ticketID, dependsOnID int64) (*models.Ticket, error) {
+	var ticket *models.Ticket
+	err := s.inTx(func(tx *TicketService) error {
+		var err error
+		ticket, err = tx.removeDependency(ticketID, dependsOnID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ticket, nil
+}
+
+func (s *TicketService) removeDependency(ticketID, dependsOnID int64) (*models.Ticket, error) {
+	ticket, err := s.GetTicketByID(ticketID)
+	if err != nil {
+		return nil, err
+	}
+	dep, err := s.GetTicketByID(dependsOnID)
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := s.depRepo.Exists(ticket.ID, dep.ID)
+	if err != nil {
+		return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to check dependency: %v", err), nil)
+	}
+	if !exists {
+		return nil, newTicketError(ErrCodeNotFound,
+			fmt.Sprintf("%s does not depend on %s", ticket.TicketKey, dep.TicketKey), nil)
+	}
+	if err := s.depRepo.Remove(ticket.ID, dep.ID); err != nil {
+		return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to remove dependency: %v", err), nil)
+	}
+	if err := s.activityRepo.LogAction(ticket.ID, models.ActionDependencyRemoved, models.ActorTypeHuman, "",
+		fmt.Sprintf("Removed dependency: %s", dep.TicketKey)); err != nil {
+		return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to log activity: %v", err), nil)
+	}
+
+	if err := s.syncBlockedStatus(ticket); err != nil {
+		return nil, err
+	}
+	return s.GetTicketByID(ticket.ID)
+}
+
+// syncBlockedStatus applies the dependency rules after dependencies change:
+// an active ticket with unresolved dependencies becomes blocked, and a
+// blocked ticket without any becomes ready. Backlog and closed tickets are
+// left alone.
+func (s *TicketService) syncBlockedStatus(ticket *models.Ticket) error {
+	hasUnresolved, err := s.depRepo.HasUnresolvedDependencies(ticket.ID)
+	if err != nil {
+		return newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to check dependencies: %v", err), nil)
+	}
+
+	oldStatus := ticket.Status
+	switch {
+	case hasUnresolved && oldStatus != models.StatusBlocked && oldStatus != models.StatusClosed && oldStatus != models.StatusBacklog:
+		ticket.Status = models.StatusBlocked
+		if err := s.ticketRepo.Update(ticket); err != nil {
+			return newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to update ticket: %v", err), nil)
+		}
+		err = s.activityRepo.LogActionWithDetails(ticket.ID, models.ActionBlocked, models.ActorTypeSystem, "",
+			fmt.Sprintf("Blocked: %s → blocked (new dependency)", oldStatus),
+			map[string]interface{}{
+				"from_status": string(oldStatus),
+				"to_status":   "blocked",
+				"reason":      "new dependency added",
+			})
+	case !hasUnresolved && oldStatus == models.StatusBlocked:
+		ticket.Status = models.StatusReady
+		if err := s.ticketRepo.Update(ticket); err != nil {
+			return newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to update ticket: %v", err), nil)
+		}
+		err = s.activityRepo.LogAction(ticket.ID, models.ActionUnblocked, models.ActorTypeSystem, "",
+			"All dependencies resolved")
+	}
+	if err != nil {
+		return newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to log activity: %v", err), nil)
+	}
+	return nil
+}
+
+// Comment adds a comment to a ticket's activity log. While the ticket is
+// claimed the comment is attributed to the claim, otherwise to workerID.
+func (s *TicketService) Comment(ticketID int64, message, workerID string) (*models.ActivityLog, error) {
+	if strings.TrimSpace(message) == "" {
+		return nil, newTicketError(ErrCodeInvalidInput, "comment message is required", nil)
+	}
+	ticket, err := s.GetTicketByID(ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	actorType := models.ActorTypeAgent
+	actorID := workerID
+	if claim, _ := s.claimRepo.GetActiveByTicketID(ticket.ID); claim != nil {
+		actorType = models.ActorTypeClaim
+		actorID = claim.ClaimID
+	}
+
+	comment := models.NewActivityLog(ticket.ID, models.ActionComment, actorType, actorID, message)
+	if err := s.activityRepo.Create(comment); err != nil {
+		return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to create comment: %v", err), nil)
+	}
+	comment.TicketKey = ticket.TicketKey
+	return comment, nil
+}
+
+// resolveKey looks up a ticket by key; a bare number is taken to be in
+// defaultProject.
+func (s *TicketService) resolveKey(key, defaultProject string) (*models.Ticket, error) {
+	projectKey, number, err := common.ParseTicketKey(key)
+	if err != nil {
+		return nil, newTicketError(ErrCodeInvalidInput, fmt.Sprintf("invalid ticket key: %s", key), nil)
+	}
+	if projectKey == "" {
+		projectKey = defaultProject
+	}
+	ticket, err := s.ticketRepo.GetByKey(projectKey, number)
+	if err != nil {
+		return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to get ticket: %v", err), nil)
+	}
+	if ticket == nil {
+		return nil, newTicketError(ErrCodeNotFound, fmt.Sprintf("ticket %s-%d not found", projectKey, number), nil)
+	}
+	return ticket, nil
+}
+
+// openMilestone returns the open milestone with the given key in a project.
+func (s *TicketService) openMilestone(projectKey, key string) (*models.Milestone, error) {
+	key = strings.ToUpper(key)
+	milestone, err := s.milestoneRepo.GetByKey(projectKey, key)
+	if err != nil {
+		return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to get milestone: %v", err), nil)
+	}
+	if milestone == nil {
+		return nil, newTicketError(ErrCodeNotFound, fmt.Sprintf("milestone %s not found in %s", key, projectKey), nil)
+	}
+	if !milestone.IsOpen() {
+		return nil, newTicketError(ErrCodeInvalidState,
+			fmt.Sprintf("milestone %s is %s and no longer accepts tickets", milestone.Key, milestone.Status), nil)
+	}
+	return milestone, nil
+}
+
+// parseScheduleDate parses an optional not_before or due_at date; an empty
+// value is no date. A due_at date without a time is the end of that day.
+func parseScheduleDate(field, value string) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	parse := common.ParseDate
+	if field == "due_at" {
+		parse = common.ParseDueDate
+	}
+	t, err := parse(value)
+	if err != nil {
+		return nil, newTicketError(ErrCodeInvalidInput, fmt.Sprintf("%s: %v", field, err), nil)
+	}
+	return &t, nil
+}
+
+// displayDate formats an optional schedule date for activity logs.
+func displayDate(t *time.Time) string {
+	if t == nil {
+		return "(none)"
+	}
+	return db.FormatTime(*t)
+}
+
+// parseLevels parses a priority and complexity, defaulting each to medium.
+func parseLevels(priority, complexity string) (models.Priority, models.Complexity, error) {
+	p, c := models.PriorityMedium, models.ComplexityMedium
+	var err error
+	if priority != "" {
+		if p, err = models.ParsePriority(priority); err != nil {
+			return "", "", newTicketError(ErrCodeInvalidInput, err.Error(), nil)
+		}
+	}
+	if complexity != "" {
+		if c, err = models.ParseComplexity(complexity); err != nil {
+			return "", "", newTicketError(ErrCodeInvalidInput, err.Error(), nil)
+		}
+	}
+	return p, c, nil
+}
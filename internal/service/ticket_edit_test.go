@@ -0,0 +1,229 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spetersoncode/wark/internal/db"
+	"github.com/spetersoncode/wark/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTicketService_Create(t *testing.T) {
+	database, _, cleanup := testDB(t)
+	defer cleanup()
+
+	createTicketTestProject(t, database, "TEST")
+	svc := NewTicketService(database.DB)
+
+	t.Run("defaults", func(t *testing.T) {
+		ticket, err := svc.Create(CreateTicketInput{ProjectKey: "test", Title: "First", Labels: []string{"Area:API"}})
+		require.NoError(t, err)
+
+		assert.Equal(t, "TEST-1", ticket.TicketKey)
+		assert.Equal(t, models.StatusBacklog, ticket.Status)
+		assert.Equal(t, models.PriorityMedium, ticket.Priority)
+		assert.Equal(t, models.ComplexityMedium, ticket.Complexity)
+		assert.Equal(t, models.TicketTypeTask, ticket.Type)
+		assert.Equal(t, []string{"area:api"}, ticket.Labels)
+	})
+
+	t.Run("epic children share its worktree", func(t *testing.T) {
+		epic, err := svc.Create(CreateTicketInput{ProjectKey: "TEST", Title: "Auth epic", Type: "epic"})
+		require.NoError(t, err)
+		assert.Equal(t, "TEST-2-auth-epic", epic.Worktree)
+
+		child, err := svc.Create(CreateTicketInput{ProjectKey: "TEST", Title: "Login", ParentKey: "2"})
+		require.NoError(t, err)
+		assert.Equal(t, epic.Worktree, child.Worktree)
+		require.NotNil(t, child.ParentTicketID)
+		assert.Equal(t, epic.ID, *child.ParentTicketID)
+	})
+
+	t.Run("unresolved dependency blocks", func(t *testing.T) {
+		ticket, err := svc.Create(CreateTicketInput{ProjectKey: "TEST", Title: "After first", DependsOn: []string{"TEST-1"}})
+		require.NoError(t, err)
+		assert.Equal(t, models.StatusBlocked, ticket.Status)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		_, err := svc.Create(CreateTicketInput{ProjectKey: "TEST", Title: "x", Priority: "urgent"})
+		requireTicketErrorCode(t, err, ErrCodeInvalidInput)
+
+		_, err = svc.Create(CreateTicketInput{ProjectKey: "TEST", Title: " "})
+		requireTicketErrorCode(t, err, ErrCodeInvalidInput)
+
+		_, err = svc.Create(CreateTicketInput{ProjectKey: "NOPE", Title: "x"})
+		requireTicketErrorCode(t, err, ErrCodeNotFound)
+
+		_, err = svc.Create(CreateTicketInput{ProjectKey: "TEST", Title: "x", RoleName: "nobody"})
+		requireTicketErrorCode(t, err, ErrCodeNotFound)
+	})
+
+	t.Run("failure creates nothing", func(t *testing.T) {
+		_, err := svc.Create(CreateTicketInput{ProjectKey: "TEST", Title: "x", DependsOn: []string{"TEST-99"}})
+		requireTicketErrorCode(t, err, ErrCodeNotFound)
+
+		tickets, err := db.NewTicketRepo(database.DB).List(db.TicketFilter{ProjectKey: "TEST"})
+		require.NoError(t, err)
+		assert.Len(t, tickets, 4)
+	})
+}
+
+func TestTicketService_Update(t *testing.T) {
+	database, _, cleanup := testDB(t)
+	defer cleanup()
+
+	project := createTicketTestProject(t, database, "TEST")
+	ticket := createTicketTestTicket(t, database, project.ID, 1, models.StatusReady)
+	svc := NewTicketService(database.DB)
+
+	title, priority := "Renamed", "high"
+	updated, err := svc.Update(ticket.ID, UpdateTicketInput{Title: &title, Priority: &priority})
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed", updated.Title)
+	assert.Equal(t, models.PriorityHigh, updated.Priority)
+
+	activity, err := db.NewActivityRepo(database.DB).ListByTicket(ticket.ID, 0)
+	require.NoError(t, err)
+	var fields []string
+	for _, a := range activity {
+		if a.Action == models.ActionFieldChanged {
+			details, _ := a.GetDetails()
+			fields = append(fields, details["field"].(string))
+		}
+	}
+	assert.ElementsMatch(t, []string{"title", "priority"}, fields)
+
+	bad := "enormous"
+	_, err = svc.Update(ticket.ID, UpdateTicketInput{Complexity: &bad})
+	requireTicketErrorCode(t, err, ErrCodeInvalidInput)
+
+	missing := "M1"
+	_, err = svc.Update(ticket.ID, UpdateTicketInput{MilestoneKey: &missing})
+	requireTicketErrorCode(t, err, ErrCodeNotFound)
+}
+
+func TestTicketService_ScheduleDates(t *testing.T) {
+	database, _, cleanup := testDB(t)
+	defer cleanup()
+
+	createTicketTestProject(t, database, "TEST")
+	svc := NewTicketService(database.DB)
+	ticketRepo := db.NewTicketRepo(database.DB)
+
+	_, err := svc.Create(CreateTicketInput{ProjectKey: "TEST", Title: "x", DueAt: "soon"})
+	requireTicketErrorCode(t, err, ErrCodeInvalidInput)
+
+	past := time.Now().Add(-48 * time.Hour).UTC().Format(time.RFC3339)
+	overdue, err := svc.Create(CreateTicketInput{ProjectKey: "TEST", Title: "Overdue", Priority: "low", DueAt: past})
+	require.NoError(t, err)
+	require.NotNil(t, overdue.DueAt)
+	plain, err := svc.Create(CreateTicketInput{ProjectKey: "TEST", Title: "Plain"})
+	require.NoError(t, err)
+	deferred, err := svc.Create(CreateTicketInput{ProjectKey: "TEST", Title: "Deferred", Priority: "highest", NotBefore: "2099-01-01"})
+	require.NoError(t, err)
+	for _, ticket := range []*models.Ticket{overdue, plain, deferred} {
+		require.NoError(t, svc.Prioritize(ticket.ID))
+	}
+
+	// Overdue low ties with medium and is older; the deferred ticket is hidden
+	next, err := svc.NextScheduled(db.TicketFilter{ProjectKey: "TEST"})
+	require.NoError(t, err)
+	assert.Equal(t, "TEST-1", next.Ticket.TicketKey)
+	assert.Equal(t, 3.0, next.Score)
+	assert.Equal(t, "low priority, overdue", next.Reason)
+
+	// Clearing not_before makes it workable
+	empty := ""
+	updated, err := svc.Update(deferred.ID, UpdateTicketInput{NotBefore: &empty})
+	require.NoError(t, err)
+	assert.Nil(t, updated.NotBefore)
+	next, err = svc.NextScheduled(db.TicketFilter{ProjectKey: "TEST"})
+	require.NoError(t, err)
+	assert.Equal(t, "TEST-3", next.Ticket.TicketKey)
+
+	activity, err := db.NewActivityRepo(database.DB).ListByTicket(deferred.ID, 0)
+	require.NoError(t, err)
+	require.NotEmpty(t, activity)
+	assert.Contains(t, activity[0].Summary, "Not before: 2099-01-01")
+
+	// Later with a date defers the ticket
+	require.NoError(t, ticketRepo.UpdateStatus(plain.ID, models.StatusHuman))
+	until := time.Now().Add(72 * time.Hour)
+	require.NoError(t, svc.DeprioritizeUntil(plain.ID, &until))
+	got, err := ticketRepo.GetByID(plain.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusBacklog, got.Status)
+	require.NotNil(t, got.NotBefore)
+	assert.True(t, got.IsDeferred(time.Now()))
+
+	// A date-only due date lasts until the end of the day
+	dueToday, err := svc.Create(CreateTicketInput{ProjectKey: "TEST", Title: "Due today", DueAt: time.Now().Format("2006-01-02")})
+	require.NoError(t, err)
+	assert.False(t, dueToday.IsOverdue(time.Now()))
+}
+
+func TestTicketService_Dependencies(t *testing.T) {
+	database, _, cleanup := testDB(t)
+	defer cleanup()
+
+	project := createTicketTestProject(t, database, "TEST")
+	ticket := createTicketTestTicket(t, database, project.ID, 1, models.StatusReady)
+	dep := createTicketTestTicket(t, database, project.ID, 2, models.StatusReady)
+	backlog := createTicketTestTicket(t, database, project.ID, 3, models.StatusBacklog)
+	svc := NewTicketService(database.DB)
+
+	updated, err := svc.AddDependency(ticket.ID, dep.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusBlocked, updated.Status)
+
+	_, err = svc.AddDependency(ticket.ID, dep.ID)
+	requireTicketErrorCode(t, err, ErrCodeInvalidInput)
+	_, err = svc.AddDependency(dep.ID, ticket.ID)
+	requireTicketErrorCode(t, err, ErrCodeInvalidInput)
+
+	updated, err = svc.AddDependency(backlog.ID, dep.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusBacklog, updated.Status, "backlog tickets stay in backlog")
+
+	updated, err = svc.RemoveDependency(ticket.ID, dep.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusReady, updated.Status)
+
+	_, err = svc.RemoveDependency(ticket.ID, dep.ID)
+	requireTicketErrorCode(t, err, ErrCodeNotFound)
+}
+
+func TestTicketService_Comment(t *testing.T) {
+	database, _, cleanup := testDB(t)
+	defer cleanup()
+
+	project := createTicketTestProject(t, database, "TEST")
+	ticket := createTicketTestTicket(t, database, project.ID, 1, models.StatusReady)
+	svc := NewTicketService(database.DB)
+
+	comment, err := svc.Comment(ticket.ID, "Looks like a cache issue", "agent-1")
+	require.NoError(t, err)
+	assert.Equal(t, models.ActorTypeAgent, comment.ActorType)
+	assert.Equal(t, "agent-1", comment.ActorID)
+
+	result, err := svc.Claim(ticket.ID, "agent-1", time.Hour)
+	require.NoError(t, err)
+	comment, err = svc.Comment(ticket.ID, "Found it", "agent-1")
+	require.NoError(t, err)
+	assert.Equal(t, models.ActorTypeClaim, comment.ActorType)
+	assert.Equal(t, result.Claim.ClaimID, comment.ActorID)
+
+	_, err = svc.Comment(ticket.ID, "", "agent-1")
+	requireTicketErrorCode(t, err, ErrCodeInvalidInput)
+}
+
+func requireTicketErrorCode(t *testing.T, err error, code string) {
+	t.Helper()
+	require.Error(t, err)
+	svcErr, ok := err.(*TicketError)
+	require.True(t, ok, "expected *TicketError, got %T: %v", err, err)
+	assert.Equal(t, code, svcErr.Code)
+}
@@ -112,7 +112,7 @@ func TestTicketService_Release(t *testing.T) {
 	require.NoError(t, err)
 
 	t.Run("successful release", func(t *testing.T) {
-		err := svc.Release(ticket.ID, "testing release")
+		err := svc.ActingAs("worker-123", false).Release(ticket.ID, "testing release")
 		require.NoError(t, err)
 
 		// Verify ticket is back to ready
@@ -122,7 +122,7 @@ func TestTicketService_Release(t *testing.T) {
 	})
 
 	t.Run("release non-in-progress ticket", func(t *testing.T) {
-		err := svc.Release(ticket.ID, "should fail")
+		err := svc.ActingAs("worker-123", false).Release(ticket.ID, "should fail")
 		require.Error(t, err)
 
 		svcErr, ok := err.(*TicketError)
@@ -131,6 +131,237 @@ func TestTicketService_Release(t *testing.T) {
 	})
 }
 
+func TestTicketService_ClaimNext(t *testing.T) {
+	database, _, cleanup := testDB(t)
+	defer cleanup()
+
+	project := createTicketTestProject(t, database, "TEST")
+	svc := NewTicketService(database.DB)
+	ticketRepo := db.NewTicketRepo(database.DB)
+
+	large := createTicketTestTicket(t, database, project.ID, 1, models.StatusReady)
+	large.Priority = models.PriorityHighest
+	large.Complexity = models.ComplexityLarge
+	require.NoError(t, ticketRepo.Update(large))
+
+	exhausted := createTicketTestTicket(t, database, project.ID, 2, models.StatusReady)
+	exhausted.Priority = models.PriorityHigh
+	exhausted.RetryCount = exhausted.MaxRetries
+	require.NoError(t, ticketRepo.Update(exhausted))
+
+	small := createTicketTestTicket(t, database, project.ID, 3, models.StatusReady)
+	small.Complexity = models.ComplexitySmall
+	require.NoError(t, ticketRepo.Update(small))
+
+	maxSmall := models.ComplexitySmall
+
+	t.Run("dry run picks without claiming", func(t *testing.T) {
+		ticket, err := svc.NextWorkable(db.TicketFilter{ProjectKey: "TEST"})
+		require.NoError(t, err)
+		require.NotNil(t, ticket)
+		assert.Equal(t, large.ID, ticket.ID)
+		assert.Equal(t, models.StatusReady, ticket.Status)
+	})
+
+	t.Run("respects max complexity and skips exhausted retries", func(t *testing.T) {
+		result, err := svc.ClaimNext(db.TicketFilter{ProjectKey: "TEST", MaxComplexity: &maxSmall}, "worker-123", 30*time.Minute)
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		assert.Equal(t, small.ID, result.Ticket.ID)
+		assert.Equal(t, models.StatusWorking, result.Ticket.Status)
+		assert.NotEmpty(t, result.Claim.ClaimID)
+	})
+
+	t.Run("claimed tickets are not picked again", func(t *testing.T) {
+		result, err := svc.ClaimNext(db.TicketFilter{ProjectKey: "TEST", MaxComplexity: &maxSmall}, "worker-123", 30*time.Minute)
+		require.NoError(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("highest priority first", func(t *testing.T) {
+		result, err := svc.ClaimNext(db.TicketFilter{}, "worker-456", 30*time.Minute)
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		assert.Equal(t, large.ID, result.Ticket.ID)
+	})
+}
+
+func TestTicketService_ClaimOwnership(t *testing.T) {
+	database, _, cleanup := testDB(t)
+	defer cleanup()
+
+	project := createTicketTestProject(t, database, "TEST")
+	svc := NewTicketService(database.DB)
+
+	ticket := createTicketTestTicket(t, database, project.ID, 1, models.StatusReady)
+	result, err := svc.Claim(ticket.ID, "worker-a", 60*time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "worker-a", result.Claim.WorkerID)
+
+	t.Run("other worker cannot release", func(t *testing.T) {
+		err := svc.ActingAs("worker-b", false).Release(ticket.ID, "not mine")
+		require.Error(t, err)
+		ticketErr, ok := err.(*TicketError)
+		require.True(t, ok)
+		assert.Equal(t, ErrCodeClaimNotOwned, ticketErr.Code)
+		assert.Equal(t, "worker-a", ticketErr.Details["worker_id"])
+	})
+
+	t.Run("other worker cannot complete", func(t *testing.T) {
+		_, err := svc.ActingAs("worker-b", false).Complete(ticket.ID, "not mine", false)
+		require.Error(t, err)
+		ticketErr, ok := err.(*TicketError)
+		require.True(t, ok)
+		assert.Equal(t, ErrCodeClaimNotOwned, ticketErr.Code)
+
+		claimRepo := db.NewClaimRepo(database.DB)
+		claim, err := claimRepo.GetActiveByTicketID(ticket.ID)
+		require.NoError(t, err)
+		assert.NotNil(t, claim)
+	})
+
+	t.Run("force overrides ownership", func(t *testing.T) {
+		err := svc.ActingAs("worker-b", true).Release(ticket.ID, "taking over")
+		require.NoError(t, err)
+
+		ticketRepo := db.NewTicketRepo(database.DB)
+		updated, err := ticketRepo.GetByID(ticket.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.StatusReady, updated.Status)
+	})
+
+	t.Run("holder can complete", func(t *testing.T) {
+		_, err := svc.Claim(ticket.ID, "worker-b", 60*time.Minute)
+		require.NoError(t, err)
+
+		result, err := svc.ActingAs("worker-b", false).Complete(ticket.ID, "done", false)
+		require.NoError(t, err)
+		assert.Equal(t, models.StatusReview, result.Ticket.Status)
+	})
+}
+
+func TestTicketService_Heartbeat(t *testing.T) {
+	database, _, cleanup := testDB(t)
+	defer cleanup()
+
+	project := createTicketTestProject(t, database, "TEST")
+	svc := NewTicketService(database.DB)
+	ticket := createTicketTestTicket(t, database, project.ID, 1, models.StatusReady)
+
+	claimed, err := svc.Claim(ticket.ID, "worker-a", 10*time.Minute)
+	require.NoError(t, err)
+
+	t.Run("extends the claim and logs activity", func(t *testing.T) {
+		result, err := svc.ActingAs("worker-a", false).Heartbeat(ticket.ID, 30*time.Minute, 0)
+		require.NoError(t, err)
+		assert.False(t, result.Capped)
+		assert.True(t, result.Claim.ExpiresAt.After(claimed.Claim.ExpiresAt))
+
+		claimRepo := db.NewClaimRepo(database.DB)
+		claim, err := claimRepo.GetActiveByTicketID(ticket.ID)
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(30*time.Minute), claim.ExpiresAt, 5*time.Second)
+
+		activityRepo := db.NewActivityRepo(database.DB)
+		logs, err := activityRepo.ListByTicket(ticket.ID, 10)
+		require.NoError(t, err)
+		assert.Equal(t, models.ActionHeartbeat, logs[0].Action)
+		assert.Equal(t, "worker-a", logs[0].ActorID)
+	})
+
+	t.Run("never shortens the claim", func(t *testing.T) {
+		result, err := svc.ActingAs("worker-a", false).Heartbeat(ticket.ID, time.Minute, 0)
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(30*time.Minute), result.Claim.ExpiresAt, 5*time.Second)
+	})
+
+	t.Run("caps at max lifetime", func(t *testing.T) {
+		result, err := svc.ActingAs("worker-a", false).Heartbeat(ticket.ID, 2*time.Hour, time.Hour)
+		require.NoError(t, err)
+		assert.True(t, result.Capped)
+		assert.WithinDuration(t, claimed.Claim.ClaimedAt.Add(time.Hour), result.Claim.ExpiresAt, time.Second)
+
+		_, err = svc.ActingAs("worker-a", false).Heartbeat(ticket.ID, 2*time.Hour, time.Hour)
+		require.Error(t, err)
+		ticketErr, ok := err.(*TicketError)
+		require.True(t, ok)
+		assert.Equal(t, ErrCodeInvalidState, ticketErr.Code)
+	})
+
+	t.Run("other worker cannot renew", func(t *testing.T) {
+		_, err := svc.ActingAs("worker-b", false).Heartbeat(ticket.ID, 30*time.Minute, 0)
+		require.Error(t, err)
+		ticketErr, ok := err.(*TicketError)
+		require.True(t, ok)
+		assert.Equal(t, ErrCodeClaimNotOwned, ticketErr.Code)
+	})
+
+	t.Run("no active claim", func(t *testing.T) {
+		other := createTicketTestTicket(t, database, project.ID, 2, models.StatusReady)
+		_, err := svc.Heartbeat(other.ID, 30*time.Minute, 0)
+		require.Error(t, err)
+		ticketErr, ok := err.(*TicketError)
+		require.True(t, ok)
+		assert.Equal(t, ErrCodeInvalidState, ticketErr.Code)
+	})
+}
+
+func TestTicketService_RollsBackOnFailure(t *testing.T) {
+	database, _, cleanup := testDB(t)
+	defer cleanup()
+
+	project := createTicketTestProject(t, database, "TEST")
+	svc := NewTicketService(database.DB)
+	claimRepo := db.NewClaimRepo(database.DB)
+
+	// failActivity makes the next activity log insert for the given action fail,
+	// simulating an error late in the operation.
+	failActivity := func(t *testing.T, action models.Action) {
+		_, err := database.Exec(`CREATE TRIGGER fail_activity BEFORE INSERT ON activity_log
+			WHEN NEW.action = '` + string(action) + `'
+			BEGIN SELECT RAISE(ABORT, 'activity log unavailable'); END`)
+		require.NoError(t, err)
+		t.Cleanup(func() { database.Exec(`DROP TRIGGER IF EXISTS fail_activity`) })
+	}
+
+	t.Run("claim leaves no claim behind", func(t *testing.T) {
+		ticket := createTicketTestTicket(t, database, project.ID, 1, models.StatusReady)
+		failActivity(t, models.ActionClaimed)
+
+		_, err := svc.Claim(ticket.ID, "worker-123", 60*time.Minute)
+		require.Error(t, err)
+		svcErr, ok := err.(*TicketError)
+		require.True(t, ok)
+		assert.Equal(t, ErrCodeDatabase, svcErr.Code)
+
+		updated, _ := svc.GetTicketByID(ticket.ID)
+		assert.Equal(t, models.StatusReady, updated.Status)
+		claim, err := claimRepo.GetActiveByTicketID(ticket.ID)
+		require.NoError(t, err)
+		assert.Nil(t, claim)
+	})
+
+	t.Run("release keeps the claim active", func(t *testing.T) {
+		ticket := createTicketTestTicket(t, database, project.ID, 2, models.StatusReady)
+		_, err := svc.Claim(ticket.ID, "worker-123", 60*time.Minute)
+		require.NoError(t, err)
+		failActivity(t, models.ActionReleased)
+
+		err = svc.ActingAs("worker-123", false).Release(ticket.ID, "testing rollback")
+		require.Error(t, err)
+		svcErr, ok := err.(*TicketError)
+		require.True(t, ok)
+		assert.Equal(t, ErrCodeDatabase, svcErr.Code)
+
+		updated, _ := svc.GetTicketByID(ticket.ID)
+		assert.Equal(t, models.StatusWorking, updated.Status)
+		assert.Equal(t, 0, updated.RetryCount)
+		claim, err := claimRepo.GetActiveByTicketID(ticket.ID)
+		require.NoError(t, err)
+		assert.NotNil(t, claim)
+	})
+}
+
 func TestTicketService_Complete(t *testing.T) {
 	database, _, cleanup := testDB(t)
 	defer cleanup()
@@ -145,7 +376,7 @@ func TestTicketService_Complete(t *testing.T) {
 	require.NoError(t, err)
 
 	t.Run("successful complete to review", func(t *testing.T) {
-		result, err := svc.Complete(ticket.ID, "work done", false)
+		result, err := svc.ActingAs("worker-123", false).Complete(ticket.ID, "work done", false)
 		require.NoError(t, err)
 
 		assert.Equal(t, models.StatusReview, result.Ticket.Status)
@@ -167,7 +398,7 @@ func TestTicketService_CompleteWithAutoAccept(t *testing.T) {
 	require.NoError(t, err)
 
 	t.Run("successful complete with auto-accept", func(t *testing.T) {
-		result, err := svc.Complete(ticket.ID, "work done", true)
+		result, err := svc.ActingAs("worker-123", false).Complete(ticket.ID, "work done", true)
 		require.NoError(t, err)
 
 		assert.Equal(t, models.StatusClosed, result.Ticket.Status)
@@ -196,7 +427,7 @@ func TestTicketService_CompleteWithIncompleteTasks(t *testing.T) {
 	require.NoError(t, err)
 
 	t.Run("complete blocked by incomplete tasks", func(t *testing.T) {
-		_, err := svc.Complete(ticket.ID, "work done", false)
+		_, err := svc.ActingAs("worker-123", false).Complete(ticket.ID, "work done", false)
 		require.Error(t, err)
 
 		svcErr, ok := err.(*TicketError)
@@ -283,7 +514,7 @@ func TestTicketService_Release_EscalatesToHumanOnMaxRetries(t *testing.T) {
 	require.NoError(t, err)
 
 	// Release should escalate to human
-	err = svc.Release(ticket.ID, "still failing")
+	err = svc.ActingAs("worker-123", false).Release(ticket.ID, "still failing")
 	require.NoError(t, err)
 
 	// Verify ticket is escalated to human, not ready
@@ -0,0 +1,967 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spetersoncode/wark/internal/common"
+	"github.com/spetersoncode/wark/internal/db"
+	"github.com/spetersoncode/wark/internal/errors"
+	"github.com/spetersoncode/wark/internal/models"
+)
+
+// ExportSchemaVersion is the version of the export document format written by
+// Export. Import rejects documents with any other version.
+const ExportSchemaVersion = 1
+
+// ExportDocument is a portable snapshot of projects and their tickets. Tickets
+// refer to each other (parents, dependencies) by key, so an import can
+// renumber them without breaking links.
+type ExportDocument struct {
+	Version    int             `json:"version" yaml:"version"`
+	ExportedAt time.Time       `json:"exported_at" yaml:"exported_at"`
+	Roles      []ExportRole    `json:"roles,omitempty" yaml:"roles,omitempty"`
+	Projects   []ExportProject `json:"projects" yaml:"projects"`
+}
+
+// ExportRole is a role referenced by exported tickets.
+type ExportRole struct {
+	Name         string `json:"name" yaml:"name"`
+	Description  string `json:"description,omitempty" yaml:"description,omitempty"`
+	Instructions string `json:"instructions" yaml:"instructions"`
+}
+
+// ExportProject is a project with its milestones and tickets.
+type ExportProject struct {
+	Key         string            `json:"key" yaml:"key"`
+	Name        string            `json:"name" yaml:"name"`
+	Description string            `json:"description,omitempty" yaml:"description,omitempty"`
+	WIPLimits   *models.WIPLimits `json:"wip_limits,omitempty" yaml:"wip_limits,omitempty"`
+	Milestones  []ExportMilestone `json:"milestones,omitempty" yaml:"milestones,omitempty"`
+	Tickets     []ExportTicket    `json:"tickets" yaml:"tickets"`
+}
+
+// ExportMilestone is a project milestone.
+type ExportMilestone struct {
+	Key        string     `json:"key" yaml:"key"`
+	Name       string     `json:"name" yaml:"name"`
+	Goal       string     `json:"goal,omitempty" yaml:"goal,omitempty"`
+	TargetDate *time.Time `json:"target_date,omitempty" yaml:"target_date,omitempty"`
+	Status     string     `json:"status,omitempty" yaml:"status,omitempty"`
+}
+
+// ExportTicket is a ticket with its tasks, labels and history. Parent and
+// DependsOn hold ticket keys, which may point outside the document.
+type ExportTicket struct {
+	Key             string           `json:"key" yaml:"key"`
+	Title           string           `json:"title" yaml:"title"`
+	Description     string           `json:"description,omitempty" yaml:"description,omitempty"`
+	Type            string           `json:"type,omitempty" yaml:"type,omitempty"`
+	Status          string           `json:"status" yaml:"status"`
+	Resolution      string           `json:"resolution,omitempty" yaml:"resolution,omitempty"`
+	HumanFlagReason string           `json:"human_flag_reason,omitempty" yaml:"human_flag_reason,omitempty"`
+	Priority        string           `json:"priority,omitempty" yaml:"priority,omitempty"`
+	Complexity      string           `json:"complexity,omitempty" yaml:"complexity,omitempty"`
+	Role            string           `json:"role,omitempty" yaml:"role,omitempty"`
+	Milestone       string           `json:"milestone,omitempty" yaml:"milestone,omitempty"`
+	Parent          string           `json:"parent,omitempty" yaml:"parent,omitempty"`
+	DependsOn       []string         `json:"depends_on,omitempty" yaml:"depends_on,omitempty"`
+	Labels          []string         `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Worktree        string           `json:"worktree,omitempty" yaml:"worktree,omitempty"`
+	RetryCount      int              `json:"retry_count,omitempty" yaml:"retry_count,omitempty"`
+	MaxRetries      int              `json:"max_retries,omitempty" yaml:"max_retries,omitempty"`
+	Tasks           []ExportTask     `json:"tasks,omitempty" yaml:"tasks,omitempty"`
+	Activity        []ExportActivity `json:"activity,omitempty" yaml:"activity,omitempty"`
+	NotBefore       *time.Time       `json:"not_before,omitempty" yaml:"not_before,omitempty"`
+	DueAt           *time.Time       `json:"due_at,omitempty" yaml:"due_at,omitempty"`
+	CreatedAt       time.Time        `json:"created_at" yaml:"created_at"`
+	CompletedAt     *time.Time       `json:"completed_at,omitempty" yaml:"completed_at,omitempty"`
+}
+
+// ExportTask is a ticket task, in position order.
+type ExportTask struct {
+	Description string `json:"description" yaml:"description"`
+	Complete    bool   `json:"complete,omitempty" yaml:"complete,omitempty"`
+}
+
+// ExportActivity is an activity log entry, oldest first.
+type ExportActivity struct {
+	Action    string                 `json:"action" yaml:"action"`
+	ActorType string                 `json:"actor_type" yaml:"actor_type"`
+	ActorID   string                 `json:"actor_id,omitempty" yaml:"actor_id,omitempty"`
+	Summary   string                 `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Details   map[string]interface{} `json:"details,omitempty" yaml:"details,omitempty"`
+	CreatedAt time.Time              `json:"created_at" yaml:"created_at"`
+}
+
+// ExportOptions selects what Export includes.
+type ExportOptions struct {
+	// Projects limits the export to these project keys; empty means all.
+	Projects []string
+	// NoActivity leaves out ticket history.
+	NoActivity bool
+}
+
+// ImportOptions controls an import.
+type ImportOptions struct {
+	// DryRun validates the document and reports what would be imported
+	// without changing the database.
+	DryRun bool
+	// ProjectKey imports a single-project document under a different key.
+	ProjectKey string
+}
+
+// ImportResult reports what an import created.
+type ImportResult struct {
+	DryRun       bool              `json:"dry_run"`
+	Projects     []ImportedProject `json:"projects"`
+	RolesCreated []string          `json:"roles_created,omitempty"`
+	// KeyMap maps each ticket key in the document to its key after import.
+	KeyMap       map[string]string `json:"key_map"`
+	Tickets      int               `json:"tickets"`
+	Dependencies int               `json:"dependencies"`
+	Tasks        int               `json:"tasks"`
+	Activity     int               `json:"activity"`
+}
+
+// ImportedProject summarizes the import into one project.
+type ImportedProject struct {
+	Key     string `json:"key"`
+	Created bool   `json:"created"`
+	// Renumbered is true when the project already had tickets, so imported
+	// tickets were given new numbers after the existing ones.
+	Renumbered bool `json:"renumbered"`
+	Tickets    int  `json:"tickets"`
+}
+
+// TransferError represents an error from export or import. Problems lists
+// every issue found in an invalid document.
+type TransferError struct {
+	Code     string
+	Message  string
+	Problems []string
+}
+
+func (e *TransferError) Error() string {
+	if len(e.Problems) == 0 {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.Message, strings.Join(e.Problems, "; "))
+}
+
+// Kind maps the error code to the shared error kind, which determines the
+// CLI exit code and HTTP status.
+func (e *TransferError) Kind() errors.Kind {
+	switch e.Code {
+	case ErrCodeInvalidDocument, ErrCodeUnsupportedVersion:
+		return errors.KindInvalidArgs
+	case ErrCodeProjectNotFound:
+		return errors.KindNotFound
+	default:
+		return errors.KindInternal
+	}
+}
+
+// Error codes for export and import
+const (
+	ErrCodeInvalidDocument    = "INVALID_DOCUMENT"
+	ErrCodeUnsupportedVersion = "UNSUPPORTED_VERSION"
+)
+
+func newTransferError(code, format string, args ...interface{}) *TransferError {
+	return &TransferError{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// TransferService exports projects to portable documents and imports them,
+// into the same database or another one.
+type TransferService struct {
+	db *sql.DB
+}
+
+// NewTransferService creates a new TransferService.
+func NewTransferService(database *sql.DB) *TransferService {
+	return &TransferService{db: database}
+}
+
+// Export builds a document with the selected projects, their milestones and
+// tickets, and the roles those tickets use.
+func (s *TransferService) Export(opts ExportOptions) (*ExportDocument, error) {
+	projectRepo := db.NewProjectRepo(s.db)
+	ticketRepo := db.NewTicketRepo(s.db)
+	milestoneRepo := db.NewMilestoneRepo(s.db)
+	depRepo := db.NewDependencyRepo(s.db)
+	tasksRepo := db.NewTasksRepo(s.db)
+	activityRepo := db.NewActivityRepo(s.db)
+	roleRepo := db.NewRoleRepo(s.db)
+
+	var projects []*models.Project
+	if len(opts.Projects) == 0 {
+		all, err := projectRepo.List()
+		if err != nil {
+			return nil, newTransferError(ErrCodeDatabase, "failed to list projects: %v", err)
+		}
+		projects = all
+	} else {
+		for _, key := range opts.Projects {
+			key = strings.ToUpper(key)
+			project, err := projectRepo.GetByKey(key)
+			if err != nil {
+				return nil, newTransferError(ErrCodeDatabase, "failed to get project: %v", err)
+			}
+			if project == nil {
+				return nil, newTransferError(ErrCodeProjectNotFound, "project %s not found", key)
+			}
+			projects = append(projects, project)
+		}
+	}
+
+	doc := &ExportDocument{
+		Version:    ExportSchemaVersion,
+		ExportedAt: time.Now().UTC().Truncate(time.Second),
+		Projects:   []ExportProject{},
+	}
+	roleNames := make(map[string]bool)
+	keysByID := make(map[int64]string)
+
+	for _, project := range projects {
+		ep := ExportProject{
+			Key:         project.Key,
+			Name:        project.Name,
+			Description: project.Description,
+			Tickets:     []ExportTicket{},
+		}
+		if !project.WIPLimits.IsZero() {
+			limits := project.WIPLimits
+			ep.WIPLimits = &limits
+		}
+
+		milestones, err := milestoneRepo.List(&project.ID)
+		if err != nil {
+			return nil, newTransferError(ErrCodeDatabase, "failed to list milestones: %v", err)
+		}
+		for _, m := range milestones {
+			ep.Milestones = append(ep.Milestones, ExportMilestone{
+				Key:        m.Key,
+				Name:       m.Name,
+				Goal:       m.Goal,
+				TargetDate: m.TargetDate,
+				Status:     m.Status,
+			})
+		}
+
+		tickets, err := ticketRepo.List(db.TicketFilter{ProjectID: &project.ID})
+		if err != nil {
+			return nil, newTransferError(ErrCodeDatabase, "failed to list tickets: %v", err)
+		}
+		sort.Slice(tickets, func(i, j int) bool { return tickets[i].Number < tickets[j].Number })
+		for _, t := range tickets {
+			keysByID[t.ID] = t.TicketKey
+		}
+
+		for _, t := range tickets {
+			et := ExportTicket{
+				Key:             t.TicketKey,
+				Title:           t.Title,
+				Description:     t.Description,
+				Type:            string(t.Type),
+				Status:          string(t.Status),
+				HumanFlagReason: t.HumanFlagReason,
+				Priority:        string(t.Priority),
+				Complexity:      string(t.Complexity),
+				Role:            t.RoleName,
+				Milestone:       t.MilestoneKey,
+				Labels:          t.Labels,
+				Worktree:        t.Worktree,
+				RetryCount:      t.RetryCount,
+				MaxRetries:      t.MaxRetries,
+				NotBefore:       t.NotBefore,
+				DueAt:           t.DueAt,
+				CreatedAt:       t.CreatedAt,
+				CompletedAt:     t.CompletedAt,
+			}
+			if t.Resolution != nil {
+				et.Resolution = string(*t.Resolution)
+			}
+			if t.RoleName != "" {
+				roleNames[t.RoleName] = true
+			}
+
+			if t.ParentTicketID != nil {
+				key, ok := keysByID[*t.ParentTicketID]
+				if !ok {
+					parent, err := ticketRepo.GetByID(*t.ParentTicketID)
+					if err != nil {
+						return nil, newTransferError(ErrCodeDatabase, "failed to get parent ticket: %v", err)
+					}
+					if parent != nil {
+						key = parent.TicketKey
+					}
+				}
+				et.Parent = key
+			}
+
+			deps, err := depRepo.GetDependencies(t.ID)
+			if err != nil {
+				return nil, newTransferError(ErrCodeDatabase, "failed to get dependencies: %v", err)
+			}
+			for _, dep := range deps {
+				et.DependsOn = append(et.DependsOn, dep.TicketKey)
+			}
+
+			tasks, err := tasksRepo.ListTasks(context.Background(), t.ID)
+			if err != nil {
+				return nil, newTransferError(ErrCodeDatabase, "failed to list tasks: %v", err)
+			}
+			for _, task := range tasks {
+				et.Tasks = append(et.Tasks, ExportTask{Description: task.Description, Complete: task.Complete})
+			}
+
+			if !opts.NoActivity {
+				entries, err := activityRepo.ListByTicket(t.ID, 0)
+				if err != nil {
+					return nil, newTransferError(ErrCodeDatabase, "failed to list activity: %v", err)
+				}
+				// Entries are newest first; export them in the order they happened
+				for i := len(entries) - 1; i >= 0; i-- {
+					a := entries[i]
+					details, _ := a.GetDetails()
+					et.Activity = append(et.Activity, ExportActivity{
+						Action:    string(a.Action),
+						ActorType: string(a.ActorType),
+						ActorID:   a.ActorID,
+						Summary:   a.Summary,
+						Details:   details,
+						CreatedAt: a.CreatedAt,
+					})
+				}
+			}
+
+			ep.Tickets = append(ep.Tickets, et)
+		}
+
+		doc.Projects = append(doc.Projects, ep)
+	}
+
+	names := make([]string, 0, len(roleNames))
+	for name := range roleNames {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		role, err := roleRepo.GetByName(name)
+		if err != nil {
+			return nil, newTransferError(ErrCodeDatabase, "failed to get role: %v", err)
+		}
+		if role != nil {
+			doc.Roles = append(doc.Roles, ExportRole{
+				Name:         role.Name,
+				Description:  role.Description,
+				Instructions: role.Instructions,
+			})
+		}
+	}
+
+	return doc, nil
+}
+
+// Import creates the document's projects, milestones, roles and tickets.
+// Existing projects, milestones and roles with the same key or name are
+// reused. Tickets keep their numbers unless the target project already has
+// tickets, in which case they are numbered after them; the result maps old
+// keys to new ones. Dependencies are added with the usual cycle check, and
+// everything happens in one transaction, so a failed import changes nothing.
+//
+// Claims aren't exported, so working and reviewing tickets are imported as
+// ready and review. Ready and blocked tickets are re-checked against their
+// dependencies.
+func (s *TransferService) Import(doc *ExportDocument, opts ImportOptions) (*ImportResult, error) {
+	if doc != nil && doc.Version != ExportSchemaVersion {
+		return nil, newTransferError(ErrCodeUnsupportedVersion,
+			"unsupported document version %d (expected %d)", doc.Version, ExportSchemaVersion)
+	}
+	if problems := validateDocument(doc, opts); len(problems) > 0 {
+		return nil, &TransferError{Code: ErrCodeInvalidDocument, Message: "invalid document", Problems: problems}
+	}
+
+	result := &ImportResult{DryRun: opts.DryRun, KeyMap: make(map[string]string)}
+	err := db.WithTx(s.db, func(tx *sql.Tx) error {
+		imp := &importer{
+			doc:           doc,
+			opts:          opts,
+			result:        result,
+			projectRepo:   db.NewProjectRepo(tx),
+			ticketRepo:    db.NewTicketRepo(tx),
+			milestoneRepo: db.NewMilestoneRepo(tx),
+			depRepo:       db.NewDependencyRepo(tx),
+			tasksRepo:     db.NewTasksRepo(tx),
+			activityRepo:  db.NewActivityRepo(tx),
+			labelRepo:     db.NewLabelRepo(tx),
+			roleRepo:      db.NewRoleRepo(tx),
+			tickets:       make(map[string]*models.Ticket),
+		}
+		if err := imp.run(); err != nil {
+			return err
+		}
+		if opts.DryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err == nil || err == errDryRun {
+		return result, nil
+	}
+	if te, ok := err.(*TransferError); ok {
+		return nil, te
+	}
+	return nil, newTransferError(ErrCodeDatabase, "%v", err)
+}
+
+// validateDocument checks a document for problems that don't depend on the
+// target database.
+func validateDocument(doc *ExportDocument, opts ImportOptions) []string {
+	if doc == nil {
+		return []string{"document is empty"}
+	}
+	if len(doc.Projects) == 0 {
+		return []string{"document has no projects"}
+	}
+
+	var problems []string
+	add := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if opts.ProjectKey != "" {
+		if len(doc.Projects) != 1 {
+			add("a target project key can only be given for a single-project document")
+		}
+		if err := models.ValidateProjectKey(strings.ToUpper(opts.ProjectKey)); err != nil {
+			add("target project: %v", err)
+		}
+	}
+
+	for _, r := range doc.Roles {
+		if err := models.ValidateRoleName(r.Name); err != nil {
+			add("role %q: %v", r.Name, err)
+		}
+	}
+
+	projectKeys := make(map[string]bool)
+	ticketKeys := make(map[string]bool)
+	for _, p := range doc.Projects {
+		if err := models.ValidateProjectKey(p.Key); err != nil {
+			add("project %q: %v", p.Key, err)
+			continue
+		}
+		if projectKeys[p.Key] {
+			add("project %s appears more than once", p.Key)
+		}
+		projectKeys[p.Key] = true
+		if strings.TrimSpace(p.Name) == "" {
+			add("project %s: name is required", p.Key)
+		}
+
+		milestones := make(map[string]bool)
+		for _, m := range p.Milestones {
+			if err := models.ValidateMilestoneKey(m.Key); err != nil {
+				add("project %s milestone %q: %v", p.Key, m.Key, err)
+			}
+			if m.Status != "" {
+				if err := models.ValidateMilestoneStatus(m.Status); err != nil {
+					add("project %s milestone %s: %v", p.Key, m.Key, err)
+				}
+			}
+			milestones[m.Key] = true
+		}
+
+		for _, t := range p.Tickets {
+			projectKey, _, err := common.ParseTicketKey(t.Key)
+			if err != nil {
+				add("ticket %q: %v", t.Key, err)
+				continue
+			}
+			if projectKey != p.Key {
+				add("ticket %s is listed under project %s", t.Key, p.Key)
+			}
+			if ticketKeys[t.Key] {
+				add("ticket %s appears more than once", t.Key)
+			}
+			ticketKeys[t.Key] = true
+			problems = append(problems, validateTicket(t)...)
+		}
+	}
+
+	// Parents and dependencies must be in the document or in the target
+	// database; the latter is checked during import.
+	for _, p := range doc.Projects {
+		for _, t := range p.Tickets {
+			if t.Parent == t.Key && t.Key != "" {
+				add("ticket %s cannot be its own parent", t.Key)
+			}
+			for _, dep := range t.DependsOn {
+				if dep == t.Key {
+					add("ticket %s cannot depend on itself", t.Key)
+				}
+			}
+		}
+	}
+
+	return problems
+}
+
+// validateTicket checks a ticket's fields.
+func validateTicket(t ExportTicket) []string {
+	var problems []string
+	add := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf("ticket %s: ", t.Key)+fmt.Sprintf(format, args...))
+	}
+
+	if strings.TrimSpace(t.Title) == "" {
+		add("title is required")
+	}
+	if t.Type != "" {
+		if _, err := models.ParseTicketType(t.Type); err != nil {
+			add("%v", err)
+		}
+	}
+	if _, err := models.ParseStatus(t.Status); err != nil {
+		add("%v", err)
+	} else if models.Status(strings.ToLower(t.Status)) == models.StatusClosed {
+		if _, err := models.ParseResolution(t.Resolution); err != nil {
+			add("closed tickets need a valid resolution: %v", err)
+		}
+	} else if t.Resolution != "" {
+		add("resolution is only allowed on closed tickets")
+	}
+	if t.Priority != "" {
+		if _, err := models.ParsePriority(t.Priority); err != nil {
+			add("%v", err)
+		}
+	}
+	if t.Complexity != "" {
+		if _, err := models.ParseComplexity(t.Complexity); err != nil {
+			add("%v", err)
+		}
+	}
+	if _, err := models.ParseLabels(t.Labels); err != nil {
+		add("%v", err)
+	}
+	for i, task := range t.Tasks {
+		if strings.TrimSpace(task.Description) == "" {
+			add("task %d has no description", i)
+		}
+	}
+	for i, a := range t.Activity {
+		if !models.Action(a.Action).IsValid() {
+			add("activity %d: invalid action: %s", i, a.Action)
+		}
+		if !models.ActorType(a.ActorType).IsValid() {
+			add("activity %d: invalid actor type: %s", i, a.ActorType)
+		}
+	}
+	return problems
+}
+
+// importer applies a validated document inside a transaction.
+type importer struct {
+	doc    *ExportDocument
+	opts   ImportOptions
+	result *ImportResult
+
+	projectRepo   *db.ProjectRepo
+	ticketRepo    *db.TicketRepo
+	milestoneRepo *db.MilestoneRepo
+	depRepo       *db.DependencyRepo
+	tasksRepo     *db.TasksRepo
+	activityRepo  *db.ActivityRepo
+	labelRepo     *db.LabelRepo
+	roleRepo      *db.RoleRepo
+
+	// tickets maps document keys to the tickets created for them.
+	tickets  map[string]*models.Ticket
+	problems []string
+}
+
+func (imp *importer) problem(format string, args ...interface{}) {
+	imp.problems = append(imp.problems, fmt.Sprintf(format, args...))
+}
+
+func (imp *importer) run() error {
+	if err := imp.importRoles(); err != nil {
+		return err
+	}
+
+	var created []*ExportTicket
+	for i := range imp.doc.Projects {
+		tickets, err := imp.importProject(&imp.doc.Projects[i])
+		if err != nil {
+			return err
+		}
+		created = append(created, tickets...)
+	}
+
+	// Links are added once every ticket exists, since they may point forward
+	for _, et := range created {
+		if err := imp.link(et); err != nil {
+			return err
+		}
+	}
+	for _, et := range created {
+		if err := imp.fill(et); err != nil {
+			return err
+		}
+	}
+	for _, et := range created {
+		if err := imp.syncBlocked(imp.tickets[et.Key]); err != nil {
+			return err
+		}
+	}
+
+	if len(imp.problems) > 0 {
+		return &TransferError{Code: ErrCodeInvalidDocument, Message: "import failed", Problems: imp.problems}
+	}
+	return nil
+}
+
+func (imp *importer) importRoles() error {
+	for _, r := range imp.doc.Roles {
+		existing, err := imp.roleRepo.GetByName(r.Name)
+		if err != nil {
+			return newTransferError(ErrCodeDatabase, "failed to get role: %v", err)
+		}
+		if existing != nil {
+			continue
+		}
+		role := &models.Role{Name: r.Name, Description: r.Description, Instructions: r.Instructions}
+		if err := imp.roleRepo.Create(role); err != nil {
+			imp.problem("role %s: %v", r.Name, err)
+			continue
+		}
+		imp.result.RolesCreated = append(imp.result.RolesCreated, r.Name)
+	}
+	return nil
+}
+
+// importProject creates or reuses a project and its milestones, then creates
+// its tickets without links.
+func (imp *importer) importProject(ep *ExportProject) ([]*ExportTicket, error) {
+	key := ep.Key
+	if imp.opts.ProjectKey != "" {
+		key = strings.ToUpper(imp.opts.ProjectKey)
+	}
+
+	project, err := imp.projectRepo.GetByKey(key)
+	if err != nil {
+		return nil, newTransferError(ErrCodeDatabase, "failed to get project: %v", err)
+	}
+	summary := ImportedProject{Key: key}
+	if project == nil {
+		project = &models.Project{Key: key, Name: ep.Name, Description: ep.Description}
+		if ep.WIPLimits != nil {
+			project.WIPLimits = *ep.WIPLimits
+		}
+		if err := imp.projectRepo.Create(project); err != nil {
+			return nil, newTransferError(ErrCodeDatabase, "failed to create project %s: %v", key, err)
+		}
+		summary.Created = true
+	}
+
+	for _, em := range ep.Milestones {
+		existing, err := imp.milestoneRepo.GetByKey(key, em.Key)
+		if err != nil {
+			return nil, newTransferError(ErrCodeDatabase, "failed to get milestone: %v", err)
+		}
+		if existing != nil {
+			continue
+		}
+		milestone := &models.Milestone{
+			ProjectID:  project.ID,
+			Key:        em.Key,
+			Name:       em.Name,
+			Goal:       em.Goal,
+			TargetDate: em.TargetDate,
+			Status:     em.Status,
+		}
+		if err := imp.milestoneRepo.Create(milestone); err != nil {
+			imp.problem("milestone %s/%s: %v", key, em.Key, err)
+		}
+	}
+
+	existing, err := imp.ticketRepo.List(db.TicketFilter{ProjectID: &project.ID})
+	if err != nil {
+		return nil, newTransferError(ErrCodeDatabase, "failed to list tickets: %v", err)
+	}
+	next := 0
+	for _, t := range existing {
+		if t.Number > next {
+			next = t.Number
+		}
+	}
+	summary.Renumbered = len(existing) > 0
+
+	// Create tickets in their original order so renumbering keeps it
+	ordered := make([]*ExportTicket, len(ep.Tickets))
+	for i := range ep.Tickets {
+		ordered[i] = &ep.Tickets[i]
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		_, a, _ := common.ParseTicketKey(ordered[i].Key)
+		_, b, _ := common.ParseTicketKey(ordered[j].Key)
+		return a < b
+	})
+
+	for _, et := range ordered {
+		_, number, _ := common.ParseTicketKey(et.Key)
+		if summary.Renumbered {
+			next++
+			number = next
+		}
+		ticket, err := imp.createTicket(project, key, number, et)
+		if err != nil {
+			return nil, err
+		}
+		if ticket == nil {
+			continue
+		}
+		imp.tickets[et.Key] = ticket
+		imp.result.KeyMap[et.Key] = ticket.TicketKey
+		summary.Tickets++
+	}
+
+	imp.result.Tickets += summary.Tickets
+	imp.result.Projects = append(imp.result.Projects, summary)
+	return ordered, nil
+}
+
+// importedStatus maps an exported status to the status it is imported with.
+// Claims aren't carried over, so in-progress tickets go back to the queue.
+func importedStatus(status models.Status) models.Status {
+	switch status {
+	case models.StatusWorking:
+		return models.StatusReady
+	case models.StatusReviewing:
+		return models.StatusReview
+	}
+	return status
+}
+
+func (imp *importer) createTicket(project *models.Project, projectKey string, number int, et *ExportTicket) (*models.Ticket, error) {
+	status, _ := models.ParseStatus(et.Status)
+	ticket := &models.Ticket{
+		ProjectID:       project.ID,
+		Number:          number,
+		Title:           et.Title,
+		Description:     et.Description,
+		Status:          importedStatus(status),
+		HumanFlagReason: et.HumanFlagReason,
+		RetryCount:      et.RetryCount,
+		MaxRetries:      et.MaxRetries,
+		NotBefore:       et.NotBefore,
+		DueAt:           et.DueAt,
+		CreatedAt:       et.CreatedAt,
+		CompletedAt:     et.CompletedAt,
+		ProjectKey:      projectKey,
+		TicketKey:       fmt.Sprintf("%s-%d", projectKey, number),
+	}
+	if et.Type != "" {
+		ticket.Type, _ = models.ParseTicketType(et.Type)
+	}
+	if et.Priority != "" {
+		ticket.Priority, _ = models.ParsePriority(et.Priority)
+	}
+	if et.Complexity != "" {
+		ticket.Complexity, _ = models.ParseComplexity(et.Complexity)
+	}
+	if ticket.Status == models.StatusClosed {
+		resolution, _ := models.ParseResolution(et.Resolution)
+		ticket.Resolution = &resolution
+	}
+
+	if et.Role != "" {
+		role, err := imp.roleRepo.GetByName(et.Role)
+		if err != nil {
+			return nil, newTransferError(ErrCodeDatabase, "failed to get role: %v", err)
+		}
+		if role == nil {
+			imp.problem("ticket %s: role %s not found", et.Key, et.Role)
+			return nil, nil
+		}
+		ticket.RoleID = &role.ID
+	}
+	if et.Milestone != "" {
+		milestone, err := imp.milestoneRepo.GetByKey(projectKey, et.Milestone)
+		if err != nil {
+			return nil, newTransferError(ErrCodeDatabase, "failed to get milestone: %v", err)
+		}
+		if milestone == nil {
+			imp.problem("ticket %s: milestone %s not found", et.Key, et.Milestone)
+			return nil, nil
+		}
+		ticket.MilestoneID = &milestone.ID
+	}
+
+	// Epic worktree names include the ticket key, so they follow a renumbering
+	if ticket.IsEpic() {
+		ticket.Worktree = et.Worktree
+		if ticket.TicketKey != et.Key || ticket.Worktree == "" {
+			ticket.Worktree = GenerateWorktreeName(projectKey, number, et.Title)
+		}
+	}
+
+	if err := imp.ticketRepo.Create(ticket); err != nil {
+		imp.problem("ticket %s: %v", et.Key, err)
+		return nil, nil
+	}
+	return ticket, nil
+}
+
+// resolve finds the ticket a document key refers to: one being imported, or
+// one already in the database.
+func (imp *importer) resolve(key string) (*models.Ticket, error) {
+	if t, ok := imp.tickets[key]; ok {
+		return t, nil
+	}
+	projectKey, number, err := common.ParseTicketKey(key)
+	if err != nil {
+		return nil, nil
+	}
+	t, err := imp.ticketRepo.GetByKey(projectKey, number)
+	if err != nil {
+		return nil, newTransferError(ErrCodeDatabase, "failed to get ticket %s: %v", key, err)
+	}
+	return t, nil
+}
+
+// link sets a ticket's parent and adds its dependencies.
+func (imp *importer) link(et *ExportTicket) error {
+	ticket, ok := imp.tickets[et.Key]
+	if !ok {
+		return nil
+	}
+
+	if et.Parent != "" {
+		parent, err := imp.resolve(et.Parent)
+		if err != nil {
+			return err
+		}
+		if parent == nil {
+			imp.problem("ticket %s: parent %s not found", et.Key, et.Parent)
+		} else {
+			ticket.ParentTicketID = &parent.ID
+			if !ticket.IsEpic() && parent.IsEpic() {
+				ticket.Worktree = parent.Worktree
+			}
+			if err := imp.ticketRepo.Update(ticket); err != nil {
+				return newTransferError(ErrCodeDatabase, "failed to update ticket: %v", err)
+			}
+		}
+	}
+
+	for _, depKey := range et.DependsOn {
+		dep, err := imp.resolve(depKey)
+		if err != nil {
+			return err
+		}
+		if dep == nil {
+			imp.problem("ticket %s: dependency %s not found", et.Key, depKey)
+			continue
+		}
+		exists, err := imp.depRepo.Exists(ticket.ID, dep.ID)
+		if err != nil {
+			return newTransferError(ErrCodeDatabase, "%v", err)
+		}
+		if exists {
+			continue
+		}
+		if err := imp.depRepo.Add(ticket.ID, dep.ID); err != nil {
+			imp.problem("ticket %s: cannot depend on %s: %v", et.Key, depKey, err)
+			continue
+		}
+		imp.result.Dependencies++
+	}
+	return nil
+}
+
+// fill adds a ticket's labels, tasks and history.
+func (imp *importer) fill(et *ExportTicket) error {
+	ticket, ok := imp.tickets[et.Key]
+	if !ok {
+		return nil
+	}
+
+	labels, _ := models.ParseLabels(et.Labels)
+	for _, label := range labels {
+		if _, err := imp.labelRepo.Add(ticket.ID, label); err != nil {
+			return newTransferError(ErrCodeDatabase, "%v", err)
+		}
+	}
+
+	ctx := context.Background()
+	for _, t := range et.Tasks {
+		task, err := imp.tasksRepo.CreateTask(ctx, ticket.ID, t.Description)
+		if err != nil {
+			return newTransferError(ErrCodeDatabase, "%v", err)
+		}
+		if t.Complete {
+			if err := imp.tasksRepo.CompleteTask(ctx, task.ID); err != nil {
+				return newTransferError(ErrCodeDatabase, "%v", err)
+			}
+		}
+		imp.result.Tasks++
+	}
+
+	if len(et.Activity) > 0 {
+		entries := make([]*models.ActivityLog, 0, len(et.Activity))
+		for _, a := range et.Activity {
+			entry := models.NewActivityLog(ticket.ID, models.Action(a.Action), models.ActorType(a.ActorType), a.ActorID, a.Summary)
+			// Mark the entry so it isn't replayed as a live event
+			details := make(map[string]interface{}, len(a.Details)+1)
+			for k, v := range a.Details {
+				details[k] = v
+			}
+			details[models.DetailImported] = true
+			if err := entry.SetDetails(details); err != nil {
+				imp.problem("ticket %s: invalid activity details: %v", et.Key, err)
+				continue
+			}
+			entry.CreatedAt = a.CreatedAt
+			entries = append(entries, entry)
+		}
+		if err := imp.activityRepo.ReplaceHistory(ticket.ID, entries); err != nil {
+			return newTransferError(ErrCodeDatabase, "%v", err)
+		}
+		imp.result.Activity += len(entries)
+	}
+	return nil
+}
+
+// syncBlocked blocks ready tickets with unresolved dependencies and readies
+// blocked tickets without any.
+func (imp *importer) syncBlocked(ticket *models.Ticket) error {
+	if ticket == nil || (ticket.Status != models.StatusReady && ticket.Status != models.StatusBlocked) {
+		return nil
+	}
+	unresolved, err := imp.depRepo.HasUnresolvedDependencies(ticket.ID)
+	if err != nil {
+		return newTransferError(ErrCodeDatabase, "%v", err)
+	}
+
+	status := ticket.Status
+	if unresolved && status == models.StatusReady {
+		status = models.StatusBlocked
+	} else if !unresolved && status == models.StatusBlocked {
+		status = models.StatusReady
+	}
+	if status == ticket.Status {
+		return nil
+	}
+	if err := imp.ticketRepo.UpdateStatus(ticket.ID, status); err != nil {
+		return newTransferError(ErrCodeDatabase, "%v", err)
+	}
+	ticket.Status = status
+	return nil
+}
@@ -0,0 +1,261 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spetersoncode/wark/internal/config"
+	"github.com/spetersoncode/wark/internal/db"
+	"github.com/spetersoncode/wark/internal/models"
+	"github.com/spetersoncode/wark/internal/tasks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seedTransferProject creates a project with an epic, a child with tasks and
+// labels, a dependent ticket, a custom role and a milestone.
+func seedTransferProject(t *testing.T, database *db.DB) {
+	t.Helper()
+
+	createTicketTestProject(t, database, "SRC")
+	require.NoError(t, db.NewRoleRepo(database.DB).Create(&models.Role{
+		Name: "migrator", Description: "Moves data", Instructions: "Be careful",
+	}))
+	_, err := NewMilestoneService(database.DB).Create(CreateInput{ProjectKey: "SRC", Key: "V1", Name: "Version 1"})
+	require.NoError(t, err)
+
+	svc := NewTicketService(database.DB)
+	_, err = svc.Create(CreateTicketInput{ProjectKey: "SRC", Title: "Data epic", Type: "epic"})
+	require.NoError(t, err)
+	child, err := svc.Create(CreateTicketInput{
+		ProjectKey: "SRC", Title: "Copy rows", ParentKey: "SRC-1", Priority: "high",
+		RoleName: "migrator", MilestoneKey: "V1", Labels: []string{"area:db"},
+	})
+	require.NoError(t, err)
+	_, err = svc.Create(CreateTicketInput{ProjectKey: "SRC", Title: "Verify rows", DependsOn: []string{"SRC-2"}})
+	require.NoError(t, err)
+
+	tasksRepo := db.NewTasksRepo(database.DB)
+	task, err := tasksRepo.CreateTask(context.Background(), child.ID, "Write script")
+	require.NoError(t, err)
+	require.NoError(t, tasksRepo.CompleteTask(context.Background(), task.ID))
+	_, err = tasksRepo.CreateTask(context.Background(), child.ID, "Run script")
+	require.NoError(t, err)
+
+	_, err = svc.Comment(child.ID, "Use batches of 1000", "")
+	require.NoError(t, err)
+}
+
+func TestTransferService_Export(t *testing.T) {
+	database, _, cleanup := testDB(t)
+	defer cleanup()
+	seedTransferProject(t, database)
+
+	doc, err := NewTransferService(database.DB).Export(ExportOptions{Projects: []string{"src"}})
+	require.NoError(t, err)
+
+	assert.Equal(t, ExportSchemaVersion, doc.Version)
+	require.Len(t, doc.Roles, 1)
+	assert.Equal(t, "migrator", doc.Roles[0].Name)
+	require.Len(t, doc.Projects, 1)
+
+	p := doc.Projects[0]
+	assert.Equal(t, "SRC", p.Key)
+	require.Len(t, p.Milestones, 1)
+	require.Len(t, p.Tickets, 3)
+
+	child := p.Tickets[1]
+	assert.Equal(t, "SRC-2", child.Key)
+	assert.Equal(t, "SRC-1", child.Parent)
+	assert.Equal(t, "migrator", child.Role)
+	assert.Equal(t, "V1", child.Milestone)
+	assert.Equal(t, []string{"area:db"}, child.Labels)
+	assert.Equal(t, []ExportTask{{Description: "Write script", Complete: true}, {Description: "Run script"}}, child.Tasks)
+	require.NotEmpty(t, child.Activity)
+	assert.Equal(t, string(models.ActionCreated), child.Activity[0].Action, "history is oldest first")
+
+	assert.Equal(t, []string{"SRC-2"}, p.Tickets[2].DependsOn)
+
+	_, err = NewTransferService(database.DB).Export(ExportOptions{Projects: []string{"NOPE"}})
+	var te *TransferError
+	require.ErrorAs(t, err, &te)
+	assert.Equal(t, ErrCodeProjectNotFound, te.Code)
+
+	doc, err = NewTransferService(database.DB).Export(ExportOptions{NoActivity: true})
+	require.NoError(t, err)
+	assert.Empty(t, doc.Projects[0].Tickets[1].Activity)
+}
+
+func TestTransferService_Import(t *testing.T) {
+	source, _, cleanupSource := testDB(t)
+	defer cleanupSource()
+	seedTransferProject(t, source)
+
+	doc, err := NewTransferService(source.DB).Export(ExportOptions{})
+	require.NoError(t, err)
+
+	target, _, cleanupTarget := testDB(t)
+	defer cleanupTarget()
+	svc := NewTransferService(target.DB)
+	ticketRepo := db.NewTicketRepo(target.DB)
+
+	t.Run("dry run changes nothing", func(t *testing.T) {
+		result, err := svc.Import(doc, ImportOptions{DryRun: true})
+		require.NoError(t, err)
+		assert.True(t, result.DryRun)
+		assert.Equal(t, 3, result.Tickets)
+		assert.Equal(t, []string{"migrator"}, result.RolesCreated)
+
+		project, err := db.NewProjectRepo(target.DB).GetByKey("SRC")
+		require.NoError(t, err)
+		assert.Nil(t, project)
+	})
+
+	t.Run("into a new database keeps numbers", func(t *testing.T) {
+		result, err := svc.Import(doc, ImportOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, 3, result.Tickets)
+		assert.Equal(t, 1, result.Dependencies)
+		assert.Equal(t, 2, result.Tasks)
+		require.Len(t, result.Projects, 1)
+		assert.True(t, result.Projects[0].Created)
+		assert.False(t, result.Projects[0].Renumbered)
+		assert.Equal(t, "SRC-2", result.KeyMap["SRC-2"])
+
+		child, err := ticketRepo.GetByKey("SRC", 2)
+		require.NoError(t, err)
+		assert.Equal(t, models.PriorityHigh, child.Priority)
+		assert.Equal(t, "migrator", child.RoleName)
+		assert.Equal(t, "V1", child.MilestoneKey)
+		assert.Equal(t, []string{"area:db"}, child.Labels)
+		assert.Equal(t, "SRC-1-data-epic", child.Worktree)
+
+		history, err := db.NewActivityRepo(target.DB).ListByTicket(child.ID, 0)
+		require.NoError(t, err)
+		assert.Len(t, history, len(doc.Projects[0].Tickets[1].Activity), "history replaces the trigger entry")
+
+		blocked, err := ticketRepo.GetByKey("SRC", 3)
+		require.NoError(t, err)
+		assert.Equal(t, models.StatusBlocked, blocked.Status)
+	})
+
+	t.Run("into a project with tickets renumbers", func(t *testing.T) {
+		result, err := svc.Import(doc, ImportOptions{})
+		require.NoError(t, err)
+		assert.True(t, result.Projects[0].Renumbered)
+		assert.Equal(t, map[string]string{"SRC-1": "SRC-4", "SRC-2": "SRC-5", "SRC-3": "SRC-6"}, result.KeyMap)
+
+		child, err := ticketRepo.GetByKey("SRC", 5)
+		require.NoError(t, err)
+		require.NotNil(t, child.ParentTicketID)
+		parent, err := ticketRepo.GetByID(*child.ParentTicketID)
+		require.NoError(t, err)
+		assert.Equal(t, 4, parent.Number)
+		assert.Equal(t, "SRC-4-data-epic", child.Worktree)
+
+		deps, err := db.NewDependencyRepo(target.DB).GetDependencies(mustTicketID(t, ticketRepo, "SRC", 6))
+		require.NoError(t, err)
+		require.Len(t, deps, 1)
+		assert.Equal(t, "SRC-5", deps[0].TicketKey)
+	})
+
+	t.Run("under another project key", func(t *testing.T) {
+		result, err := svc.Import(doc, ImportOptions{ProjectKey: "dst"})
+		require.NoError(t, err)
+		assert.Equal(t, "DST-3", result.KeyMap["SRC-3"])
+	})
+}
+
+func TestTransferService_ImportIsNotReplayed(t *testing.T) {
+	source, _, cleanupSource := testDB(t)
+	defer cleanupSource()
+	seedTransferProject(t, source)
+	ticketID := mustTicketID(t, db.NewTicketRepo(source.DB), "SRC", 2)
+	require.NoError(t, db.NewActivityRepo(source.DB).LogAction(ticketID, models.ActionEscalated, models.ActorTypeAgent, "agent-1", "Needs a decision"))
+
+	doc, err := NewTransferService(source.DB).Export(ExportOptions{})
+	require.NoError(t, err)
+
+	target, _, cleanupTarget := testDB(t)
+	defer cleanupTarget()
+	dispatcher, err := tasks.NewWebhookDispatcher(target.DB, []config.WebhookConfig{
+		{Name: "everything", URL: "http://127.0.0.1:0"},
+	})
+	require.NoError(t, err)
+	ctx := context.Background()
+	_, err = dispatcher.RunOnce(ctx)
+	require.NoError(t, err)
+
+	_, err = NewTransferService(target.DB).Import(doc, ImportOptions{})
+	require.NoError(t, err)
+
+	// The escalation and other history came from the source database
+	result, err := dispatcher.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Queued)
+}
+
+func TestTransferService_ImportValidation(t *testing.T) {
+	database, _, cleanup := testDB(t)
+	defer cleanup()
+	svc := NewTransferService(database.DB)
+
+	requireTransferError := func(t *testing.T, err error, code string) *TransferError {
+		t.Helper()
+		var te *TransferError
+		require.ErrorAs(t, err, &te)
+		assert.Equal(t, code, te.Code)
+		return te
+	}
+
+	_, err := svc.Import(&ExportDocument{Version: 99}, ImportOptions{})
+	requireTransferError(t, err, ErrCodeUnsupportedVersion)
+
+	doc := &ExportDocument{
+		Version: ExportSchemaVersion,
+		Projects: []ExportProject{{
+			Key:  "BAD",
+			Name: "Bad",
+			Tickets: []ExportTicket{
+				{Key: "BAD-1", Title: "", Status: "ready"},
+				{Key: "BAD-2", Title: "x", Status: "closed"},
+				{Key: "OTHER-3", Title: "x", Status: "ready", Priority: "urgent"},
+			},
+		}},
+	}
+	_, err = svc.Import(doc, ImportOptions{})
+	te := requireTransferError(t, err, ErrCodeInvalidDocument)
+	assert.Len(t, te.Problems, 4)
+
+	// Cycles are caught by the dependency check, and nothing is kept
+	cyclic := &ExportDocument{
+		Version: ExportSchemaVersion,
+		Projects: []ExportProject{{
+			Key:  "CYC",
+			Name: "Cycle",
+			Tickets: []ExportTicket{
+				{Key: "CYC-1", Title: "a", Status: "ready", DependsOn: []string{"CYC-2"}},
+				{Key: "CYC-2", Title: "b", Status: "ready", DependsOn: []string{"CYC-1"}},
+				{Key: "CYC-3", Title: "c", Status: "ready", DependsOn: []string{"GONE-1"}},
+			},
+		}},
+	}
+	for _, dryRun := range []bool{true, false} {
+		_, err = svc.Import(cyclic, ImportOptions{DryRun: dryRun})
+		te = requireTransferError(t, err, ErrCodeInvalidDocument)
+		require.Len(t, te.Problems, 2)
+		assert.Contains(t, te.Problems[0], "circular")
+		assert.Contains(t, te.Problems[1], "GONE-1 not found")
+	}
+	project, err := db.NewProjectRepo(database.DB).GetByKey("CYC")
+	require.NoError(t, err)
+	assert.Nil(t, project)
+}
+
+func mustTicketID(t *testing.T, repo *db.TicketRepo, projectKey string, number int) int64 {
+	t.Helper()
+	ticket, err := repo.GetByKey(projectKey, number)
+	require.NoError(t, err)
+	require.NotNil(t, ticket)
+	return ticket.ID
+}
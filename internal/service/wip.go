@@ -0,0 +1,123 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spetersoncode/wark/internal/models"
+)
+
+// ProjectWIP is a project's work in progress against its WIP limits.
+type ProjectWIP struct {
+	ProjectKey string `json:"project_key"`
+	models.WIPUsage
+	// WorkingFull and ReviewingFull are set when no more tickets can be
+	// claimed or start review.
+	WorkingFull   bool `json:"working_full"`
+	ReviewingFull bool `json:"reviewing_full"`
+}
+
+// checkWIPLimit refuses to move another of the ticket's project's tickets into
+// status when that would break one of the project's WIP limits.
+func (s *TicketService) checkWIPLimit(ticket *models.Ticket, status models.Status) error {
+	usage, err := s.projectRepo.GetWIPUsage(ticket.ProjectID)
+	if err != nil {
+		return newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to get WIP usage: %v", err), nil)
+	}
+	if usage == nil {
+		return nil
+	}
+	if reason := usage.Exceeded(status); reason != "" {
+		return wipLimitError(ticket.ProjectKey, reason, usage)
+	}
+	return nil
+}
+
+func wipLimitError(projectKey, reason string, usage *models.WIPUsage) *TicketError {
+	return newTicketError(ErrCodeWIPLimit,
+		fmt.Sprintf("project %s is at its WIP limit (%s)", projectKey, reason),
+		map[string]interface{}{
+			"project":   projectKey,
+			"working":   usage.Working,
+			"reviewing": usage.Reviewing,
+			"limits":    usage.Limits,
+		})
+}
+
+// withinWIPLimits drops candidates whose project can't take another working
+// ticket. If every candidate is dropped, the error names the full projects.
+func (s *TicketService) withinWIPLimits(candidates []*models.Ticket) ([]*models.Ticket, error) {
+	usage := make(map[int64]*models.WIPUsage)
+	full := make(map[string]*models.WIPUsage)
+	var kept []*models.Ticket
+	for _, t := range candidates {
+		u, ok := usage[t.ProjectID]
+		if !ok {
+			var err error
+			if u, err = s.projectRepo.GetWIPUsage(t.ProjectID); err != nil {
+				return nil, newTicketError(ErrCodeDatabase, fmt.Sprintf("failed to get WIP usage: %v", err), nil)
+			}
+			usage[t.ProjectID] = u
+		}
+		if u != nil {
+			if u.Exceeded(models.StatusWorking) != "" {
+				full[t.ProjectKey] = u
+				continue
+			}
+		}
+		kept = append(kept, t)
+	}
+
+	if len(kept) == 0 && len(full) > 0 {
+		keys := make([]string, 0, len(full))
+		for key := range full {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		if len(keys) == 1 {
+			u := full[keys[0]]
+			return nil, wipLimitError(keys[0], u.Exceeded(models.StatusWorking), u)
+		}
+		parts := make([]string, len(keys))
+		for i, key := range keys {
+			parts[i] = fmt.Sprintf("%s (%s)", key, full[key].Exceeded(models.StatusWorking))
+		}
+		return nil, newTicketError(ErrCodeWIPLimit,
+			fmt.Sprintf("workable tickets are only in projects at their WIP limit: %s", strings.Join(parts, ", ")),
+			map[string]interface{}{"projects": keys})
+	}
+	return kept, nil
+}
+
+// WIPUsage returns work in progress for every project with a WIP limit, or
+// only for projectKey if given.
+func (s *StatusService) WIPUsage(projectKey string) ([]ProjectWIP, error) {
+	result := []ProjectWIP{}
+	if s.projectRepo == nil {
+		return result, nil
+	}
+	projects, err := s.projectRepo.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range projects {
+		if p.WIPLimits.IsZero() || (projectKey != "" && p.Key != strings.ToUpper(projectKey)) {
+			continue
+		}
+		usage, err := s.projectRepo.GetWIPUsage(p.ID)
+		if err != nil {
+			return nil, err
+		}
+		if usage == nil {
+			continue
+		}
+		result = append(result, ProjectWIP{
+			ProjectKey:    p.Key,
+			WIPUsage:      *usage,
+			WorkingFull:   usage.Exceeded(models.StatusWorking) != "",
+			ReviewingFull: usage.Exceeded(models.StatusReviewing) != "",
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spetersoncode/wark/internal/db"
+	"github.com/spetersoncode/wark/internal/errors"
+	"github.com/spetersoncode/wark/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setWIPLimits(t *testing.T, database *db.DB, key string, limits models.WIPLimits) {
+	t.Helper()
+	repo := db.NewProjectRepo(database.DB)
+	project, err := repo.GetByKey(key)
+	require.NoError(t, err)
+	project.WIPLimits = limits
+	require.NoError(t, repo.Update(project))
+}
+
+func requireWIPLimit(t *testing.T, err error, contains string) {
+	t.Helper()
+	var te *TicketError
+	require.ErrorAs(t, err, &te)
+	assert.Equal(t, ErrCodeWIPLimit, te.Code)
+	assert.Equal(t, errors.KindWIPLimit, te.Kind())
+	assert.Contains(t, te.Message, contains)
+}
+
+func TestTicketService_WIPLimits(t *testing.T) {
+	database, _, cleanup := testDB(t)
+	defer cleanup()
+	createTicketTestProject(t, database, "WIP")
+	svc := NewTicketService(database.DB)
+	ticketRepo := db.NewTicketRepo(database.DB)
+
+	for i := 0; i < 4; i++ {
+		ticket, err := svc.Create(CreateTicketInput{ProjectKey: "WIP", Title: "Work"})
+		require.NoError(t, err)
+		require.NoError(t, svc.Prioritize(ticket.ID))
+	}
+	setWIPLimits(t, database, "WIP", models.WIPLimits{Total: 3, Working: 2, Reviewing: 1})
+
+	_, err := svc.Claim(mustTicketID(t, ticketRepo, "WIP", 1), "agent", time.Hour)
+	require.NoError(t, err)
+	_, err = svc.ClaimNext(db.TicketFilter{ProjectKey: "WIP"}, "agent", time.Hour)
+	require.NoError(t, err)
+
+	// Two working reaches the working limit
+	_, err = svc.Claim(mustTicketID(t, ticketRepo, "WIP", 3), "agent", time.Hour)
+	requireWIPLimit(t, err, "project WIP is at its WIP limit (2 of 2 working)")
+	_, err = svc.ClaimNext(db.TicketFilter{ProjectKey: "WIP"}, "agent", time.Hour)
+	requireWIPLimit(t, err, "2 of 2 working")
+	ticket, err := ticketRepo.GetByID(mustTicketID(t, ticketRepo, "WIP", 3))
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusReady, ticket.Status, "a refused claim leaves the ticket ready")
+
+	// Moving one to review frees a working slot; starting review fills the
+	// reviewing limit
+	require.NoError(t, ticketRepo.UpdateStatus(mustTicketID(t, ticketRepo, "WIP", 1), models.StatusReview))
+	require.NoError(t, svc.StartReview(mustTicketID(t, ticketRepo, "WIP", 1)))
+	require.NoError(t, ticketRepo.UpdateStatus(mustTicketID(t, ticketRepo, "WIP", 2), models.StatusReview))
+	err = svc.StartReview(mustTicketID(t, ticketRepo, "WIP", 2))
+	requireWIPLimit(t, err, "1 of 1 reviewing")
+
+	// With WIP-2 waiting in review, one ticket is working and one reviewing
+	_, err = svc.ClaimNext(db.TicketFilter{ProjectKey: "WIP"}, "agent", time.Hour)
+	require.NoError(t, err)
+	setWIPLimits(t, database, "WIP", models.WIPLimits{Total: 2})
+	_, err = svc.Claim(mustTicketID(t, ticketRepo, "WIP", 4), "agent", time.Hour)
+	requireWIPLimit(t, err, "2 of 2 in progress")
+
+	// Without limits nothing is refused
+	setWIPLimits(t, database, "WIP", models.WIPLimits{})
+	_, err = svc.Claim(mustTicketID(t, ticketRepo, "WIP", 4), "agent", time.Hour)
+	require.NoError(t, err)
+}
+
+func TestTicketService_NextSkipsFullProjects(t *testing.T) {
+	database, _, cleanup := testDB(t)
+	defer cleanup()
+	createTicketTestProject(t, database, "FULL")
+	createTicketTestProject(t, database, "OPEN")
+	svc := NewTicketService(database.DB)
+
+	for _, input := range []CreateTicketInput{
+		{ProjectKey: "FULL", Title: "Urgent", Priority: "highest"},
+		{ProjectKey: "FULL", Title: "Also urgent", Priority: "highest"},
+		{ProjectKey: "OPEN", Title: "Routine", Priority: "low"},
+	} {
+		ticket, err := svc.Create(input)
+		require.NoError(t, err)
+		require.NoError(t, svc.Prioritize(ticket.ID))
+	}
+	setWIPLimits(t, database, "FULL", models.WIPLimits{Working: 1})
+
+	result, err := svc.ClaimNext(db.TicketFilter{}, "agent", time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, "FULL-1", result.Ticket.TicketKey)
+
+	next, err := svc.NextWorkable(db.TicketFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, "OPEN-1", next.TicketKey, "FULL is at its limit, so its higher priority ticket is skipped")
+
+	_, err = svc.NextWorkable(db.TicketFilter{ProjectKey: "FULL"})
+	requireWIPLimit(t, err, "project FULL is at its WIP limit")
+
+	status := NewStatusService(db.NewTicketRepo(database.DB), db.NewInboxRepo(database.DB),
+		db.NewClaimRepo(database.DB), db.NewActivityRepo(database.DB)).WithProjectRepo(db.NewProjectRepo(database.DB))
+	summary, err := status.GetSummary("")
+	require.NoError(t, err)
+	require.Len(t, summary.WIP, 1, "only projects with limits are reported")
+	assert.Equal(t, "FULL", summary.WIP[0].ProjectKey)
+	assert.Equal(t, 1, summary.WIP[0].Working)
+	assert.True(t, summary.WIP[0].WorkingFull)
+	assert.False(t, summary.WIP[0].ReviewingFull)
+}
@@ -1,7 +1,6 @@
 package tasks
 
 import (
-	"database/sql"
 	"fmt"
 	"time"
 
@@ -42,14 +41,15 @@ type ResolutionResult struct {
 
 // DependencyResolver handles automatic dependency resolution.
 type DependencyResolver struct {
-	db           *sql.DB
+	db           db.DBTX
 	ticketRepo   *db.TicketRepo
 	depRepo      *db.DependencyRepo
 	activityRepo *db.ActivityRepo
 }
 
 // NewDependencyResolver creates a new DependencyResolver.
-func NewDependencyResolver(database *sql.DB) *DependencyResolver {
+// Pass a *sql.Tx to run resolution as part of a larger transaction.
+func NewDependencyResolver(database db.DBTX) *DependencyResolver {
 	return &DependencyResolver{
 		db:           database,
 		ticketRepo:   db.NewTicketRepo(database),
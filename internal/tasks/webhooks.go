@@ -0,0 +1,402 @@
+package tasks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/spetersoncode/wark/internal/config"
+	"github.com/spetersoncode/wark/internal/db"
+	"github.com/spetersoncode/wark/internal/models"
+)
+
+const (
+	// DefaultWebhookMaxAttempts is how many times a delivery is tried when a
+	// webhook doesn't set max_attempts.
+	DefaultWebhookMaxAttempts = 8
+
+	// WebhookTestEvent is the event type sent by `wark webhook test`.
+	WebhookTestEvent models.EventType = "webhook.test"
+
+	// SignatureHeader carries the payload's HMAC-SHA256 signature as "sha256=<hex>".
+	SignatureHeader = "X-Wark-Signature"
+
+	webhookBatchSize = 200
+	webhookTimeout   = 10 * time.Second
+
+	// webhookLease is how long a claimed delivery is held for the dispatcher
+	// sending it; longer than a send can take.
+	webhookLease = 2 * webhookTimeout
+)
+
+// WebhookPayload is the JSON body POSTed to a webhook.
+type WebhookPayload struct {
+	Webhook string        `json:"webhook"`
+	Event   *models.Event `json:"event"`
+}
+
+// DispatchResult summarizes one dispatcher run.
+type DispatchResult struct {
+	Queued    int `json:"queued"`
+	Delivered int `json:"delivered"`
+	Retrying  int `json:"retrying"`
+	Failed    int `json:"failed"`
+}
+
+// WebhookTestResult is the outcome of sending a test event to a webhook.
+type WebhookTestResult struct {
+	Webhook    string `json:"webhook"`
+	URL        string `json:"url"`
+	StatusCode int    `json:"status_code,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
+}
+
+// webhook is a configured webhook with its filters parsed.
+type webhook struct {
+	config.WebhookConfig
+	events   map[models.EventType]bool
+	statuses map[string]bool
+}
+
+// matches reports whether the webhook wants an event.
+func (h *webhook) matches(e *models.Event) bool {
+	if len(h.events) > 0 && !h.events[e.Type] {
+		return false
+	}
+	if len(h.statuses) > 0 && e.Type == models.EventTicketStatusChanged {
+		to, _ := e.Data["to"].(string)
+		return h.statuses[to]
+	}
+	return true
+}
+
+// WebhookDispatcher turns activity into webhook deliveries and sends them.
+// New activity is read after a cursor stored in the database, so events
+// written by any wark process are delivered, and each event is queued at most
+// once per webhook. Failed deliveries are retried with exponential backoff.
+type WebhookDispatcher struct {
+	db           *sql.DB
+	hooks        []*webhook
+	client       *http.Client
+	activityRepo *db.ActivityRepo
+	webhookRepo  *db.WebhookRepo
+
+	// backoff returns the delay before retrying after the given attempt.
+	backoff func(attempt int) time.Duration
+}
+
+// NewWebhookDispatcher creates a dispatcher for the configured webhooks.
+// It returns an error if a webhook is misconfigured.
+func NewWebhookDispatcher(database *sql.DB, hooks []config.WebhookConfig) (*WebhookDispatcher, error) {
+	d := &WebhookDispatcher{
+		db:           database,
+		client:       &http.Client{Timeout: webhookTimeout},
+		activityRepo: db.NewActivityRepo(database),
+		webhookRepo:  db.NewWebhookRepo(database),
+		backoff:      webhookBackoff,
+	}
+
+	seen := make(map[string]bool)
+	for _, cfg := range hooks {
+		if cfg.Name == "" {
+			return nil, fmt.Errorf("webhook with url %q has no name", cfg.URL)
+		}
+		if seen[cfg.Name] {
+			return nil, fmt.Errorf("duplicate webhook name: %s", cfg.Name)
+		}
+		seen[cfg.Name] = true
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("webhook %s has no url", cfg.Name)
+		}
+		if cfg.MaxAttempts <= 0 {
+			cfg.MaxAttempts = DefaultWebhookMaxAttempts
+		}
+
+		h := &webhook{WebhookConfig: cfg, events: map[models.EventType]bool{}, statuses: map[string]bool{}}
+		types, err := models.ParseEventTypes(cfg.Events)
+		if err != nil {
+			return nil, fmt.Errorf("webhook %s: %w", cfg.Name, err)
+		}
+		for _, t := range types {
+			h.events[t] = true
+		}
+		for _, s := range cfg.Statuses {
+			status, err := models.ParseStatus(s)
+			if err != nil {
+				return nil, fmt.Errorf("webhook %s: %w", cfg.Name, err)
+			}
+			h.statuses[string(status)] = true
+		}
+		d.hooks = append(d.hooks, h)
+	}
+
+	return d, nil
+}
+
+// webhookBackoff waits 30s after the first failed attempt, doubling each
+// time up to an hour.
+func webhookBackoff(attempt int) time.Duration {
+	delay := 30 * time.Second
+	for i := 1; i < attempt && delay < time.Hour; i++ {
+		delay *= 2
+	}
+	if delay > time.Hour {
+		delay = time.Hour
+	}
+	return delay
+}
+
+func (d *WebhookDispatcher) hook(name string) *webhook {
+	for _, h := range d.hooks {
+		if h.Name == name {
+			return h
+		}
+	}
+	return nil
+}
+
+// RunOnce queues new events and sends any deliveries that are due.
+func (d *WebhookDispatcher) RunOnce(ctx context.Context) (*DispatchResult, error) {
+	result := &DispatchResult{}
+
+	queued, err := d.Enqueue()
+	if err != nil {
+		return nil, err
+	}
+	result.Queued = queued
+
+	if err := d.DeliverDue(ctx, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Enqueue queues a delivery for each webhook matching activity written
+// since the last run, and returns how many were queued. The first run only
+// records the current position, so webhooks start with new activity.
+func (d *WebhookDispatcher) Enqueue() (int, error) {
+	cursor, ok, err := d.webhookRepo.Cursor()
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		latest, err := d.activityRepo.LatestID()
+		if err != nil {
+			return 0, err
+		}
+		return 0, d.webhookRepo.SetCursor(latest)
+	}
+
+	queued := 0
+	for {
+		entries, err := d.activityRepo.ListAfter(cursor, webhookBatchSize)
+		if err != nil {
+			return queued, err
+		}
+		if len(entries) == 0 {
+			return queued, nil
+		}
+
+		// Queue the batch and advance the cursor together
+		err = db.WithTx(d.db, func(tx *sql.Tx) error {
+			repo := db.NewWebhookRepo(tx)
+			for _, entry := range entries {
+				event, ok := models.EventFromActivity(entry)
+				if !ok {
+					continue
+				}
+				for _, h := range d.hooks {
+					if !h.matches(event) {
+						continue
+					}
+					payload, err := json.Marshal(WebhookPayload{Webhook: h.Name, Event: event})
+					if err != nil {
+						return err
+					}
+					added, err := repo.Enqueue(&models.WebhookDelivery{
+						Webhook:   h.Name,
+						EventID:   event.ID,
+						EventType: event.Type,
+						TicketKey: event.TicketKey,
+						Payload:   string(payload),
+					})
+					if err != nil {
+						return err
+					}
+					if added {
+						queued++
+					}
+				}
+			}
+			return repo.SetCursor(entries[len(entries)-1].ID)
+		})
+		if err != nil {
+			return queued, err
+		}
+		cursor = entries[len(entries)-1].ID
+
+		if len(entries) < webhookBatchSize {
+			return queued, nil
+		}
+	}
+}
+
+// DeliverDue sends pending deliveries whose next attempt is due, adding
+// the outcomes to result.
+func (d *WebhookDispatcher) DeliverDue(ctx context.Context, result *DispatchResult) error {
+	due, err := d.webhookRepo.ListDue(time.Now(), webhookBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, delivery := range due {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		// Another dispatcher may be sending the same deliveries
+		now := time.Now()
+		claimed, err := d.webhookRepo.Claim(delivery.ID, now, now.Add(webhookLease))
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			continue
+		}
+		delivery.Attempts++
+		delivery.LastAttemptAt = &now
+
+		h := d.hook(delivery.Webhook)
+		if h == nil {
+			delivery.Status = models.DeliveryStatusFailed
+			delivery.ResponseCode = 0
+			delivery.LastError = "webhook is no longer configured"
+		} else {
+			code, err := d.send(ctx, h.WebhookConfig, delivery.EventType, delivery.ID, []byte(delivery.Payload))
+			delivery.ResponseCode = code
+			if err == nil {
+				delivery.Status = models.DeliveryStatusDelivered
+				delivery.LastError = ""
+				delivery.DeliveredAt = &now
+			} else {
+				delivery.LastError = err.Error()
+				if delivery.Attempts >= h.MaxAttempts {
+					delivery.Status = models.DeliveryStatusFailed
+				} else {
+					delivery.NextAttemptAt = now.Add(d.backoff(delivery.Attempts))
+				}
+			}
+		}
+
+		if err := d.webhookRepo.RecordAttempt(delivery); err != nil {
+			return err
+		}
+		switch delivery.Status {
+		case models.DeliveryStatusDelivered:
+			result.Delivered++
+		case models.DeliveryStatusFailed:
+			result.Failed++
+		default:
+			result.Retrying++
+		}
+	}
+
+	return nil
+}
+
+// Test sends a test event to the named webhook without queueing it.
+func (d *WebhookDispatcher) Test(ctx context.Context, name string) (*WebhookTestResult, error) {
+	h := d.hook(name)
+	if h == nil {
+		return nil, fmt.Errorf("webhook %s is not configured", name)
+	}
+
+	payload, err := json.Marshal(WebhookPayload{
+		Webhook: h.Name,
+		Event: &models.Event{
+			Type:      WebhookTestEvent,
+			ActorType: models.ActorTypeHuman,
+			Summary:   "Test event from wark",
+			CreatedAt: time.Now(),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &WebhookTestResult{Webhook: h.Name, URL: h.URL}
+	start := time.Now()
+	result.StatusCode, err = d.send(ctx, h.WebhookConfig, WebhookTestEvent, 0, payload)
+	result.DurationMs = time.Since(start).Milliseconds()
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result, nil
+}
+
+// send POSTs a payload to a webhook. Any 2xx response is a success.
+func (d *WebhookDispatcher) send(ctx context.Context, hook config.WebhookConfig, eventType models.EventType, deliveryID int64, payload []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "wark-webhook")
+	req.Header.Set("X-Wark-Event", string(eventType))
+	if deliveryID > 0 {
+		req.Header.Set("X-Wark-Delivery", strconv.FormatInt(deliveryID, 10))
+	}
+	if hook.Secret != "" {
+		req.Header.Set(SignatureHeader, SignPayload(hook.Secret, payload))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected response: %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// SignPayload returns the signature header value for a payload:
+// "sha256=" followed by the hex HMAC-SHA256 of the body keyed by secret.
+func SignPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// RunDaemon queues and sends webhook deliveries every interval until ctx
+// is canceled.
+func (d *WebhookDispatcher) RunDaemon(ctx context.Context, interval time.Duration, callback func(*DispatchResult, error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		result, err := d.RunOnce(ctx)
+		if callback != nil {
+			callback(result, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
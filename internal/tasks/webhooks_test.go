@@ -0,0 +1,311 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spetersoncode/wark/internal/config"
+	"github.com/spetersoncode/wark/internal/db"
+	"github.com/spetersoncode/wark/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// webhookReceiver records requests and answers with a configurable status.
+type webhookReceiver struct {
+	mu       sync.Mutex
+	status   int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func newWebhookReceiver(t *testing.T) (*webhookReceiver, *httptest.Server) {
+	t.Helper()
+	recv := &webhookReceiver{status: http.StatusOK}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		recv.mu.Lock()
+		recv.requests = append(recv.requests, r)
+		recv.bodies = append(recv.bodies, body)
+		status := recv.status
+		recv.mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	return recv, srv
+}
+
+func (r *webhookReceiver) setStatus(status int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.status = status
+}
+
+func (r *webhookReceiver) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.requests)
+}
+
+// setupWebhookTicket creates a ticket and returns its ID.
+func setupWebhookTicket(t *testing.T, database *db.DB) int64 {
+	t.Helper()
+	projectRepo := db.NewProjectRepo(database.DB)
+	project := &models.Project{Key: "HOOK", Name: "Hooks"}
+	require.NoError(t, projectRepo.Create(project))
+
+	ticket := &models.Ticket{
+		ProjectID:  project.ID,
+		Title:      "Webhook ticket",
+		Priority:   models.PriorityMedium,
+		Complexity: models.ComplexityMedium,
+		Status:     models.StatusReady,
+	}
+	require.NoError(t, db.NewTicketRepo(database.DB).Create(ticket))
+	return ticket.ID
+}
+
+func TestWebhookDispatcher_Config(t *testing.T) {
+	database, cleanup := testDB(t)
+	defer cleanup()
+
+	_, err := NewWebhookDispatcher(database.DB, []config.WebhookConfig{{URL: "http://example.com"}})
+	assert.Error(t, err)
+
+	_, err = NewWebhookDispatcher(database.DB, []config.WebhookConfig{{Name: "a"}})
+	assert.Error(t, err)
+
+	_, err = NewWebhookDispatcher(database.DB, []config.WebhookConfig{
+		{Name: "a", URL: "http://example.com"},
+		{Name: "a", URL: "http://example.com"},
+	})
+	assert.Error(t, err)
+
+	_, err = NewWebhookDispatcher(database.DB, []config.WebhookConfig{
+		{Name: "a", URL: "http://example.com", Events: []string{"ticket.exploded"}},
+	})
+	assert.Error(t, err)
+
+	_, err = NewWebhookDispatcher(database.DB, []config.WebhookConfig{
+		{Name: "a", URL: "http://example.com", Statuses: []string{"finished"}},
+	})
+	assert.Error(t, err)
+}
+
+func TestWebhookDispatcher_DeliversSignedEvents(t *testing.T) {
+	database, cleanup := testDB(t)
+	defer cleanup()
+
+	recv, srv := newWebhookReceiver(t)
+	ticketID := setupWebhookTicket(t, database)
+
+	dispatcher, err := NewWebhookDispatcher(database.DB, []config.WebhookConfig{
+		{Name: "closed", URL: srv.URL, Secret: "s3cret", Events: []string{"ticket.status_changed"}, Statuses: []string{"closed"}},
+		{Name: "escalations", URL: srv.URL, Events: []string{"inbox.message"}},
+	})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// The first run starts the cursor after existing activity
+	result, err := dispatcher.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Queued)
+
+	activityRepo := db.NewActivityRepo(database.DB)
+	ticketRepo := db.NewTicketRepo(database.DB)
+	ticket, err := ticketRepo.GetByID(ticketID)
+	require.NoError(t, err)
+
+	// A review transition matches neither webhook; closing matches one
+	ticket.Status = models.StatusReview
+	require.NoError(t, ticketRepo.Update(ticket))
+	ticket.Status = models.StatusClosed
+	require.NoError(t, ticketRepo.Update(ticket))
+	require.NoError(t, activityRepo.LogAction(ticketID, models.ActionEscalated, models.ActorTypeAgent, "agent-1", "Needs a decision"))
+
+	result, err = dispatcher.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Queued)
+	assert.Equal(t, 2, result.Delivered)
+	require.Equal(t, 2, recv.count())
+
+	var signed *http.Request
+	var signedBody []byte
+	for i, req := range recv.requests {
+		if req.Header.Get(SignatureHeader) != "" {
+			signed, signedBody = req, recv.bodies[i]
+		}
+	}
+	require.NotNil(t, signed, "closed webhook should be signed")
+	assert.Equal(t, SignPayload("s3cret", signedBody), signed.Header.Get(SignatureHeader))
+	assert.Equal(t, string(models.EventTicketStatusChanged), signed.Header.Get("X-Wark-Event"))
+	assert.NotEmpty(t, signed.Header.Get("X-Wark-Delivery"))
+
+	var payload WebhookPayload
+	require.NoError(t, json.Unmarshal(signedBody, &payload))
+	assert.Equal(t, "closed", payload.Webhook)
+	assert.Equal(t, "HOOK-1", payload.Event.TicketKey)
+	assert.Equal(t, "closed", payload.Event.Data["to"])
+
+	// Nothing new, nothing sent again
+	result, err = dispatcher.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Queued)
+	assert.Equal(t, 2, recv.count())
+
+	counts, err := db.NewWebhookRepo(database.DB).CountByStatus("closed")
+	require.NoError(t, err)
+	assert.Equal(t, 1, counts[models.DeliveryStatusDelivered])
+}
+
+func TestWebhookDispatcher_DeliversOnceAcrossDispatchers(t *testing.T) {
+	database, cleanup := testDB(t)
+	defer cleanup()
+
+	ticketID := setupWebhookTicket(t, database)
+	hooks := func(url string) []config.WebhookConfig {
+		return []config.WebhookConfig{{Name: "escalations", URL: url, Events: []string{"inbox.message"}}}
+	}
+
+	// While the first dispatcher is sending, a second one runs against the
+	// same database, as 'wark webhook run --daemon' can alongside 'wark serve'
+	var other *WebhookDispatcher
+	var otherResult DispatchResult
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			require.NoError(t, other.DeliverDue(r.Context(), &otherResult))
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	dispatcher, err := NewWebhookDispatcher(database.DB, hooks(srv.URL))
+	require.NoError(t, err)
+	other, err = NewWebhookDispatcher(database.DB, hooks(srv.URL))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = dispatcher.RunOnce(ctx)
+	require.NoError(t, err)
+	require.NoError(t, db.NewActivityRepo(database.DB).LogAction(ticketID, models.ActionEscalated, models.ActorTypeAgent, "agent-1", "Needs a decision"))
+
+	result, err := dispatcher.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Delivered)
+	assert.Equal(t, 1, requests)
+	assert.Equal(t, DispatchResult{}, otherResult)
+
+	deliveries, err := db.NewWebhookRepo(database.DB).List(db.DeliveryFilter{})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, 1, deliveries[0].Attempts)
+}
+
+func TestWebhookDispatcher_RetriesThenFails(t *testing.T) {
+	database, cleanup := testDB(t)
+	defer cleanup()
+
+	recv, srv := newWebhookReceiver(t)
+	recv.setStatus(http.StatusInternalServerError)
+	ticketID := setupWebhookTicket(t, database)
+
+	dispatcher, err := NewWebhookDispatcher(database.DB, []config.WebhookConfig{
+		{Name: "flaky", URL: srv.URL, Events: []string{"comment.created"}, MaxAttempts: 3},
+	})
+	require.NoError(t, err)
+	// Make retries due immediately
+	dispatcher.backoff = func(int) time.Duration { return -time.Second }
+	ctx := context.Background()
+
+	_, err = dispatcher.RunOnce(ctx)
+	require.NoError(t, err)
+
+	activityRepo := db.NewActivityRepo(database.DB)
+	require.NoError(t, activityRepo.LogAction(ticketID, models.ActionComment, models.ActorTypeHuman, "", "first"))
+	require.NoError(t, activityRepo.LogAction(ticketID, models.ActionComment, models.ActorTypeHuman, "", "second"))
+
+	result, err := dispatcher.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Queued)
+	assert.Equal(t, 2, result.Retrying)
+
+	// The receiver recovers for the retry
+	recv.setStatus(http.StatusNoContent)
+	result, err = dispatcher.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Delivered)
+
+	deliveries, err := db.NewWebhookRepo(database.DB).List(db.DeliveryFilter{Webhook: "flaky"})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+	for _, d := range deliveries {
+		assert.Equal(t, models.DeliveryStatusDelivered, d.Status)
+		assert.Equal(t, 2, d.Attempts)
+		assert.Equal(t, http.StatusNoContent, d.ResponseCode)
+		assert.NotNil(t, d.DeliveredAt)
+	}
+
+	// A receiver that keeps failing exhausts max attempts
+	recv.setStatus(http.StatusBadGateway)
+	require.NoError(t, activityRepo.LogAction(ticketID, models.ActionComment, models.ActorTypeHuman, "", "third"))
+	for i := 0; i < 3; i++ {
+		result, err = dispatcher.RunOnce(ctx)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, result.Failed)
+
+	failed, err := db.NewWebhookRepo(database.DB).List(db.DeliveryFilter{Status: models.DeliveryStatusFailed})
+	require.NoError(t, err)
+	require.Len(t, failed, 1)
+	assert.Equal(t, 3, failed[0].Attempts)
+	assert.Equal(t, http.StatusBadGateway, failed[0].ResponseCode)
+	assert.Contains(t, failed[0].LastError, "502")
+
+	// Failed deliveries are not retried
+	before := recv.count()
+	_, err = dispatcher.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, before, recv.count())
+}
+
+func TestWebhookDispatcher_Test(t *testing.T) {
+	database, cleanup := testDB(t)
+	defer cleanup()
+
+	recv, srv := newWebhookReceiver(t)
+	dispatcher, err := NewWebhookDispatcher(database.DB, []config.WebhookConfig{
+		{Name: "ci", URL: srv.URL, Secret: "key"},
+	})
+	require.NoError(t, err)
+
+	result, err := dispatcher.Test(context.Background(), "ci")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, result.StatusCode)
+	assert.Empty(t, result.Error)
+	require.Equal(t, 1, recv.count())
+	assert.Equal(t, string(WebhookTestEvent), recv.requests[0].Header.Get("X-Wark-Event"))
+	assert.Equal(t, SignPayload("key", recv.bodies[0]), recv.requests[0].Header.Get(SignatureHeader))
+
+	// Test events are not queued
+	deliveries, err := db.NewWebhookRepo(database.DB).List(db.DeliveryFilter{})
+	require.NoError(t, err)
+	assert.Empty(t, deliveries)
+
+	_, err = dispatcher.Test(context.Background(), "missing")
+	assert.Error(t, err)
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	assert.Equal(t, 30*time.Second, webhookBackoff(1))
+	assert.Equal(t, time.Minute, webhookBackoff(2))
+	assert.Equal(t, 4*time.Minute, webhookBackoff(4))
+	assert.Equal(t, time.Hour, webhookBackoff(20))
+}
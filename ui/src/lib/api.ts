@@ -33,6 +33,7 @@ async function fetchApi<T>(endpoint: string, options?: RequestInit): Promise<T>
 		throw new ApiError(res.status, error.error || error.message || "Unknown error");
 	}
 
+	if (res.status === 204) return undefined as T;
 	return res.json();
 }
 
@@ -47,6 +48,20 @@ export interface Project {
 	description?: string;
 	created_at: string;
 	updated_at: string;
+	milestones?: Milestone[];
+}
+
+export type MilestoneStatus = "open" | "achieved" | "abandoned";
+
+export interface Milestone {
+	key: string;
+	name: string;
+	goal?: string;
+	status: MilestoneStatus;
+	target_date?: string;
+	ticket_count: number;
+	closed_count: number;
+	progress: number;
 }
 
 export interface ProjectStats {
@@ -88,6 +103,8 @@ export interface Ticket {
 	complexity: TicketComplexity;
 	resolution?: Resolution;
 	worktree?: string;
+	labels: string[];
+	milestone_key?: string;
 	human_flag_reason?: string;
 	retry_count: number;
 	max_retries: number;
@@ -181,6 +198,8 @@ export interface TicketListParams {
 	status?: TicketStatus;
 	priority?: TicketPriority;
 	complexity?: TicketComplexity;
+	labels?: string[];
+	milestone?: string;
 	workable?: boolean;
 	limit?: number;
 }
@@ -191,6 +210,8 @@ export const listTickets = (params?: TicketListParams) => {
 	if (params?.status) query.set("status", params.status);
 	if (params?.priority) query.set("priority", params.priority);
 	if (params?.complexity) query.set("complexity", params.complexity);
+	for (const label of params?.labels ?? []) query.append("label", label);
+	if (params?.milestone) query.set("milestone", params.milestone);
 	if (params?.workable) query.set("workable", "true");
 	if (params?.limit) query.set("limit", params.limit.toString());
 	const queryStr = query.toString();
@@ -206,17 +227,22 @@ export const getTicket = (key: string) =>
 		history: ActivityLog[];
 	}>(`/tickets/${key}`);
 
-export const createTicket = (
-	projectKey: string,
-	data: {
-		title: string;
-		description?: string;
-		priority?: TicketPriority;
-		complexity?: TicketComplexity;
-		depends_on?: string[];
-	},
-) =>
-	fetchApi<Ticket>(`/projects/${projectKey}/tickets`, {
+export interface CreateTicketData {
+	project: string;
+	title: string;
+	description?: string;
+	priority?: TicketPriority;
+	complexity?: TicketComplexity;
+	type?: "task" | "epic";
+	parent?: string;
+	depends_on?: string[];
+	role?: string;
+	milestone?: string;
+	labels?: string[];
+}
+
+export const createTicket = (data: CreateTicketData) =>
+	fetchApi<Ticket>("/tickets", {
 		method: "POST",
 		body: JSON.stringify(data),
 	});
@@ -228,6 +254,8 @@ export const updateTicket = (
 		description?: string;
 		priority?: TicketPriority;
 		complexity?: TicketComplexity;
+		/** Milestone key, or "" to remove the ticket from its milestone. */
+		milestone?: string;
 	},
 ) =>
 	fetchApi<Ticket>(`/tickets/${key}`, {
@@ -235,6 +263,92 @@ export const updateTicket = (
 		body: JSON.stringify(data),
 	});
 
+// Ticket transitions. worker_id and force identify the caller for claim
+// ownership checks, as with the CLI's --worker-id and --force.
+export type TicketTransition =
+	| "release"
+	| "complete"
+	| "flag"
+	| "start"
+	| "review"
+	| "accept"
+	| "reject"
+	| "close"
+	| "reopen"
+	| "resume"
+	| "later";
+
+export interface TransitionData {
+	worker_id?: string;
+	force?: boolean;
+	summary?: string;
+	auto_accept?: boolean;
+	reason?: string;
+	message?: string;
+	resolution?: string;
+}
+
+export const transitionTicket = (key: string, transition: TicketTransition, data?: TransitionData) =>
+	fetchApi<Ticket>(`/tickets/${key}/${transition}`, {
+		method: "POST",
+		body: JSON.stringify(data ?? {}),
+	});
+
+export const claimTicket = (key: string, data?: { worker_id?: string; duration_mins?: number }) =>
+	fetchApi<{ ticket: Ticket; claim: Claim; worktree?: string }>(`/tickets/${key}/claim`, {
+		method: "POST",
+		body: JSON.stringify(data ?? {}),
+	});
+
+export const addDependency = (key: string, dependsOn: string) =>
+	fetchApi<Ticket>(`/tickets/${key}/dependencies`, {
+		method: "POST",
+		body: JSON.stringify({ depends_on: dependsOn }),
+	});
+
+export const removeDependency = (key: string, dependsOn: string) =>
+	fetchApi<Ticket>(`/tickets/${key}/dependencies/${dependsOn}`, { method: "DELETE" });
+
+export interface Comment {
+	id: number;
+	ticket_key: string;
+	actor_type: string;
+	actor_id?: string;
+	message: string;
+	created_at: string;
+}
+
+export const addComment = (key: string, message: string, workerId?: string) =>
+	fetchApi<Comment>(`/tickets/${key}/comments`, {
+		method: "POST",
+		body: JSON.stringify({ message, worker_id: workerId }),
+	});
+
+// Tasks
+export interface Task {
+	id: number;
+	position: number;
+	description: string;
+	complete: boolean;
+}
+
+export const listTasks = (key: string) => fetchApi<Task[]>(`/tickets/${key}/tasks`);
+
+export const addTask = (key: string, description: string) =>
+	fetchApi<Task>(`/tickets/${key}/tasks`, {
+		method: "POST",
+		body: JSON.stringify({ description }),
+	});
+
+export const setTaskComplete = (key: string, position: number, complete: boolean) =>
+	fetchApi<Task>(`/tickets/${key}/tasks/${position}`, {
+		method: "PATCH",
+		body: JSON.stringify({ complete }),
+	});
+
+export const removeTask = (key: string, position: number) =>
+	fetchApi<void>(`/tickets/${key}/tasks/${position}`, { method: "DELETE" });
+
 // Inbox
 export interface InboxListParams {
 	pending?: boolean;
@@ -266,10 +380,19 @@ export const listClaims = (active?: boolean) =>
 	);
 
 // Search
+export type SearchSource = "key" | "ticket" | "comment" | "summary" | "inbox";
+
+/** A ticket matching a search. `snippet` is HTML-escaped with matches in <mark>. */
+export interface SearchResult extends Ticket {
+	source: SearchSource;
+	snippet: string;
+	score: number;
+}
+
 export const searchTickets = (query: string, limit?: number) => {
 	const params = new URLSearchParams({ q: query });
 	if (limit) params.set("limit", limit.toString());
-	return fetchApi<Ticket[]>(`/tickets/search?${params.toString()}`);
+	return fetchApi<SearchResult[]>(`/tickets/search?${params.toString()}`);
 };
 
 // Analytics
@@ -346,3 +469,41 @@ export const getAnalytics = (params?: AnalyticsFilter) => {
 	const queryStr = query.toString();
 	return fetchApi<AnalyticsResult>(`/analytics${queryStr ? `?${queryStr}` : ""}`);
 };
+
+// Events (Server-Sent Events)
+export type EventType =
+	| "ticket.status_changed"
+	| "claim.created"
+	| "claim.expired"
+	| "inbox.message"
+	| "inbox.response"
+	| "comment.created";
+
+export interface WarkEvent {
+	id: number;
+	type: EventType;
+	ticket_id: number;
+	ticket_key: string;
+	actor_type: string;
+	actor_id?: string;
+	summary?: string;
+	data?: Record<string, unknown>;
+	created_at: string;
+}
+
+/**
+ * Subscribes to live events. The browser reconnects automatically and
+ * resumes from the last event it saw. Returns a function that closes the stream.
+ */
+export const subscribeEvents = (onEvent: (event: WarkEvent) => void, types?: EventType[]) => {
+	const query = new URLSearchParams();
+	if (types?.length) query.set("type", types.join(","));
+	const queryStr = query.toString();
+	const source = new EventSource(`${API_BASE}/events${queryStr ? `?${queryStr}` : ""}`);
+	const handler = (e: MessageEvent) => onEvent(JSON.parse(e.data) as WarkEvent);
+	const eventTypes: EventType[] = types?.length
+		? types
+		: ["ticket.status_changed", "claim.created", "claim.expired", "inbox.message", "inbox.response", "comment.created"];
+	for (const type of eventTypes) source.addEventListener(type, handler);
+	return () => source.close();
+};